@@ -0,0 +1,329 @@
+package ffclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/notifier"
+)
+
+const (
+	// bindTagName is the name of the struct tag used by Bind.
+	bindTagName = "goff"
+	// bindDefaultOption is the option of the struct tag containing the default value of the field.
+	bindDefaultOption = "default="
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// BindFieldError is the error of a field that can't be bound to its flag.
+type BindFieldError struct {
+	// Field is the path of the field in the struct (ex: "HTTP.MaxRetries").
+	Field string
+	// FlagKey is the key of the flag bound to the field.
+	FlagKey string
+	// ErrorCode is the error code of the evaluation (ex: FLAG_NOT_FOUND, TYPE_MISMATCH).
+	ErrorCode flag.ErrorCode
+	// Details is a description of the error.
+	Details string
+}
+
+// BindError is returned by Bind when some fields can't be bound to their flags.
+// Those fields are set with the default value of their tag, or keep their value if they have no default.
+type BindError struct {
+	Fields []BindFieldError
+}
+
+// Error returns a description of all the fields in error.
+func (e *BindError) Error() string {
+	details := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		details = append(details, fmt.Sprintf("%s (flag %s): %s", f.Field, f.FlagKey, f.ErrorCode))
+	}
+	return "impossible to bind the flags: " + strings.Join(details, ", ")
+}
+
+// Missing returns the keys of the flags that don't exist.
+func (e *BindError) Missing() []string {
+	return e.flagKeys(flag.ErrorCodeFlagNotFound)
+}
+
+// TypeMismatch returns the keys of the flags with a value that can't be decoded in their field.
+func (e *BindError) TypeMismatch() []string {
+	return e.flagKeys(flag.ErrorCodeTypeMismatch)
+}
+
+func (e *BindError) flagKeys(errorCode flag.ErrorCode) []string {
+	keys := make([]string, 0)
+	for _, f := range e.Fields {
+		if f.ErrorCode == errorCode {
+			keys = append(keys, f.FlagKey)
+		}
+	}
+	return keys
+}
+
+// Bind fills the struct pointed by target with the values of the flags, for the evaluation context
+// stored in ctx (see ffcontext.WithEvaluationContext).
+//
+// The fields are bound with the tag `goff:"<flag key>,default=<default value>"`, the default value
+// is used if the flag can't be evaluated and is parsed as JSON (except for strings and durations).
+// A flag with an object or array value can be bound to a struct, a map or a slice.
+// Fields without tag of type struct are bound recursively, use `goff:"-"` to ignore a field.
+//
+// If some flags are missing or have the wrong type, the other fields are still bound and a
+// *BindError is returned.
+func Bind(ctx context.Context, target any) error {
+	return ff.Bind(ctx, target)
+}
+
+// Bind fills the struct pointed by target with the values of the flags, for the evaluation context
+// stored in ctx (see ffcontext.WithEvaluationContext).
+// See ffclient.Bind for the format of the struct tags.
+func (g *GoFeatureFlag) Bind(ctx context.Context, target any) error {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("impossible to bind the flags: target should be a non nil pointer to a struct, got %T",
+			target)
+	}
+
+	bindErr := &BindError{}
+	if err := g.bindStruct(ctx, evaluationContextFrom(ctx), value.Elem(), "", bindErr); err != nil {
+		return err
+	}
+	if len(bindErr.Fields) > 0 {
+		return bindErr
+	}
+	return nil
+}
+
+// bindStruct binds all the fields of a struct, path is the path of the struct in the target.
+func (g *GoFeatureFlag) bindStruct(
+	ctx context.Context,
+	evaluationCtx ffcontext.Context,
+	value reflect.Value,
+	path string,
+	bindErr *BindError,
+) error {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		fieldValue := value.Field(i)
+		tag, hasTag := field.Tag.Lookup(bindTagName)
+		if !field.IsExported() || tag == "-" {
+			continue
+		}
+
+		fieldPath := field.Name
+		if path != "" {
+			fieldPath = path + "." + field.Name
+		}
+
+		if !hasTag {
+			if field.Type.Kind() == reflect.Struct && field.Type != reflect.TypeOf(time.Time{}) {
+				if err := g.bindStruct(ctx, evaluationCtx, fieldValue, fieldPath, bindErr); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		flagKey, defaultValue, hasDefault := parseBindTag(tag)
+		if flagKey == "" {
+			return fmt.Errorf("impossible to bind the flags: field %s has no flag key in its tag", fieldPath)
+		}
+		if err := g.bindField(ctx, evaluationCtx, fieldValue, flagKey, fieldPath, bindErr); err != nil {
+			if !hasDefault {
+				continue
+			}
+			if err := decodeBindDefault(defaultValue, fieldValue); err != nil {
+				return fmt.Errorf("impossible to bind the flags: invalid default value for field %s: %w",
+					fieldPath, err)
+			}
+		}
+	}
+	return nil
+}
+
+// bindField sets the value of the flag in the field.
+// It returns an error if the field has not been set and should use its default value.
+func (g *GoFeatureFlag) bindField(
+	ctx context.Context,
+	evaluationCtx ffcontext.Context,
+	fieldValue reflect.Value,
+	flagKey string,
+	fieldPath string,
+	bindErr *BindError,
+) error {
+	res, err := evaluateWithHooks(ctx, g, flagKey, evaluationCtx, nil, "interface{}", getVariation[any])
+	if err != nil {
+		bindErr.Fields = append(bindErr.Fields, BindFieldError{
+			Field:     fieldPath,
+			FlagKey:   flagKey,
+			ErrorCode: res.ErrorCode,
+			Details:   err.Error(),
+		})
+		return err
+	}
+	if res.Value == nil {
+		// the flag is disabled or the SDK default value is used.
+		return fmt.Errorf("no value for flag %s", flagKey)
+	}
+	if err := decodeBindValue(res.Value, fieldValue); err != nil {
+		bindErr.Fields = append(bindErr.Fields, BindFieldError{
+			Field:     fieldPath,
+			FlagKey:   flagKey,
+			ErrorCode: flag.ErrorCodeTypeMismatch,
+			Details:   err.Error(),
+		})
+		return err
+	}
+	return nil
+}
+
+// parseBindTag returns the flag key and the default value of a tag.
+// Everything after "default=" is the default value, so it can contain commas (ex: a JSON array).
+func parseBindTag(tag string) (flagKey string, defaultValue string, hasDefault bool) {
+	flagKey, options, _ := strings.Cut(tag, ",")
+	if strings.HasPrefix(options, bindDefaultOption) {
+		return strings.TrimSpace(flagKey), strings.TrimPrefix(options, bindDefaultOption), true
+	}
+	return strings.TrimSpace(flagKey), "", false
+}
+
+// decodeBindValue decodes the value of a flag in a field, using the JSON representation of the value.
+// A duration can be bound to a flag with a string value (ex: "5s").
+func decodeBindValue(value any, fieldValue reflect.Value) error {
+	if s, ok := value.(string); ok && fieldValue.Type() == durationType {
+		return decodeBindDefault(s, fieldValue)
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	decoded := reflect.New(fieldValue.Type())
+	if err := json.Unmarshal(content, decoded.Interface()); err != nil {
+		return fmt.Errorf("impossible to convert %s into %s: %w", content, fieldValue.Type(), err)
+	}
+	fieldValue.Set(decoded.Elem())
+	return nil
+}
+
+// decodeBindDefault decodes the default value of a tag in a field.
+func decodeBindDefault(defaultValue string, fieldValue reflect.Value) error {
+	switch {
+	case fieldValue.Type() == durationType:
+		d, err := time.ParseDuration(defaultValue)
+		if err != nil {
+			return err
+		}
+		fieldValue.SetInt(int64(d))
+	case fieldValue.Kind() == reflect.String:
+		fieldValue.SetString(defaultValue)
+	default:
+		decoded := reflect.New(fieldValue.Type())
+		if err := json.Unmarshal([]byte(defaultValue), decoded.Interface()); err != nil {
+			return err
+		}
+		fieldValue.Set(decoded.Elem())
+	}
+	return nil
+}
+
+// Binding is a struct bound to the flags and kept up to date by Watch.
+type Binding[T any] struct {
+	value    atomic.Pointer[T]
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// Get returns the latest version of the bound struct.
+func (b *Binding[T]) Get() T {
+	return *b.value.Load()
+}
+
+// Stop stops watching the configuration changes.
+func (b *Binding[T]) Stop() {
+	b.stopOnce.Do(func() { close(b.stop) })
+}
+
+// Watch binds a struct of type T with the flags (see ffclient.Bind) and binds it again each time the
+// configuration of the flags changes.
+// The new version of the struct replaces the previous one atomically, and onChange (optional) is called
+// if the struct or the bind error has changed.
+//
+// The returned error is the error of the first bind, if it is a *BindError the binding can still be used.
+// Watching stops when ctx is done or when Binding.Stop is called.
+func Watch[T any](ctx context.Context, onChange func(value T, err error)) (*Binding[T], error) {
+	return WatchFrom[T](ctx, ff, onChange)
+}
+
+// WatchFrom is the same as Watch but for a specific go-feature-flag instance.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func WatchFrom[T any](
+	ctx context.Context,
+	g *GoFeatureFlag,
+	onChange func(value T, err error),
+) (*Binding[T], error) {
+	if g == nil {
+		return nil, fmt.Errorf("go-feature-flag is not initialised, impossible to watch the flags")
+	}
+	// the listener is registered before the first bind to not miss a change happening during the bind.
+	// It only signals the change, the notification service expects the listeners to not block.
+	changed := make(chan struct{}, 1)
+	removeListener := func() {}
+	if g.notificationService != nil {
+		removeListener = g.notificationService.AddListener(func(_ notifier.DiffCache) {
+			select {
+			case changed <- struct{}{}:
+			default:
+				// a bind is already pending, it will read the latest configuration.
+			}
+		})
+	}
+
+	value := new(T)
+	err := g.Bind(ctx, value)
+	var bindErr *BindError
+	if err != nil && !errors.As(err, &bindErr) {
+		removeListener()
+		return nil, err
+	}
+
+	b := &Binding[T]{stop: make(chan struct{})}
+	b.value.Store(value)
+	if g.notificationService == nil {
+		// no configuration is loaded in offline mode, so the flags will never change.
+		return b, err
+	}
+	go func() {
+		defer removeListener()
+		lastErr := err
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-b.stop:
+				return
+			case <-changed:
+				newValue := new(T)
+				newErr := g.Bind(ctx, newValue)
+				previous := b.value.Swap(newValue)
+				if onChange != nil &&
+					(!reflect.DeepEqual(previous, newValue) || !reflect.DeepEqual(lastErr, newErr)) {
+					onChange(*newValue, newErr)
+				}
+				lastErr = newErr
+			}
+		}
+	}()
+	return b, err
+}
@@ -0,0 +1,257 @@
+package ffclient
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/retriever/fileretriever"
+)
+
+const bindFlagConfig = `
+max-retries:
+  variations:
+    default: 5
+  defaultRule:
+    variation: default
+timeout:
+  variations:
+    default: "2s"
+  defaultRule:
+    variation: default
+banner:
+  variations:
+    default:
+      title: Black Friday
+      maxItems: 3
+  defaultRule:
+    variation: default
+premium-colors:
+  variations:
+    default: ["gold", "black"]
+    premium: ["gold", "black", "purple"]
+  targeting:
+    - query: plan eq "premium"
+      variation: premium
+  defaultRule:
+    variation: default
+wrong-type:
+  variations:
+    default: "not a number"
+  defaultRule:
+    variation: default
+disabled:
+  disable: true
+  variations:
+    default: 10
+  defaultRule:
+    variation: default
+`
+
+type bindHTTPConfig struct {
+	MaxRetries int           `goff:"max-retries,default=3"`
+	Timeout    time.Duration `goff:"timeout,default=1s"`
+}
+
+type bindConfig struct {
+	HTTP          bindHTTPConfig
+	Banner        bannerConfig `goff:"banner"`
+	Colors        []string     `goff:"premium-colors,default=[\"white\"]"`
+	Mode          string       `goff:"missing-flag,default=safe"`
+	Workers       int          `goff:"wrong-type,default=4"`
+	Disabled      int          `goff:"disabled,default=1"`
+	NoDefault     string       `goff:"other-missing-flag"`
+	Ignored       string       `goff:"-"`
+	notExported   int          `goff:"max-retries"` // nolint: unused
+	WithoutTagInt int
+}
+
+func newBindTestClient(t *testing.T, content string) (*GoFeatureFlag, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "flags.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	g, err := New(Config{
+		PollingInterval: 10 * time.Minute,
+		Retriever:       &fileretriever.Retriever{Path: path},
+	})
+	require.NoError(t, err)
+	t.Cleanup(g.Close)
+	return g, path
+}
+
+func TestBind(t *testing.T) {
+	g, _ := newBindTestClient(t, bindFlagConfig)
+
+	t.Run("bind all the fields", func(t *testing.T) {
+		cfg := bindConfig{NoDefault: "unchanged", Ignored: "ignored"}
+		ctx := ffcontext.WithEvaluationContext(context.Background(),
+			ffcontext.NewEvaluationContextBuilder("user-key").AddCustom("plan", "premium").Build())
+		err := g.Bind(ctx, &cfg)
+
+		var bindErr *BindError
+		require.ErrorAs(t, err, &bindErr)
+		assert.ElementsMatch(t, []string{"missing-flag", "other-missing-flag"}, bindErr.Missing())
+		assert.Equal(t, []string{"wrong-type"}, bindErr.TypeMismatch())
+		assert.Equal(t, bindConfig{
+			HTTP:      bindHTTPConfig{MaxRetries: 5, Timeout: 2 * time.Second},
+			Banner:    bannerConfig{Title: "Black Friday", MaxItems: 3},
+			Colors:    []string{"gold", "black", "purple"},
+			Mode:      "safe",
+			Workers:   4,
+			Disabled:  1,
+			NoDefault: "unchanged",
+			Ignored:   "ignored",
+		}, cfg)
+	})
+
+	t.Run("no error if all the flags are bound", func(t *testing.T) {
+		var cfg bindHTTPConfig
+		require.NoError(t, g.Bind(context.Background(), &cfg))
+		assert.Equal(t, bindHTTPConfig{MaxRetries: 5, Timeout: 2 * time.Second}, cfg)
+	})
+
+	t.Run("target is not a pointer to a struct", func(t *testing.T) {
+		var cfg bindHTTPConfig
+		err := g.Bind(context.Background(), cfg)
+		assert.EqualError(t, err,
+			"impossible to bind the flags: target should be a non nil pointer to a struct, got ffclient.bindHTTPConfig")
+	})
+
+	t.Run("invalid default value", func(t *testing.T) {
+		cfg := struct {
+			Workers int `goff:"missing-flag,default=four"`
+		}{}
+		err := g.Bind(context.Background(), &cfg)
+		assert.ErrorContains(t, err, "invalid default value for field Workers")
+	})
+
+	t.Run("not initialised", func(t *testing.T) {
+		var cfg bindHTTPConfig
+		var goff *GoFeatureFlag
+		err := goff.Bind(context.Background(), &cfg)
+		var bindErr *BindError
+		require.ErrorAs(t, err, &bindErr)
+		assert.Equal(t, flag.ErrorCodeProviderNotReady, bindErr.Fields[0].ErrorCode)
+		assert.Equal(t, bindHTTPConfig{MaxRetries: 3, Timeout: time.Second}, cfg)
+	})
+}
+
+func TestParseBindTag(t *testing.T) {
+	tests := []struct {
+		tag            string
+		wantKey        string
+		wantDefault    string
+		wantHasDefault bool
+	}{
+		{tag: "max-retries", wantKey: "max-retries"},
+		{tag: "max-retries,default=3", wantKey: "max-retries", wantDefault: "3", wantHasDefault: true},
+		{tag: "colors,default=[\"a\",\"b\"]", wantKey: "colors", wantDefault: "[\"a\",\"b\"]", wantHasDefault: true},
+		{tag: "mode,default=", wantKey: "mode", wantDefault: "", wantHasDefault: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			key, defaultValue, hasDefault := parseBindTag(tt.tag)
+			assert.Equal(t, tt.wantKey, key)
+			assert.Equal(t, tt.wantDefault, defaultValue)
+			assert.Equal(t, tt.wantHasDefault, hasDefault)
+		})
+	}
+}
+
+func TestWatch(t *testing.T) {
+	g, path := newBindTestClient(t, bindFlagConfig)
+
+	var mu sync.Mutex
+	var changes []bindHTTPConfig
+	binding, err := WatchFrom(context.Background(), g, func(value bindHTTPConfig, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		assert.NoError(t, err)
+		changes = append(changes, value)
+	})
+	require.NoError(t, err)
+	defer binding.Stop()
+	assert.Equal(t, bindHTTPConfig{MaxRetries: 5, Timeout: 2 * time.Second}, binding.Get())
+
+	// a refresh without change does not call the callback
+	time.Sleep(20 * time.Millisecond)
+	require.True(t, g.ForceRefresh())
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	assert.Empty(t, changes)
+	mu.Unlock()
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+max-retries:
+  variations:
+    default: 8
+  defaultRule:
+    variation: default
+timeout:
+  variations:
+    default: "2s"
+  defaultRule:
+    variation: default
+`), 0o600))
+	time.Sleep(20 * time.Millisecond)
+	require.True(t, g.ForceRefresh())
+
+	want := bindHTTPConfig{MaxRetries: 8, Timeout: 2 * time.Second}
+	assert.Eventually(t, func() bool { return binding.Get() == want }, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(changes) == 1 && changes[0] == want
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestWatch_Stop(t *testing.T) {
+	g, path := newBindTestClient(t, bindFlagConfig)
+	binding, err := WatchFrom[bindHTTPConfig](context.Background(), g, func(_ bindHTTPConfig, _ error) {
+		assert.Fail(t, "the callback should not be called once the binding is stopped")
+	})
+	require.NoError(t, err)
+	binding.Stop()
+	binding.Stop()
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+max-retries:
+  variations:
+    default: 8
+  defaultRule:
+    variation: default
+`), 0o600))
+	require.True(t, g.ForceRefresh())
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, bindHTTPConfig{MaxRetries: 5, Timeout: 2 * time.Second}, binding.Get())
+}
+
+func TestWatch_Errors(t *testing.T) {
+	t.Run("not initialised", func(t *testing.T) {
+		_, err := WatchFrom[bindHTTPConfig](context.Background(), nil, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid target", func(t *testing.T) {
+		g, _ := newBindTestClient(t, bindFlagConfig)
+		_, err := WatchFrom[int](context.Background(), g, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("bind error", func(t *testing.T) {
+		g, _ := newBindTestClient(t, bindFlagConfig)
+		binding, err := WatchFrom[bindConfig](context.Background(), g, nil)
+		defer binding.Stop()
+		var bindErr *BindError
+		assert.True(t, errors.As(err, &bindErr))
+		assert.Equal(t, "safe", binding.Get().Mode)
+	})
+}
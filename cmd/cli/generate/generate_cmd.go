@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/cobra"
+	"github.com/thomaspoignant/go-feature-flag/cmd/cli/generate/golang"
 	"github.com/thomaspoignant/go-feature-flag/cmd/cli/generate/manifest"
 	"github.com/thomaspoignant/go-feature-flag/cmd/cli/helper"
 )
@@ -12,7 +13,7 @@ func NewGenerateCmd() *cobra.Command {
 	g := &cobra.Command{
 		Use:   "generate",
 		Short: "🏗️ Generate GO Feature Flag related files",
-		Long:  `🏗️ Generate GO Feature Flag relates files (examples: flag manifest, ...)`,
+		Long:  `🏗️ Generate GO Feature Flag relates files (examples: flag manifest, Go code, ...)`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			output := helper.Output{}
 			output.Add("you must specify a subcommand (e.g., manifest)", helper.ErrorLevel)
@@ -24,5 +25,6 @@ func NewGenerateCmd() *cobra.Command {
 	}
 
 	g.AddCommand(manifest.NewManifestCmd())
+	g.AddCommand(golang.NewGoCmd())
 	return g
 }
@@ -0,0 +1,260 @@
+package golang
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/thomaspoignant/go-feature-flag/cmd/cli/helper"
+	configHelper "github.com/thomaspoignant/go-feature-flag/cmdhelpers/configfile"
+	"github.com/thomaspoignant/go-feature-flag/model/dto"
+	dtoCore "github.com/thomaspoignant/go-feature-flag/modules/core/dto"
+)
+
+const defaultPackageName = "flags"
+
+func NewGenerator(configFile, configFormat, destination, packageName string) (Generator, error) {
+	if destination == "" {
+		return Generator{}, fmt.Errorf("--output is mandatory")
+	}
+	if packageName == "" {
+		packageName = defaultPackageName
+	}
+	if !token.IsIdentifier(packageName) {
+		return Generator{}, fmt.Errorf("invalid package name: %s", packageName)
+	}
+	flagDTOs, err := configHelper.LoadConfigFile(
+		configFile,
+		configFormat,
+		configHelper.ConfigFileDefaultLocations,
+	)
+	if err != nil {
+		return Generator{}, err
+	}
+	return Generator{
+		dtos:        flagDTOs,
+		destination: destination,
+		packageName: packageName,
+	}, nil
+}
+
+// Generator generates a Go file with a typed accessor for each flag.
+type Generator struct {
+	dtos        map[string]dto.DTO
+	destination string
+	packageName string
+}
+
+type templateData struct {
+	Package string
+	HasJSON bool
+	Flags   []flagData
+	Structs []structType
+}
+
+type flagData struct {
+	Key            string
+	Name           string
+	FlagType       string
+	GoType         string
+	Method         string
+	IsJSON         bool
+	DefaultLiteral string
+	// Description contains the lines of the doc comment generated from the metadata.
+	Description []string
+	Variations  []variationData
+}
+
+type variationData struct {
+	Key  string
+	Name string
+}
+
+// Generate the Go file
+func (g *Generator) Generate() (helper.Output, error) {
+	output := helper.Output{}
+	code, err := g.code()
+	if err != nil {
+		return output, err
+	}
+	if err := os.WriteFile(g.destination, code, 0600); err != nil {
+		return output, err
+	}
+	return output.Add("🎉 Go code has been generated", helper.InfoLevel), nil
+}
+
+// code returns the formatted Go code for all the flags.
+func (g *Generator) code() ([]byte, error) {
+	keys := make([]string, 0, len(g.dtos))
+	for key := range g.dtos {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	data := templateData{Package: g.packageName}
+	types := newTypeBuilder()
+	names := map[string]string{}
+	for _, key := range keys {
+		f, err := g.flagData(key, types)
+		if err != nil {
+			return nil, fmt.Errorf("invalid configuration for flag %s: %w", key, err)
+		}
+		if otherKey, ok := names[f.Name]; ok {
+			return nil, fmt.Errorf("flags %s and %s have the same Go name %s", otherKey, key, f.Name)
+		}
+		names[f.Name] = key
+		data.HasJSON = data.HasJSON || f.IsJSON
+		data.Flags = append(data.Flags, f)
+	}
+	data.Structs = types.structs
+
+	var buf bytes.Buffer
+	if err := codeTemplate.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// flagData collects the information needed to generate the accessor of a flag.
+func (g *Generator) flagData(key string, types *typeBuilder) (flagData, error) {
+	internalFlag := dtoCore.ConvertDtoToInternalFlag(g.dtos[key])
+	if len(internalFlag.GetVariations()) == 0 {
+		return flagData{}, fmt.Errorf("no variations provided")
+	}
+
+	f := flagData{Key: key, Name: exportedName(key)}
+	variationNames := make([]string, 0, len(internalFlag.GetVariations()))
+	for name := range internalFlag.GetVariations() {
+		variationNames = append(variationNames, name)
+	}
+	sort.Strings(variationNames)
+	values := make([]any, 0, len(variationNames))
+	seenVariations := map[string]string{}
+	for _, name := range variationNames {
+		variation := variationData{Key: name, Name: exportedName(name)}
+		if other, ok := seenVariations[variation.Name]; ok {
+			return flagData{}, fmt.Errorf("variations %s and %s have the same Go name %s", other, name, variation.Name)
+		}
+		seenVariations[variation.Name] = name
+		f.Variations = append(f.Variations, variation)
+
+		value, err := normalizeValue(internalFlag.GetVariationValue(name))
+		if err != nil {
+			return flagData{}, err
+		}
+		values = append(values, value)
+	}
+
+	types.flagKey = key
+	goType, err := types.goType(f.Name+"Value", values)
+	if err != nil {
+		return flagData{}, err
+	}
+	if goType == "any" {
+		return flagData{}, fmt.Errorf("impossible to find type, all variations should have the same type")
+	}
+	f.GoType = goType
+
+	switch {
+	case goType == "bool":
+		f.FlagType, f.Method = "boolean", "BoolVariation"
+	case goType == "string":
+		f.FlagType, f.Method = "string", "StringVariation"
+	case goType == "int":
+		f.FlagType, f.Method = "integer", "IntVariation"
+	case goType == "float64":
+		f.FlagType, f.Method = "float", "Float64Variation"
+	case strings.HasPrefix(goType, "[]"):
+		f.FlagType, f.Method, f.IsJSON = "array", "JSONArrayVariation", true
+	default:
+		f.FlagType, f.Method, f.IsJSON = "object", "JSONVariation", true
+	}
+
+	metadata := internalFlag.GetMetadata()
+	if description, ok := metadata["description"].(string); ok && description != "" {
+		f.Description = []string{"//"}
+		for _, line := range strings.Split(strings.TrimSpace(description), "\n") {
+			f.Description = append(f.Description, strings.TrimSpace("// "+commentText(line)))
+		}
+	}
+	f.DefaultLiteral, err = defaultLiteral(f, metadata["defaultValue"])
+	if err != nil {
+		return flagData{}, fmt.Errorf("invalid defaultValue: %w", err)
+	}
+	return f, nil
+}
+
+// commentText returns the text of a line of a doc comment, the control characters are replaced by spaces
+// to keep the text inside the comment.
+func commentText(line string) string {
+	return strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, line))
+}
+
+// defaultLiteral returns the Go literal of the default value of the flag.
+// If no defaultValue is set in the metadata, we use the zero value of the type.
+func defaultLiteral(f flagData, defaultValue any) (string, error) {
+	value, err := normalizeValue(defaultValue)
+	if err != nil {
+		return "", err
+	}
+	if value == nil {
+		switch f.FlagType {
+		case "boolean":
+			return "false", nil
+		case "string":
+			return `""`, nil
+		case "integer":
+			return "0", nil
+		case "float":
+			return "0.0", nil
+		default:
+			return "nil", nil
+		}
+	}
+
+	valid := false
+	switch v := value.(type) {
+	case bool:
+		valid = f.FlagType == "boolean"
+	case string:
+		valid = f.FlagType == "string"
+	case float64:
+		valid = f.FlagType == "float" || f.FlagType == "integer"
+	case map[string]any:
+		valid = f.FlagType == "object"
+	case []any:
+		valid = f.FlagType == "array"
+	default:
+		return "", fmt.Errorf("unsupported type %T", v)
+	}
+	if !valid {
+		return "", fmt.Errorf("the default value should be of type %s", f.FlagType)
+	}
+	return goLiteral(value, f.GoType)
+}
+
+// normalizeValue converts a value loaded from YAML, TOML or JSON into its JSON representation
+// (numbers as float64, objects as map[string]any and arrays as []any).
+func normalizeValue(value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var normalized any
+	err = json.Unmarshal(content, &normalized)
+	return normalized, err
+}
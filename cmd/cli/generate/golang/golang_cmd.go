@@ -0,0 +1,48 @@
+package golang
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var (
+	goFlagFormat  string
+	goConfigFile  string
+	goDestination string
+	goPackageName string
+)
+
+func NewGoCmd() *cobra.Command {
+	goCmd := &cobra.Command{
+		Use:   "go",
+		Short: "🐹 (experimental) Generate a Go package with a typed accessor for each flag.",
+		Long: "🐹 (experimental) Generate a Go package with a typed accessor for each flag of your configuration. " +
+			"If a flag is removed or renamed, your build breaks instead of silently returning the default value. " +
+			"⚠️ note that this is an experimental feature and we may change this command line without warning.",
+
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			g, err := NewGenerator(goConfigFile, goFlagFormat, goDestination, goPackageName)
+			if err != nil {
+				return err
+			}
+			output, err := g.Generate()
+			if err != nil {
+				cmd.SilenceUsage = true
+				return err
+			}
+			output.PrintLines(cmd)
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	goCmd.Flags().StringVarP(&goFlagFormat,
+		"format", "f", "yaml", "Format of your input file (YAML, JSON or TOML)")
+	goCmd.Flags().StringVarP(&goConfigFile,
+		"config", "c", "", "Location of your GO Feature Flag local configuration file")
+	goCmd.Flags().StringVarP(&goDestination,
+		"output", "o", "", "Destination of the generated Go file.")
+	goCmd.Flags().StringVar(&goPackageName,
+		"package", defaultPackageName, "Name of the generated Go package.")
+	_ = goCmd.MarkFlagRequired("output")
+	return goCmd
+}
@@ -0,0 +1,129 @@
+package golang_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/cmd/cli/generate/golang"
+)
+
+func TestGoCmd(t *testing.T) {
+	pterm.DisableStyling()
+	pterm.DisableColor()
+	tests := []struct {
+		name           string
+		args           []string
+		expectedCode   string
+		expectedOutput string
+		expectedError  string
+		assertError    assert.ErrorAssertionFunc
+	}{
+		{
+			name:           "should return success if everything is ok",
+			args:           []string{"--config=testdata/input/flags.goff.yaml"},
+			expectedCode:   "testdata/output/flags.go",
+			expectedOutput: "INFO: 🎉 Go code has been generated\n",
+			assertError:    assert.NoError,
+		},
+		{
+			name:           "should escape the keys and the comments",
+			args:           []string{"--config=testdata/input/flag-special-characters.yaml"},
+			expectedCode:   "testdata/output/flag-special-characters.go",
+			expectedOutput: "INFO: 🎉 Go code has been generated\n",
+			assertError:    assert.NoError,
+		},
+		{
+			name:          "should error if variations have different types",
+			args:          []string{"--config=testdata/input/flag-mixed-types.yaml"},
+			assertError:   assert.Error,
+			expectedError: "invalid configuration for flag test-flag: impossible to find type, all variations should have the same type", // nolint: lll
+		},
+		{
+			name:          "should error if 2 flags have the same Go name",
+			args:          []string{"--config=testdata/input/flag-same-name.yaml"},
+			assertError:   assert.Error,
+			expectedError: "flags test-flag and test_flag have the same Go name TestFlag",
+		},
+		{
+			name:          "should error if the default value has not the type of the flag",
+			args:          []string{"--config=testdata/input/flag-invalid-default.yaml"},
+			assertError:   assert.Error,
+			expectedError: "invalid configuration for flag test-flag: invalid defaultValue: the default value should be of type boolean", // nolint: lll
+		},
+		{
+			name:          "should error if the package name is invalid",
+			args:          []string{"--config=testdata/input/flags.goff.yaml", "--package=my-flags"},
+			assertError:   assert.Error,
+			expectedError: "invalid package name: my-flags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			destination := filepath.Join(t.TempDir(), "flags.go")
+			redirectionStd, err := os.CreateTemp("", "temp")
+			require.NoError(t, err)
+			defer func() { _ = os.Remove(redirectionStd.Name()) }()
+
+			cmd := golang.NewGoCmd()
+			cmd.SetErr(redirectionStd)
+			cmd.SetOut(redirectionStd)
+			cmd.SetArgs(append(tt.args, "--output", destination))
+
+			err = cmd.Execute()
+			tt.assertError(t, err)
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			}
+
+			output, err := os.ReadFile(redirectionStd.Name())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedOutput, string(output), "output is not expected")
+
+			if tt.expectedCode != "" {
+				wantCode, err := os.ReadFile(tt.expectedCode)
+				assert.NoError(t, err)
+				gotCode, err := os.ReadFile(destination)
+				assert.NoError(t, err)
+				assert.Equal(t, string(wantCode), string(gotCode), "generated code is not expected")
+			}
+		})
+	}
+}
+
+func TestNewGenerator(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		destination string
+		errorAssert assert.ErrorAssertionFunc
+	}{
+		{
+			name:        "should error if config file does not exist",
+			config:      "testdata/invalid.yaml",
+			destination: "flags.go",
+			errorAssert: assert.Error,
+		},
+		{
+			name:        "should not error if config file exists",
+			config:      "testdata/input/flags.goff.yaml",
+			destination: "flags.go",
+			errorAssert: assert.NoError,
+		},
+		{
+			name:        "should error if no destination provided",
+			config:      "testdata/input/flags.goff.yaml",
+			errorAssert: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := golang.NewGenerator(tt.config, "yaml", tt.destination, "")
+			tt.errorAssert(t, err)
+		})
+	}
+}
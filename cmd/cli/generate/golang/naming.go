@@ -0,0 +1,68 @@
+package golang
+
+import (
+	"strings"
+	"unicode"
+)
+
+// commonInitialisms are the words written in upper case in a Go identifier.
+var commonInitialisms = map[string]bool{
+	"API":  true,
+	"CPU":  true,
+	"CSS":  true,
+	"DNS":  true,
+	"HTML": true,
+	"HTTP": true,
+	"ID":   true,
+	"IP":   true,
+	"JSON": true,
+	"SQL":  true,
+	"TTL":  true,
+	"UI":   true,
+	"URL":  true,
+	"UUID": true,
+	"XML":  true,
+}
+
+// exportedName converts a flag key, a variation name or a JSON field into an exported Go identifier.
+// "new-checkout-flow" becomes "NewCheckoutFlow" and "user_id" becomes "UserID".
+func exportedName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var identifier strings.Builder
+	for _, word := range splitCamelCase(words) {
+		if upper := strings.ToUpper(word); commonInitialisms[upper] {
+			identifier.WriteString(upper)
+			continue
+		}
+		runes := []rune(word)
+		identifier.WriteRune(unicode.ToUpper(runes[0]))
+		identifier.WriteString(string(runes[1:]))
+	}
+
+	result := identifier.String()
+	if result == "" || !unicode.IsLetter([]rune(result)[0]) {
+		// an identifier can't start with a digit
+		result = "X" + result
+	}
+	return result
+}
+
+// splitCamelCase splits the words already in camelCase (ex: "maxItems" → "max", "Items").
+func splitCamelCase(words []string) []string {
+	result := make([]string, 0, len(words))
+	for _, word := range words {
+		runes := []rune(word)
+		start := 0
+		for i := 1; i < len(runes); i++ {
+			if unicode.IsUpper(runes[i]) && unicode.IsLower(runes[i-1]) {
+				result = append(result, string(runes[start:i]))
+				start = i
+			}
+		}
+		result = append(result, string(runes[start:]))
+	}
+	return result
+}
@@ -0,0 +1,28 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_exportedName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "new-checkout-flow", want: "NewCheckoutFlow"},
+		{name: "enable_dark_mode", want: "EnableDarkMode"},
+		{name: "maxItems", want: "MaxItems"},
+		{name: "user_id", want: "UserID"},
+		{name: "api.url", want: "APIURL"},
+		{name: "3d-secure", want: "X3dSecure"},
+		{name: "Default", want: "Default"},
+		{name: "-", want: "X"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, exportedName(tt.name))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package golang
+
+import "text/template"
+
+// codeTemplate is the template of the generated Go file.
+var codeTemplate = template.Must(template.New("go").Parse(`// Code generated by go-feature-flag-cli generate go. DO NOT EDIT.
+
+// Package {{ .Package }} contains typed accessors for the flags of your GO Feature Flag configuration.
+package {{ .Package }}
+
+import (
+{{- if .HasJSON }}
+	"encoding/json"
+{{ end }}
+	ffclient "github.com/thomaspoignant/go-feature-flag"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+)
+{{ range .Flags }}
+// {{ .Name }}Key is the key of the flag {{ printf "%q" .Key }}.
+const {{ .Name }}Key = {{ printf "%q" .Key }}
+{{ if .Variations }}
+// Variations of the flag {{ printf "%q" .Key }}.
+const (
+{{- $flag := . }}
+{{- range .Variations }}
+	{{ $flag.Name }}Variation{{ .Name }} = {{ printf "%q" .Key }}
+{{- end }}
+)
+{{ end }}
+// {{ .Name }} returns the value of the flag {{ printf "%q" .Key }}.
+{{- range .Description }}
+{{ . }}
+{{- end }}
+//
+// The flag is a type of {{ .FlagType }} and defaults to {{ .DefaultLiteral }}.
+func {{ .Name }}(ctx ffcontext.Context) ({{ .GoType }}, error) {
+{{- if .IsJSON }}
+	return decodeJSONVariation[{{ .GoType }}](ffclient.{{ .Method }}({{ .Name }}Key, ctx, {{ .DefaultLiteral }}))
+{{- else }}
+	return ffclient.{{ .Method }}({{ .Name }}Key, ctx, {{ .DefaultLiteral }})
+{{- end }}
+}
+{{ end }}
+{{- range .Structs }}
+// {{ .Name }} is generated from the JSON variations of the flag {{ printf "%q" .FlagKey }}.
+type {{ .Name }} struct {
+{{- range .Fields }}
+	{{ .Name }} {{ .Type }} ` + "`" + `json:{{ printf "%q" .JSONName }}` + "`" + `
+{{- end }}
+}
+{{ end }}
+{{- if .HasJSON }}
+// decodeJSONVariation converts the value of a JSON flag into its generated type.
+func decodeJSONVariation[T any](value any, err error) (T, error) {
+	var result T
+	content, marshalErr := json.Marshal(value)
+	if marshalErr != nil {
+		return result, marshalErr
+	}
+	if unmarshalErr := json.Unmarshal(content, &result); unmarshalErr != nil {
+		return result, unmarshalErr
+	}
+	return result, err
+}
+{{ end -}}
+`))
@@ -0,0 +1,28 @@
+// Code generated by go-feature-flag-cli generate go. DO NOT EDIT.
+
+// Package flags contains typed accessors for the flags of your GO Feature Flag configuration.
+package flags
+
+import (
+	ffclient "github.com/thomaspoignant/go-feature-flag"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+)
+
+// FlagWithQuotesAndNewlineKey is the key of the flag "flag\"with\\quotes\nand-newline".
+const FlagWithQuotesAndNewlineKey = "flag\"with\\quotes\nand-newline"
+
+// Variations of the flag "flag\"with\\quotes\nand-newline".
+const (
+	FlagWithQuotesAndNewlineVariationOff = "off\\"
+	FlagWithQuotesAndNewlineVariationOn  = "on\""
+)
+
+// FlagWithQuotesAndNewline returns the value of the flag "flag\"with\\quotes\nand-newline".
+//
+// first line const Injected = 1
+// second line
+//
+// The flag is a type of boolean and defaults to false.
+func FlagWithQuotesAndNewline(ctx ffcontext.Context) (bool, error) {
+	return ffclient.BoolVariation(FlagWithQuotesAndNewlineKey, ctx, false)
+}
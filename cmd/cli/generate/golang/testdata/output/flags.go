@@ -0,0 +1,140 @@
+// Code generated by go-feature-flag-cli generate go. DO NOT EDIT.
+
+// Package flags contains typed accessors for the flags of your GO Feature Flag configuration.
+package flags
+
+import (
+	"encoding/json"
+
+	ffclient "github.com/thomaspoignant/go-feature-flag"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+)
+
+// AllowedCountriesKey is the key of the flag "allowed-countries".
+const AllowedCountriesKey = "allowed-countries"
+
+// Variations of the flag "allowed-countries".
+const (
+	AllowedCountriesVariationEurope = "europe"
+	AllowedCountriesVariationWorld  = "world"
+)
+
+// AllowedCountries returns the value of the flag "allowed-countries".
+//
+// The flag is a type of array and defaults to nil.
+func AllowedCountries(ctx ffcontext.Context) ([]string, error) {
+	return decodeJSONVariation[[]string](ffclient.JSONArrayVariation(AllowedCountriesKey, ctx, nil))
+}
+
+// BannerConfigKey is the key of the flag "banner-config".
+const BannerConfigKey = "banner-config"
+
+// Variations of the flag "banner-config".
+const (
+	BannerConfigVariationBig   = "big"
+	BannerConfigVariationSmall = "small"
+)
+
+// BannerConfig returns the value of the flag "banner-config".
+//
+// Configuration of the banner.
+// Owned by the growth team.
+//
+// The flag is a type of object and defaults to map[string]any{"title": "Default"}.
+func BannerConfig(ctx ffcontext.Context) (BannerConfigValue, error) {
+	return decodeJSONVariation[BannerConfigValue](ffclient.JSONVariation(BannerConfigKey, ctx, map[string]any{"title": "Default"}))
+}
+
+// DiscountRateKey is the key of the flag "discount-rate".
+const DiscountRateKey = "discount-rate"
+
+// Variations of the flag "discount-rate".
+const (
+	DiscountRateVariationNone  = "none"
+	DiscountRateVariationSmall = "small"
+)
+
+// DiscountRate returns the value of the flag "discount-rate".
+//
+// The flag is a type of float and defaults to 0.0.
+func DiscountRate(ctx ffcontext.Context) (float64, error) {
+	return ffclient.Float64Variation(DiscountRateKey, ctx, 0.0)
+}
+
+// EnableDarkModeKey is the key of the flag "enable-dark-mode".
+const EnableDarkModeKey = "enable-dark-mode"
+
+// Variations of the flag "enable-dark-mode".
+const (
+	EnableDarkModeVariationDisabled = "disabled"
+	EnableDarkModeVariationEnabled  = "enabled"
+)
+
+// EnableDarkMode returns the value of the flag "enable-dark-mode".
+//
+// The flag is a type of boolean and defaults to false.
+func EnableDarkMode(ctx ffcontext.Context) (bool, error) {
+	return ffclient.BoolVariation(EnableDarkModeKey, ctx, false)
+}
+
+// MaxItemsKey is the key of the flag "max-items".
+const MaxItemsKey = "max-items"
+
+// Variations of the flag "max-items".
+const (
+	MaxItemsVariationHigh = "high"
+	MaxItemsVariationLow  = "low"
+)
+
+// MaxItems returns the value of the flag "max-items".
+//
+// The flag is a type of integer and defaults to 5.
+func MaxItems(ctx ffcontext.Context) (int, error) {
+	return ffclient.IntVariation(MaxItemsKey, ctx, 5)
+}
+
+// NewCheckoutFlowKey is the key of the flag "new-checkout-flow".
+const NewCheckoutFlowKey = "new-checkout-flow"
+
+// Variations of the flag "new-checkout-flow".
+const (
+	NewCheckoutFlowVariationLegacy  = "legacy"
+	NewCheckoutFlowVariationOnePage = "one-page"
+)
+
+// NewCheckoutFlow returns the value of the flag "new-checkout-flow".
+//
+// Select the checkout flow displayed to the user.
+//
+// The flag is a type of string and defaults to "legacy".
+func NewCheckoutFlow(ctx ffcontext.Context) (string, error) {
+	return ffclient.StringVariation(NewCheckoutFlowKey, ctx, "legacy")
+}
+
+// BannerConfigValue is generated from the JSON variations of the flag "banner-config".
+type BannerConfigValue struct {
+	Colors   []string                 `json:"colors"`
+	Layout   *BannerConfigValueLayout `json:"layout"`
+	MaxItems int                      `json:"maxItems"`
+	Title    string                   `json:"title"`
+	UserID   string                   `json:"user_id"`
+}
+
+// BannerConfigValueLayout is generated from the JSON variations of the flag "banner-config".
+type BannerConfigValueLayout struct {
+	Columns int     `json:"columns"`
+	Ratio   float64 `json:"ratio"`
+}
+
+// decodeJSONVariation converts the value of a JSON flag into its generated type.
+func decodeJSONVariation[T any](value any, err error) (T, error) {
+	var result T
+	content, marshalErr := json.Marshal(value)
+	if marshalErr != nil {
+		return result, marshalErr
+	}
+	if unmarshalErr := json.Unmarshal(content, &result); unmarshalErr != nil {
+		return result, unmarshalErr
+	}
+	return result, err
+}
@@ -0,0 +1,194 @@
+package golang
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// structType is a Go struct generated from the JSON variations of a flag.
+type structType struct {
+	Name    string
+	FlagKey string
+	Fields  []structField
+}
+
+// structField is a field of a generated struct.
+type structField struct {
+	Name     string
+	JSONName string
+	Type     string
+}
+
+// typeBuilder infers the Go types of JSON values and collects the struct types needed to represent them.
+type typeBuilder struct {
+	// flagKey is the key of the flag we are currently generating the types for.
+	flagKey string
+	structs []structType
+	names   map[string]bool
+}
+
+func newTypeBuilder() *typeBuilder {
+	return &typeBuilder{names: map[string]bool{}}
+}
+
+// goType returns the Go type able to represent all the values.
+// The values should be normalized (see normalizeValue), name is the name used if a struct type needs to be generated.
+func (b *typeBuilder) goType(name string, values []any) (string, error) {
+	kinds := map[string]bool{}
+	objects := make([]map[string]any, 0)
+	arrays := make([]any, 0)
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case bool:
+			kinds["bool"] = true
+		case string:
+			kinds["string"] = true
+		case float64:
+			if v == math.Trunc(v) {
+				kinds["int"] = true
+			} else {
+				kinds["float64"] = true
+			}
+		case map[string]any:
+			kinds["object"] = true
+			objects = append(objects, v)
+		case []any:
+			kinds["array"] = true
+			arrays = append(arrays, v...)
+		default:
+			return "", fmt.Errorf("unsupported type %T", value)
+		}
+	}
+
+	if kinds["int"] && kinds["float64"] {
+		// integers and floats are mixed, we use a float.
+		delete(kinds, "int")
+	}
+	if len(kinds) != 1 {
+		// no value or different types, we can't have a typed field.
+		return "any", nil
+	}
+
+	switch {
+	case kinds["object"]:
+		return b.structType(name, objects)
+	case kinds["array"]:
+		elemType, err := b.goType(name+"Item", arrays)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + elemType, nil
+	default:
+		for kind := range kinds {
+			return kind, nil
+		}
+	}
+	return "any", nil
+}
+
+// structType creates a struct with all the fields available in the objects.
+func (b *typeBuilder) structType(name string, objects []map[string]any) (string, error) {
+	name = b.uniqueName(name)
+	valuesByField := map[string][]any{}
+	for _, object := range objects {
+		for key, value := range object {
+			valuesByField[key] = append(valuesByField[key], value)
+		}
+	}
+	keys := make([]string, 0, len(valuesByField))
+	for key := range valuesByField {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// we reserve the index of the struct before generating the nested structs to keep the order of the file
+	index := len(b.structs)
+	b.structs = append(b.structs, structType{Name: name, FlagKey: b.flagKey})
+	fields := make([]structField, 0, len(keys))
+	fieldNames := map[string]bool{}
+	for _, key := range keys {
+		fieldName := exportedName(key)
+		if fieldNames[fieldName] {
+			return "", fmt.Errorf("fields %q of %s have the same Go name %s", key, name, fieldName)
+		}
+		fieldNames[fieldName] = true
+		fieldType, err := b.goType(name+fieldName, valuesByField[key])
+		if err != nil {
+			return "", fmt.Errorf("field %q: %w", key, err)
+		}
+		if b.names[fieldType] {
+			// nested objects are pointers since they are not always set
+			fieldType = "*" + fieldType
+		}
+		fields = append(fields, structField{Name: fieldName, JSONName: key, Type: fieldType})
+	}
+	b.structs[index].Fields = fields
+	return name, nil
+}
+
+// uniqueName returns a name not used by another struct.
+func (b *typeBuilder) uniqueName(name string) string {
+	candidate := name
+	for i := 2; b.names[candidate]; i++ {
+		candidate = name + strconv.Itoa(i)
+	}
+	b.names[candidate] = true
+	return candidate
+}
+
+// goLiteral returns the Go literal of a normalized value in the expected type.
+// Objects and arrays are represented with map[string]any and []any.
+func goLiteral(value any, goType string) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "nil", nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case string:
+		return strconv.Quote(v), nil
+	case float64:
+		if goType == "int" {
+			if v != math.Trunc(v) {
+				return "", fmt.Errorf("%v is not an integer", v)
+			}
+			return strconv.FormatInt(int64(v), 10), nil
+		}
+		literal := strconv.FormatFloat(v, 'f', -1, 64)
+		if !strings.ContainsAny(literal, ".e") && goType == "float64" {
+			literal += ".0"
+		}
+		return literal, nil
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		items := make([]string, 0, len(keys))
+		for _, key := range keys {
+			item, err := goLiteral(v[key], "any")
+			if err != nil {
+				return "", err
+			}
+			items = append(items, strconv.Quote(key)+": "+item)
+		}
+		return "map[string]any{" + strings.Join(items, ", ") + "}", nil
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, elem := range v {
+			item, err := goLiteral(elem, "any")
+			if err != nil {
+				return "", err
+			}
+			items = append(items, item)
+		}
+		return "[]any{" + strings.Join(items, ", ") + "}", nil
+	default:
+		return "", fmt.Errorf("unsupported type %T", value)
+	}
+}
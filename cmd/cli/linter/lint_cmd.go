@@ -31,7 +31,11 @@ func runLint(cmd *cobra.Command, args []string, lintFlagFormat string) error {
 		InputFile:   extractFilePathFromArgs(args),
 		InputFormat: lintFlagFormat,
 	}
-	if errs := l.Lint(); len(errs) > 0 {
+	errs := l.Lint()
+	for _, warning := range l.Warnings() {
+		output.Add(warning, helper.WarnLevel)
+	}
+	if len(errs) > 0 {
 		for _, err := range errs {
 			output.Add(err.Error(), helper.ErrorLevel)
 		}
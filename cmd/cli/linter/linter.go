@@ -2,17 +2,27 @@ package linter
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"time"
 
 	helper "github.com/thomaspoignant/go-feature-flag/cmdhelpers/configfile"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagenv"
+	"github.com/thomaspoignant/go-feature-flag/internal/lifecycle"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/dto"
 )
 
 type Linter struct {
 	InputFile   string
 	InputFormat string
+
+	// warnings are the issues found by the last call to Lint that don't make the configuration invalid.
+	warnings []string
 }
 
 func (l *Linter) Lint() []error {
-	flags, err := helper.LoadConfigFile(
+	l.warnings = nil
+	flags, overrides, err := helper.LoadConfigFileWithOverrides(
 		l.InputFile,
 		l.InputFormat,
 		helper.ConfigFileDefaultLocations,
@@ -21,11 +31,47 @@ func (l *Linter) Lint() []error {
 		return []error{err}
 	}
 	errs := make([]error, 0)
+	now := time.Now()
 	for key, flagDto := range flags {
 		flag := flagDto.Convert()
 		if err := flag.IsValid(); err != nil {
 			errs = append(errs, fmt.Errorf("%s: invalid flag %s: %w", l.InputFile, key, err))
 		}
+
+		errs = append(errs, l.lintEnvironments(key, flagDto, overrides[key])...)
+
+		flagLifecycle, err := lifecycle.FromMetadata(flag.GetMetadata())
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid flag %s: %w", l.InputFile, key, err))
+		}
+		if flagLifecycle.IsExpired(now) {
+			l.warnings = append(l.warnings, fmt.Sprintf("%s: flag %s has expired on %s and should be removed",
+				l.InputFile, key, flagLifecycle.Expiry.Format(time.DateOnly)))
+		}
+		if flagLifecycle.Type == lifecycle.Temporary && flagLifecycle.Expiry == nil {
+			l.warnings = append(l.warnings, fmt.Sprintf("%s: temporary flag %s has no expiry date",
+				l.InputFile, key))
+		}
 	}
+	slices.Sort(l.warnings)
 	return errs
 }
+
+// lintEnvironments checks that the flag is valid in each environment once its overrides are applied.
+func (l *Linter) lintEnvironments(key string, flagDto dto.DTO, overrides flagenv.Overrides) []error {
+	errs := make([]error, 0)
+	for _, environment := range slices.Sorted(maps.Keys(overrides.Environments)) {
+		environmentDto := overrides.Environments[environment].Apply(flagDto)
+		environmentFlag := environmentDto.Convert()
+		if err := environmentFlag.IsValid(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid flag %s in environment %s: %w",
+				l.InputFile, key, environment, err))
+		}
+	}
+	return errs
+}
+
+// Warnings returns the warnings found by the last call to Lint, ex: the expired flags.
+func (l *Linter) Warnings() []string {
+	return l.warnings
+}
@@ -77,6 +77,22 @@ func TestLinter_Lint(t *testing.T) {
 			},
 			wantErr: assert.Error,
 		},
+		{
+			name: "valid include",
+			linter: Linter{
+				InputFile:   "testdata/include-valid.yaml",
+				InputFormat: "yaml",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "include with an invalid flag",
+			linter: Linter{
+				InputFile:   "testdata/include-invalid.yaml",
+				InputFormat: "yaml",
+			},
+			wantErr: assert.Error,
+		},
 		{
 			name: "no default rule",
 			linter: Linter{
@@ -93,6 +109,46 @@ func TestLinter_Lint(t *testing.T) {
 			},
 			wantErr: assert.Error,
 		},
+		{
+			name: "valid schedule",
+			linter: Linter{
+				InputFile:   "testdata/valid-schedule.yaml",
+				InputFormat: "yaml",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "invalid schedule timezone",
+			linter: Linter{
+				InputFile:   "testdata/invalid-schedule-timezone.yaml",
+				InputFormat: "yaml",
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "invalid schedule cron",
+			linter: Linter{
+				InputFile:   "testdata/invalid-schedule-cron.yaml",
+				InputFormat: "yaml",
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "valid json schema",
+			linter: Linter{
+				InputFile:   "testdata/valid-json-schema.yaml",
+				InputFormat: "yaml",
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "variation not matching the json schema",
+			linter: Linter{
+				InputFile:   "testdata/invalid-json-schema.yaml",
+				InputFormat: "yaml",
+			},
+			wantErr: assert.Error,
+		},
 		{
 			name: "invalid file",
 			linter: Linter{
@@ -111,3 +167,58 @@ func TestLinter_Lint(t *testing.T) {
 		})
 	}
 }
+
+func TestLinter_Lifecycle(t *testing.T) {
+	tests := []struct {
+		name         string
+		linter       Linter
+		wantErrs     []string
+		wantWarnings []string
+	}{
+		{
+			name:     "expired and temporary flags",
+			linter:   Linter{InputFile: "testdata/lifecycle.yaml", InputFormat: "yaml"},
+			wantErrs: []string{},
+			wantWarnings: []string{
+				"testdata/lifecycle.yaml: flag expired-flag has expired on 2020-01-01 and should be removed",
+				"testdata/lifecycle.yaml: temporary flag temporary-flag has no expiry date",
+			},
+		},
+		{
+			name:   "invalid lifecycle metadata",
+			linter: Linter{InputFile: "testdata/invalid-lifecycle.yaml", InputFormat: "yaml"},
+			wantErrs: []string{
+				"testdata/invalid-lifecycle.yaml: invalid flag invalid-lifecycle-flag: invalid lifecycle metadata: " +
+					"expiry should be a date with the format 2006-01-02 or RFC3339, " +
+					"lifecycle should be temporary or permanent, deprecated should be a boolean",
+			},
+		},
+		{
+			name:   "invalid tags",
+			linter: Linter{InputFile: "testdata/invalid-tags.yaml", InputFormat: "yaml"},
+			wantErrs: []string{
+				"testdata/invalid-tags.yaml: invalid flag invalid-tags-flag: " +
+					"invalid tags: the tag \"checkout,mobile\" cannot contain a comma",
+			},
+		},
+		{
+			name:   "invalid flag in an environment",
+			linter: Linter{InputFile: "testdata/environments.yaml", InputFormat: "yaml"},
+			wantErrs: []string{
+				"testdata/environments.yaml: invalid flag environment-flag in environment production: " +
+					"impossible to return value",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.linter.Lint()
+			gotErrs := make([]string, 0, len(errs))
+			for _, err := range errs {
+				gotErrs = append(gotErrs, err.Error())
+			}
+			assert.Equal(t, tt.wantErrs, gotErrs)
+			assert.Equal(t, tt.wantWarnings, tt.linter.Warnings())
+		})
+	}
+}
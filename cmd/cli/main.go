@@ -6,6 +6,8 @@ import (
 	"github.com/thomaspoignant/go-feature-flag/cmd/cli/generate"
 	"github.com/thomaspoignant/go-feature-flag/cmd/cli/helper"
 	"github.com/thomaspoignant/go-feature-flag/cmd/cli/linter"
+	"github.com/thomaspoignant/go-feature-flag/cmd/cli/refs"
+	"github.com/thomaspoignant/go-feature-flag/cmd/cli/snapshot"
 )
 
 func main() {
@@ -23,5 +25,7 @@ func initRootCmd() *cobra.Command {
 	rootCmd.AddCommand(evaluate.NewEvaluateCmd())
 	rootCmd.AddCommand(linter.NewLintCmd())
 	rootCmd.AddCommand(generate.NewGenerateCmd())
+	rootCmd.AddCommand(refs.NewRefsCmd())
+	rootCmd.AddCommand(snapshot.NewSnapshotCmd())
 	return rootCmd
 }
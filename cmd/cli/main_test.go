@@ -1,6 +1,7 @@
 package main
 
 import (
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -32,4 +33,20 @@ func TestCmdExist(t *testing.T) {
 		err := cmd.Execute()
 		require.NoError(t, err)
 	})
+	t.Run("refs command should exist", func(t *testing.T) {
+		cmd := initRootCmd()
+		assert.NotNil(t, cmd)
+		cmd.SetOut(io.Discard)
+		cmd.SetArgs([]string{"refs", "refs/testdata/repo", "--config", "refs/testdata/flags.yaml"})
+		err := cmd.Execute()
+		require.NoError(t, err)
+	})
+	t.Run("snapshot command should exist", func(t *testing.T) {
+		cmd := initRootCmd()
+		assert.NotNil(t, cmd)
+		cmd.SetOut(io.Discard)
+		cmd.SetArgs([]string{"snapshot", "--help"})
+		err := cmd.Execute()
+		require.NoError(t, err)
+	})
 }
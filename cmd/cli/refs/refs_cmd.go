@@ -0,0 +1,97 @@
+package refs
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	configHelper "github.com/thomaspoignant/go-feature-flag/cmdhelpers/configfile"
+)
+
+var (
+	refsFlagFormat      string
+	refsConfigFile      string
+	refsOutputFormat    string
+	refsPatterns        []string
+	refsExclude         []string
+	refsFailOnUndefined bool
+)
+
+func NewRefsCmd() *cobra.Command {
+	refsCmd := &cobra.Command{
+		Use:   "refs [path]",
+		Short: "🔎 Find the references to your flags in the source code.",
+		Long: "🔎 Scan the source code to find the references to your flags and cross-reference them with your " +
+			"flags configuration file, to report the unused flags and the references to flags that don't exist.",
+		Example: `
+# Scan the current directory and print a JSON report
+refs --config ./flags.goff.yaml
+
+# Scan a directory and print a SARIF report, with a custom pattern for the ruby files
+refs ./src --config ./flags.goff.yaml --output-format sarif --pattern '*.rb=feature_enabled\?\("([^"]+)"'`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRefs(cmd, args)
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	refsCmd.Flags().StringVarP(&refsFlagFormat,
+		"format", "f", "yaml", "Format of your input file (YAML, JSON or TOML)")
+	refsCmd.Flags().StringVarP(&refsConfigFile,
+		"config", "c", "", "Location of your GO Feature Flag local configuration file")
+	refsCmd.Flags().StringVarP(&refsOutputFormat,
+		"output-format", "o", OutputFormatJSON, "Format of the report (json or sarif)")
+	refsCmd.Flags().StringArrayVar(&refsPatterns,
+		"pattern", []string{}, "Pattern to find the flag keys in other languages, with the format "+
+			"<glob>=<regex> where the first capture group of the regex is the flag key (can be repeated)")
+	refsCmd.Flags().StringArrayVar(&refsExclude,
+		"exclude", []string{}, "Glob of the files or directories to ignore (can be repeated)")
+	refsCmd.Flags().BoolVar(&refsFailOnUndefined,
+		"fail-on-undefined", false, "Return an error if some references to undefined flags are found")
+	return refsCmd
+}
+
+func runRefs(cmd *cobra.Command, args []string) error {
+	root := "."
+	if len(args) > 0 {
+		root = args[0]
+	}
+
+	patterns := slices.Clone(DefaultPatterns)
+	for _, value := range refsPatterns {
+		p, err := ParsePattern(value)
+		if err != nil {
+			return err
+		}
+		patterns = append(patterns, p)
+	}
+
+	flagDTOs, err := configHelper.LoadConfigFile(
+		refsConfigFile,
+		refsFlagFormat,
+		configHelper.ConfigFileDefaultLocations,
+	)
+	if err != nil {
+		return err
+	}
+	flagKeys := make([]string, 0, len(flagDTOs))
+	for key := range flagDTOs {
+		flagKeys = append(flagKeys, key)
+	}
+
+	scanner := Scanner{Root: root, Patterns: patterns, Exclude: refsExclude}
+	references, err := scanner.Scan()
+	if err != nil {
+		return fmt.Errorf("impossible to scan %s: %w", root, err)
+	}
+
+	report := NewReport(flagKeys, references, refsConfigFile)
+	if err := report.Write(cmd.OutOrStdout(), refsOutputFormat); err != nil {
+		return err
+	}
+	if refsFailOnUndefined && len(report.UndefinedReferences) > 0 {
+		return fmt.Errorf("%d reference(s) to undefined flags found", len(report.UndefinedReferences))
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package refs_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/cmd/cli/refs"
+)
+
+func TestCmdRefs(t *testing.T) {
+	pterm.DisableStyling()
+	pterm.DisableColor()
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    assert.ErrorAssertionFunc
+		wantErrMsg string
+		check      func(t *testing.T, output []byte)
+	}{
+		{
+			name:    "json report",
+			args:    []string{"testdata/repo", "--config", "testdata/flags.yaml"},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, output []byte) {
+				var report refs.Report
+				require.NoError(t, json.Unmarshal(output, &report))
+				assert.Equal(t, []string{"legacy-flag"}, report.UnusedFlags)
+				assert.Equal(t, []refs.Location{
+					{File: "main.go", Line: 19, Column: 32},
+					{File: "web/app.ts", Line: 4, Column: 53},
+				}, report.Flags["new-checkout"])
+				assert.Equal(t, []refs.Location{{File: "main.go", Line: 14, Column: 17}}, report.Flags["max-retries"])
+				assert.Equal(t, []refs.Reference{
+					{FlagKey: "unknown-flag", File: "main.go", Line: 23, Column: 51, Source: refs.SourceGo},
+					{FlagKey: "dark-mode", File: "web/app.ts", Line: 5, Column: 45, Source: refs.SourcePattern},
+				}, report.UndefinedReferences)
+			},
+		},
+		{
+			name: "custom pattern",
+			args: []string{
+				"testdata/repo", "--config", "testdata/flags.yaml",
+				"--pattern", `*.rb=feature_enabled\?\("([^"]+)"`,
+			},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, output []byte) {
+				var report refs.Report
+				require.NoError(t, json.Unmarshal(output, &report))
+				assert.Empty(t, report.UnusedFlags)
+				assert.Equal(t, []refs.Location{{File: "feature.rb", Line: 1, Column: 22}}, report.Flags["legacy-flag"])
+			},
+		},
+		{
+			name:    "sarif report",
+			args:    []string{"testdata/repo", "--config", "testdata/flags.yaml", "--output-format", "sarif"},
+			wantErr: assert.NoError,
+			check: func(t *testing.T, output []byte) {
+				var sarif struct {
+					Version string `json:"version"`
+					Runs    []struct {
+						Results []struct {
+							RuleID    string `json:"ruleId"`
+							Level     string `json:"level"`
+							Locations []struct {
+								PhysicalLocation struct {
+									ArtifactLocation struct {
+										URI string `json:"uri"`
+									} `json:"artifactLocation"`
+									Region struct {
+										StartLine int `json:"startLine"`
+									} `json:"region"`
+								} `json:"physicalLocation"`
+							} `json:"locations"`
+						} `json:"results"`
+					} `json:"runs"`
+				}
+				require.NoError(t, json.Unmarshal(output, &sarif))
+				assert.Equal(t, "2.1.0", sarif.Version)
+				require.Len(t, sarif.Runs, 1)
+				results := sarif.Runs[0].Results
+				require.Len(t, results, 7)
+				assert.Equal(t, "undefined-flag-reference", results[0].RuleID)
+				assert.Equal(t, "error", results[0].Level)
+				assert.Equal(t, "main.go", results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+				assert.Equal(t, "unused-flag", results[2].RuleID)
+				assert.Equal(t, "warning", results[2].Level)
+				assert.Equal(t, "testdata/flags.yaml", results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+				assert.Equal(t, 22, results[2].Locations[0].PhysicalLocation.Region.StartLine)
+				assert.Equal(t, "flag-reference", results[3].RuleID)
+				assert.Equal(t, "note", results[3].Level)
+			},
+		},
+		{
+			name:       "fail on undefined references",
+			args:       []string{"testdata/repo", "--config", "testdata/flags.yaml", "--fail-on-undefined"},
+			wantErr:    assert.Error,
+			wantErrMsg: "2 reference(s) to undefined flags found",
+		},
+		{
+			name:       "invalid output format",
+			args:       []string{"testdata/repo", "--config", "testdata/flags.yaml", "--output-format", "xml"},
+			wantErr:    assert.Error,
+			wantErrMsg: `invalid output format "xml", accepted values are json and sarif`,
+		},
+		{
+			name:       "invalid pattern",
+			args:       []string{"testdata/repo", "--config", "testdata/flags.yaml", "--pattern", "*.rb"},
+			wantErr:    assert.Error,
+			wantErrMsg: `invalid pattern "*.rb": expected format <glob>=<regex>`,
+		},
+		{
+			name:    "missing configuration file",
+			args:    []string{"testdata/repo", "--config", "testdata/not-exists.yaml"},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			cmd := refs.NewRefsCmd()
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			tt.wantErr(t, err)
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+			}
+			if tt.check != nil {
+				tt.check(t, stdout.Bytes())
+			}
+		})
+	}
+}
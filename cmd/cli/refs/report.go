@@ -0,0 +1,269 @@
+package refs
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// OutputFormatJSON is the JSON output format of the report.
+	OutputFormatJSON = "json"
+	// OutputFormatSARIF is the SARIF 2.1.0 output format of the report, used by the code scanning tools.
+	OutputFormatSARIF = "sarif"
+)
+
+const (
+	sarifVersion  = "2.1.0"
+	sarifSchema   = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName = "go-feature-flag-cli"
+	sarifToolURI  = "https://gofeatureflag.org/docs/tooling/cli"
+
+	ruleUndefinedReference = "undefined-flag-reference"
+	ruleUnusedFlag         = "unused-flag"
+	ruleFlagReference      = "flag-reference"
+)
+
+// Location is the location of a flag reference in the source code.
+type Location struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// Report is the result of the cross-reference between the source code and the flag configuration.
+type Report struct {
+	// Flags are the locations of the references of each flag of the configuration.
+	// A flag without reference has an empty list of locations.
+	Flags map[string][]Location `json:"flags"`
+	// UnusedFlags are the flags of the configuration never referenced in the source code.
+	UnusedFlags []string `json:"unusedFlags"`
+	// UndefinedReferences are the references to flags that are not in the configuration.
+	UndefinedReferences []Reference `json:"undefinedReferences"`
+
+	// configFile is the flag configuration file used to locate the unused flags in the SARIF output.
+	configFile string
+}
+
+// NewReport cross-references the references found in the source code with the flags of the configuration.
+func NewReport(flagKeys []string, references []Reference, configFile string) Report {
+	report := Report{
+		Flags:               make(map[string][]Location, len(flagKeys)),
+		UnusedFlags:         []string{},
+		UndefinedReferences: []Reference{},
+		configFile:          configFile,
+	}
+	for _, key := range flagKeys {
+		report.Flags[key] = []Location{}
+	}
+	for _, ref := range references {
+		locations, ok := report.Flags[ref.FlagKey]
+		if !ok {
+			report.UndefinedReferences = append(report.UndefinedReferences, ref)
+			continue
+		}
+		report.Flags[ref.FlagKey] = append(locations, Location{File: ref.File, Line: ref.Line, Column: ref.Column})
+	}
+	for key, locations := range report.Flags {
+		if len(locations) == 0 {
+			report.UnusedFlags = append(report.UnusedFlags, key)
+		}
+	}
+	slices.Sort(report.UnusedFlags)
+	return report
+}
+
+// Write writes the report in the output format.
+func (r Report) Write(w io.Writer, outputFormat string) error {
+	var content any
+	switch strings.ToLower(outputFormat) {
+	case OutputFormatJSON, "":
+		content = r
+	case OutputFormatSARIF:
+		content = r.sarif()
+	default:
+		return fmt.Errorf("invalid output format %q, accepted values are %s and %s",
+			outputFormat, OutputFormatJSON, OutputFormatSARIF)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(content)
+}
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	DefaultConfig    sarifConfig  `json:"defaultConfiguration"`
+}
+
+type sarifConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// sarif converts the report into a SARIF log.
+// The undefined references are errors, the unused flags are warnings located in the configuration file
+// and the other references are notes.
+func (r Report) sarif() sarifLog {
+	results := make([]sarifResult, 0)
+	for _, ref := range r.UndefinedReferences {
+		results = append(results, sarifResult{
+			RuleID:    ruleUndefinedReference,
+			Level:     "error",
+			Message:   sarifMessage{Text: fmt.Sprintf("flag %q is not defined in the flag configuration", ref.FlagKey)},
+			Locations: []sarifLocation{newSarifLocation(ref.File, ref.Line, ref.Column)},
+		})
+	}
+
+	configLines := flagLinesInFile(r.configFile, r.UnusedFlags)
+	for _, key := range r.UnusedFlags {
+		result := sarifResult{
+			RuleID:  ruleUnusedFlag,
+			Level:   "warning",
+			Message: sarifMessage{Text: fmt.Sprintf("flag %q is never referenced in the source code", key)},
+		}
+		if r.configFile != "" {
+			result.Locations = []sarifLocation{
+				newSarifLocation(filepath.ToSlash(r.configFile), max(configLines[key], 1), 0)}
+		}
+		results = append(results, result)
+	}
+
+	keys := make([]string, 0, len(r.Flags))
+	for key := range r.Flags {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		for _, location := range r.Flags[key] {
+			results = append(results, sarifResult{
+				RuleID:    ruleFlagReference,
+				Level:     "note",
+				Message:   sarifMessage{Text: fmt.Sprintf("reference to flag %q", key)},
+				Locations: []sarifLocation{newSarifLocation(location.File, location.Line, location.Column)},
+			})
+		}
+	}
+
+	return sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           sarifToolName,
+				InformationURI: sarifToolURI,
+				Rules: []sarifRule{
+					newSarifRule(ruleUndefinedReference, "error",
+						"The flag is referenced in the source code but not defined in the flag configuration."),
+					newSarifRule(ruleUnusedFlag, "warning",
+						"The flag is defined in the flag configuration but never referenced in the source code."),
+					newSarifRule(ruleFlagReference, "note", "Reference to a flag in the source code."),
+				},
+			}},
+			Results: results,
+		}},
+	}
+}
+
+func newSarifRule(id string, level string, description string) sarifRule {
+	return sarifRule{
+		ID:               id,
+		ShortDescription: sarifMessage{Text: description},
+		DefaultConfig:    sarifConfig{Level: level},
+	}
+}
+
+func newSarifLocation(file string, line int, column int) sarifLocation {
+	return sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+		ArtifactLocation: sarifArtifactLocation{URI: file},
+		Region:           sarifRegion{StartLine: line, StartColumn: column},
+	}}
+}
+
+// flagLinesInFile returns the line where each flag is declared in the configuration file.
+// It looks for the first line starting with the flag key (optionally quoted), which works for
+// the YAML, JSON and TOML formats. The flags not found are not in the returned map.
+func flagLinesInFile(file string, flagKeys []string) map[string]int {
+	lines := map[string]int{}
+	if file == "" || len(flagKeys) == 0 {
+		return lines
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return lines
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimLeft(scanner.Text(), " \t[")
+		for _, key := range flagKeys {
+			if _, ok := lines[key]; ok {
+				continue
+			}
+			for _, prefix := range []string{key, `"` + key + `"`, "'" + key + "'"} {
+				rest, found := strings.CutPrefix(text, prefix)
+				rest = strings.TrimLeft(rest, " \t]")
+				if found && (rest == "" || rest[0] == ':' || rest[0] == '=') {
+					lines[key] = line
+					break
+				}
+			}
+		}
+	}
+	return lines
+}
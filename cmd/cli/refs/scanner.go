@@ -0,0 +1,536 @@
+package refs
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SourceGo is the source of the references found by parsing the Go files.
+	SourceGo = "go"
+	// SourcePattern is the source of the references found with a pattern.
+	SourcePattern = "pattern"
+)
+
+const (
+	// goModuleImportPath is the import path of the Go module.
+	goModuleImportPath = "github.com/thomaspoignant/go-feature-flag"
+	// goOpenFeatureImportPath is the import path of the OpenFeature Go SDK.
+	goOpenFeatureImportPath = "github.com/open-feature/go-sdk/openfeature"
+)
+
+// goVariationFunc matches the variation functions of the Go module
+// (ex: BoolVariation, StringVariationDetailsCtx, VariationFrom, RawVariation).
+// The "From" functions take the client before the flag key and the "Ctx" functions take a context.Context first.
+var goVariationFunc = regexp.MustCompile(
+	`^(Bool|Int|Float64|String|JSONArray|JSON|Raw)?Variation(Details)?(From)?(Ctx)?$`)
+
+// goOpenFeatureFunc matches the evaluation methods of the OpenFeature Go client (ex: BooleanValue, StringValueDetails).
+// The flag key is the second argument, after the context.Context.
+var goOpenFeatureFunc = regexp.MustCompile(`^(Boolean|String|Int|Float|Object)Value(Details)?$`)
+
+// defaultExcludedDirs are the directories never scanned.
+var defaultExcludedDirs = []string{".git", "vendor", "node_modules"}
+
+// DefaultPatterns are the patterns used to find the references in the other languages,
+// they match the evaluation methods of the OpenFeature SDKs.
+var DefaultPatterns = []Pattern{
+	{
+		Glob: "*.{js,jsx,ts,tsx,java,kt,cs,swift,dart,php,rb}",
+		Regex: regexp.MustCompile(
+			`(?:get|Get)(?:Boolean|String|Number|Integer|Double|Float|Object|Int|Struct)(?:Value|Details)` +
+				`(?:Async)?\(\s*["']([^"']+)["']`),
+	},
+	{
+		Glob:  "*.py",
+		Regex: regexp.MustCompile(`get_(?:boolean|string|integer|float|object)_(?:value|details)\(\s*["']([^"']+)["']`),
+	},
+}
+
+// Pattern is a regular expression finding flag keys in the files matching Glob.
+// The first capture group of the regex is the flag key.
+type Pattern struct {
+	// Glob is matched against the name of the file, it supports alternatives (ex: "*.{js,ts}").
+	Glob  string
+	Regex *regexp.Regexp
+}
+
+// ParsePattern parses a pattern with the format "<glob>=<regex>".
+func ParsePattern(value string) (Pattern, error) {
+	glob, expr, ok := strings.Cut(value, "=")
+	if !ok || glob == "" || expr == "" {
+		return Pattern{}, fmt.Errorf("invalid pattern %q: expected format <glob>=<regex>", value)
+	}
+	regex, err := regexp.Compile(expr)
+	if err != nil {
+		return Pattern{}, fmt.Errorf("invalid pattern %q: %w", value, err)
+	}
+	if regex.NumSubexp() < 1 {
+		return Pattern{}, fmt.Errorf("invalid pattern %q: the regex needs a capture group for the flag key", value)
+	}
+	return Pattern{Glob: glob, Regex: regex}, nil
+}
+
+// matches returns true if the file name matches the glob of the pattern.
+func (p Pattern) matches(name string) bool {
+	for _, glob := range expandGlob(p.Glob) {
+		if ok, _ := filepath.Match(glob, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// expandGlob expands the alternatives of a glob ("*.{js,ts}" gives "*.js" and "*.ts").
+func expandGlob(glob string) []string {
+	start := strings.Index(glob, "{")
+	end := strings.Index(glob, "}")
+	if start < 0 || end < start {
+		return []string{glob}
+	}
+	globs := make([]string, 0)
+	for _, alternative := range strings.Split(glob[start+1:end], ",") {
+		globs = append(globs, expandGlob(glob[:start]+alternative+glob[end+1:])...)
+	}
+	return globs
+}
+
+// Reference is a flag key found in the source code.
+type Reference struct {
+	FlagKey string `json:"flagKey"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column"`
+	// Source is how the reference has been found (go or pattern).
+	Source string `json:"source"`
+}
+
+// Scanner finds the flag references in a directory.
+type Scanner struct {
+	// Root is the directory to scan.
+	Root string
+	// Patterns are used to find the references in the files that are not Go files.
+	Patterns []Pattern
+	// Exclude are globs of the files and directories to ignore, matched against the path relative to Root
+	// and against the name.
+	Exclude []string
+}
+
+// Scan walks the directory and returns the references sorted by file and position.
+// The paths of the references are relative to Root.
+func (s *Scanner) Scan() ([]Reference, error) {
+	refs := make([]Reference, 0)
+	goFiles := map[string][]string{}
+	err := filepath.WalkDir(s.Root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.Root, path)
+		if err != nil {
+			return err
+		}
+		if rel != "." && s.isExcluded(rel, d) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if strings.HasSuffix(d.Name(), ".go") {
+			goFiles[filepath.Dir(path)] = append(goFiles[filepath.Dir(path)], path)
+			return nil
+		}
+		fileRefs, err := s.scanWithPatterns(path, d.Name())
+		if err != nil {
+			return err
+		}
+		refs = append(refs, fileRefs...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, files := range goFiles {
+		goRefs, err := scanGoPackage(files)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, goRefs...)
+	}
+
+	for i := range refs {
+		if rel, err := filepath.Rel(s.Root, refs[i].File); err == nil {
+			refs[i].File = filepath.ToSlash(rel)
+		}
+	}
+	slices.SortFunc(refs, func(a, b Reference) int {
+		if c := strings.Compare(a.File, b.File); c != 0 {
+			return c
+		}
+		if a.Line != b.Line {
+			return a.Line - b.Line
+		}
+		return a.Column - b.Column
+	})
+	return refs, nil
+}
+
+func (s *Scanner) isExcluded(rel string, d fs.DirEntry) bool {
+	if d.IsDir() && slices.Contains(defaultExcludedDirs, d.Name()) {
+		return true
+	}
+	for _, glob := range s.Exclude {
+		for _, g := range expandGlob(glob) {
+			if ok, _ := filepath.Match(g, filepath.ToSlash(rel)); ok {
+				return true
+			}
+			if ok, _ := filepath.Match(g, d.Name()); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// scanWithPatterns finds the references in a file with the patterns matching its name.
+func (s *Scanner) scanWithPatterns(path string, name string) ([]Reference, error) {
+	patterns := make([]Pattern, 0)
+	for _, p := range s.Patterns {
+		if p.matches(name) {
+			patterns = append(patterns, p)
+		}
+	}
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	refs := make([]Reference, 0)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		for _, p := range patterns {
+			for _, match := range p.Regex.FindAllStringSubmatchIndex(scanner.Text(), -1) {
+				if len(match) < 4 || match[2] < 0 {
+					continue
+				}
+				refs = append(refs, Reference{
+					FlagKey: scanner.Text()[match[2]:match[3]],
+					File:    path,
+					Line:    line,
+					Column:  match[2] + 1,
+					Source:  SourcePattern,
+				})
+			}
+		}
+	}
+	return refs, scanner.Err()
+}
+
+// scanGoPackage finds the references in the Go files of a directory.
+// The flag keys can be string literals or string constants declared in the directory.
+func scanGoPackage(paths []string) ([]Reference, error) {
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(paths))
+	for _, path := range paths {
+		f, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			// we ignore the files that can't be parsed (ex: templates), they can't be built anyway.
+			continue
+		}
+		files = append(files, f)
+	}
+
+	constants := map[string]string{}
+	clients := goClients{goModule: map[string]bool{}, openFeature: map[string]bool{}}
+	for _, f := range files {
+		collectStringConstants(f, constants)
+		collectClients(f, clients)
+	}
+
+	refs := make([]Reference, 0)
+	for _, f := range files {
+		imports := goImports{
+			goModule:    importName(f, goModuleImportPath, "ffclient"),
+			openFeature: importName(f, goOpenFeatureImportPath, "openfeature"),
+		}
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.CallExpr:
+				if key, pos, ok := flagKeyFromCall(node, imports, clients, constants); ok {
+					refs = append(refs, goReference(fset, key, pos))
+				}
+			case *ast.Field:
+				if key, ok := flagKeyFromBindTag(node); ok {
+					refs = append(refs, goReference(fset, key, node.Tag.Pos()))
+				}
+			}
+			return true
+		})
+	}
+	return refs, nil
+}
+
+// goImports are the names of the Go module and OpenFeature SDK packages in a file, empty if not imported.
+type goImports struct {
+	goModule    string
+	openFeature string
+}
+
+// goClients are the names of the variables and fields of a package holding a client.
+// The Go files are not type checked, so a client is found by the type of its declaration
+// (ex: *ffclient.GoFeatureFlag, openfeature.IClient) or by the constructor it comes from
+// (ex: ffclient.New, openfeature.NewClient).
+type goClients struct {
+	goModule    map[string]bool
+	openFeature map[string]bool
+}
+
+// importName returns the name of the package imported with path in the file, empty if not imported.
+func importName(f *ast.File, path string, defaultName string) string {
+	for _, spec := range f.Imports {
+		if value, err := strconv.Unquote(spec.Path.Value); err != nil || value != path {
+			continue
+		}
+		if spec.Name == nil {
+			return defaultName
+		}
+		if spec.Name.Name == "_" || spec.Name.Name == "." {
+			return ""
+		}
+		return spec.Name.Name
+	}
+	return ""
+}
+
+// collectClients adds the variables and fields of the file holding a client to clients.
+func collectClients(f *ast.File, clients goClients) {
+	imports := goImports{
+		goModule:    importName(f, goModuleImportPath, "ffclient"),
+		openFeature: importName(f, goOpenFeatureImportPath, "openfeature"),
+	}
+	if imports.goModule == "" && imports.openFeature == "" {
+		return
+	}
+	add := func(name string, typ ast.Expr, value ast.Expr) {
+		switch {
+		case name == "" || name == "_":
+		case isPackageSelector(typ, imports.goModule, "GoFeatureFlag"),
+			isConstructor(value, imports.goModule, "New"):
+			clients.goModule[name] = true
+		case isPackageSelector(typ, imports.openFeature, "Client", "IClient"),
+			isConstructor(value, imports.openFeature, "NewClient", "NewDefaultClient"):
+			clients.openFeature[name] = true
+		}
+	}
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.Field:
+			for _, name := range node.Names {
+				add(name.Name, node.Type, nil)
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				add(name.Name, node.Type, valueAt(node.Values, i, len(node.Names)))
+			}
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				add(receiverName(lhs), nil, valueAt(node.Rhs, i, len(node.Lhs)))
+			}
+		}
+		return true
+	})
+}
+
+// valueAt returns the value assigned to the i-th name, the first name gets the first result
+// of a call returning several values (ex: client, err := ffclient.New(config)).
+func valueAt(values []ast.Expr, i int, names int) ast.Expr {
+	switch {
+	case len(values) == names:
+		return values[i]
+	case len(values) == 1 && i == 0:
+		return values[0]
+	default:
+		return nil
+	}
+}
+
+// isPackageSelector returns true if expr is (a pointer to) one of the names of the package pkg.
+func isPackageSelector(expr ast.Expr, pkg string, names ...string) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	selector, ok := expr.(*ast.SelectorExpr)
+	if !ok || pkg == "" {
+		return false
+	}
+	ident, ok := selector.X.(*ast.Ident)
+	return ok && ident.Name == pkg && slices.Contains(names, selector.Sel.Name)
+}
+
+// isConstructor returns true if expr is a call to one of the functions of the package pkg.
+func isConstructor(expr ast.Expr, pkg string, names ...string) bool {
+	if unary, ok := expr.(*ast.UnaryExpr); ok {
+		expr = unary.X
+	}
+	call, ok := expr.(*ast.CallExpr)
+	return ok && isPackageSelector(call.Fun, pkg, names...)
+}
+
+// receiverName returns the name of the variable or field in expr (ex: "client" for s.client).
+func receiverName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	default:
+		return ""
+	}
+}
+
+func goReference(fset *token.FileSet, flagKey string, pos token.Pos) Reference {
+	position := fset.Position(pos)
+	return Reference{
+		FlagKey: flagKey,
+		File:    position.Filename,
+		Line:    position.Line,
+		Column:  position.Column,
+		Source:  SourceGo,
+	}
+}
+
+// collectStringConstants adds the string constants declared in the file to constants.
+func collectStringConstants(f *ast.File, constants map[string]string) {
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			for i, name := range valueSpec.Names {
+				if i >= len(valueSpec.Values) {
+					continue
+				}
+				if value, ok := stringLiteral(valueSpec.Values[i]); ok {
+					constants[name.Name] = value
+				}
+			}
+		}
+	}
+}
+
+// flagKeyFromCall returns the flag key of a call to a variation function of the Go module or an evaluation
+// method of the OpenFeature client. The other calls with the same names are ignored, and only the argument
+// at the position of the flag key is read, so the default values are never reported as flag keys.
+func flagKeyFromCall(
+	call *ast.CallExpr, imports goImports, clients goClients, constants map[string]string,
+) (string, token.Pos, bool) {
+	fun := call.Fun
+	switch f := fun.(type) {
+	case *ast.IndexExpr:
+		fun = f.X
+	case *ast.IndexListExpr:
+		fun = f.X
+	}
+	selector, ok := fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", token.NoPos, false
+	}
+
+	name := selector.Sel.Name
+	keyIndex := -1
+	switch receiver := receiverName(selector.X); {
+	case goVariationFunc.MatchString(name) && isPackageIdent(selector.X, imports.goModule):
+		keyIndex = 0
+		if strings.Contains(name, "From") {
+			keyIndex++
+		}
+		if strings.HasSuffix(name, "Ctx") {
+			keyIndex++
+		}
+	case goVariationFunc.MatchString(name) && !strings.Contains(name, "From") && clients.goModule[receiver]:
+		keyIndex = 0
+		if strings.HasSuffix(name, "Ctx") {
+			keyIndex++
+		}
+	case goOpenFeatureFunc.MatchString(name) && clients.openFeature[receiver]:
+		keyIndex = 1
+	}
+	if keyIndex < 0 || keyIndex >= len(call.Args) {
+		return "", token.NoPos, false
+	}
+
+	arg := call.Args[keyIndex]
+	if value, ok := stringLiteral(arg); ok {
+		return value, arg.Pos(), true
+	}
+	if ident, ok := arg.(*ast.Ident); ok {
+		if value, ok := constants[ident.Name]; ok {
+			return value, arg.Pos(), true
+		}
+	}
+	return "", token.NoPos, false
+}
+
+// isPackageIdent returns true if expr is the name of the imported package pkg.
+func isPackageIdent(expr ast.Expr, pkg string) bool {
+	ident, ok := expr.(*ast.Ident)
+	return ok && pkg != "" && ident.Name == pkg
+}
+
+// flagKeyFromBindTag returns the flag key of a field bound with ffclient.Bind (`goff:"<flag key>,..."`).
+func flagKeyFromBindTag(field *ast.Field) (string, bool) {
+	if field.Tag == nil {
+		return "", false
+	}
+	tag, err := strconv.Unquote(field.Tag.Value)
+	if err != nil {
+		return "", false
+	}
+	value, ok := reflect.StructTag(tag).Lookup("goff")
+	if !ok {
+		return "", false
+	}
+	key, _, _ := strings.Cut(value, ",")
+	key = strings.TrimSpace(key)
+	if key == "" || key == "-" {
+		return "", false
+	}
+	return key, true
+}
+
+func stringLiteral(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	if err != nil {
+		return "", false
+	}
+	return value, true
+}
@@ -0,0 +1,108 @@
+package refs_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/cmd/cli/refs"
+)
+
+func TestScanner_Scan(t *testing.T) {
+	tests := []struct {
+		name    string
+		scanner refs.Scanner
+		want    []refs.Reference
+	}{
+		{
+			name:    "default patterns",
+			scanner: refs.Scanner{Root: "testdata/repo", Patterns: refs.DefaultPatterns},
+			want: []refs.Reference{
+				{FlagKey: "max-retries", File: "main.go", Line: 14, Column: 17, Source: refs.SourceGo},
+				{FlagKey: "new-checkout", File: "main.go", Line: 19, Column: 32, Source: refs.SourceGo},
+				{FlagKey: "banner-color", File: "main.go", Line: 20, Column: 34, Source: refs.SourceGo},
+				{FlagKey: "unknown-flag", File: "main.go", Line: 23, Column: 51, Source: refs.SourceGo},
+				{FlagKey: "new-checkout", File: "web/app.ts", Line: 4, Column: 53, Source: refs.SourcePattern},
+				{FlagKey: "dark-mode", File: "web/app.ts", Line: 5, Column: 45, Source: refs.SourcePattern},
+			},
+		},
+		{
+			name: "custom pattern",
+			scanner: refs.Scanner{Root: "testdata/repo", Patterns: []refs.Pattern{
+				{Glob: "*.rb", Regex: regexp.MustCompile(`feature_enabled\?\("([^"]+)"`)},
+			}},
+			want: []refs.Reference{
+				{FlagKey: "legacy-flag", File: "feature.rb", Line: 1, Column: 22, Source: refs.SourcePattern},
+				{FlagKey: "max-retries", File: "main.go", Line: 14, Column: 17, Source: refs.SourceGo},
+				{FlagKey: "new-checkout", File: "main.go", Line: 19, Column: 32, Source: refs.SourceGo},
+				{FlagKey: "banner-color", File: "main.go", Line: 20, Column: 34, Source: refs.SourceGo},
+				{FlagKey: "unknown-flag", File: "main.go", Line: 23, Column: 51, Source: refs.SourceGo},
+			},
+		},
+		{
+			name: "exclude files",
+			scanner: refs.Scanner{
+				Root:     "testdata/repo",
+				Patterns: refs.DefaultPatterns,
+				Exclude:  []string{"*.go"},
+			},
+			want: []refs.Reference{
+				{FlagKey: "new-checkout", File: "web/app.ts", Line: 4, Column: 53, Source: refs.SourcePattern},
+				{FlagKey: "dark-mode", File: "web/app.ts", Line: 5, Column: 45, Source: refs.SourcePattern},
+			},
+		},
+		{
+			name: "exclude directory",
+			scanner: refs.Scanner{
+				Root:     "testdata/repo",
+				Patterns: refs.DefaultPatterns,
+				Exclude:  []string{"web"},
+			},
+			want: []refs.Reference{
+				{FlagKey: "max-retries", File: "main.go", Line: 14, Column: 17, Source: refs.SourceGo},
+				{FlagKey: "new-checkout", File: "main.go", Line: 19, Column: 32, Source: refs.SourceGo},
+				{FlagKey: "banner-color", File: "main.go", Line: 20, Column: 34, Source: refs.SourceGo},
+				{FlagKey: "unknown-flag", File: "main.go", Line: 23, Column: 51, Source: refs.SourceGo},
+			},
+		},
+		{
+			name:    "only the flag keys of the clients are reported",
+			scanner: refs.Scanner{Root: "testdata/calls"},
+			want: []refs.Reference{
+				{FlagKey: "new-checkout", File: "calls.go", Line: 22, Column: 30, Source: refs.SourceGo},
+				{FlagKey: "banner-color", File: "calls.go", Line: 23, Column: 40, Source: refs.SourceGo},
+				{FlagKey: "dark-mode", File: "calls.go", Line: 24, Column: 37, Source: refs.SourceGo},
+				{FlagKey: "max-retries", File: "calls.go", Line: 25, Column: 48, Source: refs.SourceGo},
+				{FlagKey: "raw-flag", File: "calls.go", Line: 30, Column: 29, Source: refs.SourceGo},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.scanner.Scan()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParsePattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{name: "valid pattern", value: `*.rb=feature\("([^"]+)"\)`, wantErr: assert.NoError},
+		{name: "missing glob", value: `=feature\("([^"]+)"\)`, wantErr: assert.Error},
+		{name: "missing separator", value: `*.rb`, wantErr: assert.Error},
+		{name: "invalid regex", value: `*.rb=feature(`, wantErr: assert.Error},
+		{name: "no capture group", value: `*.rb=feature`, wantErr: assert.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := refs.ParsePattern(tt.value)
+			tt.wantErr(t, err)
+		})
+	}
+}
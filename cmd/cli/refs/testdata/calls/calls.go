@@ -0,0 +1,33 @@
+package calls
+
+import (
+	"context"
+
+	of "github.com/open-feature/go-sdk/openfeature"
+	ffclient "github.com/thomaspoignant/go-feature-flag"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const checkoutFlag = "new-checkout"
+
+var defaultBanner = "legacy-default"
+
+type Service struct {
+	goff     *ffclient.GoFeatureFlag
+	features of.IClient
+}
+
+func (s *Service) Evaluate(ctx context.Context, evalCtx ffcontext.Context) {
+	_, _ = s.goff.BoolVariation(checkoutFlag, evalCtx, false)
+	_, _ = s.goff.StringVariationCtx(ctx, "banner-color", "legacy-default")
+	_, _ = s.features.StringValue(ctx, "dark-mode", "legacy-default", of.EvaluationContext{})
+	_, _ = ffclient.VariationFromCtx(ctx, s.goff, "max-retries", 3)
+	_, _ = ffclient.StringVariation(defaultBanner, evalCtx, "legacy-default")
+	_ = attribute.StringValue("otel-value")
+
+	client, _ := ffclient.New(ffclient.Config{})
+	_, _ = client.RawVariation("raw-flag", evalCtx, nil)
+	other := newOther()
+	_, _ = other.BoolVariation("not-a-client", evalCtx, false)
+}
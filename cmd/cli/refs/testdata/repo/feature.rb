@@ -0,0 +1,3 @@
+if feature_enabled?("legacy-flag")
+  puts "legacy"
+end
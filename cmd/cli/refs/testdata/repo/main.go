@@ -0,0 +1,24 @@
+package main
+
+import (
+	"context"
+
+	"github.com/open-feature/go-sdk/openfeature"
+	ffclient "github.com/thomaspoignant/go-feature-flag"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+)
+
+const bannerFlag = "banner-color"
+
+type Config struct {
+	MaxRetries int `goff:"max-retries,default=3"`
+}
+
+func main() {
+	ctx := ffcontext.NewEvaluationContext("user-123")
+	_, _ = ffclient.BoolVariation("new-checkout", ctx, false)
+	_, _ = ffclient.StringVariation(bannerFlag, ctx, "red")
+
+	client := openfeature.NewClient("app")
+	_, _ = client.BooleanValue(context.Background(), "unknown-flag", false, openfeature.EvaluationContext{})
+}
@@ -0,0 +1,5 @@
+import { OpenFeature } from '@openfeature/web-sdk';
+
+const client = OpenFeature.getClient();
+export const showCheckout = client.getBooleanValue('new-checkout', false);
+export const theme = client.getStringValue("dark-mode", 'light');
@@ -0,0 +1,85 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+)
+
+// ListResponse is the response of the relay proxy when listing the snapshots.
+type ListResponse struct {
+	Pinned    *int                 `json:"pinned,omitempty"`
+	Snapshots []retriever.Snapshot `json:"snapshots"`
+}
+
+// PinResponse is the response of the relay proxy when pinning or unpinning a snapshot.
+type PinResponse struct {
+	Pinned *retriever.Snapshot `json:"pinned"`
+}
+
+// Client calls the admin API of the relay proxy to manage the snapshots of the flag configuration.
+type Client struct {
+	RelayURL   string
+	APIKey     string
+	HTTPClient *http.Client
+}
+
+// List returns the snapshots of the flag configuration.
+func (c *Client) List(ctx context.Context) (ListResponse, error) {
+	var resp ListResponse
+	err := c.call(ctx, http.MethodGet, "/admin/v1/snapshots", &resp)
+	return resp, err
+}
+
+// Pin pins the snapshot with this ID.
+func (c *Client) Pin(ctx context.Context, id int) (PinResponse, error) {
+	var resp PinResponse
+	err := c.call(ctx, http.MethodPost, fmt.Sprintf("/admin/v1/snapshots/%d/pin", id), &resp)
+	return resp, err
+}
+
+// Unpin removes the pin of the snapshot.
+func (c *Client) Unpin(ctx context.Context) (PinResponse, error) {
+	var resp PinResponse
+	err := c.call(ctx, http.MethodDelete, "/admin/v1/snapshots/pin", &resp)
+	return resp, err
+}
+
+func (c *Client) call(ctx context.Context, method string, path string, result any) error {
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(c.RelayURL, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+	if c.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.APIKey)
+	}
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("impossible to call the relay proxy: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var httpErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &httpErr) == nil && httpErr.Message != "" {
+			return fmt.Errorf("the relay proxy answered %d: %s", resp.StatusCode, httpErr.Message)
+		}
+		return fmt.Errorf("the relay proxy answered %d", resp.StatusCode)
+	}
+	return json.Unmarshal(body, result)
+}
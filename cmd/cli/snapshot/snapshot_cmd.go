@@ -0,0 +1,127 @@
+package snapshot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/thomaspoignant/go-feature-flag/cmd/cli/helper"
+)
+
+var (
+	snapshotRelayURL string
+	snapshotAPIKey   string
+)
+
+func NewSnapshotCmd() *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "📸 List and pin the snapshots of the flag configuration of a relay proxy.",
+		Long: "📸 List the last versions of the flag configuration kept by a relay proxy, and pin one of them " +
+			"to roll back a bad flag configuration. The retrievers are not called until the snapshot is unpinned.",
+		Example: `
+# List the snapshots
+snapshot list --relay-url http://localhost:1031 --api-key <admin-api-key>
+
+# Roll back to the snapshot 3
+snapshot pin 3 --relay-url http://localhost:1031 --api-key <admin-api-key>
+
+# Remove the pin and use the retrievers again
+snapshot unpin --relay-url http://localhost:1031 --api-key <admin-api-key>`,
+	}
+	snapshotCmd.PersistentFlags().StringVar(&snapshotRelayURL,
+		"relay-url", "http://localhost:1031", "URL of the relay proxy")
+	snapshotCmd.PersistentFlags().StringVar(&snapshotAPIKey,
+		"api-key", "", "Admin API key of the relay proxy")
+	snapshotCmd.AddCommand(newListCmd(), newPinCmd(), newUnpinCmd())
+	return snapshotCmd
+}
+
+func newClient() *Client {
+	return &Client{RelayURL: snapshotRelayURL, APIKey: snapshotAPIKey}
+}
+
+func newListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the snapshots of the flag configuration, from the newest to the oldest.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			resp, err := newClient().List(cmd.Context())
+			if err != nil {
+				return err
+			}
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			_, _ = fmt.Fprintln(w, "ID\tDATE\tFLAGS\tSOURCES\tPINNED")
+			for _, snapshot := range resp.Snapshots {
+				sources := make([]string, 0, len(snapshot.Sources))
+				for _, source := range snapshot.Sources {
+					description := source.Name
+					if source.Location != "" {
+						description += " " + source.Location
+					}
+					if source.Version != "" {
+						description += "@" + source.Version
+					}
+					sources = append(sources, description)
+				}
+				pinned := ""
+				if resp.Pinned != nil && *resp.Pinned == snapshot.ID {
+					pinned = "yes"
+				}
+				_, _ = fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\n", snapshot.ID, snapshot.Date.Format(time.RFC3339),
+					snapshot.NumberOfFlags, strings.Join(sources, ", "), pinned)
+			}
+			return w.Flush()
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+}
+
+func newPinCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "pin [id]",
+		Short: "Pin a snapshot of the flag configuration, the retrievers are not called until it is unpinned.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid snapshot id %q", args[0])
+			}
+			resp, err := newClient().Pin(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+			output := helper.Output{}
+			output.Add(fmt.Sprintf("Snapshot %d pinned (%d flags from %s).", resp.Pinned.ID,
+				resp.Pinned.NumberOfFlags, resp.Pinned.Date.Format(time.RFC3339)), helper.InfoLevel)
+			output.PrintLines(cmd)
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+}
+
+func newUnpinCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "unpin",
+		Short: "Remove the pin of the snapshot, the retrievers are called again.",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if _, err := newClient().Unpin(cmd.Context()); err != nil {
+				return err
+			}
+			output := helper.Output{}
+			output.Add("Snapshot unpinned, the flag configuration is retrieved again.", helper.InfoLevel)
+			output.PrintLines(cmd)
+			return nil
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+}
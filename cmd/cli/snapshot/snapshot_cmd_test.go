@@ -0,0 +1,105 @@
+package snapshot_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/cmd/cli/snapshot"
+)
+
+func newRelayProxy(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /admin/v1/snapshots", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"pinned":1,"snapshots":[
+			{"id":2,"date":"2026-10-19T10:00:00Z","numberOfFlags":3,
+			 "sources":[{"name":"*gitlabretriever.Retriever","location":"flags.yaml","version":"abc123"}]},
+			{"id":1,"date":"2026-10-18T10:00:00Z","numberOfFlags":2,"sources":[{"name":"*fileretriever.Retriever"}]}
+		]}`))
+	})
+	mux.HandleFunc("POST /admin/v1/snapshots/{id}/pin", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("id") != "1" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"flag configuration snapshot not found: ` + r.PathValue("id") + `"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"pinned":{"id":1,"date":"2026-10-18T10:00:00Z","numberOfFlags":2}}`))
+	})
+	mux.HandleFunc("DELETE /admin/v1/snapshots/pin", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer admin-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Unauthorized"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"pinned":null}`))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestCmdSnapshot(t *testing.T) {
+	pterm.DisableStyling()
+	pterm.DisableColor()
+	server := newRelayProxy(t)
+	tests := []struct {
+		name       string
+		args       []string
+		wantErrMsg string
+		wantOutput string
+	}{
+		{
+			name: "list the snapshots",
+			args: []string{"list"},
+			wantOutput: "ID  DATE                  FLAGS  SOURCES                                       PINNED\n" +
+				"2   2026-10-19T10:00:00Z  3      *gitlabretriever.Retriever flags.yaml@abc123  \n" +
+				"1   2026-10-18T10:00:00Z  2      *fileretriever.Retriever                      yes\n",
+		},
+		{
+			name:       "pin a snapshot",
+			args:       []string{"pin", "1"},
+			wantOutput: "INFO: Snapshot 1 pinned (2 flags from 2026-10-18T10:00:00Z).\n",
+		},
+		{
+			name:       "pin an unknown snapshot",
+			args:       []string{"pin", "42"},
+			wantErrMsg: "the relay proxy answered 404: flag configuration snapshot not found: 42",
+		},
+		{
+			name:       "pin an invalid snapshot id",
+			args:       []string{"pin", "abc"},
+			wantErrMsg: `invalid snapshot id "abc"`,
+		},
+		{
+			name:       "unpin the snapshot",
+			args:       []string{"unpin", "--api-key", "admin-key"},
+			wantOutput: "INFO: Snapshot unpinned, the flag configuration is retrieved again.\n",
+		},
+		{
+			name:       "unpin without api key",
+			args:       []string{"unpin", "--api-key", ""},
+			wantErrMsg: "the relay proxy answered 401: Unauthorized",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout := &bytes.Buffer{}
+			cmd := snapshot.NewSnapshotCmd()
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+			cmd.SetArgs(append(tt.args, "--relay-url", server.URL))
+			err := cmd.Execute()
+			if tt.wantErrMsg != "" {
+				assert.EqualError(t, err, tt.wantErrMsg)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOutput, stdout.String())
+		})
+	}
+}
@@ -5,8 +5,21 @@ import (
 	controller "github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/handler/goff"
 )
 
-func (s *Server) addAdminRoutes(cRetrieverRefresh controller.Controller, authMiddleware echo.MiddlewareFunc) {
+func (s *Server) addAdminRoutes(
+	cRetrieverRefresh controller.Controller,
+	cFlagUsage controller.Controller,
+	cListSnapshots controller.Controller,
+	cPinSnapshot controller.Controller,
+	cUnpinSnapshot controller.Controller,
+	cRetrieversStatus controller.Controller,
+	authMiddleware echo.MiddlewareFunc,
+) {
 	adminGrp := s.apiEcho.Group("/admin/v1")
 	adminGrp.Use(authMiddleware)
 	adminGrp.POST("/retriever/refresh", cRetrieverRefresh.Handler)
+	adminGrp.GET("/flags/usage", cFlagUsage.Handler)
+	adminGrp.GET("/snapshots", cListSnapshots.Handler)
+	adminGrp.POST("/snapshots/:id/pin", cPinSnapshot.Handler)
+	adminGrp.DELETE("/snapshots/pin", cUnpinSnapshot.Handler)
+	adminGrp.GET("/retrievers", cRetrieversStatus.Handler)
 }
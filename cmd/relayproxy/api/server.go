@@ -116,6 +116,14 @@ func (s *Server) initRoutes() {
 		s.services.FlagsetManager,
 		s.services.Metrics,
 	)
+	cFlagUsage := controller.NewFlagUsage(
+		s.services.FlagsetManager,
+		s.services.Metrics,
+	)
+	cListSnapshots := controller.NewListSnapshots(s.services.FlagsetManager, s.services.Metrics)
+	cPinSnapshot := controller.NewPinSnapshot(s.services.FlagsetManager, s.services.Metrics)
+	cUnpinSnapshot := controller.NewUnpinSnapshot(s.services.FlagsetManager, s.services.Metrics)
+	cRetrieversStatus := controller.NewRetrieversStatus(s.services.FlagsetManager, s.services.Metrics)
 	cFlagChangeAPI := controller.NewAPIFlagChange(
 		s.services.FlagsetManager,
 		s.services.Metrics,
@@ -132,7 +140,8 @@ func (s *Server) initRoutes() {
 	s.addOFREPRoutes(cFlagEvalOFREP, userAuth)
 	s.addStreamRoutes()
 	s.addMonitoringRoutes()
-	s.addAdminRoutes(cRetrieverRefresh, adminAuth)
+	s.addAdminRoutes(
+		cRetrieverRefresh, cFlagUsage, cListSnapshots, cPinSnapshot, cUnpinSnapshot, cRetrieversStatus, adminAuth)
 	s.addManifestRoutes(cManifest, userAuth)
 }
 
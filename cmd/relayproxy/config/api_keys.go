@@ -4,6 +4,15 @@ package config
 type APIKeys struct {
 	Admin      []string `mapstructure:"admin"      koanf:"admin"`
 	Evaluation []string `mapstructure:"evaluation" koanf:"evaluation"`
+	// TagRestrictions limits some API keys to the flags having at least one of the configured tags.
+	// A restriction does not authorize the API key by itself, the key has to be configured elsewhere.
+	TagRestrictions []APIKeyTagRestriction `mapstructure:"tagRestrictions" koanf:"tagrestrictions"`
+}
+
+// APIKeyTagRestriction restricts an API key to the flags having at least one of the tags.
+type APIKeyTagRestriction struct {
+	APIKey string   `mapstructure:"apiKey" koanf:"apikey"`
+	Tags   []string `mapstructure:"tags"   koanf:"tags"`
 }
 
 type ApiKeyType = string
@@ -16,6 +25,21 @@ const (
 	ErrorKeyType      ApiKeyType = "ERROR"
 )
 
+// cloneTagRestrictions returns a deep copy of the tag restrictions.
+func cloneTagRestrictions(restrictions []APIKeyTagRestriction) []APIKeyTagRestriction {
+	if restrictions == nil {
+		return nil
+	}
+	res := make([]APIKeyTagRestriction, 0, len(restrictions))
+	for _, restriction := range restrictions {
+		res = append(res, APIKeyTagRestriction{
+			APIKey: restriction.APIKey,
+			Tags:   append([]string(nil), restriction.Tags...),
+		})
+	}
+	return res
+}
+
 // APIKeysAdminExists is checking if an admin API Key exist in the relay proxy configuration
 func (c *Config) APIKeysAdminExists(apiKey string) bool {
 	c.mutex.RLock()
@@ -31,6 +55,14 @@ func (c *Config) APIKeyExists(apiKey string) bool {
 	return ok
 }
 
+// APIKeyTags returns the tags an API key is restricted to.
+// It returns nil if the API key has no tag restriction.
+func (c *Config) APIKeyTags(apiKey string) []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.apiKeyTags[apiKey]
+}
+
 // IsAuthenticationEnabled returns true if we need to be authenticated.
 func (c *Config) IsAuthenticationEnabled() bool {
 	c.mutex.RLock()
@@ -66,6 +98,12 @@ func (c *Config) preloadAPIKeysLocked() {
 
 	addAPIKeys(c.AuthorizedKeys.Admin, AdminKeyType)
 	c.apiKeysSet = apiKeySet
+
+	apiKeyTags := make(map[string][]string)
+	for _, restriction := range c.AuthorizedKeys.TagRestrictions {
+		apiKeyTags[restriction.APIKey] = append(apiKeyTags[restriction.APIKey], restriction.Tags...)
+	}
+	c.apiKeyTags = apiKeyTags
 }
 
 // preloadAPIKeys is storing in the struct all the API Keys available for the relay-proxy.
@@ -83,5 +121,6 @@ func (c *Config) ForceReloadAPIKeys() {
 	defer c.mutex.Unlock()
 	c.forceAuthenticatedRequests = false
 	c.apiKeysSet = nil
+	c.apiKeyTags = nil
 	c.preloadAPIKeysLocked()
 }
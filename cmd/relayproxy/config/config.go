@@ -146,6 +146,7 @@ type Config struct {
 	// - APIKeys
 	// - FlagSets
 	// - apiKeysSet
+	// - apiKeyTags
 	// - forceAuthenticatedRequests
 	mutex sync.RWMutex
 
@@ -153,6 +154,9 @@ type Config struct {
 	// we store them in a set to be
 	apiKeysSet map[string]ApiKeyType
 
+	// apiKeyTags is the list of tags each restricted API key has access to.
+	apiKeyTags map[string][]string
+
 	// forceAuthenticatedRequests is true if we have at least 1 AuthorizedKey.Evaluation key set.
 	forceAuthenticatedRequests bool
 
@@ -225,8 +229,9 @@ func (c *Config) GetAuthorizedKeys() APIKeys {
 	defer c.mutex.RUnlock()
 	// Return a deep copy to avoid external modification
 	return APIKeys{
-		Admin:      append([]string(nil), c.AuthorizedKeys.Admin...),
-		Evaluation: append([]string(nil), c.AuthorizedKeys.Evaluation...),
+		Admin:           append([]string(nil), c.AuthorizedKeys.Admin...),
+		Evaluation:      append([]string(nil), c.AuthorizedKeys.Evaluation...),
+		TagRestrictions: cloneTagRestrictions(c.AuthorizedKeys.TagRestrictions),
 	}
 }
 
@@ -14,6 +14,7 @@ import (
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/config"
 	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/retrieverconf"
 	"github.com/thomaspoignant/go-feature-flag/exporter/kafkaexporter"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -1245,6 +1246,126 @@ func TestConfig_APIAdminKeyExists(t *testing.T) {
 	}
 }
 
+func TestConfig_APIKeyTags(t *testing.T) {
+	c := config.Config{
+		AuthorizedKeys: config.APIKeys{
+			Evaluation: []string{"frontend-key", "backend-key"},
+			TagRestrictions: []config.APIKeyTagRestriction{
+				{APIKey: "frontend-key", Tags: []string{"frontend"}},
+				{APIKey: "frontend-key", Tags: []string{"mobile"}},
+			},
+		},
+	}
+	c.ForceReloadAPIKeys()
+
+	assert.Equal(t, []string{"frontend", "mobile"}, c.APIKeyTags("frontend-key"))
+	assert.Nil(t, c.APIKeyTags("backend-key"))
+	assert.False(t, c.APIKeyExists("unknown-key"))
+
+	keys := c.GetAuthorizedKeys()
+	keys.TagRestrictions[0].Tags[0] = "updated"
+	assert.Equal(t, "frontend", c.AuthorizedKeys.TagRestrictions[0].Tags[0])
+}
+
+func TestConfig_IsValid_TagRestrictions(t *testing.T) {
+	tests := []struct {
+		name         string
+		restrictions []config.APIKeyTagRestriction
+		wantErr      assert.ErrorAssertionFunc
+	}{
+		{
+			name:         "valid restriction",
+			restrictions: []config.APIKeyTagRestriction{{APIKey: "xxx", Tags: []string{"frontend"}}},
+			wantErr:      assert.NoError,
+		},
+		{
+			name:         "restriction without api key",
+			restrictions: []config.APIKeyTagRestriction{{Tags: []string{"frontend"}}},
+			wantErr:      assert.Error,
+		},
+		{
+			name:         "restriction without tags",
+			restrictions: []config.APIKeyTagRestriction{{APIKey: "xxx"}},
+			wantErr:      assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &config.Config{
+				CommonFlagSet: config.CommonFlagSet{
+					Retriever: &retrieverconf.RetrieverConf{
+						Kind: "file",
+						Path: "../testdata/config/valid-file.yaml",
+					},
+				},
+				Server: config.Server{Port: 8080},
+				AuthorizedKeys: config.APIKeys{
+					Evaluation:      []string{"xxx"},
+					TagRestrictions: tt.restrictions,
+				},
+			}
+			tt.wantErr(t, c.IsValid())
+		})
+	}
+}
+
+func TestConfig_IsValid_RetrieverMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    retriever.Mode
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name:    "default mode",
+			mode:    "",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "merge mode",
+			mode:    "merge",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "fallback mode",
+			mode:    "fallback",
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "invalid mode",
+			mode:    "random",
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commonFlagSet := config.CommonFlagSet{
+				Retriever: &retrieverconf.RetrieverConf{
+					Kind: "file",
+					Path: "../testdata/config/valid-file.yaml",
+				},
+				RetrieverMode: tt.mode,
+			}
+			c := &config.Config{
+				CommonFlagSet: commonFlagSet,
+				Server:        config.Server{Port: 8080},
+			}
+			tt.wantErr(t, c.IsValid(), "default mode")
+
+			c = &config.Config{
+				Server: config.Server{Port: 8080},
+				FlagSets: []config.FlagSet{
+					{
+						Name:          "test-flagset",
+						APIKeys:       []string{"test-api-key"},
+						CommonFlagSet: commonFlagSet,
+					},
+				},
+			}
+			tt.wantErr(t, c.IsValid(), "flagset mode")
+		})
+	}
+}
+
 func TestMergeConfig_FromOSEnv(t *testing.T) {
 	tests := []struct {
 		name                       string
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/retrieverconf"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -23,6 +24,9 @@ func (c *Config) IsValid() error {
 	if err := c.validateServerConfig(); err != nil {
 		return err
 	}
+	if err := validateTagRestrictions(c.AuthorizedKeys.TagRestrictions); err != nil {
+		return err
+	}
 	if len(c.FlagSets) > 0 {
 		return c.validateFlagSets()
 	}
@@ -40,6 +44,19 @@ func (c *Config) validateServerConfig() error {
 	return nil
 }
 
+// validateTagRestrictions validates the tag restrictions of the API keys
+func validateTagRestrictions(restrictions []APIKeyTagRestriction) error {
+	for i, restriction := range restrictions {
+		if restriction.APIKey == "" {
+			return fmt.Errorf("tag restriction at index %d has no apiKey", i)
+		}
+		if len(restriction.Tags) == 0 {
+			return fmt.Errorf("tag restriction at index %d has no tags", i)
+		}
+	}
+	return nil
+}
+
 // validateLogFormat validates the log format
 func validateLogFormat(logFormat string) error {
 	switch strings.ToLower(logFormat) {
@@ -66,6 +83,9 @@ func (c *Config) validateDefaultMode() error {
 	if err := validateRetrievers(c.Retriever, c.Retrievers); err != nil {
 		return err
 	}
+	if err := retriever.IsValidMode(c.RetrieverMode); err != nil {
+		return err
+	}
 	if err := validateExporters(c.Exporter, c.Exporters); err != nil {
 		return err
 	}
@@ -109,6 +129,9 @@ func (c *Config) validateFlagSetCommonConfig(flagset *FlagSet) error {
 	if err := validateRetrievers(flagset.Retriever, flagset.Retrievers); err != nil {
 		return err
 	}
+	if err := retriever.IsValidMode(flagset.RetrieverMode); err != nil {
+		return err
+	}
 
 	if err := validateExporters(flagset.Exporter, flagset.Exporters); err != nil {
 		return err
@@ -31,15 +31,32 @@ type ExporterConf struct {
 	DatasetID               string                 `mapstructure:"datasetID"               koanf:"datasetid"`
 	TableName               string                 `mapstructure:"tableName"               koanf:"tablename"`
 	//nolint:gosec
-	GoogleCredentials string `mapstructure:"googleCredentials"       koanf:"googlecredentials"`
-	AutoMigrate       bool   `mapstructure:"autoMigrate"             koanf:"automigrate"`
-	Topic             string `mapstructure:"topic"                   koanf:"topic"`
-	StreamArn         string `mapstructure:"streamArn"               koanf:"streamarn"`
-	StreamName        string `mapstructure:"streamName"              koanf:"streamname"`
-	AccountName       string `mapstructure:"accountName"             koanf:"accountname"`
-	AccountKey        string `mapstructure:"accountKey"              koanf:"accountkey"`
-	Container         string `mapstructure:"container"               koanf:"container"`
-	ExporterEventType string `mapstructure:"eventType"               koanf:"eventtype"`
+	GoogleCredentials string   `mapstructure:"googleCredentials"       koanf:"googlecredentials"`
+	AutoMigrate       bool     `mapstructure:"autoMigrate"             koanf:"automigrate"`
+	Topic             string   `mapstructure:"topic"                   koanf:"topic"`
+	StreamArn         string   `mapstructure:"streamArn"               koanf:"streamarn"`
+	StreamName        string   `mapstructure:"streamName"              koanf:"streamname"`
+	AccountName       string   `mapstructure:"accountName"             koanf:"accountname"`
+	AccountKey        string   `mapstructure:"accountKey"              koanf:"accountkey"`
+	Container         string   `mapstructure:"container"               koanf:"container"`
+	ExporterEventType string   `mapstructure:"eventType"               koanf:"eventtype"`
+	NATS              NATSConf `mapstructure:"nats"                    koanf:"nats"`
+}
+
+// NATSConf contains the configuration of the NATS JetStream exporter.
+type NATSConf struct {
+	// URL is the address of the NATS server(s), separated by commas.
+	URL string `mapstructure:"url"            koanf:"url"`
+	// Subject is the JetStream subject on which the events are published.
+	Subject  string `mapstructure:"subject"        koanf:"subject"`
+	Token    string `mapstructure:"token"          koanf:"token"` //nolint:gosec
+	Username string `mapstructure:"username"       koanf:"username"`
+	Password string `mapstructure:"password"       koanf:"password"` //nolint:gosec
+	// ClientCertPath and ClientKeyPath are the files used for mutual TLS.
+	ClientCertPath string `mapstructure:"clientCertPath" koanf:"clientcertpath"`
+	ClientKeyPath  string `mapstructure:"clientKeyPath"  koanf:"clientkeypath"`
+	// CACertPath is the CA certificate used to verify the NATS server.
+	CACertPath string `mapstructure:"caCertPath"     koanf:"cacertpath"`
 }
 
 func (c *ExporterConf) IsValid() error {
@@ -86,6 +103,13 @@ func (c *ExporterConf) IsValid() error {
 		)
 	}
 
+	if c.Kind == NATSExporter && c.NATS.Subject == "" {
+		return fmt.Errorf(
+			"invalid exporter: no \"nats.subject\" property found for kind \"%s\"",
+			c.Kind,
+		)
+	}
+
 	if c.Kind == PubSubExporter && (c.ProjectID == "" || c.Topic == "") {
 		return fmt.Errorf(
 			"invalid exporter: \"projectID\" and \"topic\" are required for kind \"%s\"",
@@ -131,6 +155,7 @@ const (
 	BigQueryExporter      ExporterKind = "bigquery"
 	AzureExporter         ExporterKind = "azureBlobStorage"
 	OpenTelemetryExporter ExporterKind = "opentelemetry"
+	NATSExporter          ExporterKind = "nats"
 )
 
 // IsValid is checking if the value is part of the enum
@@ -147,7 +172,8 @@ func (r ExporterKind) IsValid() error {
 		BigQueryExporter,
 		KinesisExporter,
 		AzureExporter,
-		OpenTelemetryExporter:
+		OpenTelemetryExporter,
+		NATSExporter:
 		return nil
 	}
 	return fmt.Errorf("invalid exporter: kind \"%s\" is not supported", r)
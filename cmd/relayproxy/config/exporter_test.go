@@ -27,6 +27,7 @@ func TestExporterConf_IsValid(t *testing.T) {
 		StreamName              string
 		Container               string
 		AccountName             string
+		NATS                    config.NATSConf
 	}
 	tests := []struct {
 		name     string
@@ -225,6 +226,23 @@ func TestExporterConf_IsValid(t *testing.T) {
 			wantErr:  true,
 			errValue: "invalid exporter: \"projectID\" and \"topic\" are required for kind \"pubsub\"",
 		},
+		{
+			name: "kind NATS valid",
+			fields: fields{
+				Kind: "nats",
+				NATS: config.NATSConf{URL: "nats://localhost:4222", Subject: "goff.events"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "kind NATS without subject",
+			fields: fields{
+				Kind: "nats",
+				NATS: config.NATSConf{URL: "nats://localhost:4222"},
+			},
+			wantErr:  true,
+			errValue: "invalid exporter: no \"nats.subject\" property found for kind \"nats\"",
+		},
 		{
 			name: "kind BigQuery valid",
 			fields: fields{
@@ -274,6 +292,7 @@ func TestExporterConf_IsValid(t *testing.T) {
 				StreamName:              tt.fields.StreamName,
 				AccountName:             tt.fields.AccountName,
 				Container:               tt.fields.Container,
+				NATS:                    tt.fields.NATS,
 			}
 			err := c.IsValid()
 			assert.Equal(t, tt.wantErr, err != nil)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/retrieverconf"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
 )
 
 // FlagSet is the configuration for a flag set.
@@ -35,6 +36,12 @@ type CommonFlagSet struct {
 	// after we will use the order of Retrievers.
 	Retrievers *[]retrieverconf.RetrieverConf `mapstructure:"retrievers" koanf:"retrievers"`
 
+	// RetrieverMode (optional) is the way the retrievers are used:
+	// - merge: all the retrievers are called and their flags are merged.
+	// - fallback: the retrievers are tried in order and the flags of the first one answering are used.
+	// Default: merge
+	RetrieverMode retriever.Mode `mapstructure:"retrieverMode" koanf:"retrievermode"`
+
 	// Notifiers is the configuration on where to notify a flag change
 	Notifiers []NotifierConf `mapstructure:"notifiers" koanf:"notifiers"`
 
@@ -87,8 +94,19 @@ type CommonFlagSet struct {
 	// PersistentFlagConfigurationFile is the flag to enable the persistent flag configuration file.
 	PersistentFlagConfigurationFile string `mapstructure:"persistentFlagConfigurationFile" koanf:"persistentflagconfigurationfile"` //nolint: lll
 
+	// FlagConfigurationSnapshots is the number of versions of the flag configuration kept to be pinned.
+	// Default: 0 (no snapshot)
+	FlagConfigurationSnapshots int `mapstructure:"flagConfigurationSnapshots" koanf:"flagconfigurationsnapshots"`
+
+	// IncludeAllowedOrigins is the list of origins of the remote files that can be included by the
+	// flag configuration, in addition to the origin of a remote flag configuration.
+	IncludeAllowedOrigins []string `mapstructure:"includeAllowedOrigins" koanf:"includeallowedorigins"`
+
 	// Environment is the environment of the flag set.
 	Environment string `mapstructure:"environment" koanf:"environment"`
+
+	// UsageTracking (optional) is the configuration of the flag usage tracking.
+	UsageTracking UsageTrackingConf `mapstructure:"usageTracking" koanf:"usagetracking"`
 }
 
 // GetFlagSets returns a shallow copy of the configured flag sets.
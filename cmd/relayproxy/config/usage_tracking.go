@@ -0,0 +1,23 @@
+package config
+
+import "time"
+
+// UsageTrackingConf is the configuration of the flag usage tracking.
+type UsageTrackingConf struct {
+	// Enabled (optional) enables the tracking of the usage of the flags, the report is available
+	// on the admin endpoint /admin/v1/flags/usage.
+	// Default: false
+	Enabled bool `mapstructure:"enabled" koanf:"enabled"`
+
+	// StaleAfter (optional) is the duration without evaluation after which a flag is reported as stale.
+	// Default: 720h (30 days)
+	StaleAfter time.Duration `mapstructure:"staleAfter" koanf:"staleafter"`
+
+	// PersistentFile (optional) is the file where the usage is stored to survive restarts.
+	// Default: "" (the usage is only kept in memory)
+	PersistentFile string `mapstructure:"persistentFile" koanf:"persistentfile"`
+
+	// MaxUnknownFlags (optional) is the maximum number of unknown flags tracked.
+	// Default: 1000
+	MaxUnknownFlags int `mapstructure:"maxUnknownFlags" koanf:"maxunknownflags"`
+}
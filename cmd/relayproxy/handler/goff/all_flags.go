@@ -9,7 +9,6 @@ import (
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/model"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
 	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/configfile"
-	"github.com/thomaspoignant/go-feature-flag/internal/flagstate"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -34,11 +33,14 @@ func NewAllFlags(flagsetManager service.FlagsetManager, metrics metric.Metrics)
 // @Description
 // @Description To get a variation you should provide information about the user.
 // @Description For that you should provide some user information in JSON in the request body.
+// @Description
+// @Description You can use the `tags` query parameter to evaluate only the flags having at least one of the tags.
 // @Security     ApiKeyAuth
 // @Security     XApiKeyAuth
 // @Produce      json
 // @Accept		 json
 // @Param 	     data body model.AllFlagRequest true "Payload of the user we want to challenge against the flag."
+// @Param        tags query []string false "Use only the flags with one of these tags" collectionFormat(multi)
 // @Success      200  {object} modeldocs.AllFlags "Success"
 // @Failure      400 {object} modeldocs.HTTPErrorDoc "Bad Request"
 // @Failure      500 {object} modeldocs.HTTPErrorDoc "Internal server error"
@@ -66,16 +68,7 @@ func (h *allFlags) Handler(c echo.Context) error {
 		return httpErr
 	}
 
-	var allFlags flagstate.AllFlags
-	if len(evaluationCtx.ExtractGOFFProtectedFields().FlagList) > 0 {
-		// if we have a list of flags to evaluate in the evaluation context, we evaluate only those flags.
-		allFlags = flagset.GetFlagStates(
-			evaluationCtx,
-			evaluationCtx.ExtractGOFFProtectedFields().FlagList,
-		)
-	} else {
-		allFlags = flagset.AllFlagsState(evaluationCtx)
-	}
+	allFlags := helper.AllFlagsState(c, h.flagsetManager, flagset, evaluationCtx)
 
 	flagNames := make([]string, 0, len(allFlags.GetFlags()))
 	for key := range allFlags.GetFlags() {
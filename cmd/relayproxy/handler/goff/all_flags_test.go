@@ -1,6 +1,7 @@
 package controller_test
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -308,3 +309,78 @@ func Test_all_flag_Handler_FlagsetMode(t *testing.T) {
 		})
 	}
 }
+
+func Test_all_flag_Handler_Tags(t *testing.T) {
+	conf := config.Config{
+		CommonFlagSet: config.CommonFlagSet{
+			Retriever: &retrieverconf.RetrieverConf{
+				Kind: retrieverconf.FileRetriever,
+				Path: "../../testdata/tagged_flags.yaml",
+			},
+		},
+		AuthorizedKeys: config.APIKeys{
+			Evaluation: []string{"all-flags-key", "frontend-key"},
+			TagRestrictions: []config.APIKeyTagRestriction{
+				{APIKey: "frontend-key", Tags: []string{"frontend"}},
+			},
+		},
+	}
+	conf.ForceReloadAPIKeys()
+	flagsetManager, err := service.NewFlagsetManager(&conf, zap.NewNop(), []notifier.Notifier{}, nil)
+	assert.NoError(t, err, "impossible to create flagset manager")
+	defer flagsetManager.Close()
+	ctrl := controller.NewAllFlags(flagsetManager, metric.Metrics{})
+
+	tests := []struct {
+		name      string
+		query     string
+		apiKey    string
+		wantFlags []string
+	}{
+		{
+			name:      "no tags",
+			apiKey:    "all-flags-key",
+			wantFlags: []string{"backend-flag", "frontend-flag", "untagged-flag"},
+		},
+		{
+			name:      "filtered by tags",
+			query:     "?tags=backend&tags=unknown",
+			apiKey:    "all-flags-key",
+			wantFlags: []string{"backend-flag"},
+		},
+		{
+			name:      "restricted API key",
+			apiKey:    "frontend-key",
+			wantFlags: []string{"frontend-flag"},
+		},
+		{
+			name:      "restricted API key with a tag not allowed",
+			query:     "?tags=backend",
+			apiKey:    "frontend-key",
+			wantFlags: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(echo.POST, "/v1/allflags"+tt.query,
+				strings.NewReader(`{"evaluationContext":{"key":"user-1"}}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-API-Key", tt.apiKey)
+			c := e.NewContext(req, rec)
+			assert.NoError(t, ctrl.Handler(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var resp struct {
+				Flags map[string]any `json:"flags"`
+			}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			gotFlags := make([]string, 0, len(resp.Flags))
+			for key := range resp.Flags {
+				gotFlags = append(gotFlags, key)
+			}
+			assert.ElementsMatch(t, tt.wantFlags, gotFlags)
+		})
+	}
+}
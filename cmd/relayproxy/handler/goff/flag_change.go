@@ -2,12 +2,15 @@ package controller
 
 import (
 	"encoding/json"
+	"maps"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/helper"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/metric"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagtag"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/utils"
 )
 
@@ -38,6 +41,7 @@ type FlagChangeResponse struct {
 // @Produce     json
 // @Accept      json
 // @Param       If-None-Match header string false "The request will be processed only if ETag doesn't match."
+// @Param       tags query []string false "Use only the flags with one of these tags" collectionFormat(multi)
 // @Success     200  {object} FlagChangeResponse "Success"
 // @Success     304 {string} string "Etag: \"117-0193435c612c50d93b798619d9464856263dbf9f\""
 // @Failure     500 {object}  modeldocs.HTTPErrorDoc "Internal server error"
@@ -52,6 +56,10 @@ func (h *FlagChangeAPICtrl) Handler(c echo.Context) error {
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
+	tags, ok := helper.Tags(c, h.flagsetManager)
+	maps.DeleteFunc(flags, func(_ string, f flag.Flag) bool {
+		return !ok || !flagtag.Match(f, tags)
+	})
 	res, err := json.Marshal(flags)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"fmt"
+	"maps"
 	"net/http"
 	"time"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/metric"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
 	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/configfile"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagtag"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
@@ -56,6 +58,7 @@ type FlagConfigurationResponse struct {
 // @Accept      json
 // @Param 		data body FlagConfigurationRequest false "List of flags to get the configuration from."
 // @Param       If-None-Match header string false "The request will be processed only if ETag doesn't match."
+// @Param       tags query []string false "Use only the flags with one of these tags" collectionFormat(multi)
 // @Success     200  {object} FlagConfigurationResponse "Success"
 // @Success     304 {string} string "Etag: \"117-0193435c612c50d93b798619d9464856263dbf9f\""
 // @Failure     500 {object}  modeldocs.HTTPErrorDoc "Internal server error"
@@ -100,6 +103,12 @@ func (h *FlagConfigurationAPICtrl) Handler(c echo.Context) error {
 		flags = tmpFlags
 	}
 
+	// filter the flags with the tags of the request, restricted to the tags allowed for the API key.
+	tags, ok := helper.Tags(c, h.flagsetManager)
+	maps.DeleteFunc(flags, func(_ string, f flag.Flag) bool {
+		return !ok || !flagtag.Match(f, tags)
+	})
+
 	span.SetAttributes(attribute.Int("flagConfiguration.configurationSize", len(flags)))
 	c.Response().Header().
 		Set(echo.HeaderLastModified, flagset.GetCacheRefreshDate().
@@ -1,9 +1,12 @@
 package controller_test
 
 import (
+	"encoding/json"
+	"maps"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"slices"
 	"strings"
 	"testing"
 
@@ -246,3 +249,74 @@ func TestFlagConfigurationAPICtrl_Handler_FlagsetMode(t *testing.T) {
 		})
 	}
 }
+
+func TestFlagConfigurationAPICtrl_Handler_Tags(t *testing.T) {
+	conf := config.Config{
+		CommonFlagSet: config.CommonFlagSet{
+			Retriever: &retrieverconf.RetrieverConf{
+				Kind: retrieverconf.FileRetriever,
+				Path: "../../testdata/tagged_flags.yaml",
+			},
+		},
+		AuthorizedKeys: config.APIKeys{
+			Evaluation: []string{"all-flags-key", "frontend-key"},
+			TagRestrictions: []config.APIKeyTagRestriction{
+				{APIKey: "frontend-key", Tags: []string{"frontend"}},
+			},
+		},
+	}
+	conf.ForceReloadAPIKeys()
+	flagsetManager, err := service.NewFlagsetManager(&conf, zap.NewNop(), []notifier.Notifier{}, nil)
+	assert.NoError(t, err, "impossible to create flagset manager")
+	defer flagsetManager.Close()
+	ctrl := controller.NewAPIFlagConfiguration(flagsetManager, metric.Metrics{})
+
+	tests := []struct {
+		name     string
+		apiKey   string
+		query    string
+		wantKeys []string
+	}{
+		{
+			name:     "no tags",
+			apiKey:   "all-flags-key",
+			wantKeys: []string{"backend-flag", "frontend-flag", "untagged-flag"},
+		},
+		{
+			name:     "filtered by tags",
+			apiKey:   "all-flags-key",
+			query:    "?tags=backend",
+			wantKeys: []string{"backend-flag"},
+		},
+		{
+			name:     "restricted API key",
+			apiKey:   "frontend-key",
+			wantKeys: []string{"frontend-flag"},
+		},
+		{
+			name:     "tag not allowed for the API key",
+			apiKey:   "frontend-key",
+			query:    "?tags=backend",
+			wantKeys: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(echo.POST, "/v1/flag/configuration"+tt.query, strings.NewReader(`{}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-API-Key", tt.apiKey)
+			c := e.NewContext(req, rec)
+			c.SetPath("/v1/flag/configuration")
+
+			assert.NoError(t, ctrl.Handler(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			var resp struct {
+				Flags map[string]any `json:"flags"`
+			}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.ElementsMatch(t, tt.wantKeys, slices.Collect(maps.Keys(resp.Flags)))
+		})
+	}
+}
@@ -10,6 +10,8 @@ import (
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/model"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
 	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/configfile"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	ffmodel "github.com/thomaspoignant/go-feature-flag/modules/core/model"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -79,6 +81,17 @@ func (h *flagEval) Handler(c echo.Context) error {
 		return httpErr
 	}
 
+	if !helper.FlagAllowed(c, h.flagsetMngr, flagset, flagKey) {
+		// a flag without any of the tags allowed for the API key is handled as a missing flag.
+		return c.JSON(http.StatusOK, ffmodel.RawVarResult{
+			Value:         reqBody.DefaultValue,
+			VariationType: flag.VariationSDKDefault,
+			ErrorCode:     flag.ErrorCodeFlagNotFound,
+			Failed:        true,
+			Reason:        flag.ReasonError,
+		})
+	}
+
 	flagValue, _ := flagset.RawVariation(flagKey, evaluationCtx, reqBody.DefaultValue)
 
 	span.SetAttributes(
@@ -98,5 +111,6 @@ func (h *flagEval) Handler(c echo.Context) error {
 		span.SetAttributes(attribute.String("flagEvaluation.flagSetName", flagsetName))
 	}
 
+	helper.SetDeprecationHeader(c, flagValue.Metadata)
 	return c.JSON(http.StatusOK, flagValue)
 }
@@ -200,3 +200,98 @@ func Test_flag_eval_Handler(t *testing.T) {
 		})
 	}
 }
+
+func Test_flag_eval_Handler_DeprecatedFlag(t *testing.T) {
+	conf := config.Config{
+		CommonFlagSet: config.CommonFlagSet{
+			Retriever: &retrieverconf.RetrieverConf{
+				Kind: retrieverconf.FileRetriever,
+				Path: "../../testdata/deprecated_flags.yaml",
+			},
+		},
+	}
+	flagsetManager, err := service.NewFlagsetManager(&conf, zap.NewNop(), []notifier.Notifier{}, nil)
+	assert.NoError(t, err, "impossible to create flagset manager")
+	defer flagsetManager.Close()
+	flagEval := controller.NewFlagEval(flagsetManager, metric.Metrics{})
+
+	tests := []struct {
+		flagKey         string
+		wantDeprecation string
+	}{
+		{flagKey: "deprecated-flag", wantDeprecation: "?1"},
+		{flagKey: "dated-deprecated-flag", wantDeprecation: "@1740787200"},
+		{flagKey: "active-flag", wantDeprecation: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.flagKey, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(echo.POST, "/v1/feature/"+tt.flagKey+"/eval",
+				strings.NewReader(`{"evaluationContext":{"key":"user-1"},"defaultValue":false}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			c := e.NewContext(req, rec)
+			c.SetPath("/v1/feature/:flagKey/eval")
+			c.SetParamNames("flagKey")
+			c.SetParamValues(tt.flagKey)
+
+			assert.NoError(t, flagEval.Handler(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.wantDeprecation, rec.Header().Get("Deprecation"))
+		})
+	}
+}
+
+func Test_flag_eval_Handler_TagRestrictedAPIKey(t *testing.T) {
+	conf := config.Config{
+		CommonFlagSet: config.CommonFlagSet{
+			Retriever: &retrieverconf.RetrieverConf{
+				Kind: retrieverconf.FileRetriever,
+				Path: "../../testdata/tagged_flags.yaml",
+			},
+		},
+		AuthorizedKeys: config.APIKeys{
+			Evaluation: []string{"frontend-key"},
+			TagRestrictions: []config.APIKeyTagRestriction{
+				{APIKey: "frontend-key", Tags: []string{"frontend"}},
+			},
+		},
+	}
+	conf.ForceReloadAPIKeys()
+	flagsetManager, err := service.NewFlagsetManager(&conf, zap.NewNop(), []notifier.Notifier{}, nil)
+	assert.NoError(t, err, "impossible to create flagset manager")
+	defer flagsetManager.Close()
+	flagEval := controller.NewFlagEval(flagsetManager, metric.Metrics{})
+
+	tests := []struct {
+		flagKey  string
+		wantBody string
+	}{
+		{
+			flagKey:  "frontend-flag",
+			wantBody: `"value":true`,
+		},
+		{
+			flagKey:  "backend-flag",
+			wantBody: `"errorCode":"FLAG_NOT_FOUND"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.flagKey, func(t *testing.T) {
+			e := echo.New()
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(echo.POST, "/v1/feature/"+tt.flagKey+"/eval",
+				strings.NewReader(`{"evaluationContext":{"key":"user-1"},"defaultValue":false}`))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.Header.Set("X-API-Key", "frontend-key")
+			c := e.NewContext(req, rec)
+			c.SetPath("/v1/feature/:flagKey/eval")
+			c.SetParamNames("flagKey")
+			c.SetParamValues(tt.flagKey)
+
+			assert.NoError(t, flagEval.Handler(c))
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
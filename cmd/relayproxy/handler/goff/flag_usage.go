@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/helper"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/metric"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
+	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/configfile"
+	"go.opentelemetry.io/otel"
+)
+
+type flagUsage struct {
+	flagsetManager service.FlagsetManager
+	metrics        metric.Metrics
+}
+
+// NewFlagUsage initialize the controller for the /admin/v1/flags/usage endpoint
+func NewFlagUsage(flagsetManager service.FlagsetManager, metrics metric.Metrics) Controller {
+	return &flagUsage{
+		flagsetManager: flagsetManager,
+		metrics:        metrics,
+	}
+}
+
+// Handler is returning the usage report of the flags.
+// It contains the evaluations per flag and per variation, the flags never evaluated, the stale flags,
+// the flags always serving the same variation and the flags requested but not in the configuration.
+// @Summary      This endpoint is returning the usage report of the flags.
+// @Tags Admin API to manage GO Feature Flag
+// @Description  This endpoint is returning the usage report of the flags since the tracking started.
+// @Description  It contains the evaluations per flag and per variation, the flags never evaluated, the stale
+// @Description flags, the flags always serving the same variation and the flags requested but not in the
+// @Description configuration.
+// @Description The usage tracking has to be enabled in the configuration (`usageTracking.enabled`).
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object} usage.Report "Success"
+// @Failure 	 400 {object} modeldocs.HTTPErrorDoc "Bad Request"
+// @Failure      500 {object} modeldocs.HTTPErrorDoc "Internal server error"
+// @Router       /admin/v1/flags/usage [get]
+func (h *flagUsage) Handler(c echo.Context) error {
+	flagset, httpErr := helper.FlagSet(h.flagsetManager, helper.APIKey(c))
+	if httpErr != nil {
+		return httpErr
+	}
+
+	tracer := otel.GetTracerProvider().Tracer(configfile.OtelTracerName)
+	_, span := tracer.Start(c.Request().Context(), "flagUsage")
+	defer span.End()
+	report, err := flagset.GetFlagUsageReport()
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	return c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,75 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/config"
+	controller "github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/handler/goff"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/metric"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
+	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/retrieverconf"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/notifier"
+	"github.com/thomaspoignant/go-feature-flag/usage"
+	"go.uber.org/zap"
+)
+
+func Test_flag_usage_Handler(t *testing.T) {
+	tests := []struct {
+		name          string
+		usageTracking config.UsageTrackingConf
+		wantErr       string
+	}{
+		{
+			name:          "usage tracking enabled",
+			usageTracking: config.UsageTrackingConf{Enabled: true},
+		},
+		{
+			name:    "usage tracking not enabled",
+			wantErr: "code=400, message=the usage tracking is not enabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := config.Config{
+				CommonFlagSet: config.CommonFlagSet{
+					Retriever: &retrieverconf.RetrieverConf{
+						Kind: retrieverconf.FileRetriever,
+						Path: "../../../../testdata/flag-config.yaml",
+					},
+					UsageTracking: tt.usageTracking,
+				},
+			}
+			flagsetManager, err := service.NewFlagsetManager(&conf, zap.NewNop(), []notifier.Notifier{}, nil)
+			require.NoError(t, err, "impossible to create flagset manager")
+			defer flagsetManager.Close()
+			_, _ = flagsetManager.Default().BoolVariation(
+				"test-flag", ffcontext.NewEvaluationContext("random-key"), false)
+			_, _ = flagsetManager.Default().BoolVariation(
+				"unknown-flag", ffcontext.NewEvaluationContext("random-key"), false)
+
+			ctrl := controller.NewFlagUsage(flagsetManager, metric.Metrics{})
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(echo.GET, "/admin/v1/flags/usage", nil)
+			handlerErr := ctrl.Handler(echo.New().NewContext(req, rec))
+			if tt.wantErr != "" {
+				assert.EqualError(t, handlerErr, tt.wantErr)
+				return
+			}
+			require.NoError(t, handlerErr)
+			assert.Equal(t, http.StatusOK, rec.Code)
+
+			var report usage.Report
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+			assert.Equal(t, int64(1), report.Flags["test-flag"].EvaluationCount)
+			assert.Equal(t, int64(1), report.UnknownFlags["unknown-flag"].EvaluationCount)
+			assert.Contains(t, report.Unused, "test-flag2")
+		})
+	}
+}
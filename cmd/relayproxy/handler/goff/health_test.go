@@ -1,6 +1,7 @@
 package controller_test
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -8,8 +9,10 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/config"
 	controller "github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/handler/goff"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/model"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
 	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/retrieverconf"
 	"github.com/thomaspoignant/go-feature-flag/notifier"
@@ -68,7 +71,26 @@ func Test_health_Handler(t *testing.T) {
 			body, err := os.ReadFile(tt.want.bodyFile)
 			assert.NoError(t, err, "Impossible the expected body file %s", tt.want.bodyFile)
 			assert.Equal(t, tt.want.httpCode, rec.Code, "Invalid HTTP Code")
-			assert.JSONEq(t, string(body), rec.Body.String(), "Invalid response body")
+			assert.JSONEq(t, string(body), withoutRetrieverDates(t, rec.Body.Bytes()), "Invalid response body")
 		})
 	}
 }
+
+// withoutRetrieverDates removes the dates of the retrievers status from the health response,
+// after checking that they are set, to be able to compare it with the expected body.
+func withoutRetrieverDates(t *testing.T, body []byte) string {
+	t.Helper()
+	var response model.HealthResponse
+	require.NoError(t, json.Unmarshal(body, &response))
+	for _, statuses := range response.Retrievers {
+		for i := range statuses {
+			assert.NotNil(t, statuses[i].LastSuccess, "the last success of the retriever should be set")
+			assert.NotNil(t, statuses[i].NextAttempt, "the next attempt of the retriever should be set")
+			statuses[i].LastSuccess = nil
+			statuses[i].NextAttempt = nil
+		}
+	}
+	res, err := json.Marshal(response)
+	require.NoError(t, err)
+	return string(res)
+}
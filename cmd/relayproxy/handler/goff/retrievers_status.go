@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/helper"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/metric"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/model"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
+	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/configfile"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	urlInMessage    = regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s"'<>]+`)
+	secretInMessage = regexp.MustCompile(`(?i)\b(token|password|secret|api[_-]?key)=[^\s&"']+`)
+)
+
+type retrieversStatusResponse struct {
+	// Retrievers are the status of the retrievers of the flag set, in the order of the configuration.
+	Retrievers []model.RetrieverStatus `json:"retrievers"`
+}
+
+type retrieversStatus struct {
+	flagsetManager service.FlagsetManager
+	metrics        metric.Metrics
+}
+
+// NewRetrieversStatus initialize the controller for the GET /admin/v1/retrievers endpoint
+func NewRetrieversStatus(flagsetManager service.FlagsetManager, metrics metric.Metrics) Controller {
+	return &retrieversStatus{
+		flagsetManager: flagsetManager,
+		metrics:        metrics,
+	}
+}
+
+// Handler is returning the refresh status of the retrievers.
+// @Summary      This endpoint is returning the refresh status of the retrievers.
+// @Tags Admin API to manage GO Feature Flag
+// @Description  This endpoint is returning the refresh status of each retriever of the flag set: the last success,
+// @Description the last error, the next attempt and the state of the circuit breaker.
+// @Description The credentials and the query parameters of the URLs are removed from the locations and from the
+// @Description error messages.
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object} retrieversStatusResponse "Success"
+// @Failure 	 400 {object} modeldocs.HTTPErrorDoc "Bad Request"
+// @Failure      500 {object} modeldocs.HTTPErrorDoc "Internal server error"
+// @Router       /admin/v1/retrievers [get]
+func (h *retrieversStatus) Handler(c echo.Context) error {
+	flagset, httpErr := helper.FlagSet(h.flagsetManager, helper.APIKey(c))
+	if httpErr != nil {
+		return httpErr
+	}
+
+	tracer := otel.GetTracerProvider().Tracer(configfile.OtelTracerName)
+	_, span := tracer.Start(c.Request().Context(), "retrieversStatus")
+	defer span.End()
+	statuses := flagset.GetRetrieversStatus()
+	resp := retrieversStatusResponse{Retrievers: make([]model.RetrieverStatus, 0, len(statuses))}
+	for _, status := range statuses {
+		resp.Retrievers = append(resp.Retrievers, model.RetrieverStatus{
+			Name:              status.Name,
+			Location:          redactURL(status.Location),
+			Status:            status.Status,
+			LastSuccess:       nonZeroTime(status.LastSuccess),
+			LastError:         nonZeroTime(status.LastError),
+			LastErrorMessage:  redactMessage(status.LastErrorMessage),
+			ConsecutiveErrors: status.ConsecutiveErrors,
+			NextAttempt:       nonZeroTime(status.NextAttempt),
+			CircuitBreaker:    status.CircuitBreaker,
+		})
+	}
+	span.SetAttributes(attribute.Int("retrieversStatus.count", len(resp.Retrievers)))
+	return c.JSON(http.StatusOK, resp)
+}
+
+// redactURL removes the credentials, the query and the fragment of a URL, they can contain secrets (ex: a token).
+// A location which is not a URL (ex: a file path) is returned unchanged.
+func redactURL(location string) string {
+	if !strings.Contains(location, "://") {
+		return location
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return redacted
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.ForceQuery = false
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// redactMessage removes the secrets of the URLs and the secret parameters from an error message.
+func redactMessage(message string) string {
+	message = urlInMessage.ReplaceAllStringFunc(message, redactURL)
+	return secretInMessage.ReplaceAllString(message, "$1="+redacted)
+}
+
+// nonZeroTime returns a pointer to the time, or nil if the time is zero.
+func nonZeroTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
@@ -0,0 +1,135 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/config"
+	controller "github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/handler/goff"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/metric"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/model"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
+	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/retrieverconf"
+	"github.com/thomaspoignant/go-feature-flag/notifier"
+	"go.uber.org/zap"
+)
+
+func Test_retrievers_status_Handler(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	conf := config.Config{
+		CommonFlagSet: config.CommonFlagSet{
+			StartWithRetrieverError: true,
+			Retrievers: &[]retrieverconf.RetrieverConf{
+				{
+					Kind:            retrieverconf.FileRetriever,
+					Path:            "../../../../testdata/flag-config.yaml",
+					PollingInterval: 600000,
+				},
+				{
+					Kind: retrieverconf.HTTPRetriever,
+					URL:  "http://user:password@" + server.Listener.Addr().String() + "/flags.yaml?token=secret",
+				},
+			},
+		},
+	}
+	flagsetManager, err := service.NewFlagsetManager(&conf, zap.NewNop(), []notifier.Notifier{}, nil)
+	require.NoError(t, err, "impossible to create flagset manager")
+	defer flagsetManager.Close()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(echo.GET, "/admin/v1/retrievers", nil)
+	ctrl := controller.NewRetrieversStatus(flagsetManager, metric.Metrics{})
+	require.NoError(t, ctrl.Handler(echo.New().NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "password")
+	assert.NotContains(t, rec.Body.String(), "secret")
+
+	var resp struct {
+		Retrievers []model.RetrieverStatus `json:"retrievers"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	require.Len(t, resp.Retrievers, 2)
+
+	file := resp.Retrievers[0]
+	assert.Equal(t, "*fileretriever.Retriever", file.Name)
+	assert.Equal(t, "../../../../testdata/flag-config.yaml", file.Location)
+	assert.Equal(t, "READY", file.Status)
+	assert.Equal(t, "CLOSED", file.CircuitBreaker)
+	require.NotNil(t, file.LastSuccess)
+	assert.Nil(t, file.LastError)
+	require.NotNil(t, file.NextAttempt)
+	// the polling interval of the retriever is used instead of the one of the relay proxy.
+	assert.WithinDuration(t, file.LastSuccess.Add(10*time.Minute), *file.NextAttempt, time.Second)
+
+	remote := resp.Retrievers[1]
+	assert.Equal(t, "*httpretriever.Retriever", remote.Name)
+	assert.Equal(t, "http://"+server.Listener.Addr().String()+"/flags.yaml", remote.Location)
+	assert.Equal(t, "ERROR", remote.Status)
+	assert.Equal(t, 1, remote.ConsecutiveErrors)
+	require.NotNil(t, remote.LastError)
+	assert.NotEmpty(t, remote.LastErrorMessage)
+}
+
+func Test_retrievers_status_Handler_flagsets(t *testing.T) {
+	conf := config.Config{
+		FlagSets: []config.FlagSet{
+			{
+				Name: "flagset1",
+				CommonFlagSet: config.CommonFlagSet{
+					Retrievers: &[]retrieverconf.RetrieverConf{
+						{Kind: retrieverconf.FileRetriever, Path: "../../../../testdata/flag-config.yaml"},
+						{Kind: retrieverconf.FileRetriever, Path: "../../../../testdata/flag-config-2nd-file.yaml"},
+					},
+				},
+				APIKeys: []string{"api-key-1"},
+			},
+			{
+				Name: "flagset2",
+				CommonFlagSet: config.CommonFlagSet{
+					Retriever: &retrieverconf.RetrieverConf{
+						Kind: retrieverconf.FileRetriever,
+						Path: "../../../../testdata/flag-config-2nd-file.yaml",
+					},
+				},
+				APIKeys: []string{"api-key-2"},
+			},
+		},
+	}
+	conf.ForceReloadAPIKeys()
+	flagsetManager, err := service.NewFlagsetManager(&conf, zap.NewNop(), []notifier.Notifier{}, nil)
+	require.NoError(t, err, "impossible to create flagset manager")
+	defer flagsetManager.Close()
+
+	tests := []struct {
+		apiKey string
+		want   int
+	}{
+		{apiKey: "api-key-1", want: 2},
+		{apiKey: "api-key-2", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.apiKey, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(echo.GET, "/admin/v1/retrievers", nil)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+tt.apiKey)
+			ctrl := controller.NewRetrieversStatus(flagsetManager, metric.Metrics{})
+			require.NoError(t, ctrl.Handler(echo.New().NewContext(req, rec)))
+
+			var resp struct {
+				Retrievers []model.RetrieverStatus `json:"retrievers"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Len(t, resp.Retrievers, tt.want)
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/helper"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/metric"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
+	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/configfile"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type snapshotsResponse struct {
+	// Pinned is the ID of the snapshot pinned, if any.
+	Pinned *int `json:"pinned,omitempty"`
+	// Snapshots are the versions of the flag configuration kept, from the newest to the oldest.
+	Snapshots []retriever.Snapshot `json:"snapshots"`
+}
+
+type snapshotPinResponse struct {
+	// Pinned is the snapshot pinned, nil if no snapshot is pinned anymore.
+	Pinned *retriever.Snapshot `json:"pinned"`
+}
+
+type listSnapshots struct {
+	flagsetManager service.FlagsetManager
+	metrics        metric.Metrics
+}
+
+// NewListSnapshots initialize the controller for the GET /admin/v1/snapshots endpoint
+func NewListSnapshots(flagsetManager service.FlagsetManager, metrics metric.Metrics) Controller {
+	return &listSnapshots{
+		flagsetManager: flagsetManager,
+		metrics:        metrics,
+	}
+}
+
+// Handler is returning the snapshots of the flag configuration.
+// @Summary      This endpoint is returning the snapshots of the flag configuration.
+// @Tags Admin API to manage GO Feature Flag
+// @Description  This endpoint is returning the last versions of the flag configuration, from the newest to
+// @Description the oldest, and the snapshot pinned if any.
+// @Description The snapshots have to be enabled in the configuration (`flagConfigurationSnapshots`).
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object} snapshotsResponse "Success"
+// @Failure 	 400 {object} modeldocs.HTTPErrorDoc "Bad Request"
+// @Failure      500 {object} modeldocs.HTTPErrorDoc "Internal server error"
+// @Router       /admin/v1/snapshots [get]
+func (h *listSnapshots) Handler(c echo.Context) error {
+	flagset, httpErr := helper.FlagSet(h.flagsetManager, helper.APIKey(c))
+	if httpErr != nil {
+		return httpErr
+	}
+
+	tracer := otel.GetTracerProvider().Tracer(configfile.OtelTracerName)
+	_, span := tracer.Start(c.Request().Context(), "listSnapshots")
+	defer span.End()
+	snapshots, err := flagset.GetFlagConfigurationSnapshots()
+	if err != nil {
+		return snapshotHTTPError(err)
+	}
+	resp := snapshotsResponse{Snapshots: snapshots}
+	if pinned, ok := flagset.GetPinnedFlagConfigurationSnapshot(); ok {
+		resp.Pinned = &pinned.ID
+	}
+	span.SetAttributes(attribute.Int("listSnapshots.count", len(snapshots)))
+	return c.JSON(http.StatusOK, resp)
+}
+
+type pinSnapshot struct {
+	flagsetManager service.FlagsetManager
+	metrics        metric.Metrics
+}
+
+// NewPinSnapshot initialize the controller for the POST /admin/v1/snapshots/:id/pin endpoint
+func NewPinSnapshot(flagsetManager service.FlagsetManager, metrics metric.Metrics) Controller {
+	return &pinSnapshot{
+		flagsetManager: flagsetManager,
+		metrics:        metrics,
+	}
+}
+
+// Handler is pinning a snapshot of the flag configuration.
+// @Summary      This endpoint is pinning a snapshot of the flag configuration.
+// @Tags Admin API to manage GO Feature Flag
+// @Description  This endpoint applies a snapshot of the flag configuration and stops calling the retrievers
+// @Description until the pin is removed. It is used to roll back a bad flag configuration.
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Param        id path int true "ID of the snapshot"
+// @Success      200  {object} snapshotPinResponse "Success"
+// @Failure 	 400 {object} modeldocs.HTTPErrorDoc "Bad Request"
+// @Failure 	 404 {object} modeldocs.HTTPErrorDoc "Snapshot not found"
+// @Failure      500 {object} modeldocs.HTTPErrorDoc "Internal server error"
+// @Router       /admin/v1/snapshots/{id}/pin [post]
+func (h *pinSnapshot) Handler(c echo.Context) error {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid snapshot id: "+c.Param("id"))
+	}
+	flagset, httpErr := helper.FlagSet(h.flagsetManager, helper.APIKey(c))
+	if httpErr != nil {
+		return httpErr
+	}
+
+	tracer := otel.GetTracerProvider().Tracer(configfile.OtelTracerName)
+	_, span := tracer.Start(c.Request().Context(), "pinSnapshot")
+	defer span.End()
+	span.SetAttributes(attribute.Int("pinSnapshot.id", id))
+	snapshot, err := flagset.PinFlagConfigurationSnapshot(id)
+	if err != nil {
+		return snapshotHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, snapshotPinResponse{Pinned: &snapshot})
+}
+
+type unpinSnapshot struct {
+	flagsetManager service.FlagsetManager
+	metrics        metric.Metrics
+}
+
+// NewUnpinSnapshot initialize the controller for the DELETE /admin/v1/snapshots/pin endpoint
+func NewUnpinSnapshot(flagsetManager service.FlagsetManager, metrics metric.Metrics) Controller {
+	return &unpinSnapshot{
+		flagsetManager: flagsetManager,
+		metrics:        metrics,
+	}
+}
+
+// Handler is removing the pin of the snapshot of the flag configuration.
+// @Summary      This endpoint is removing the pin of the snapshot of the flag configuration.
+// @Tags Admin API to manage GO Feature Flag
+// @Description  This endpoint removes the pin of the snapshot, the retrievers are called again to refresh
+// @Description the flag configuration.
+// @Security     ApiKeyAuth
+// @Produce      json
+// @Success      200  {object} snapshotPinResponse "Success"
+// @Failure 	 400 {object} modeldocs.HTTPErrorDoc "Bad Request"
+// @Failure      500 {object} modeldocs.HTTPErrorDoc "Internal server error"
+// @Router       /admin/v1/snapshots/pin [delete]
+func (h *unpinSnapshot) Handler(c echo.Context) error {
+	flagset, httpErr := helper.FlagSet(h.flagsetManager, helper.APIKey(c))
+	if httpErr != nil {
+		return httpErr
+	}
+
+	tracer := otel.GetTracerProvider().Tracer(configfile.OtelTracerName)
+	_, span := tracer.Start(c.Request().Context(), "unpinSnapshot")
+	defer span.End()
+	if err := flagset.UnpinFlagConfigurationSnapshot(); err != nil {
+		return snapshotHTTPError(err)
+	}
+	return c.JSON(http.StatusOK, snapshotPinResponse{})
+}
+
+// snapshotHTTPError converts an error of the snapshots to an HTTP error.
+func snapshotHTTPError(err error) *echo.HTTPError {
+	switch {
+	case errors.Is(err, retriever.ErrSnapshotNotFound):
+		return echo.NewHTTPError(http.StatusNotFound, err.Error())
+	case errors.Is(err, retriever.ErrSnapshotsDisabled), errors.Is(err, retriever.ErrNoPinnedSnapshot):
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	default:
+		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
+	}
+}
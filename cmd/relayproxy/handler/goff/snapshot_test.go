@@ -0,0 +1,103 @@
+package controller_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/config"
+	controller "github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/handler/goff"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/metric"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
+	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/retrieverconf"
+	"github.com/thomaspoignant/go-feature-flag/notifier"
+	"go.uber.org/zap"
+)
+
+func newSnapshotFlagsetManager(t *testing.T, snapshots int) service.FlagsetManager {
+	t.Helper()
+	conf := config.Config{
+		CommonFlagSet: config.CommonFlagSet{
+			Retriever: &retrieverconf.RetrieverConf{
+				Kind: retrieverconf.FileRetriever,
+				Path: "../../../../testdata/flag-config.yaml",
+			},
+			FlagConfigurationSnapshots: snapshots,
+		},
+	}
+	flagsetManager, err := service.NewFlagsetManager(&conf, zap.NewNop(), []notifier.Notifier{}, nil)
+	require.NoError(t, err, "impossible to create flagset manager")
+	t.Cleanup(flagsetManager.Close)
+	return flagsetManager
+}
+
+func Test_snapshots_Handler(t *testing.T) {
+	flagsetManager := newSnapshotFlagsetManager(t, 5)
+	e := echo.New()
+
+	// list the snapshots
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(echo.GET, "/admin/v1/snapshots", nil)
+	require.NoError(t, controller.NewListSnapshots(flagsetManager, metric.Metrics{}).Handler(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var list struct {
+		Pinned    *int `json:"pinned"`
+		Snapshots []struct {
+			ID            int `json:"id"`
+			NumberOfFlags int `json:"numberOfFlags"`
+		} `json:"snapshots"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Nil(t, list.Pinned)
+	require.Len(t, list.Snapshots, 1)
+	assert.Equal(t, 1, list.Snapshots[0].ID)
+
+	// pin a snapshot
+	pin := func(id string) (*httptest.ResponseRecorder, error) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(echo.POST, "/admin/v1/snapshots/"+id+"/pin", nil)
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+		return rec, controller.NewPinSnapshot(flagsetManager, metric.Metrics{}).Handler(c)
+	}
+	_, err := pin("invalid")
+	assert.EqualError(t, err, "code=400, message=invalid snapshot id: invalid")
+	_, err = pin("42")
+	assert.EqualError(t, err, "code=404, message=flag configuration snapshot not found: 42")
+	rec, err = pin("1")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"pinned":{"id":1`)
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(echo.GET, "/admin/v1/snapshots", nil)
+	require.NoError(t, controller.NewListSnapshots(flagsetManager, metric.Metrics{}).Handler(e.NewContext(req, rec)))
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.NotNil(t, list.Pinned)
+	assert.Equal(t, 1, *list.Pinned)
+
+	// unpin the snapshot
+	unpin := func() (*httptest.ResponseRecorder, error) {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(echo.DELETE, "/admin/v1/snapshots/pin", nil)
+		return rec, controller.NewUnpinSnapshot(flagsetManager, metric.Metrics{}).Handler(e.NewContext(req, rec))
+	}
+	rec, err = unpin()
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"pinned":null}`, rec.Body.String())
+	_, err = unpin()
+	assert.EqualError(t, err, "code=400, message=no flag configuration snapshot is pinned")
+}
+
+func Test_snapshots_Handler_disabled(t *testing.T) {
+	flagsetManager := newSnapshotFlagsetManager(t, 0)
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(echo.GET, "/admin/v1/snapshots", nil)
+	err := controller.NewListSnapshots(flagsetManager, metric.Metrics{}).Handler(echo.New().NewContext(req, rec))
+	assert.EqualError(t, err, "code=400, message=the flag configuration snapshots are not enabled")
+}
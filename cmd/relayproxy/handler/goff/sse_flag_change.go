@@ -5,6 +5,7 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/helper"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service/stream"
 	"github.com/thomaspoignant/go-feature-flag/utils"
@@ -40,6 +41,7 @@ type sseFlagChange struct {
 // @Description  or persisted by intermediaries.
 // @Produce      text/event-stream
 // @Param        apiKey query string false "apiKey to authorize the connection to the relay proxy"
+// @Param        tags query []string false "Use only the flags with one of these tags" collectionFormat(multi)
 // @Success      200  {object} notifier.DiffCache "SSE stream of flag change events"
 // @Failure      400  {object} modeldocs.HTTPErrorDoc "Bad Request"
 // @Failure      401  {object} modeldocs.HTTPErrorDoc "Unauthorized"
@@ -52,16 +54,22 @@ func (h *sseFlagChange) Handler(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
 	}
 
-	h.logger.Debug("SSE client connecting", zap.String("flagset", flagsetName))
+	tags, ok := helper.StreamTags(c, h.flagsetManager)
+	if !ok {
+		return echo.NewHTTPError(http.StatusForbidden, "none of the requested tags is allowed for this API key")
+	}
+
+	h.logger.Debug("SSE client connecting", zap.String("flagset", flagsetName), zap.Strings("tags", tags))
 
 	// r3labs/sse routes by the "stream" query parameter.
-	// We need to set the "stream" query parameter to the flagset name.
+	// We need to set the "stream" query parameter to the flagset name, or to a stream filtered by tags.
 	// We also need to delete the "apiKey" query parameter.
 	// This is because the r3labs/sse server will use the "stream" query parameter to route the
 	// request to the correct flagset. After that point the apiKey is not needed anymore, so we delete it.
 	q := c.Request().URL.Query()
-	q.Set("stream", flagsetName)
+	q.Set("stream", h.sseService.TaggedStream(flagsetName, tags))
 	q.Del("apiKey")
+	q.Del(helper.TagsQueryParam)
 	c.Request().URL.RawQuery = q.Encode()
 
 	h.sseService.ServeHTTP(c.Response(), c.Request())
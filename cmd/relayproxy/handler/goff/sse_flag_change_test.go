@@ -44,6 +44,7 @@ func (m *mockFlagsetManagerSSE) AllFlagSets() (map[string]*ffclient.GoFeatureFla
 	return nil, nil
 }
 func (m *mockFlagsetManagerSSE) Default() *ffclient.GoFeatureFlag { return nil }
+func (m *mockFlagsetManagerSSE) AllowedTags(_ string) []string    { return nil }
 func (m *mockFlagsetManagerSSE) Close()                           {}
 func (m *mockFlagsetManagerSSE) OnConfigChange(_ *config.Config)  {}
 
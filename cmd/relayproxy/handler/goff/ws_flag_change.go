@@ -8,7 +8,11 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/labstack/echo/v4"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/helper"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service/stream"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagtag"
+	"github.com/thomaspoignant/go-feature-flag/notifier"
 	"go.uber.org/zap"
 )
 
@@ -20,10 +24,20 @@ import (
 type threadSafeConn struct {
 	conn *websocket.Conn
 	mu   sync.Mutex
+	// tags is the list of tags the client is subscribed to, an empty list means all the flags.
+	tags []string
 }
 
 // WriteJSON satisfies stream.WebsocketConnector (used by BroadcastFlagChanges).
+// Flag changes are filtered to keep only the flags matching the tags of the connection.
 func (c *threadSafeConn) WriteJSON(v any) error {
+	if diff, ok := v.(notifier.DiffCache); ok && len(c.tags) > 0 {
+		filtered := flagtag.FilterDiff(diff, c.tags)
+		if !filtered.HasDiff() {
+			return nil
+		}
+		v = filtered
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	return c.conn.WriteJSON(v)
@@ -38,9 +52,14 @@ func (c *threadSafeConn) ping() error {
 
 // NewWsFlagChange is the constructor to create a new controller to handle websocket
 // request to be notified about flag changes.
-func NewWsFlagChange(websocketService stream.WebsocketService, logger *zap.Logger) *WSFlagChange {
+func NewWsFlagChange(
+	websocketService stream.WebsocketService,
+	flagsetManager service.FlagsetManager,
+	logger *zap.Logger,
+) *WSFlagChange {
 	return &WSFlagChange{
 		websocketService: websocketService,
+		flagsetManager:   flagsetManager,
 		upgrader: websocket.Upgrader{
 			CheckOrigin: func(_ *http.Request) bool {
 				return true
@@ -57,6 +76,7 @@ const defaultPingInterval = 1 * time.Second
 // WSFlagChange is the implementation of the controller
 type WSFlagChange struct {
 	websocketService stream.WebsocketService
+	flagsetManager   service.FlagsetManager
 	upgrader         websocket.Upgrader
 	logger           *zap.Logger
 	// pingInterval is the keep-alive ping period; defaults to defaultPingInterval.
@@ -73,6 +93,7 @@ type WSFlagChange struct {
 // @Produce      json
 // @Accept       json
 // @Param        apiKey query string false "apiKey to authorize the connection to the relay proxy"
+// @Param        tags query []string false "Use only the flags with one of these tags" collectionFormat(multi)
 // @Success      200  {object} notifier.DiffCache "Success"
 // @Failure      400  {object} modeldocs.HTTPErrorDoc "Bad Request"
 // @Failure      401  {object} modeldocs.HTTPErrorDoc "Unauthorized"
@@ -91,12 +112,18 @@ func (f *WSFlagChange) LegacyHandler(c echo.Context) error {
 // @Produce      json
 // @Accept       json
 // @Param        apiKey query string false "apiKey to authorize the connection to the relay proxy"
+// @Param        tags query []string false "Use only the flags with one of these tags" collectionFormat(multi)
 // @Success      200  {object} notifier.DiffCache "Success"
 // @Failure      400  {object} modeldocs.HTTPErrorDoc "Bad Request"
 // @Failure      401  {object} modeldocs.HTTPErrorDoc "Unauthorized"
 // @Failure      500  {object} modeldocs.HTTPErrorDoc "Internal server error"
 // @Router       /stream/v1/ws/flag/change [get]
 func (f *WSFlagChange) Handler(c echo.Context) error {
+	tags, ok := helper.StreamTags(c, f.flagsetManager)
+	if !ok {
+		return echo.NewHTTPError(http.StatusForbidden, "none of the requested tags is allowed for this API key")
+	}
+
 	conn, err := f.upgrader.Upgrade(c.Response(), c.Request(), nil)
 	if err != nil {
 		return err
@@ -106,7 +133,7 @@ func (f *WSFlagChange) Handler(c echo.Context) error {
 	// Wrap the connection so that all writes (ping loop + flag-change broadcast)
 	// are serialized through a single mutex. gorilla/websocket only supports one
 	// concurrent writer.
-	safeConn := &threadSafeConn{conn: conn}
+	safeConn := &threadSafeConn{conn: conn, tags: tags}
 
 	f.websocketService.Register(safeConn)
 	defer f.websocketService.Deregister(safeConn)
@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service/stream"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/notifier"
 	"go.uber.org/zap"
 )
 
@@ -96,7 +98,7 @@ func Test_pingPongLoop_pings_then_stops_on_context(t *testing.T) {
 		}
 	}()
 
-	f := NewWsFlagChange(stream.NewWebsocketService(), zap.NewNop())
+	f := NewWsFlagChange(stream.NewWebsocketService(), nil, zap.NewNop())
 	f.pingInterval = 10 * time.Millisecond
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -126,7 +128,7 @@ func Test_pingPongLoop_stops_on_write_error(t *testing.T) {
 	_ = client.Close()
 	_ = server.Close()
 
-	f := NewWsFlagChange(stream.NewWebsocketService(), zap.NewNop())
+	f := NewWsFlagChange(stream.NewWebsocketService(), nil, zap.NewNop())
 	f.pingInterval = 5 * time.Millisecond
 
 	done := make(chan struct{})
@@ -147,7 +149,7 @@ func Test_pingPongLoop_stops_on_write_error(t *testing.T) {
 func Test_pingPongLoop_zero_interval_uses_default(t *testing.T) {
 	server, _ := newWSConnPair(t)
 
-	f := NewWsFlagChange(stream.NewWebsocketService(), zap.NewNop())
+	f := NewWsFlagChange(stream.NewWebsocketService(), nil, zap.NewNop())
 	f.pingInterval = 0 // force the fallback branch
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -164,3 +166,28 @@ func Test_pingPongLoop_zero_interval_uses_default(t *testing.T) {
 		t.Fatal("ping loop did not start/stop with a zero interval")
 	}
 }
+
+func Test_threadSafeConn_WriteJSON_Tags(t *testing.T) {
+	server, client := newWSConnPair(t)
+	conn := &threadSafeConn{conn: server, tags: []string{"frontend"}}
+
+	backendOnly := notifier.DiffCache{
+		Added: map[string]flag.Flag{
+			"backend-flag": &flag.InternalFlag{Tags: &[]string{"backend"}},
+		},
+	}
+	mixed := notifier.DiffCache{
+		Added: map[string]flag.Flag{
+			"frontend-flag": &flag.InternalFlag{Tags: &[]string{"frontend"}},
+			"backend-flag":  &flag.InternalFlag{Tags: &[]string{"backend"}},
+		},
+	}
+	// the diff without any flag matching the tags is not sent to the client.
+	require.NoError(t, conn.WriteJSON(backendOnly))
+	require.NoError(t, conn.WriteJSON(mixed))
+
+	var received map[string]map[string]any
+	require.NoError(t, client.ReadJSON(&received))
+	assert.Contains(t, received["added"], "frontend-flag")
+	assert.NotContains(t, received["added"], "backend-flag")
+}
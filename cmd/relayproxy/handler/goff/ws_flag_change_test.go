@@ -121,7 +121,7 @@ func Test_websocket_flag_change(t *testing.T) {
 			}()
 
 			log := zap.L()
-			ctrl := controller.NewWsFlagChange(websocketService, log)
+			ctrl := controller.NewWsFlagChange(websocketService, nil, log)
 
 			e := echo.New()
 			e.GET("/ws/v1/flag/change", ctrl.Handler)
@@ -208,7 +208,7 @@ func Test_websocket_concurrent_writes(t *testing.T) {
 		}
 	}()
 
-	ctrl := controller.NewWsFlagChange(websocketService, zap.L())
+	ctrl := controller.NewWsFlagChange(websocketService, nil, zap.L())
 
 	e := echo.New()
 	e.GET("/ws/v1/flag/change", ctrl.Handler)
@@ -300,7 +300,7 @@ func Test_websocket_legacy_handler(t *testing.T) {
 		}
 	}()
 
-	ctrl := controller.NewWsFlagChange(websocketService, zap.L())
+	ctrl := controller.NewWsFlagChange(websocketService, nil, zap.L())
 
 	e := echo.New()
 	e.GET("/ws/v1/flag/change", ctrl.LegacyHandler)
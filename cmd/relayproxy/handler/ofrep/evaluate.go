@@ -2,6 +2,7 @@ package ofrep
 
 import (
 	"fmt"
+	"maps"
 	"net/http"
 	"sort"
 
@@ -11,7 +12,7 @@ import (
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/model"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
 	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/configfile"
-	"github.com/thomaspoignant/go-feature-flag/internal/flagstate"
+	"github.com/thomaspoignant/go-feature-flag/internal/lifecycle"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/ffcontext"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/utils"
@@ -87,6 +88,14 @@ func (h *EvaluateCtrl) Evaluate(c echo.Context) error {
 		return httpErr
 	}
 
+	// a flag without any of the tags allowed for the API key is not visible.
+	if !helper.FlagAllowed(c, h.flagsetManager, flagset, flagKey) {
+		return c.JSON(
+			http.StatusNotFound,
+			NewEvaluateError(flagKey, flag.ErrorCodeFlagNotFound,
+				fmt.Sprintf("Error while evaluating the flag: %s", flagKey)))
+	}
+
 	// we set a nil value to the default value to avoid the default value to be used.
 	var defaultValue any = nil
 	flagValue, _ := flagset.RawVariation(flagKey, evalCtx, defaultValue)
@@ -122,6 +131,10 @@ func (h *EvaluateCtrl) Evaluate(c echo.Context) error {
 		}
 		metadata["gofeatureflag_cacheable"] = true
 	}
+	if lifecycle.IsDeprecated(metadata) {
+		helper.SetDeprecationHeader(c, metadata)
+		metadata = deprecationMetadata(metadata)
+	}
 
 	return c.JSON(http.StatusOK, model.OFREPEvaluateSuccessResponse{
 		Key:      flagKey,
@@ -139,12 +152,14 @@ func (h *EvaluateCtrl) Evaluate(c echo.Context) error {
 // @Description of feature flags for this evaluation context.
 // @Description
 // @Description If no flags are provided, the API will evaluate all available flags in the configuration.
+// @Description You can use the `tags` query parameter to evaluate only the flags having at least one of the tags.
 // @Security    ApiKeyAuth
 // @Security    XApiKeyAuth
 // @Produce     json
 // @Accept	 	json
 // @Param       If-None-Match header string false "The request will be processed only if ETag doesn't match."
 // @Param 		data body model.OFREPEvalFlagRequest true "Evaluation Context and list of flag for this API call"
+// @Param       tags query []string false "Use only the flags with one of these tags" collectionFormat(multi)
 // @Success     200 {object} model.OFREPBulkEvaluateSuccessResponse "OFREP successful evaluation response"
 // @Success     304 {string} string "Etag: \"117-0193435c612c50d93b798619d9464856263dbf9f\""
 // @Failure     400 {object}  model.OFREPCommonResponseError "Bad evaluation request"
@@ -182,13 +197,7 @@ func (h *EvaluateCtrl) BulkEvaluate(c echo.Context) error {
 		return httpErr
 	}
 
-	var allFlagsResp flagstate.AllFlags
-	if len(evalCtx.ExtractGOFFProtectedFields().FlagList) > 0 {
-		// if we have a list of flags to evaluate in the evaluation context, we evaluate only those flags.
-		allFlagsResp = flagset.GetFlagStates(evalCtx, evalCtx.ExtractGOFFProtectedFields().FlagList)
-	} else {
-		allFlagsResp = flagset.AllFlagsState(evalCtx)
-	}
+	allFlagsResp := helper.AllFlagsState(c, h.flagsetManager, flagset, evalCtx)
 	flagNames := make([]string, 0, len(allFlagsResp.GetFlags()))
 	for key, val := range allFlagsResp.GetFlags() {
 		flagNames = append(flagNames, key)
@@ -202,7 +211,7 @@ func (h *EvaluateCtrl) BulkEvaluate(c echo.Context) error {
 				Value:    value,
 				Reason:   val.Reason,
 				Variant:  val.VariationType,
-				Metadata: val.Metadata,
+				Metadata: deprecationMetadata(val.Metadata),
 			},
 			ErrorCode:    val.ErrorCode,
 			ErrorDetails: val.ErrorDetails,
@@ -222,6 +231,17 @@ func (h *EvaluateCtrl) BulkEvaluate(c echo.Context) error {
 	return c.JSON(http.StatusOK, response)
 }
 
+// deprecationMetadata adds the gofeatureflag_deprecated metadata if the flag is deprecated.
+// The metadata is copied since it can be the metadata of the flag in the cache.
+func deprecationMetadata(metadata map[string]any) map[string]any {
+	if !lifecycle.IsDeprecated(metadata) {
+		return metadata
+	}
+	metadata = maps.Clone(metadata)
+	metadata["gofeatureflag_deprecated"] = true
+	return metadata
+}
+
 func assertOFREPEvaluateRequest(
 	ofrepEvalReq *model.OFREPEvalFlagRequest,
 ) *model.OFREPCommonResponseError {
@@ -1,6 +1,7 @@
 package ofrep_test
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -10,9 +11,11 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/config"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/handler/ofrep"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/metric"
+	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/model"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
 	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/retrieverconf"
 	"go.uber.org/zap"
@@ -328,3 +331,148 @@ func Test_Evaluate(t *testing.T) {
 		})
 	}
 }
+
+func Test_Evaluate_DeprecatedFlag(t *testing.T) {
+	conf := &config.Config{
+		CommonFlagSet: config.CommonFlagSet{
+			FileFormat: "yaml",
+			Retrievers: &[]retrieverconf.RetrieverConf{
+				{
+					Kind: retrieverconf.FileRetriever,
+					Path: testdataDir + "/deprecated_flags.yaml",
+				},
+			},
+		},
+	}
+	flagsetManager, err := service.NewFlagsetManager(conf, zap.NewNop(), nil, nil)
+	require.NoError(t, err)
+	defer flagsetManager.Close()
+
+	ctrl := ofrep.NewOFREPEvaluate(flagsetManager, metric.Metrics{})
+	e := echo.New()
+	e.POST("/ofrep/v1/evaluate/flags/:flagKey", ctrl.Evaluate)
+	e.POST("/ofrep/v1/evaluate/flags", ctrl.BulkEvaluate)
+	evaluate := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(echo.POST, path, strings.NewReader(`{"context":{"targetingKey":"user-1"}}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("deprecated flag", func(t *testing.T) {
+		rec := evaluate("/ofrep/v1/evaluate/flags/deprecated-flag")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "?1", rec.Header().Get("Deprecation"), "the date of deprecation is unknown")
+		var resp model.OFREPEvaluateSuccessResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, true, resp.Metadata["gofeatureflag_deprecated"])
+		assert.Equal(t, "team-checkout", resp.Metadata["owner"])
+	})
+
+	t.Run("deprecated flag with a deprecation date", func(t *testing.T) {
+		rec := evaluate("/ofrep/v1/evaluate/flags/dated-deprecated-flag")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "@1740787200", rec.Header().Get("Deprecation"))
+		assert.Contains(t, rec.Body.String(), "gofeatureflag_deprecated")
+	})
+
+	t.Run("not deprecated flag", func(t *testing.T) {
+		rec := evaluate("/ofrep/v1/evaluate/flags/active-flag")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get("Deprecation"))
+		assert.NotContains(t, rec.Body.String(), "gofeatureflag_deprecated")
+	})
+
+	t.Run("bulk evaluation", func(t *testing.T) {
+		rec := evaluate("/ofrep/v1/evaluate/flags")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var resp model.OFREPBulkEvaluateSuccessResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp.Flags, 3)
+		assert.Equal(t, "active-flag", resp.Flags[0].Key)
+		assert.Nil(t, resp.Flags[0].Metadata["gofeatureflag_deprecated"])
+		assert.Equal(t, "dated-deprecated-flag", resp.Flags[1].Key)
+		assert.Equal(t, true, resp.Flags[1].Metadata["gofeatureflag_deprecated"])
+		assert.Equal(t, "deprecated-flag", resp.Flags[2].Key)
+		assert.Equal(t, true, resp.Flags[2].Metadata["gofeatureflag_deprecated"])
+	})
+}
+
+func Test_Evaluate_Tags(t *testing.T) {
+	conf := &config.Config{
+		CommonFlagSet: config.CommonFlagSet{
+			FileFormat: "yaml",
+			Retrievers: &[]retrieverconf.RetrieverConf{
+				{
+					Kind: retrieverconf.FileRetriever,
+					Path: testdataDir + "/tagged_flags.yaml",
+				},
+			},
+		},
+		AuthorizedKeys: config.APIKeys{
+			Evaluation: []string{"all-flags-key", "frontend-key"},
+			TagRestrictions: []config.APIKeyTagRestriction{
+				{APIKey: "frontend-key", Tags: []string{"frontend"}},
+			},
+		},
+	}
+	conf.ForceReloadAPIKeys()
+	flagsetManager, err := service.NewFlagsetManager(conf, zap.NewNop(), nil, nil)
+	require.NoError(t, err)
+	defer flagsetManager.Close()
+
+	ctrl := ofrep.NewOFREPEvaluate(flagsetManager, metric.Metrics{})
+	e := echo.New()
+	e.POST("/ofrep/v1/evaluate/flags/:flagKey", ctrl.Evaluate)
+	e.POST("/ofrep/v1/evaluate/flags", ctrl.BulkEvaluate)
+	evaluate := func(path string, apiKey string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(echo.POST, path, strings.NewReader(`{"context":{"targetingKey":"user-1"}}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("X-API-Key", apiKey)
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	bulkKeys := func(t *testing.T, rec *httptest.ResponseRecorder) []string {
+		require.Equal(t, http.StatusOK, rec.Code)
+		var resp model.OFREPBulkEvaluateSuccessResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		keys := make([]string, 0, len(resp.Flags))
+		for _, f := range resp.Flags {
+			keys = append(keys, f.Key)
+		}
+		return keys
+	}
+
+	t.Run("bulk evaluation filtered by tags", func(t *testing.T) {
+		rec := evaluate("/ofrep/v1/evaluate/flags?tags=backend", "all-flags-key")
+		assert.Equal(t, []string{"backend-flag"}, bulkKeys(t, rec))
+	})
+
+	t.Run("bulk evaluation without tags", func(t *testing.T) {
+		rec := evaluate("/ofrep/v1/evaluate/flags", "all-flags-key")
+		assert.Equal(t, []string{"backend-flag", "frontend-flag", "untagged-flag"}, bulkKeys(t, rec))
+	})
+
+	t.Run("bulk evaluation with a restricted API key", func(t *testing.T) {
+		rec := evaluate("/ofrep/v1/evaluate/flags", "frontend-key")
+		assert.Equal(t, []string{"frontend-flag"}, bulkKeys(t, rec))
+	})
+
+	t.Run("bulk evaluation with a tag not allowed for the API key", func(t *testing.T) {
+		rec := evaluate("/ofrep/v1/evaluate/flags?tags=backend", "frontend-key")
+		assert.Empty(t, bulkKeys(t, rec))
+	})
+
+	t.Run("single evaluation of an allowed flag", func(t *testing.T) {
+		rec := evaluate("/ofrep/v1/evaluate/flags/frontend-flag", "frontend-key")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("single evaluation of a flag not allowed for the API key", func(t *testing.T) {
+		rec := evaluate("/ofrep/v1/evaluate/flags/backend-flag", "frontend-key")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Contains(t, rec.Body.String(), "FLAG_NOT_FOUND")
+	})
+}
@@ -7,3 +7,9 @@ const ContentTypeHeader = "Content-Type"
 const AuthorizationHeader = "Authorization"
 const BearerPrefix = "Bearer "
 const ContentTypeValueJSON = "application/json"
+
+// DeprecationHeader is set in the response when the evaluated flag is deprecated.
+const DeprecationHeader = "Deprecation"
+
+// TagsQueryParam is the query parameter used to filter the flags by tags.
+const TagsQueryParam = "tags"
@@ -2,11 +2,17 @@ package helper
 
 import (
 	"net/http"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 	ffclient "github.com/thomaspoignant/go-feature-flag"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/service"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagstate"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagtag"
+	"github.com/thomaspoignant/go-feature-flag/internal/lifecycle"
 )
 
 // APIKey extracts the API key from the request headers.
@@ -40,3 +46,73 @@ func FlagSet(flagsetManager service.FlagsetManager, apiKey string) (*ffclient.Go
 	}
 	return flagset, nil
 }
+
+// SetDeprecationHeader adds the Deprecation header to the response if the metadata of the evaluated
+// flag marks it as deprecated. The header contains the deprecation date as defined in RFC 9745
+// (ex: @1740787200), or the boolean ?1 if the date is unknown.
+func SetDeprecationHeader(c echo.Context, metadata map[string]any) {
+	if !lifecycle.IsDeprecated(metadata) {
+		return
+	}
+	value := "?1"
+	if date, ok := lifecycle.DeprecationDate(metadata); ok {
+		value = "@" + strconv.FormatInt(date.Unix(), 10)
+	}
+	c.Response().Header().Set(DeprecationHeader, value)
+}
+
+// Tags returns the tags used to filter the flags for this request.
+// The tags are read from the "tags" query parameter (repeated or comma separated) and restricted to
+// the tags allowed for the API key. It returns false if none of the requested tags is allowed,
+// meaning that no flag should be returned.
+func Tags(c echo.Context, flagsetManager service.FlagsetManager) ([]string, bool) {
+	return restrictedTags(c, flagsetManager, APIKey(c))
+}
+
+// StreamTags is the same as Tags for the stream endpoints, where the API key is passed
+// in the "apiKey" query parameter.
+func StreamTags(c echo.Context, flagsetManager service.FlagsetManager) ([]string, bool) {
+	return restrictedTags(c, flagsetManager, c.QueryParam("apiKey"))
+}
+
+func restrictedTags(c echo.Context, flagsetManager service.FlagsetManager, apiKey string) ([]string, bool) {
+	requested := flagtag.ParseList(c.QueryParams()[TagsQueryParam])
+	if flagsetManager == nil {
+		return requested, true
+	}
+	return flagtag.Restrict(requested, flagsetManager.AllowedTags(apiKey))
+}
+
+// FlagAllowed returns false if the API key of the request is restricted to some tags
+// and the flag has none of them.
+func FlagAllowed(
+	c echo.Context, flagsetManager service.FlagsetManager, flagset *ffclient.GoFeatureFlag, flagKey string,
+) bool {
+	if flagsetManager == nil {
+		return true
+	}
+	allowed := flagsetManager.AllowedTags(APIKey(c))
+	if len(allowed) == 0 {
+		return true
+	}
+	flagTags, err := flagset.GetFlagTags(flagKey)
+	if err != nil {
+		return false
+	}
+	return slices.ContainsFunc(flagTags, func(tag string) bool { return slices.Contains(allowed, tag) })
+}
+
+// AllFlagsState evaluates the flags for the request, only the flags matching the tags of the request
+// are evaluated. If the evaluation context contains a list of flags, only those flags are evaluated.
+func AllFlagsState(
+	c echo.Context,
+	flagsetManager service.FlagsetManager,
+	flagset *ffclient.GoFeatureFlag,
+	evaluationCtx ffcontext.Context,
+) flagstate.AllFlags {
+	tags, ok := Tags(c, flagsetManager)
+	if !ok {
+		return flagstate.NewAllFlags()
+	}
+	return flagset.GetFlagStatesWithTags(evaluationCtx, evaluationCtx.ExtractGOFFProtectedFields().FlagList, tags)
+}
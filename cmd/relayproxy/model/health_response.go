@@ -4,4 +4,8 @@ package model
 type HealthResponse struct {
 	// Set to true if the HTTP server is started
 	Initialized bool `json:"initialized" example:"true"`
+
+	// Retrievers contains the redacted status of the retrievers of each flagset.
+	// The format is {"flagset name": [retriever status]}, the flagset is named "default" when not using flagsets.
+	Retrievers map[string][]RetrieverHealth `json:"retrievers,omitempty"`
 }
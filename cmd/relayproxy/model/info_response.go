@@ -11,4 +11,8 @@ type InfoResponse struct {
 	// Flagsets contains the cache refresh dates for each flagset when using multiple flagsets.
 	// The format is {"flagset name": "2022-06-13T11:22:55.941628+02:00"}
 	Flagsets map[string]time.Time `json:"flagsets,omitempty" example:"default:2022-06-13T11:22:55.941628+02:00,feature-flags:2022-06-13T11:22:55.941628+02:00"` //nolint: lll
+
+	// Retrievers contains the redacted status of the retrievers of each flagset.
+	// The format is {"flagset name": [retriever status]}, the flagset is named "default" when not using flagsets.
+	Retrievers map[string][]RetrieverHealth `json:"retrievers,omitempty"`
 }
@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// RetrieverStatus is the refresh status of a retriever
+type RetrieverStatus struct {
+	// Name is the type of the retriever.
+	Name string `json:"name" example:"*fileretriever.Retriever"`
+	// Location is the location of the flag configuration, if the retriever exposes it.
+	// The credentials, the query and the fragment of a URL are removed.
+	Location string `json:"location,omitempty" example:"/goff/flags.yaml"`
+	// Status is READY if the last call of the retriever succeeded, ERROR if it failed.
+	Status string `json:"status" example:"READY"`
+	// LastSuccess is the date of the last successful call of the retriever.
+	LastSuccess *time.Time `json:"lastSuccess,omitempty" example:"2022-06-13T11:22:55.941628+02:00"`
+	// LastError is the date of the last failed call of the retriever.
+	LastError *time.Time `json:"lastError,omitempty" example:"2022-06-13T11:21:55.941628+02:00"`
+	// LastErrorMessage is the error of the last failed call of the retriever, with the secrets of the URLs removed.
+	LastErrorMessage string `json:"lastErrorMessage,omitempty" example:"context deadline exceeded"`
+	// ConsecutiveErrors is the number of failed calls since the last successful one.
+	ConsecutiveErrors int `json:"consecutiveErrors" example:"0"`
+	// NextAttempt is the date of the next call of the retriever, empty if the retriever is not polled.
+	NextAttempt *time.Time `json:"nextAttempt,omitempty" example:"2022-06-13T11:23:55.941628+02:00"`
+	// CircuitBreaker is the state of the circuit breaker of the retriever (CLOSED, OPEN or HALF_OPEN).
+	CircuitBreaker string `json:"circuitBreaker" example:"CLOSED"`
+}
+
+// RetrieverHealth is the redacted status of a retriever exposed by the /health and /info endpoints,
+// it contains no location and no error message because these endpoints are not authenticated.
+type RetrieverHealth struct {
+	// Kind is the type of the retriever.
+	Kind string `json:"kind" example:"*fileretriever.Retriever"`
+	// Status is READY if the last call of the retriever succeeded, ERROR if it failed.
+	Status string `json:"status" example:"READY"`
+	// LastSuccess is the date of the last successful call of the retriever.
+	LastSuccess *time.Time `json:"lastSuccess,omitempty" example:"2022-06-13T11:22:55.941628+02:00"`
+	// LastError is the date of the last failed call of the retriever.
+	LastError *time.Time `json:"lastError,omitempty" example:"2022-06-13T11:21:55.941628+02:00"`
+	// NextAttempt is the date of the next call of the retriever, empty if the retriever is not polled.
+	NextAttempt *time.Time `json:"nextAttempt,omitempty" example:"2022-06-13T11:23:55.941628+02:00"`
+}
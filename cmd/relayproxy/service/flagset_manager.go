@@ -40,6 +40,8 @@ type FlagsetManager interface {
 	Default() *ffclient.GoFeatureFlag
 	// IsDefaultFlagSet returns true if the manager is in default mode (no flagsets configured)
 	IsDefaultFlagSet() bool
+	// AllowedTags returns the tags the API Key is restricted to, nil if the API Key has no restriction
+	AllowedTags(apiKey string) []string
 	// Close closes the flagset manager
 	Close()
 	// OnConfigChange is called when the configuration changes
@@ -121,6 +123,7 @@ func newFlagsetManagerWithDefaultConfig(
 		CommonFlagSet: config.CommonFlagSet{
 			Retriever:                       c.Retriever,
 			Retrievers:                      c.Retrievers,
+			RetrieverMode:                   c.RetrieverMode,
 			Notifiers:                       c.Notifiers,
 			Exporter:                        c.Exporter,
 			Exporters:                       c.Exporters,
@@ -131,6 +134,9 @@ func newFlagsetManagerWithDefaultConfig(
 			DisableNotifierOnInit:           c.DisableNotifierOnInit,
 			EvaluationContextEnrichment:     c.EvaluationContextEnrichment,
 			PersistentFlagConfigurationFile: c.PersistentFlagConfigurationFile,
+			FlagConfigurationSnapshots:      c.FlagConfigurationSnapshots,
+			IncludeAllowedOrigins:           c.IncludeAllowedOrigins,
+			UsageTracking:                   c.UsageTracking,
 		},
 	}
 	allNotifiers := appendSSENotifier(notifiers, sseService, utils.DefaultFlagSetName)
@@ -279,6 +285,14 @@ func (m *flagsetManagerImpl) IsDefaultFlagSet() bool {
 	return m.mode == flagsetManagerModeDefault
 }
 
+// AllowedTags returns the tags the API Key is restricted to, nil if the API Key has no restriction
+func (m *flagsetManagerImpl) AllowedTags(apiKey string) []string {
+	if m.config == nil || apiKey == "" {
+		return nil
+	}
+	return m.config.APIKeyTags(apiKey)
+}
+
 // Close closes the flagset manager
 func (m *flagsetManagerImpl) Close() {
 	if m.DefaultFlagSet != nil {
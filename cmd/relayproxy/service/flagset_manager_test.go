@@ -457,6 +457,26 @@ func TestFlagsetManager_Default(t *testing.T) {
 		assert.NotNil(t, defaultFlagset)
 	})
 
+	t.Run("default mode with the fallback retriever mode", func(t *testing.T) {
+		config := &config.Config{
+			FlagSets: []config.FlagSet{},
+			CommonFlagSet: config.CommonFlagSet{
+				Retrievers: &[]retrieverconf.RetrieverConf{
+					{Kind: "file", Path: flagConfig},
+					{Kind: "file", Path: "../testdata/goff/unknown.yaml"},
+				},
+				RetrieverMode: "fallback",
+			},
+		}
+		manager, err := service.NewFlagsetManager(config, zap.NewNop(), []notifier.Notifier{}, nil)
+		require.NoError(t, err)
+		defer manager.Close()
+
+		activeRetriever, ok := manager.Default().GetActiveRetriever()
+		assert.True(t, ok)
+		assert.NotNil(t, activeRetriever)
+	})
+
 	// Test flagset mode
 	t.Run("flagset mode", func(t *testing.T) {
 		config := &config.Config{
@@ -13,6 +13,7 @@ import (
 	ffclient "github.com/thomaspoignant/go-feature-flag"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/config"
 	"github.com/thomaspoignant/go-feature-flag/cmd/relayproxy/config/kafka"
+	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/retrieverconf"
 	retrieverInit "github.com/thomaspoignant/go-feature-flag/cmdhelpers/retrieverconf/init"
 	"github.com/thomaspoignant/go-feature-flag/exporter"
 	"github.com/thomaspoignant/go-feature-flag/exporter/azureexporter"
@@ -22,6 +23,7 @@ import (
 	"github.com/thomaspoignant/go-feature-flag/exporter/kafkaexporter"
 	"github.com/thomaspoignant/go-feature-flag/exporter/kinesisexporter"
 	"github.com/thomaspoignant/go-feature-flag/exporter/logsexporter"
+	"github.com/thomaspoignant/go-feature-flag/exporter/natsexporter"
 	"github.com/thomaspoignant/go-feature-flag/exporter/opentelemetryexporter"
 	"github.com/thomaspoignant/go-feature-flag/exporter/pubsubexporterv2"
 	"github.com/thomaspoignant/go-feature-flag/exporter/s3exporterv2"
@@ -33,6 +35,8 @@ import (
 	"github.com/thomaspoignant/go-feature-flag/notifier/slacknotifier"
 	"github.com/thomaspoignant/go-feature-flag/notifier/webhooknotifier"
 	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/dirretriever"
+	"github.com/thomaspoignant/go-feature-flag/usage"
 	"github.com/thomaspoignant/go-feature-flag/utils"
 	"go.uber.org/zap"
 )
@@ -83,6 +87,7 @@ func NewGoFeatureFlagClient(
 		LeveledLogger:                   initLeveledLogger(cFlagSet, logger),
 		Context:                         context.Background(),
 		Retrievers:                      retrievers,
+		RetrieverMode:                   cFlagSet.RetrieverMode,
 		Notifiers:                       notif,
 		FileFormat:                      cFlagSet.FileFormat,
 		DataExporters:                   exporters,
@@ -91,7 +96,17 @@ func NewGoFeatureFlagClient(
 		DisableNotifierOnInit:           cFlagSet.DisableNotifierOnInit,
 		EvaluationContextEnrichment:     cFlagSet.EvaluationContextEnrichment,
 		PersistentFlagConfigurationFile: cFlagSet.PersistentFlagConfigurationFile,
+		FlagConfigurationSnapshots:      cFlagSet.FlagConfigurationSnapshots,
+		IncludeAllowedOrigins:           cFlagSet.IncludeAllowedOrigins,
 		Name:                            &cFlagSet.Name,
+		Environment:                     cFlagSet.Environment,
+	}
+	if cFlagSet.UsageTracking.Enabled {
+		f.UsageTracking = &usage.Config{
+			StaleAfter:      cFlagSet.UsageTracking.StaleAfter,
+			PersistentFile:  cFlagSet.UsageTracking.PersistentFile,
+			MaxUnknownFlags: cFlagSet.UsageTracking.MaxUnknownFlags,
+		}
 	}
 	client, err := ffclient.New(f)
 	if err != nil {
@@ -121,7 +136,8 @@ func initRetrievers(proxyConf *config.FlagSet) ([]retriever.Retriever, error) {
 		if err != nil {
 			return nil, err
 		}
-		retrievers = append(retrievers, currentRetriever)
+		withIncludeAllowedOrigins(currentRetriever, proxyConf.IncludeAllowedOrigins)
+		retrievers = append(retrievers, withRefreshPolicy(currentRetriever, proxyConf.Retriever))
 	}
 	// if the retrievers are set, we add them to the retrievers
 	if proxyConf.Retrievers != nil {
@@ -130,12 +146,30 @@ func initRetrievers(proxyConf *config.FlagSet) ([]retriever.Retriever, error) {
 			if err != nil {
 				return nil, err
 			}
-			retrievers = append(retrievers, currentRetriever)
+			withIncludeAllowedOrigins(currentRetriever, proxyConf.IncludeAllowedOrigins)
+			retrievers = append(retrievers, withRefreshPolicy(currentRetriever, &r))
 		}
 	}
 	return retrievers, nil
 }
 
+// withIncludeAllowedOrigins sets the allowed origins of the includes on the directory retriever, it resolves
+// the includes of its files itself.
+func withIncludeAllowedOrigins(r retriever.Retriever, origins []string) {
+	if dir, ok := r.(*dirretriever.Retriever); ok {
+		dir.IncludeAllowedOrigins = origins
+	}
+}
+
+// withRefreshPolicy adds the refresh policy of the configuration to the retriever, if the configuration has one.
+func withRefreshPolicy(r retriever.Retriever, c *retrieverconf.RetrieverConf) retriever.Retriever {
+	policy := retrieverInit.InitRetrieverPolicy(c)
+	if policy == (retriever.Policy{}) {
+		return r
+	}
+	return retriever.WithPolicy(r, policy)
+}
+
 // initDataExporters initialize the exporters based on the configuration
 // it handles both the `exporter` and `exporters` fields.
 func initDataExporters(proxyConf *config.FlagSet) ([]ffclient.DataExporter, error) {
@@ -297,6 +331,17 @@ func createExporter(c *config.ExporterConf) (exporter.CommonExporter, error) {
 			ProjectID: c.ProjectID,
 			Topic:     c.Topic,
 		}, nil
+	case config.NATSExporter:
+		return &natsexporter.Exporter{
+			URL:            c.NATS.URL,
+			Subject:        c.NATS.Subject,
+			Token:          c.NATS.Token,
+			Username:       c.NATS.Username,
+			Password:       c.NATS.Password,
+			ClientCertPath: c.NATS.ClientCertPath,
+			ClientKeyPath:  c.NATS.ClientKeyPath,
+			CACertPath:     c.NATS.CACertPath,
+		}, nil
 	case config.BigQueryExporter:
 		return &bigqueryexporter.Exporter{
 			ProjectID:         c.ProjectID,
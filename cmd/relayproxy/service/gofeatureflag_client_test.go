@@ -21,10 +21,12 @@ import (
 	"github.com/thomaspoignant/go-feature-flag/exporter/kafkaexporter"
 	"github.com/thomaspoignant/go-feature-flag/exporter/kinesisexporter"
 	"github.com/thomaspoignant/go-feature-flag/exporter/logsexporter"
+	"github.com/thomaspoignant/go-feature-flag/exporter/natsexporter"
 	"github.com/thomaspoignant/go-feature-flag/exporter/pubsubexporterv2"
 	"github.com/thomaspoignant/go-feature-flag/exporter/s3exporterv2"
 	"github.com/thomaspoignant/go-feature-flag/exporter/sqsexporter"
 	"github.com/thomaspoignant/go-feature-flag/exporter/webhookexporter"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
 	"github.com/thomaspoignant/go-feature-flag/notifier"
 	"github.com/thomaspoignant/go-feature-flag/notifier/discordnotifier"
 	"github.com/thomaspoignant/go-feature-flag/notifier/microsoftteamsnotifier"
@@ -348,6 +350,29 @@ func Test_initExporter(t *testing.T) {
 			wantType:               &pubsubexporterv2.Exporter{},
 			skipCompleteValidation: true,
 		},
+		{
+			name:    "Convert NATSExporter",
+			wantErr: assert.NoError,
+			conf: &config.ExporterConf{
+				Kind: "nats",
+				NATS: config.NATSConf{
+					URL:      "nats://localhost:4222",
+					Subject:  "goff.events",
+					Username: "user",
+					Password: "pass",
+				},
+			},
+			want: ffclient.DataExporter{
+				Exporter: &natsexporter.Exporter{
+					URL:      "nats://localhost:4222",
+					Subject:  "goff.events",
+					Username: "user",
+					Password: "pass",
+				},
+			},
+			wantType:               &natsexporter.Exporter{},
+			skipCompleteValidation: true,
+		},
 		{
 			name:    "Convert BigQueryExporter",
 			wantErr: assert.NoError,
@@ -898,3 +923,21 @@ func Test_initLeveledLogger_FlagsetAttribute(t *testing.T) {
 		}
 	})
 }
+
+func TestNewGoFeatureFlagClient_Environment(t *testing.T) {
+	flagSet := &config.FlagSet{
+		CommonFlagSet: config.CommonFlagSet{
+			Retrievers: &[]retrieverconf.RetrieverConf{
+				{Kind: "file", Path: "../testdata/environment_flags.yaml"},
+			},
+			Environment: "staging",
+		},
+	}
+	goff, err := NewGoFeatureFlagClient(flagSet, zap.NewNop(), nil)
+	assert.NoError(t, err)
+	defer goff.Close()
+
+	got, err := goff.BoolVariation("new-checkout", ffcontext.NewEvaluationContext("user-key"), false)
+	assert.NoError(t, err)
+	assert.True(t, got)
+}
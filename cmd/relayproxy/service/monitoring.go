@@ -25,10 +25,11 @@ type monitoringImpl struct {
 	flagsetManager FlagsetManager
 }
 
-// Health returns a static object to show that the server is initialized
+// Health returns an object to show that the server is initialized, with the redacted status of the retrievers
 func (m *monitoringImpl) Health() model.HealthResponse {
 	return model.HealthResponse{
 		Initialized: true,
+		Retrievers:  m.retrieversHealth(),
 	}
 }
 
@@ -49,6 +50,7 @@ func (m *monitoringImpl) Info() (model.InfoResponse, error) {
 		cacheRefreshDate := m.flagsetManager.Default().GetCacheRefreshDate()
 		return model.InfoResponse{
 			LatestCacheRefresh: &cacheRefreshDate,
+			Retrievers:         m.retrieversHealth(),
 		}, nil
 	}
 
@@ -63,5 +65,42 @@ func (m *monitoringImpl) Info() (model.InfoResponse, error) {
 	return model.InfoResponse{
 		Flagsets:           refreshDates,
 		LatestCacheRefresh: &latestRefreshDate,
+		Retrievers:         m.retrieversHealth(),
 	}, nil
 }
+
+// retrieversHealth returns the redacted status of the retrievers of each flagset.
+// The locations and the error messages are not exposed because /health and /info are not authenticated,
+// they are available in the admin endpoint /admin/v1/retrievers.
+func (m *monitoringImpl) retrieversHealth() map[string][]model.RetrieverHealth {
+	if m.flagsetManager == nil {
+		return nil
+	}
+	flagSets, err := m.flagsetManager.AllFlagSets()
+	if err != nil {
+		return nil
+	}
+	health := make(map[string][]model.RetrieverHealth, len(flagSets))
+	for flagsetName, flagset := range flagSets {
+		statuses := flagset.GetRetrieversStatus()
+		health[flagsetName] = make([]model.RetrieverHealth, 0, len(statuses))
+		for _, status := range statuses {
+			health[flagsetName] = append(health[flagsetName], model.RetrieverHealth{
+				Kind:        status.Name,
+				Status:      status.Status,
+				LastSuccess: nonZeroTime(status.LastSuccess),
+				LastError:   nonZeroTime(status.LastError),
+				NextAttempt: nonZeroTime(status.NextAttempt),
+			})
+		}
+	}
+	return health
+}
+
+// nonZeroTime returns a pointer to the time, or nil if the time is zero.
+func nonZeroTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
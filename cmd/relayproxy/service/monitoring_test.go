@@ -1,8 +1,12 @@
 package service_test
 
 import (
+	"encoding/json"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -306,3 +310,100 @@ func TestMonitoringInfoErrors(t *testing.T) {
 		assert.False(t, info.LatestCacheRefresh.IsZero(), "Expected LatestCacheRefresh to not be zero")
 	})
 }
+
+func TestRetrieversHealth(t *testing.T) {
+	t.Run("default mode", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		manager, err := service.NewFlagsetManager(&config.Config{
+			CommonFlagSet: config.CommonFlagSet{
+				PollingInterval:         60000, // 1 minute in milliseconds
+				FileFormat:              "yaml",
+				StartWithRetrieverError: true,
+				Retrievers: &[]retrieverconf.RetrieverConf{
+					{Kind: "file", Path: "../../../testdata/flag-config.yaml", PollingInterval: 600000},
+					{Kind: "http", URL: "http://user:password@" + server.Listener.Addr().String() + "/flags.yaml?token=secret"},
+				},
+			},
+		}, zap.NewNop(), nil, nil)
+		require.NoError(t, err)
+		defer manager.Close()
+
+		monitoring := service.NewMonitoring(manager)
+		health := monitoring.Health()
+		require.Len(t, health.Retrievers["default"], 2)
+		file := health.Retrievers["default"][0]
+		assert.Equal(t, "*fileretriever.Retriever", file.Kind)
+		assert.Equal(t, "READY", file.Status)
+		require.NotNil(t, file.LastSuccess)
+		assert.Nil(t, file.LastError)
+		require.NotNil(t, file.NextAttempt)
+		// the polling interval of the retriever is used instead of the one of the relay proxy.
+		assert.WithinDuration(t, file.LastSuccess.Add(10*time.Minute), *file.NextAttempt, time.Second)
+
+		remote := health.Retrievers["default"][1]
+		assert.Equal(t, "*httpretriever.Retriever", remote.Kind)
+		assert.Equal(t, "ERROR", remote.Status)
+		assert.Nil(t, remote.LastSuccess)
+		assert.NotNil(t, remote.LastError)
+
+		// the locations and the error messages are not exposed.
+		body, err := json.Marshal(health)
+		require.NoError(t, err)
+		assert.NotContains(t, string(body), server.Listener.Addr().String())
+		assert.NotContains(t, string(body), "secret")
+
+		info, err := monitoring.Info()
+		require.NoError(t, err)
+		assert.Equal(t, health.Retrievers, info.Retrievers)
+	})
+
+	t.Run("flagsets mode", func(t *testing.T) {
+		manager, err := service.NewFlagsetManager(&config.Config{
+			FlagSets: []config.FlagSet{
+				{
+					Name: "flagset1",
+					CommonFlagSet: config.CommonFlagSet{
+						FileFormat: "yaml",
+						Retrievers: &[]retrieverconf.RetrieverConf{
+							{Kind: "file", Path: "../../../testdata/flag-config.yaml"},
+							{Kind: "file", Path: "../../../testdata/flag-config-2nd-file.yaml"},
+						},
+					},
+					APIKeys: []string{"api-key-1"},
+				},
+				{
+					Name: "flagset2",
+					CommonFlagSet: config.CommonFlagSet{
+						FileFormat: "yaml",
+						Retrievers: &[]retrieverconf.RetrieverConf{
+							{Kind: "file", Path: "../../../testdata/flag-config-2nd-file.yaml"},
+						},
+					},
+					APIKeys: []string{"api-key-2"},
+				},
+			},
+		}, zap.NewNop(), nil, nil)
+		require.NoError(t, err)
+		defer manager.Close()
+
+		monitoring := service.NewMonitoring(manager)
+		health := monitoring.Health()
+		assert.Len(t, health.Retrievers, 2)
+		assert.Len(t, health.Retrievers["flagset1"], 2)
+		assert.Len(t, health.Retrievers["flagset2"], 1)
+
+		info, err := monitoring.Info()
+		require.NoError(t, err)
+		assert.Equal(t, health.Retrievers, info.Retrievers)
+	})
+
+	t.Run("without flagset manager", func(t *testing.T) {
+		health := service.NewMonitoring(nil).Health()
+		assert.True(t, health.Initialized)
+		assert.Nil(t, health.Retrievers)
+	})
+}
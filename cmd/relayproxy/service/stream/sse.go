@@ -4,8 +4,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/r3labs/sse/v2"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagtag"
 	"github.com/thomaspoignant/go-feature-flag/notifier"
 )
 
@@ -17,6 +21,10 @@ type SSEService interface {
 	// BroadcastFlagChanges sends the diff to all clients subscribed to the
 	// given flagset stream.
 	BroadcastFlagChanges(flagsetName string, diff notifier.DiffCache) error
+	// TaggedStream registers a stream receiving only the changes of the flags of the
+	// flagset having at least one of the tags, and returns its stream ID.
+	// If tags is empty, the flagset stream is returned.
+	TaggedStream(flagsetName string, tags []string) string
 	// ServeHTTP handles incoming SSE client connections. The request must
 	// carry a "stream" query parameter set to the target flagset name.
 	ServeHTTP(w http.ResponseWriter, r *http.Request)
@@ -32,19 +40,74 @@ func NewSSEService() SSEService {
 	server := sse.New()
 	server.AutoReplay = false
 	server.AutoStream = true
-	return &sseServiceImpl{server: server}
+	return &sseServiceImpl{server: server, taggedStreams: map[string]map[string][]string{}}
 }
 
 type sseServiceImpl struct {
 	server *sse.Server
+	// taggedStreams contains, per flagset, the tags of each tagged stream ID.
+	taggedStreams map[string]map[string][]string
+	mutex         sync.Mutex
 }
 
 func (s *sseServiceImpl) BroadcastFlagChanges(flagsetName string, diff notifier.DiffCache) error {
+	if err := s.publish(flagsetName, diff); err != nil {
+		return err
+	}
+	for streamID, tags := range s.tagsByStream(flagsetName) {
+		filtered := flagtag.FilterDiff(diff, tags)
+		if !filtered.HasDiff() {
+			continue
+		}
+		if err := s.publish(streamID, filtered); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *sseServiceImpl) TaggedStream(flagsetName string, tags []string) string {
+	if len(tags) == 0 {
+		return flagsetName
+	}
+	sortedTags := slices.Clone(tags)
+	slices.Sort(sortedTags)
+	sortedTags = slices.Compact(sortedTags)
+	streamID := flagsetName + "#tags=" + strings.Join(sortedTags, ",")
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.taggedStreams[flagsetName]; !ok {
+		s.taggedStreams[flagsetName] = map[string][]string{}
+	}
+	s.taggedStreams[flagsetName][streamID] = sortedTags
+	// the stream is created now, so it is not forgotten before the client subscribes to it.
+	s.server.CreateStream(streamID)
+	return streamID
+}
+
+// tagsByStream returns a copy of the tagged streams of a flagset.
+// The tagged streams without subscribers anymore are forgotten.
+func (s *sseServiceImpl) tagsByStream(flagsetName string) map[string][]string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	res := make(map[string][]string, len(s.taggedStreams[flagsetName]))
+	for streamID, tags := range s.taggedStreams[flagsetName] {
+		if !s.server.StreamExists(streamID) {
+			delete(s.taggedStreams[flagsetName], streamID)
+			continue
+		}
+		res[streamID] = tags
+	}
+	return res
+}
+
+func (s *sseServiceImpl) publish(streamID string, diff notifier.DiffCache) error {
 	data, err := json.Marshal(diff)
 	if err != nil {
-		return fmt.Errorf("sse: failed to marshal flag diff for stream %q: %w", flagsetName, err)
+		return fmt.Errorf("sse: failed to marshal flag diff for stream %q: %w", streamID, err)
 	}
-	s.server.Publish(flagsetName, &sse.Event{
+	s.server.Publish(streamID, &sse.Event{
 		Data: data,
 	})
 	return nil
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -130,3 +131,56 @@ func TestSSEService_Close(t *testing.T) {
 	sseService := stream.NewSSEService()
 	sseService.Close()
 }
+
+func TestSSEService_TaggedStream(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	sseService := stream.NewSSEService()
+	defer sseService.Close()
+	subscribed := make(chan struct{}, 1)
+	sseService.SetOnSubscribe(func(_ string) {
+		select {
+		case subscribed <- struct{}{}:
+		default:
+		}
+	})
+
+	assert.Equal(t, "flagsetA", sseService.TaggedStream("flagsetA", nil))
+	streamID := sseService.TaggedStream("flagsetA", []string{"frontend", "checkout", "frontend"})
+	assert.Equal(t, "flagsetA#tags=checkout,frontend", streamID)
+
+	srv := httptest.NewServer(http.HandlerFunc(sseService.ServeHTTP))
+	defer srv.Close()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"?stream="+url.QueryEscape(streamID), nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	select {
+	case <-subscribed:
+	case <-ctx.Done():
+		t.Fatal("timed out waiting for SSE client to subscribe")
+	}
+
+	backendFlag := &flag.InternalFlag{Tags: &[]string{"backend"}}
+	frontendFlag := &flag.InternalFlag{Tags: &[]string{"frontend"}}
+	require.NoError(t, sseService.BroadcastFlagChanges("flagsetA", notifier.DiffCache{
+		Added: map[string]flag.Flag{"backend-flag": backendFlag},
+	}))
+	require.NoError(t, sseService.BroadcastFlagChanges("flagsetA", notifier.DiffCache{
+		Added: map[string]flag.Flag{"backend-flag": backendFlag, "frontend-flag": frontendFlag},
+	}))
+
+	scanner := bufio.NewScanner(resp.Body)
+	var received string
+	for scanner.Scan() {
+		if data, ok := strings.CutPrefix(scanner.Text(), "data: "); ok {
+			received = data
+			break
+		}
+	}
+	assert.Contains(t, received, "frontend-flag")
+	assert.NotContains(t, received, "backend-flag")
+}
@@ -11,6 +11,7 @@ type MockFlagsetManager struct {
 	DefaultFlagSet      *ffclient.GoFeatureFlag
 	IsDefaultFlagSeItem bool
 	GetFlagSetsErr      error
+	AllowedTagsByKey    map[string][]string
 }
 
 func (m *MockFlagsetManager) FlagSet(_ string) (*ffclient.GoFeatureFlag, error) {
@@ -46,6 +47,10 @@ func (m *MockFlagsetManager) IsDefaultFlagSet() bool {
 	return m.IsDefaultFlagSeItem
 }
 
+func (m *MockFlagsetManager) AllowedTags(apiKey string) []string {
+	return m.AllowedTagsByKey[apiKey]
+}
+
 func (m *MockFlagsetManager) Close() {
 	// nothing to do
 }
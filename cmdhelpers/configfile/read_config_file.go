@@ -1,12 +1,15 @@
 package configfile
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
 
 	"github.com/BurntSushi/toml"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagenv"
+	"github.com/thomaspoignant/go-feature-flag/internal/flaginclude"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/dto"
 	"gopkg.in/yaml.v3"
 )
@@ -17,11 +20,23 @@ var ConfigFileDefaultLocations = []string{
 	"/etc/opt/goff/",
 }
 
+// LoadConfigFile loads the flags of the configuration file with their base definition.
 func LoadConfigFile(
 	inputFilePath string,
 	configFormat string,
 	defaultLocations []string,
 ) (map[string]dto.DTO, error) {
+	flags, _, err := LoadConfigFileWithOverrides(inputFilePath, configFormat, defaultLocations)
+	return flags, err
+}
+
+// LoadConfigFileWithOverrides loads the flags of the configuration file with their base definition,
+// and the overrides of the flags by environment.
+func LoadConfigFileWithOverrides(
+	inputFilePath string,
+	configFormat string,
+	defaultLocations []string,
+) (map[string]dto.DTO, map[string]flagenv.Overrides, error) {
 	filename := "flags.goff"
 	if defaultLocations == nil {
 		defaultLocations = ConfigFileDefaultLocations
@@ -35,7 +50,7 @@ func LoadConfigFile(
 
 	if inputFilePath != "" {
 		if _, err := os.Stat(inputFilePath); err != nil {
-			return nil, fmt.Errorf("impossible to find config file %s", inputFilePath)
+			return nil, nil, fmt.Errorf("impossible to find config file %s", inputFilePath)
 		}
 		return readConfigFile(inputFilePath, configFormat)
 	}
@@ -48,17 +63,34 @@ func LoadConfigFile(
 			return readConfigFile(configFile, ext)
 		}
 	}
-	return nil, fmt.Errorf(
+	return nil, nil, fmt.Errorf(
 		"impossible to find config file in the default locations [%s]",
 		strings.Join(defaultLocations, ","),
 	)
 }
 
-func readConfigFile(configFile, configFormat string) (map[string]dto.DTO, error) {
+func readConfigFile(configFile, configFormat string) (map[string]dto.DTO, map[string]flagenv.Overrides, error) {
 	dat, err := os.ReadFile(configFile)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	// the includes are resolved the same way as in the retrievers
+	dat, configFormat, err = flaginclude.Expand(context.Background(), dat, configFormat, configFile, flaginclude.Options{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", configFile, err)
 	}
+	flags, err := parseConfigFile(configFile, dat, configFormat)
+	if err != nil {
+		return nil, nil, err
+	}
+	overrides, err := flagenv.Parse(dat, configFormat)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: could not parse the environments: %w", configFile, err)
+	}
+	return flags, overrides, nil
+}
+
+func parseConfigFile(configFile string, dat []byte, configFormat string) (map[string]dto.DTO, error) {
 	var flags map[string]dto.DTO
 	switch strings.ToLower(configFormat) {
 	case "toml":
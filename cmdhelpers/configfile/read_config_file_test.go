@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/configfile"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagenv"
 	"github.com/thomaspoignant/go-feature-flag/model/dto"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/testutils/testconvert"
@@ -215,6 +216,28 @@ func TestLoadConfigFile(t *testing.T) {
 			configFormat:  "toml",
 			expectErr:     true,
 		},
+		{
+			name:          "should load the flag file with its includes",
+			inputFilePath: "testdata/include/flags.goff.yaml",
+			configFormat:  "yaml",
+			expected: map[string]dto.DTO{
+				"test-flag": {
+					Variations: &map[string]*any{
+						"enabled":  testconvert.Interface(true),
+						"disabled": testconvert.Interface(false),
+					},
+					DefaultRule: &flag.Rule{
+						VariationResult: testconvert.String("enabled"),
+					},
+				},
+			},
+		},
+		{
+			name:          "should error if the includes have a cycle",
+			inputFilePath: "testdata/include/cycle.goff.yaml",
+			configFormat:  "yaml",
+			expectErr:     true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -247,3 +270,15 @@ func TestLoadConfigFile(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadConfigFileWithOverrides(t *testing.T) {
+	flags, overrides, err := configfile.LoadConfigFileWithOverrides(
+		"testdata/environments.goff.yaml", "yaml", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "disabled", *flags["test-flag"].DefaultRule.VariationResult)
+	assert.Equal(t, map[string]flagenv.Overrides{
+		"test-flag": {Environments: map[string]flagenv.Override{
+			"staging": {DefaultRule: &flag.Rule{VariationResult: testconvert.String("enabled")}},
+		}},
+	}, overrides)
+}
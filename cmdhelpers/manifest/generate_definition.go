@@ -3,9 +3,11 @@ package manifest
 import (
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/thomaspoignant/go-feature-flag/cmd/cli/helper"
 	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/manifest/model"
+	"github.com/thomaspoignant/go-feature-flag/internal/lifecycle"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
 )
 
@@ -50,12 +52,30 @@ func GenerateDefinitionFromFlags(flags map[string]flag.Flag) (
 			DefaultValue: defaultValue,
 			Description:  description,
 		}
+		flagLifecycle, err := lifecycle.FromMetadata(*metadata)
+		if err != nil {
+			slog.Warn(fmt.Sprintf("flag %s: %s", k, err))
+		}
+		definition.Owner = flagLifecycle.Owner
+		definition.Lifecycle = string(flagLifecycle.Type)
+		definition.Deprecated = flagLifecycle.Deprecated
+		if flagLifecycle.Expiry != nil {
+			definition.Expiry = formatExpiry(*flagLifecycle.Expiry)
+		}
 		definitions[k] = definition
 	}
 
 	return definitions, nil
 }
 
+// formatExpiry formats the expiry date as a date, or as a RFC3339 timestamp if it has a time.
+func formatExpiry(expiry time.Time) string {
+	if expiry.Equal(expiry.Truncate(24 * time.Hour)) {
+		return expiry.Format(time.DateOnly)
+	}
+	return expiry.Format(time.RFC3339)
+}
+
 func GenerateDefinitionFromInternalFlags(flags map[string]flag.InternalFlag) (
 	map[string]model.FlagDefinition, error) {
 	asFlag := make(map[string]flag.Flag, len(flags))
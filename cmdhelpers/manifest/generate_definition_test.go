@@ -146,6 +146,33 @@ func TestGenerateDefinition(t *testing.T) {
 			},
 			goldenFile: "experimentation.json",
 		},
+		{
+			name: "lifecycle metadata",
+			flags: map[string]flag.InternalFlag{
+				"lifecycle-flag": boolFlag("False", map[string]any{
+					"defaultValue": false,
+					"description":  "temporary flag",
+					"owner":        "team-checkout",
+					"lifecycle":    "temporary",
+					"expiry":       time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+					"deprecated":   true,
+				}),
+			},
+			goldenFile: "lifecycle.json",
+		},
+		{
+			name: "invalid lifecycle metadata is ignored and logged",
+			flags: map[string]flag.InternalFlag{
+				"lifecycle-flag": boolFlag("False", map[string]any{
+					"defaultValue": false,
+					"owner":        "team-checkout",
+					"expiry":       "next-week",
+				}),
+			},
+			goldenFile: "invalid_lifecycle.json",
+			wantLogs: []string{"flag lifecycle-flag: invalid lifecycle metadata: " +
+				"expiry should be a date with the format 2006-01-02 or RFC3339"},
+		},
 		{
 			name: "string flag",
 			flags: map[string]flag.InternalFlag{
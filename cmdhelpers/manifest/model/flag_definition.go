@@ -4,4 +4,9 @@ type FlagDefinition struct {
 	FlagType     FlagType `json:"flagType"`
 	DefaultValue any      `json:"defaultValue"`
 	Description  string   `json:"description"`
+	// Owner, Expiry, Lifecycle and Deprecated are the lifecycle fields of the flag metadata.
+	Owner      string `json:"owner,omitempty"`
+	Expiry     string `json:"expiry,omitempty"`
+	Lifecycle  string `json:"lifecycle,omitempty"`
+	Deprecated bool   `json:"deprecated,omitempty"`
 }
@@ -11,16 +11,23 @@ import (
 	"github.com/thomaspoignant/go-feature-flag/retriever"
 	azblobretriever "github.com/thomaspoignant/go-feature-flag/retriever/azblobstorageretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/bitbucketretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/consulretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/dirretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/etcdretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/fileretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/gcstorageretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/githubretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/gitlabretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/gitretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/httpretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/k8sretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/mongodbretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/mysqlretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/natsretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/postgresqlretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/redisretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/s3retrieverv2"
+	"github.com/thomaspoignant/go-feature-flag/retriever/sqliteretriever"
 	"k8s.io/client-go/rest"
 )
 
@@ -41,6 +48,13 @@ var retrieverFactories = map[retrieverconf.RetrieverKind]retrieverFactory{
 	retrieverconf.RedisRetriever:         createRedisRetriever,
 	retrieverconf.AzBlobStorageRetriever: createAzBlobStorageRetriever,
 	retrieverconf.PostgreSQLRetriever:    createPostgreSQLRetriever,
+	retrieverconf.MySQLRetriever:         createMySQLRetriever,
+	retrieverconf.SQLiteRetriever:        createSQLiteRetriever,
+	retrieverconf.GitRetriever:           createGitRetriever,
+	retrieverconf.DirectoryRetriever:     createDirectoryRetriever,
+	retrieverconf.EtcdRetriever:          createEtcdRetriever,
+	retrieverconf.ConsulRetriever:        createConsulRetriever,
+	retrieverconf.NATSRetriever:          createNATSRetriever,
 }
 
 // InitRetriever initialize the retriever based on the configuration
@@ -57,6 +71,23 @@ func InitRetriever(
 	return retrieverFactory(c, retrieverTimeout)
 }
 
+// InitRetrieverPolicy returns the refresh policy of the retriever based on the configuration
+func InitRetrieverPolicy(c *retrieverconf.RetrieverConf) retriever.Policy {
+	policy := retriever.Policy{
+		PollingInterval: time.Duration(c.PollingInterval) * time.Millisecond,
+		Timeout:         time.Duration(c.Timeout) * time.Millisecond,
+	}
+	if c.Backoff != nil {
+		policy.BackoffInitialInterval = time.Duration(c.Backoff.InitialInterval) * time.Millisecond
+		policy.BackoffMaxInterval = time.Duration(c.Backoff.MaxInterval) * time.Millisecond
+	}
+	if c.CircuitBreaker != nil {
+		policy.CircuitBreakerThreshold = c.CircuitBreaker.Threshold
+		policy.CircuitBreakerCooldown = time.Duration(c.CircuitBreaker.Cooldown) * time.Millisecond
+	}
+	return policy
+}
+
 // Factory functions for each retriever type
 func createGitHubRetriever(
 	c *retrieverconf.RetrieverConf, timeout time.Duration) (retriever.Retriever, error) {
@@ -186,3 +217,79 @@ func createPostgreSQLRetriever(
 	c *retrieverconf.RetrieverConf, _ time.Duration) (retriever.Retriever, error) {
 	return &postgresqlretriever.Retriever{URI: c.URI, Table: c.Table, Columns: c.Columns}, nil
 }
+
+func createMySQLRetriever(
+	c *retrieverconf.RetrieverConf, _ time.Duration) (retriever.Retriever, error) {
+	return &mysqlretriever.Retriever{URI: c.URI, Table: c.Table, Columns: c.Columns}, nil
+}
+
+func createSQLiteRetriever(
+	c *retrieverconf.RetrieverConf, _ time.Duration) (retriever.Retriever, error) {
+	return &sqliteretriever.Retriever{Path: c.Path, Table: c.Table, Columns: c.Columns}, nil
+}
+
+func createGitRetriever(
+	c *retrieverconf.RetrieverConf, timeout time.Duration) (retriever.Retriever, error) {
+	return &gitretriever.Retriever{
+		URL: c.URL,
+		Ref: func() string {
+			if c.Branch == "" {
+				return retrieverconf.DefaultRetrieverConfig.GitBranch
+			}
+			return c.Branch
+		}(),
+		FilePath:   c.Path,
+		AuthToken:  c.AuthToken,
+		Username:   c.Username,
+		SSHKeyPath: c.SSHKeyPath,
+		Directory:  c.Directory,
+		Timeout:    timeout,
+	}, nil
+}
+
+func createDirectoryRetriever(
+	c *retrieverconf.RetrieverConf, _ time.Duration) (retriever.Retriever, error) {
+	return &dirretriever.Retriever{Path: c.Path}, nil
+}
+
+func createEtcdRetriever(
+	c *retrieverconf.RetrieverConf, timeout time.Duration) (retriever.Retriever, error) {
+	return &etcdretriever.Retriever{
+		Endpoints:      c.Endpoints,
+		Key:            c.Key,
+		Prefix:         c.Prefix,
+		Username:       c.Username,
+		Password:       c.Password,
+		ClientCertPath: c.HTTPClientCertPath,
+		ClientKeyPath:  c.HTTPClientKeyPath,
+		CACertPath:     c.HTTPCACertPath,
+		DialTimeout:    timeout,
+	}, nil
+}
+
+func createConsulRetriever(
+	c *retrieverconf.RetrieverConf, _ time.Duration) (retriever.Retriever, error) {
+	return &consulretriever.Retriever{
+		Address:        c.URL,
+		Key:            c.Key,
+		Prefix:         c.Prefix,
+		Token:          c.AuthToken,
+		ClientCertPath: c.HTTPClientCertPath,
+		ClientKeyPath:  c.HTTPClientKeyPath,
+		CACertPath:     c.HTTPCACertPath,
+	}, nil
+}
+
+func createNATSRetriever(
+	c *retrieverconf.RetrieverConf, _ time.Duration) (retriever.Retriever, error) {
+	return &natsretriever.Retriever{
+		URL:            c.URL,
+		Bucket:         c.Bucket,
+		Token:          c.AuthToken,
+		Username:       c.Username,
+		Password:       c.Password,
+		ClientCertPath: c.HTTPClientCertPath,
+		ClientKeyPath:  c.HTTPClientKeyPath,
+		CACertPath:     c.HTTPCACertPath,
+	}, nil
+}
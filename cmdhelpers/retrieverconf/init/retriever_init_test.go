@@ -10,14 +10,21 @@ import (
 	"github.com/thomaspoignant/go-feature-flag/cmdhelpers/retrieverconf"
 	"github.com/thomaspoignant/go-feature-flag/retriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/bitbucketretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/consulretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/dirretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/etcdretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/fileretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/gcstorageretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/githubretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/gitlabretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/gitretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/httpretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/mysqlretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/natsretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/postgresqlretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/redisretriever"
 	"github.com/thomaspoignant/go-feature-flag/retriever/s3retrieverv2"
+	"github.com/thomaspoignant/go-feature-flag/retriever/sqliteretriever"
 )
 
 func Test_InitRetriever(t *testing.T) {
@@ -156,6 +163,16 @@ func Test_InitRetriever(t *testing.T) {
 			want:     &fileretriever.Retriever{Path: "testdata/flag-config.yaml"},
 			wantType: &fileretriever.Retriever{},
 		},
+		{
+			name:    "Convert Directory Retriever",
+			wantErr: assert.NoError,
+			conf: &retrieverconf.RetrieverConf{
+				Kind: "directory",
+				Path: "/etc/goff/flags/*.yaml",
+			},
+			want:     &dirretriever.Retriever{Path: "/etc/goff/flags/*.yaml"},
+			wantType: &dirretriever.Retriever{},
+		},
 		{
 			name:    "Convert S3 Retriever",
 			wantErr: assert.NoError,
@@ -288,6 +305,138 @@ func Test_InitRetriever(t *testing.T) {
 			},
 			wantType: &postgresqlretriever.Retriever{},
 		},
+		{
+			name:    "Convert MySQL Retriever",
+			wantErr: assert.NoError,
+			conf: &retrieverconf.RetrieverConf{
+				Kind:    "mysql",
+				URI:     "user:password@tcp(localhost:3306)/database",
+				Table:   "flags",
+				Columns: map[string]string{"flagset": "settings"},
+			},
+			want: &mysqlretriever.Retriever{
+				URI:     "user:password@tcp(localhost:3306)/database",
+				Table:   "flags",
+				Columns: map[string]string{"flagset": "settings"},
+			},
+			wantType: &mysqlretriever.Retriever{},
+		},
+		{
+			name:    "Convert SQLite Retriever",
+			wantErr: assert.NoError,
+			conf: &retrieverconf.RetrieverConf{
+				Kind:  "sqlite",
+				Path:  "/data/flags.db",
+				Table: "flags",
+			},
+			want: &sqliteretriever.Retriever{
+				Path:  "/data/flags.db",
+				Table: "flags",
+			},
+			wantType: &sqliteretriever.Retriever{},
+		},
+		{
+			name:    "Convert etcd Retriever",
+			wantErr: assert.NoError,
+			conf: &retrieverconf.RetrieverConf{
+				Kind:           "etcd",
+				Endpoints:      []string{"https://etcd-1:2379", "https://etcd-2:2379"},
+				Prefix:         "goff/flags/",
+				Username:       "goff",
+				Password:       "secret",
+				HTTPCACertPath: "/certs/ca.crt",
+			},
+			want: &etcdretriever.Retriever{
+				Endpoints:   []string{"https://etcd-1:2379", "https://etcd-2:2379"},
+				Prefix:      "goff/flags/",
+				Username:    "goff",
+				Password:    "secret",
+				CACertPath:  "/certs/ca.crt",
+				DialTimeout: 10 * time.Second,
+			},
+			wantType: &etcdretriever.Retriever{},
+		},
+		{
+			name:    "Convert Consul Retriever",
+			wantErr: assert.NoError,
+			conf: &retrieverconf.RetrieverConf{
+				Kind:               "consul",
+				URL:                "https://consul:8501",
+				Key:                "goff/flags.yaml",
+				AuthToken:          "acl-token",
+				HTTPClientCertPath: "/certs/client.crt",
+				HTTPClientKeyPath:  "/certs/client.key",
+			},
+			want: &consulretriever.Retriever{
+				Address:        "https://consul:8501",
+				Key:            "goff/flags.yaml",
+				Token:          "acl-token",
+				ClientCertPath: "/certs/client.crt",
+				ClientKeyPath:  "/certs/client.key",
+			},
+			wantType: &consulretriever.Retriever{},
+		},
+		{
+			name:    "Convert NATS Retriever",
+			wantErr: assert.NoError,
+			conf: &retrieverconf.RetrieverConf{
+				Kind:           "nats",
+				URL:            "nats://nats-1:4222,nats://nats-2:4222",
+				Bucket:         "flags",
+				Username:       "goff",
+				Password:       "secret",
+				HTTPCACertPath: "/certs/ca.crt",
+			},
+			want: &natsretriever.Retriever{
+				URL:        "nats://nats-1:4222,nats://nats-2:4222",
+				Bucket:     "flags",
+				Username:   "goff",
+				Password:   "secret",
+				CACertPath: "/certs/ca.crt",
+			},
+			wantType: &natsretriever.Retriever{},
+		},
+		{
+			name:    "Convert Git Retriever",
+			wantErr: assert.NoError,
+			conf: &retrieverconf.RetrieverConf{
+				Kind:       "git",
+				URL:        "git@gitea.example.com:org/repo.git",
+				Branch:     "v1.2.0",
+				Path:       "flags/config.goff.yaml",
+				SSHKeyPath: "/etc/goff/id_ed25519",
+				Directory:  "/var/lib/goff/repo",
+			},
+			want: &gitretriever.Retriever{
+				URL:        "git@gitea.example.com:org/repo.git",
+				Ref:        "v1.2.0",
+				FilePath:   "flags/config.goff.yaml",
+				SSHKeyPath: "/etc/goff/id_ed25519",
+				Directory:  "/var/lib/goff/repo",
+				Timeout:    10000000000,
+			},
+			wantType: &gitretriever.Retriever{},
+		},
+		{
+			name:    "Convert Git Retriever with default branch",
+			wantErr: assert.NoError,
+			conf: &retrieverconf.RetrieverConf{
+				Kind:      "git",
+				URL:       "https://gitea.example.com/org/repo.git",
+				Path:      "flags/config.goff.yaml",
+				AuthToken: "XXX_TOKEN",
+				Username:  "goff",
+			},
+			want: &gitretriever.Retriever{
+				URL:       "https://gitea.example.com/org/repo.git",
+				Ref:       "main",
+				FilePath:  "flags/config.goff.yaml",
+				AuthToken: "XXX_TOKEN",
+				Username:  "goff",
+				Timeout:   10000000000,
+			},
+			wantType: &gitretriever.Retriever{},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -424,3 +573,41 @@ func Test_InitRetriever_Redis(t *testing.T) {
 		})
 	}
 }
+
+func Test_InitRetrieverPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		conf *retrieverconf.RetrieverConf
+		want retriever.Policy
+	}{
+		{
+			name: "no refresh policy",
+			conf: &retrieverconf.RetrieverConf{Kind: "file", Path: "flags.yaml"},
+			want: retriever.Policy{},
+		},
+		{
+			name: "complete refresh policy",
+			conf: &retrieverconf.RetrieverConf{
+				Kind:            "http",
+				URL:             "https://example.com/flags.yaml",
+				Timeout:         2000,
+				PollingInterval: 10000,
+				Backoff:         &retrieverconf.BackoffConf{InitialInterval: 1000, MaxInterval: 60000},
+				CircuitBreaker:  &retrieverconf.CircuitBreakerConf{Threshold: 5, Cooldown: 30000},
+			},
+			want: retriever.Policy{
+				PollingInterval:         10 * time.Second,
+				Timeout:                 2 * time.Second,
+				BackoffInitialInterval:  time.Second,
+				BackoffMaxInterval:      time.Minute,
+				CircuitBreakerThreshold: 5,
+				CircuitBreakerCooldown:  30 * time.Second,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, InitRetrieverPolicy(tt.conf))
+		})
+	}
+}
@@ -45,15 +45,18 @@ type RetrieverConf struct {
 
 	// URI is used by
 	// - the postgresql retriever
+	// - the mysql retriever
 	// - the mongodb retriever
 	URI string `mapstructure:"uri"  koanf:"uri"`
 
 	// Table is used by
 	// - the postgresql retriever
+	// - the mysql retriever
+	// - the sqlite retriever
 	Table string `mapstructure:"table"  koanf:"table"`
 
 	// Columns is used by
-	// - the postgresql retriever (it allows to use custom column names)
+	// - the postgresql, mysql and sqlite retrievers (it allows to use custom column names)
 	Columns    map[string]string `mapstructure:"columns"        koanf:"columns"`
 	Database   string            `mapstructure:"database"       koanf:"database"`
 	Collection string            `mapstructure:"collection"     koanf:"collection"`
@@ -61,10 +64,46 @@ type RetrieverConf struct {
 	// RedisOptions is the serializable redis configuration that can be used in JSON/YAML files
 	RedisOptions *SerializableRedisOptions `mapstructure:"redisOptions"   koanf:"redisOptions"`
 
+	// Username, SSHKeyPath and Directory are used by
+	// - the git retriever (Username)
+	// - the etcd and nats retrievers (Username)
+	Username   string `mapstructure:"username"   koanf:"username"`
+	SSHKeyPath string `mapstructure:"sshKeyPath" koanf:"sshkeypath"`
+	Directory  string `mapstructure:"directory"  koanf:"directory"`
+
+	// Endpoints, Prefix and Password are used by
+	// - the etcd retriever (Endpoints, Prefix, Password)
+	// - the consul retriever (Prefix)
+	// - the nats retriever (Password)
+	// The key-value store retrievers also use Key, the TLS fields (clientCertPath, clientKeyPath, caCertPath),
+	// and the consul retriever uses URL for the address of the agent and AuthToken for the ACL token.
+	// The nats retriever uses URL for the servers, Bucket for the key-value bucket and AuthToken for the token.
+	Endpoints []string `mapstructure:"endpoints" koanf:"endpoints"`
+	Prefix    string   `mapstructure:"prefix"    koanf:"prefix"`
+	Password  string   `mapstructure:"password"  koanf:"password"` //nolint:gosec // G117
+
 	RedisPrefix string `mapstructure:"redisPrefix"    koanf:"redisPrefix"`
 	AccountName string `mapstructure:"accountName"    koanf:"accountname"`
 	AccountKey  string `mapstructure:"accountKey"     koanf:"accountkey"`
 	Container   string `mapstructure:"container"      koanf:"container"`
+
+	// PollingInterval, Backoff and CircuitBreaker are the refresh policy of the retriever,
+	// they can be used by all the retrievers (with Timeout, in milliseconds).
+	PollingInterval int                 `mapstructure:"pollingInterval" koanf:"pollinginterval"`
+	Backoff         *BackoffConf        `mapstructure:"backoff"         koanf:"backoff"`
+	CircuitBreaker  *CircuitBreakerConf `mapstructure:"circuitBreaker"  koanf:"circuitbreaker"`
+}
+
+// BackoffConf is the exponential backoff of a retriever after errors, the intervals are in milliseconds.
+type BackoffConf struct {
+	InitialInterval int64 `mapstructure:"initialInterval" koanf:"initialinterval"`
+	MaxInterval     int64 `mapstructure:"maxInterval"     koanf:"maxinterval"`
+}
+
+// CircuitBreakerConf is the circuit breaker of a retriever, the cooldown is in milliseconds.
+type CircuitBreakerConf struct {
+	Threshold int   `mapstructure:"threshold" koanf:"threshold"`
+	Cooldown  int64 `mapstructure:"cooldown"  koanf:"cooldown"`
 }
 
 // IsValid validate the configuration of the retriever
@@ -73,8 +112,14 @@ func (c *RetrieverConf) IsValid() error {
 	if err := c.Kind.IsValid(); err != nil {
 		return err
 	}
-	if c.Kind == PostgreSQLRetriever {
-		return c.validatePostgreSQLRetriever()
+	if err := c.validateRefreshPolicy(); err != nil {
+		return err
+	}
+	if c.Kind == PostgreSQLRetriever || c.Kind == MySQLRetriever {
+		return c.validateSQLRetriever()
+	}
+	if c.Kind == SQLiteRetriever {
+		return c.validateSQLiteRetriever()
 	}
 	if c.Kind == GitHubRetriever || c.Kind == GitlabRetriever || c.Kind == BitbucketRetriever {
 		return c.validateGitRetriever()
@@ -88,7 +133,7 @@ func (c *RetrieverConf) IsValid() error {
 	if c.Kind == GoogleStorageRetriever && c.Object == "" {
 		return err.NewRetrieverConfError("object", string(c.Kind))
 	}
-	if c.Kind == FileRetriever && c.Path == "" {
+	if (c.Kind == FileRetriever || c.Kind == DirectoryRetriever) && c.Path == "" {
 		return err.NewRetrieverConfError("path", string(c.Kind))
 	}
 	if (c.Kind == S3Retriever || c.Kind == GoogleStorageRetriever) && c.Bucket == "" {
@@ -106,6 +151,26 @@ func (c *RetrieverConf) IsValid() error {
 	if c.Kind == AzBlobStorageRetriever {
 		return c.validateAzBlobStorageRetriever()
 	}
+	if c.Kind == GitRetriever {
+		return c.validateGenericGitRetriever()
+	}
+	if c.Kind == EtcdRetriever || c.Kind == ConsulRetriever {
+		return c.validateKVRetriever()
+	}
+	if c.Kind == NATSRetriever {
+		return c.validateNATSRetriever()
+	}
+	return nil
+}
+
+// validateRefreshPolicy validates the backoff and the circuit breaker of the retriever
+func (c *RetrieverConf) validateRefreshPolicy() error {
+	if c.Backoff != nil && c.Backoff.InitialInterval <= 0 {
+		return err.NewRetrieverConfError("backoff.initialInterval", string(c.Kind))
+	}
+	if c.CircuitBreaker != nil && c.CircuitBreaker.Threshold <= 0 {
+		return err.NewRetrieverConfError("circuitBreaker.threshold", string(c.Kind))
+	}
 	return nil
 }
 
@@ -122,8 +187,42 @@ func (c *RetrieverConf) validateHTTPRetriever() error {
 	return nil
 }
 
-// validatePostgreSQLRetriever validates the configuration of the postgresql retriever
-func (c *RetrieverConf) validatePostgreSQLRetriever() error {
+// validateKVRetriever validates the configuration of the etcd and consul retrievers
+func (c *RetrieverConf) validateKVRetriever() error {
+	if c.Kind == EtcdRetriever && len(c.Endpoints) == 0 {
+		return err.NewRetrieverConfError("endpoints", string(c.Kind))
+	}
+	if c.Key == "" && c.Prefix == "" {
+		return err.NewRetrieverConfError("key or prefix", string(c.Kind))
+	}
+	if c.Key != "" && c.Prefix != "" {
+		return fmt.Errorf("invalid retriever: only one of \"key\" and \"prefix\" can be set for kind \"%s\"", c.Kind)
+	}
+	if c.HTTPClientCertPath != "" && c.HTTPClientKeyPath == "" {
+		return err.NewRetrieverConfError("clientKeyPath", string(c.Kind))
+	}
+	if c.HTTPClientCertPath == "" && c.HTTPClientKeyPath != "" {
+		return err.NewRetrieverConfError("clientCertPath", string(c.Kind))
+	}
+	return nil
+}
+
+// validateNATSRetriever validates the configuration of the nats retriever
+func (c *RetrieverConf) validateNATSRetriever() error {
+	if c.Bucket == "" {
+		return err.NewRetrieverConfError("bucket", string(c.Kind))
+	}
+	if c.HTTPClientCertPath != "" && c.HTTPClientKeyPath == "" {
+		return err.NewRetrieverConfError("clientKeyPath", string(c.Kind))
+	}
+	if c.HTTPClientCertPath == "" && c.HTTPClientKeyPath != "" {
+		return err.NewRetrieverConfError("clientCertPath", string(c.Kind))
+	}
+	return nil
+}
+
+// validateSQLRetriever validates the configuration of the postgresql and mysql retrievers
+func (c *RetrieverConf) validateSQLRetriever() error {
 	if c.URI == "" {
 		return err.NewRetrieverConfError("uri", string(c.Kind))
 	}
@@ -133,6 +232,17 @@ func (c *RetrieverConf) validatePostgreSQLRetriever() error {
 	return nil
 }
 
+// validateSQLiteRetriever validates the configuration of the sqlite retriever
+func (c *RetrieverConf) validateSQLiteRetriever() error {
+	if c.Path == "" {
+		return err.NewRetrieverConfError("path", string(c.Kind))
+	}
+	if c.Table == "" {
+		return err.NewRetrieverConfError("table", string(c.Kind))
+	}
+	return nil
+}
+
 func (c *RetrieverConf) validateGitRetriever() error {
 	if c.RepositorySlug == "" {
 		return err.NewRetrieverConfError("repositorySlug", string(c.Kind))
@@ -143,6 +253,17 @@ func (c *RetrieverConf) validateGitRetriever() error {
 	return nil
 }
 
+// validateGenericGitRetriever validates the configuration of the git retriever
+func (c *RetrieverConf) validateGenericGitRetriever() error {
+	if c.URL == "" {
+		return err.NewRetrieverConfError("url", string(c.Kind))
+	}
+	if c.Path == "" {
+		return err.NewRetrieverConfError("path", string(c.Kind))
+	}
+	return nil
+}
+
 func (c *RetrieverConf) validateKubernetesRetriever() error {
 	if c.ConfigMap == "" {
 		return err.NewRetrieverConfError("configmap", string(c.Kind))
@@ -208,6 +329,13 @@ const (
 	BitbucketRetriever     RetrieverKind = "bitbucket"
 	AzBlobStorageRetriever RetrieverKind = "azureBlobStorage"
 	PostgreSQLRetriever    RetrieverKind = "postgresql"
+	MySQLRetriever         RetrieverKind = "mysql"
+	SQLiteRetriever        RetrieverKind = "sqlite"
+	GitRetriever           RetrieverKind = "git"
+	DirectoryRetriever     RetrieverKind = "directory"
+	EtcdRetriever          RetrieverKind = "etcd"
+	ConsulRetriever        RetrieverKind = "consul"
+	NATSRetriever          RetrieverKind = "nats"
 )
 
 // IsValid is checking if the value is part of the enum
@@ -215,7 +343,8 @@ func (r RetrieverKind) IsValid() error {
 	switch r {
 	case HTTPRetriever, GitHubRetriever, GitlabRetriever, S3Retriever, RedisRetriever,
 		FileRetriever, GoogleStorageRetriever, KubernetesRetriever, MongoDBRetriever,
-		BitbucketRetriever, AzBlobStorageRetriever, PostgreSQLRetriever:
+		BitbucketRetriever, AzBlobStorageRetriever, PostgreSQLRetriever, GitRetriever, DirectoryRetriever,
+		MySQLRetriever, SQLiteRetriever, EtcdRetriever, ConsulRetriever, NATSRetriever:
 		return nil
 	}
 	return fmt.Errorf("invalid retriever: kind \"%s\" is not supported", r)
@@ -124,6 +124,51 @@ func TestRetrieverConf_IsValid(t *testing.T) {
 			wantErr:  true,
 			errValue: "invalid retriever: no \"path\" property found for kind \"file\"",
 		},
+		{
+			name: "kind directory valid",
+			fields: retrieverconf.RetrieverConf{
+				Kind: "directory",
+				Path: "/etc/goff/flags",
+			},
+		},
+		{
+			name: "kind directory without path",
+			fields: retrieverconf.RetrieverConf{
+				Kind: "directory",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"path\" property found for kind \"directory\"",
+		},
+		{
+			name: "kind file with refresh policy",
+			fields: retrieverconf.RetrieverConf{
+				Kind:            "file",
+				Path:            "/etc/goff/flags.yaml",
+				PollingInterval: 10000,
+				Backoff:         &retrieverconf.BackoffConf{InitialInterval: 1000, MaxInterval: 60000},
+				CircuitBreaker:  &retrieverconf.CircuitBreakerConf{Threshold: 5, Cooldown: 60000},
+			},
+		},
+		{
+			name: "backoff without initial interval",
+			fields: retrieverconf.RetrieverConf{
+				Kind:    "file",
+				Path:    "/etc/goff/flags.yaml",
+				Backoff: &retrieverconf.BackoffConf{MaxInterval: 60000},
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"backoff.initialInterval\" property found for kind \"file\"",
+		},
+		{
+			name: "circuit breaker without threshold",
+			fields: retrieverconf.RetrieverConf{
+				Kind:           "http",
+				URL:            "https://example.com/flags.yaml",
+				CircuitBreaker: &retrieverconf.CircuitBreakerConf{Cooldown: 60000},
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"circuitBreaker.threshold\" property found for kind \"http\"",
+		},
 		{
 			name: "kind s3 without bucket",
 			fields: retrieverconf.RetrieverConf{
@@ -396,6 +441,183 @@ func TestRetrieverConf_IsValid(t *testing.T) {
 			wantErr:  true,
 			errValue: "invalid retriever: no \"table\" property found for kind \"postgresql\"",
 		},
+		{
+			name: "kind mysql valid",
+			fields: retrieverconf.RetrieverConf{
+				Kind:  "mysql",
+				URI:   "user:password@tcp(localhost:3306)/database",
+				Table: "xxx",
+			},
+		},
+		{
+			name: "kind mysql invalid without URI",
+			fields: retrieverconf.RetrieverConf{
+				Kind:  "mysql",
+				Table: "xxx",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"uri\" property found for kind \"mysql\"",
+		},
+		{
+			name: "kind mysql invalid without Table",
+			fields: retrieverconf.RetrieverConf{
+				Kind: "mysql",
+				URI:  "xxx",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"table\" property found for kind \"mysql\"",
+		},
+		{
+			name: "kind sqlite valid",
+			fields: retrieverconf.RetrieverConf{
+				Kind:  "sqlite",
+				Path:  "/data/flags.db",
+				Table: "xxx",
+			},
+		},
+		{
+			name: "kind sqlite invalid without Path",
+			fields: retrieverconf.RetrieverConf{
+				Kind:  "sqlite",
+				Table: "xxx",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"path\" property found for kind \"sqlite\"",
+		},
+		{
+			name: "kind sqlite invalid without Table",
+			fields: retrieverconf.RetrieverConf{
+				Kind: "sqlite",
+				Path: "/data/flags.db",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"table\" property found for kind \"sqlite\"",
+		},
+		{
+			name: "kind etcd valid with key",
+			fields: retrieverconf.RetrieverConf{
+				Kind:      "etcd",
+				Endpoints: []string{"http://localhost:2379"},
+				Key:       "goff/flags",
+			},
+		},
+		{
+			name: "kind etcd valid with prefix",
+			fields: retrieverconf.RetrieverConf{
+				Kind:      "etcd",
+				Endpoints: []string{"http://localhost:2379"},
+				Prefix:    "goff/flags/",
+			},
+		},
+		{
+			name: "kind etcd invalid without endpoints",
+			fields: retrieverconf.RetrieverConf{
+				Kind: "etcd",
+				Key:  "goff/flags",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"endpoints\" property found for kind \"etcd\"",
+		},
+		{
+			name: "kind etcd invalid with key and prefix",
+			fields: retrieverconf.RetrieverConf{
+				Kind:      "etcd",
+				Endpoints: []string{"http://localhost:2379"},
+				Key:       "goff/flags",
+				Prefix:    "goff/flags/",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: only one of \"key\" and \"prefix\" can be set for kind \"etcd\"",
+		},
+		{
+			name: "kind etcd invalid with client certificate without key",
+			fields: retrieverconf.RetrieverConf{
+				Kind:               "etcd",
+				Endpoints:          []string{"https://localhost:2379"},
+				Key:                "goff/flags",
+				HTTPClientCertPath: "client.crt",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"clientKeyPath\" property found for kind \"etcd\"",
+		},
+		{
+			name: "kind consul valid without address",
+			fields: retrieverconf.RetrieverConf{
+				Kind:   "consul",
+				Prefix: "goff/flags/",
+			},
+		},
+		{
+			name: "kind consul invalid without key and prefix",
+			fields: retrieverconf.RetrieverConf{
+				Kind: "consul",
+				URL:  "http://localhost:8500",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"key or prefix\" property found for kind \"consul\"",
+		},
+		{
+			name: "kind consul invalid with client key without certificate",
+			fields: retrieverconf.RetrieverConf{
+				Kind:              "consul",
+				Key:               "goff/flags",
+				HTTPClientKeyPath: "client.key",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"clientCertPath\" property found for kind \"consul\"",
+		},
+		{
+			name: "kind nats valid without url",
+			fields: retrieverconf.RetrieverConf{
+				Kind:   "nats",
+				Bucket: "flags",
+			},
+		},
+		{
+			name: "kind nats invalid without bucket",
+			fields: retrieverconf.RetrieverConf{
+				Kind: "nats",
+				URL:  "nats://localhost:4222",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"bucket\" property found for kind \"nats\"",
+		},
+		{
+			name: "kind nats invalid with client certificate without key",
+			fields: retrieverconf.RetrieverConf{
+				Kind:               "nats",
+				Bucket:             "flags",
+				HTTPClientCertPath: "client.crt",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"clientKeyPath\" property found for kind \"nats\"",
+		},
+		{
+			name: "kind git valid",
+			fields: retrieverconf.RetrieverConf{
+				Kind: "git",
+				URL:  "https://gitea.example.com/org/repo.git",
+				Path: "flags.yaml",
+			},
+		},
+		{
+			name: "kind git invalid without URL",
+			fields: retrieverconf.RetrieverConf{
+				Kind: "git",
+				Path: "flags.yaml",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"url\" property found for kind \"git\"",
+		},
+		{
+			name: "kind git invalid without Path",
+			fields: retrieverconf.RetrieverConf{
+				Kind: "git",
+				URL:  "https://gitea.example.com/org/repo.git",
+			},
+			wantErr:  true,
+			errValue: "invalid retriever: no \"path\" property found for kind \"git\"",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
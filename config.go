@@ -8,8 +8,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/thomaspoignant/go-feature-flag/guardedrollout"
+	"github.com/thomaspoignant/go-feature-flag/hook"
 	"github.com/thomaspoignant/go-feature-flag/notifier"
 	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/usage"
 	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
 )
 
@@ -49,7 +52,8 @@ type Config struct {
 	// Default: context.Background()
 	Context context.Context
 
-	// Environment (optional) can be checked in feature flag rules
+	// Environment (optional) can be checked in feature flag rules, and selects the
+	// overrides of the flags defined in their "environments" field.
 	// Default: ""
 	Environment string
 
@@ -62,8 +66,17 @@ type Config struct {
 	//
 	// Note: If both Retriever and Retrievers are set, we will start by calling the Retriever and,
 	// after we will use the order of Retrievers.
+	//
+	// Use retriever.WithPolicy to give a retriever its own polling interval, timeout, backoff and circuit breaker.
 	Retrievers []retriever.Retriever
 
+	// RetrieverMode (optional) is the way the retrievers are used:
+	// - retriever.MergeMode: all the retrievers are called and their flags are merged.
+	// - retriever.FallbackMode: the retrievers are tried in order (primary, secondary, ...) and the
+	//   flags of the first one answering are used.
+	// Default: retriever.MergeMode
+	RetrieverMode retriever.Mode
+
 	// Notifiers (optional) is the list of notifiers called when a flag change
 	Notifiers []notifier.Notifier
 
@@ -109,6 +122,40 @@ type Config struct {
 	// you ensure that GO Feature Flag will always start with a configuration but which can be out-dated.
 	PersistentFlagConfigurationFile string
 
+	// FlagConfigurationSnapshots (optional) is the number of versions of the flag configuration kept in memory.
+	// A snapshot can be pinned to freeze the flag configuration (ex: to roll back a bad flag file), the retrievers
+	// are not called until the snapshot is unpinned.
+	// If PersistentFlagConfigurationFile is set, the snapshots are stored next to it to be kept across restarts.
+	// Default: 0 (no snapshot)
+	FlagConfigurationSnapshots int
+
+	// IncludeAllowedOrigins (optional) is the list of origins (ex: https://config.example.com) of the remote
+	// files that can be included by the flag configuration.
+	// A remote flag configuration can always include the files of its own origin.
+	// Default: nil (only the files of the same origin can be included)
+	IncludeAllowedOrigins []string
+
+	// GuardedRollouts (optional) is the list of progressive rollouts watched by a health signal.
+	// When the signal crosses the threshold, the rollout is paused or rolled back to the initial variation,
+	// and the notifiers are called.
+	// Default: nil
+	GuardedRollouts []guardedrollout.Guard
+
+	// Hooks (optional) are called around every flag evaluation (variation functions and AllFlagsState).
+	// They can enrich or replace the evaluation context, observe the result of the evaluation and inject errors.
+	// Default: nil
+	Hooks []hook.Hook
+
+	// OpenTelemetry (optional) instruments the evaluations with OpenTelemetry traces and metrics.
+	// Default: nil (no instrumentation)
+	OpenTelemetry *OpenTelemetryConfig
+
+	// UsageTracking (optional) tracks the number of evaluations and the date of the last evaluation of
+	// each flag and variation, and the flags requested but not present in the configuration.
+	// The report is available with GetFlagUsageReport.
+	// Default: nil (no tracking)
+	UsageTracking *usage.Config
+
 	// Name (optional) is the name of the flagset, this is used to identify the flagset inside the
 	// GO Feature Flag instance. This allow to identify the flagset.
 	// Default: nil
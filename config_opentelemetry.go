@@ -0,0 +1,27 @@
+package ffclient
+
+import (
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OpenTelemetryConfig is the configuration of the OpenTelemetry instrumentation of the evaluations.
+//
+// Each evaluation done with a context.Context containing a span (the variation functions with the Ctx suffix)
+// creates a child span following the feature flag semantic conventions (flag key, variant, reason and
+// error type), and we record metrics for the evaluations, the refreshes of the cache and the errors of
+// the retrievers.
+type OpenTelemetryConfig struct {
+	// TracerProvider (optional) is the provider used to create the spans.
+	// Default: the global tracer provider (otel.GetTracerProvider())
+	TracerProvider trace.TracerProvider
+
+	// MeterProvider (optional) is the provider used to create the metrics.
+	// Default: the global meter provider (otel.GetMeterProvider())
+	MeterProvider metric.MeterProvider
+
+	// RecordTargetingKey (optional) adds the targeting key of the evaluation context to the spans
+	// (feature_flag.context.id), only enable it if your traces can contain the identifiers of your users.
+	// Default: false
+	RecordTargetingKey bool
+}
@@ -0,0 +1,123 @@
+package natsexporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/thomaspoignant/go-feature-flag/exporter"
+	"github.com/thomaspoignant/go-feature-flag/internal/natsconn"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+var _ exporter.Exporter = &Exporter{}
+
+// Exporter publishes events on a NATS JetStream subject.
+type Exporter struct {
+	// URL is the address of the NATS server(s), separated by commas.
+	// Default: nats://127.0.0.1:4222
+	URL string
+
+	// Subject is the JetStream subject on which the events are published.
+	// A stream capturing this subject should exist, otherwise the publication fails.
+	Subject string
+
+	// Token is the token used to authenticate to NATS.
+	Token string
+
+	// Username and Password are the credentials used to authenticate to NATS.
+	Username string
+	Password string
+
+	// ClientCertPath and ClientKeyPath are the paths to the client certificate and key used for mutual TLS.
+	ClientCertPath string
+	ClientKeyPath  string
+
+	// CACertPath is the path to the CA certificate used to verify the NATS server.
+	CACertPath string
+
+	// Options are additional options used to connect to NATS.
+	Options []nats.Option
+
+	mutex sync.Mutex
+	js    jetstream.JetStream
+}
+
+// Export publishes a NATS message for each exporter.ExportableEvent received
+// and waits for the acknowledgement of JetStream.
+func (e *Exporter) Export(ctx context.Context, _ *fflog.FFLogger, events []exporter.ExportableEvent) error {
+	js, err := e.jetStream()
+	if err != nil {
+		return err
+	}
+
+	acks := make([]jetstream.PubAckFuture, 0, len(events))
+	for _, event := range events {
+		messageBody, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		msg := &nats.Msg{
+			Subject: e.Subject,
+			Data:    messageBody,
+			Header:  nats.Header{"emitter": []string{"GO Feature Flag"}},
+		}
+		ack, err := js.PublishMsgAsync(msg)
+		if err != nil {
+			return fmt.Errorf("impossible to publish the event on subject %q: %w", e.Subject, err)
+		}
+		acks = append(acks, ack)
+	}
+
+	for _, ack := range acks {
+		select {
+		case <-ack.Ok():
+		case err := <-ack.Err():
+			// Return the first error encountered.
+			return fmt.Errorf("impossible to publish the event on subject %q: %w", e.Subject, err)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// IsBulk always returns false as the NATS exporter sends each event as a separate message.
+func (e *Exporter) IsBulk() bool {
+	return false
+}
+
+// jetStream returns the JetStream context, the connection is opened on the first call.
+func (e *Exporter) jetStream() (jetstream.JetStream, error) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if e.js != nil {
+		return e.js, nil
+	}
+	if e.Subject == "" {
+		return nil, errors.New("nats exporter: subject is mandatory")
+	}
+
+	conn, err := natsconn.Connect(e.URL, natsconn.Auth{
+		Token:          e.Token,
+		Username:       e.Username,
+		Password:       e.Password,
+		ClientCertPath: e.ClientCertPath,
+		ClientKeyPath:  e.ClientKeyPath,
+		CACertPath:     e.CACertPath,
+	}, e.Options...)
+	if err != nil {
+		return nil, fmt.Errorf("impossible to connect to nats: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("impossible to use jetstream: %w", err)
+	}
+	e.js = js
+	return js, nil
+}
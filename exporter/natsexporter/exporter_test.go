@@ -0,0 +1,115 @@
+package natsexporter_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/exporter"
+	"github.com/thomaspoignant/go-feature-flag/exporter/natsexporter"
+)
+
+func TestExporter_Export(t *testing.T) {
+	url, js := startNATS(t, "")
+	stream, err := js.CreateStream(context.TODO(), jetstream.StreamConfig{Name: "EVENTS", Subjects: []string{"goff.>"}})
+	require.NoError(t, err)
+
+	events := []exporter.ExportableEvent{
+		exporter.FeatureEvent{
+			Kind: "feature", ContextKind: "anonymousUser", UserKey: "ABCD", CreationDate: 1617970547, Key: "random-key",
+			Variation: "Default", Value: "YO", Default: false,
+		},
+		exporter.TrackingEvent{
+			Kind: "tracking", ContextKind: "user", UserKey: "ABCD", CreationDate: 1617970547, Key: "checkout",
+			EvaluationContext: map[string]any{"targetingKey": "ABCD"},
+			TrackingDetails:   map[string]any{"amount": float64(12)},
+		},
+	}
+
+	e := &natsexporter.Exporter{URL: url, Subject: "goff.events"}
+	require.NoError(t, e.Export(context.TODO(), nil, events))
+	assert.False(t, e.IsBulk())
+
+	consumer, err := stream.CreateConsumer(context.TODO(), jetstream.ConsumerConfig{})
+	require.NoError(t, err)
+	batch, err := consumer.Fetch(len(events), jetstream.FetchMaxWait(5*time.Second))
+	require.NoError(t, err)
+	i := 0
+	for msg := range batch.Messages() {
+		want, err := json.Marshal(events[i])
+		require.NoError(t, err)
+		assert.JSONEq(t, string(want), string(msg.Data()))
+		assert.Equal(t, "goff.events", msg.Subject())
+		assert.Equal(t, "GO Feature Flag", msg.Headers().Get("emitter"))
+		i++
+	}
+	require.NoError(t, batch.Error())
+	assert.Equal(t, len(events), i)
+}
+
+func TestExporter_ExportErrors(t *testing.T) {
+	event := []exporter.ExportableEvent{exporter.FeatureEvent{Kind: "feature", Key: "random-key"}}
+
+	t.Run("no subject", func(t *testing.T) {
+		e := &natsexporter.Exporter{URL: "nats://127.0.0.1:4222"}
+		assert.EqualError(t, e.Export(context.TODO(), nil, event), "nats exporter: subject is mandatory")
+	})
+
+	t.Run("nats not reachable", func(t *testing.T) {
+		e := &natsexporter.Exporter{
+			URL:     "nats://127.0.0.1:1",
+			Subject: "goff.events",
+			Options: []nats.Option{nats.Timeout(100 * time.Millisecond)},
+		}
+		assert.ErrorContains(t, e.Export(context.TODO(), nil, event), "impossible to connect to nats")
+	})
+
+	t.Run("no stream for the subject", func(t *testing.T) {
+		url, _ := startNATS(t, "")
+		e := &natsexporter.Exporter{URL: url, Subject: "goff.events"}
+		assert.ErrorContains(t, e.Export(context.TODO(), nil, event),
+			`impossible to publish the event on subject "goff.events"`)
+	})
+
+	t.Run("authentication", func(t *testing.T) {
+		url, js := startNATS(t, "secret-token")
+		_, err := js.CreateStream(context.TODO(), jetstream.StreamConfig{Name: "EVENTS", Subjects: []string{"goff.>"}})
+		require.NoError(t, err)
+
+		e := &natsexporter.Exporter{URL: url, Subject: "goff.events"}
+		assert.Error(t, e.Export(context.TODO(), nil, event))
+
+		e = &natsexporter.Exporter{URL: url, Subject: "goff.events", Token: "secret-token"}
+		assert.NoError(t, e.Export(context.TODO(), nil, event))
+	})
+}
+
+// startNATS starts an embedded NATS server with JetStream and returns its URL and a JetStream client.
+func startNATS(t *testing.T, token string) (string, jetstream.JetStream) {
+	t.Helper()
+	opts := &server.Options{
+		Host:          "127.0.0.1",
+		Port:          server.RANDOM_PORT,
+		JetStream:     true,
+		StoreDir:      t.TempDir(),
+		Authorization: token,
+	}
+	s, err := server.NewServer(opts)
+	require.NoError(t, err)
+	go s.Start()
+	t.Cleanup(s.Shutdown)
+	require.True(t, s.ReadyForConnections(10*time.Second), "nats is not ready")
+
+	conn, err := nats.Connect(s.ClientURL(), nats.Token(token))
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	return s.ClientURL(), js
+}
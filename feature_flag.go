@@ -7,8 +7,14 @@ import (
 	"time"
 
 	"github.com/thomaspoignant/go-feature-flag/exporter"
+	"github.com/thomaspoignant/go-feature-flag/guardedrollout"
 	"github.com/thomaspoignant/go-feature-flag/internal/cache"
+	"github.com/thomaspoignant/go-feature-flag/internal/lifecycle"
 	"github.com/thomaspoignant/go-feature-flag/internal/notification"
+	"github.com/thomaspoignant/go-feature-flag/internal/rolloutguard"
+	"github.com/thomaspoignant/go-feature-flag/internal/telemetry"
+	"github.com/thomaspoignant/go-feature-flag/internal/usagetracker"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
 	"github.com/thomaspoignant/go-feature-flag/notifier/logsnotifier"
 	"github.com/thomaspoignant/go-feature-flag/retriever"
 	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
@@ -43,6 +49,11 @@ type GoFeatureFlag struct {
 	featureEventDataExporter  exporter.Manager[exporter.FeatureEvent]
 	trackingEventDataExporter exporter.Manager[exporter.TrackingEvent]
 	retrieverManager          *retriever.Manager
+	notificationService       notification.Service
+	rolloutGuardController    *rolloutguard.Controller
+	telemetry                 *telemetry.Telemetry
+	usageTracker              *usagetracker.Tracker
+	deprecationLogger         *lifecycle.DeprecationLogger
 	// evalExporterWg is a wait group to wait for the evaluation exporter to finish the export before closing GOFF
 	evalExporterWg sync.WaitGroup
 }
@@ -53,6 +64,10 @@ var (
 	onceFF sync.Once
 )
 
+// deprecationWarningInterval is the minimum interval between 2 warnings logged for the evaluation
+// of the same deprecated flag.
+var deprecationWarningInterval = 10 * time.Minute
+
 // New creates a new go-feature-flag instances that retrieve the config from a YAML file
 // and return everything you need to manage your flags.
 func New(config Config) (*GoFeatureFlag, error) {
@@ -63,13 +78,35 @@ func New(config Config) (*GoFeatureFlag, error) {
 		evalExporterWg: sync.WaitGroup{},
 	}
 
+	if config.OpenTelemetry != nil {
+		var err error
+		goFF.telemetry, err = initializeTelemetry(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if config.Offline {
 		// in case we are in offline mode, we don't need to initialize the cache since we will not use it.
 		goFF.config.internalLogger.Info("GO Feature Flag is in offline mode")
 		return goFF, nil
 	}
 
-	retrieverManager, err := initializeRetrieverManager(config)
+	for _, guard := range config.GuardedRollouts {
+		if err := guard.IsValid(); err != nil {
+			return nil, err
+		}
+	}
+	if config.UsageTracking != nil {
+		if err := config.UsageTracking.IsValid(); err != nil {
+			return nil, err
+		}
+		goFF.usageTracker = usagetracker.New(*config.UsageTracking, config.internalLogger)
+	}
+
+	goFF.deprecationLogger = lifecycle.NewDeprecationLogger(config.internalLogger, deprecationWarningInterval)
+	goFF.notificationService = initializeNotificationService(config)
+	retrieverManager, err := initializeRetrieverManager(config, goFF.notificationService, goFF.telemetry)
 	if err != nil && (goFF.retrieverManager == nil || !config.StartWithRetrieverError) {
 		return nil, fmt.Errorf(
 			"impossible to initialize the retrievers, please check your configuration: %v",
@@ -79,6 +116,8 @@ func New(config Config) (*GoFeatureFlag, error) {
 	goFF.retrieverManager = retrieverManager
 	goFF.featureEventDataExporter, goFF.trackingEventDataExporter = initializeDataExporters(
 		config, goFF.config.internalLogger)
+	goFF.rolloutGuardController = initializeRolloutGuardController(goFF)
+	goFF.usageTracker.Start()
 	config.internalLogger.Debug("GO Feature Flag is initialized")
 	return goFF, nil
 }
@@ -90,12 +129,59 @@ func initializeNotificationService(config Config) notification.Service {
 	return notification.NewService(notifiers)
 }
 
+// initializeTelemetry is a function that will create the OpenTelemetry instruments.
+func initializeTelemetry(config Config) (*telemetry.Telemetry, error) {
+	flagSetName := ""
+	if config.Name != nil {
+		flagSetName = *config.Name
+	}
+	t, err := telemetry.New(
+		config.OpenTelemetry.TracerProvider,
+		config.OpenTelemetry.MeterProvider,
+		flagSetName,
+		config.OpenTelemetry.RecordTargetingKey,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("impossible to initialize the OpenTelemetry instrumentation: %w", err)
+	}
+	return t, nil
+}
+
+// initializeRolloutGuardController is a function that will start the controller checking the guarded rollouts.
+// It returns nil if no guarded rollout is configured.
+func initializeRolloutGuardController(g *GoFeatureFlag) *rolloutguard.Controller {
+	if len(g.config.GuardedRollouts) == 0 {
+		return nil
+	}
+	controller := rolloutguard.NewController(
+		g.config.GuardedRollouts,
+		g.retrieverManager.GetFlag,
+		func(flagKey string, before, after flag.Flag) {
+			g.notificationService.Notify(
+				map[string]flag.Flag{flagKey: before},
+				map[string]flag.Flag{flagKey: after},
+				g.config.internalLogger,
+			)
+		},
+		g.config.internalLogger,
+	)
+	controller.Start(g.config.Context)
+	return controller
+}
+
 // initializeRetrieverManager is a function that will initialize the retriever manager with the retrievers
-func initializeRetrieverManager(config Config) (*retriever.Manager, error) {
+func initializeRetrieverManager(
+	config Config,
+	notificationService notification.Service,
+	instrumentation *telemetry.Telemetry,
+) (*retriever.Manager, error) {
 	retrievers, err := config.GetRetrievers()
 	if err != nil {
 		return nil, err
 	}
+	if err := retriever.IsValidMode(config.RetrieverMode); err != nil {
+		return nil, err
+	}
 	mngrConfig := retriever.ManagerConfig{
 		FileFormat:                      config.FileFormat,
 		DisableNotifierOnInit:           config.DisableNotifierOnInit,
@@ -104,9 +190,13 @@ func initializeRetrieverManager(config Config) (*retriever.Manager, error) {
 		EnablePollingJitter:             config.EnablePollingJitter,
 		PollingInterval:                 config.PollingInterval,
 		Name:                            config.Name,
+		Environment:                     config.Environment,
+		Mode:                            config.RetrieverMode,
+		Telemetry:                       instrumentation,
+		Snapshots:                       config.FlagConfigurationSnapshots,
+		IncludeAllowedOrigins:           config.IncludeAllowedOrigins,
 	}
 
-	notificationService := initializeNotificationService(config)
 	// init internal cache
 	cacheMngr := cache.New(
 		notificationService,
@@ -159,6 +249,10 @@ func initializeDataExporters(config Config, logger *fflog.FFLogger) (
 // Close wait until thread are done
 func (g *GoFeatureFlag) Close() {
 	if g != nil {
+		// the guarded rollouts are stopped first, they are using the cache and the notifiers.
+		if g.rolloutGuardController != nil {
+			g.rolloutGuardController.Close()
+		}
 		if g.retrieverManager != nil {
 			_ = g.retrieverManager.Shutdown(g.config.Context)
 		}
@@ -171,7 +265,48 @@ func (g *GoFeatureFlag) Close() {
 		if g.trackingEventDataExporter != nil {
 			g.trackingEventDataExporter.Stop()
 		}
+		g.usageTracker.Close()
+	}
+}
+
+// GetActiveRetriever returns the retriever which served the current flag configuration when using
+// the fallback retriever mode. It returns false in merge mode, or if no retriever served the flags yet.
+func (g *GoFeatureFlag) GetActiveRetriever() (retriever.Retriever, bool) {
+	if g == nil || g.IsOffline() {
+		return nil, false
 	}
+	return g.retrieverManager.ActiveRetriever()
+}
+
+// GetRetrieversStatus returns the refresh status of each retriever, in the order of the retrievers.
+func (g *GoFeatureFlag) GetRetrieversStatus() []retriever.RetrieverStatus {
+	if g == nil || g.IsOffline() {
+		return nil
+	}
+	return g.retrieverManager.RetrieversStatus()
+}
+
+// GetFlagConfigurationSnapshots returns the versions of the flag configuration kept, from the newest to the oldest.
+// It returns retriever.ErrSnapshotsDisabled if FlagConfigurationSnapshots is not set.
+func (g *GoFeatureFlag) GetFlagConfigurationSnapshots() ([]retriever.Snapshot, error) {
+	return g.retrieverManager.Snapshots()
+}
+
+// GetPinnedFlagConfigurationSnapshot returns the snapshot pinned, and false if no snapshot is pinned.
+func (g *GoFeatureFlag) GetPinnedFlagConfigurationSnapshot() (retriever.Snapshot, bool) {
+	return g.retrieverManager.PinnedSnapshot()
+}
+
+// PinFlagConfigurationSnapshot applies a snapshot of the flag configuration and stops calling the retrievers
+// until UnpinFlagConfigurationSnapshot is called.
+func (g *GoFeatureFlag) PinFlagConfigurationSnapshot(id int) (retriever.Snapshot, error) {
+	return g.retrieverManager.PinSnapshot(id)
+}
+
+// UnpinFlagConfigurationSnapshot removes the pin of the snapshot, the retrievers are called again to refresh the
+// flag configuration.
+func (g *GoFeatureFlag) UnpinFlagConfigurationSnapshot() error {
+	return g.retrieverManager.UnpinSnapshot(g.config.Context)
 }
 
 // GetCacheRefreshDate gives the last refresh date of the cache
@@ -182,6 +317,15 @@ func (g *GoFeatureFlag) GetCacheRefreshDate() time.Time {
 	return g.retrieverManager.GetCacheRefreshDate()
 }
 
+// GetGuardedRolloutStates returns the guarded rollouts that have been paused or rolled back
+// because their signal crossed the threshold.
+func (g *GoFeatureFlag) GetGuardedRolloutStates() []guardedrollout.State {
+	if g == nil || g.rolloutGuardController == nil {
+		return []guardedrollout.State{}
+	}
+	return g.rolloutGuardController.States()
+}
+
 // GetEvaluationContextEnrichment returns the evaluation context enrichment
 func (g *GoFeatureFlag) GetEvaluationContextEnrichment() map[string]any {
 	return g.config.EvaluationContextEnrichment
@@ -75,6 +75,93 @@ func TestMultipleRetrieversWithOverrideFlag(t *testing.T) {
 	assert.NotEqual(t, flag.ErrorCodeFlagNotFound, flagRes2.ErrorCode)
 }
 
+func TestMultipleRetrieversWithFallbackMode(t *testing.T) {
+	secondary := &fileretriever.Retriever{Path: "testdata/multiple_files/config-2.yaml"}
+	client, err := ffclient.New(ffclient.Config{
+		PollingInterval: 60 * time.Second,
+		LeveledLogger:   slog.Default(),
+		Retrievers: []retriever.Retriever{
+			&fileretriever.Retriever{Path: "testdata/multiple_files/not-existing.yaml"},
+			secondary,
+			&fileretriever.Retriever{Path: "testdata/multiple_files/config-1.yaml"},
+		},
+		RetrieverMode: retriever.FallbackMode,
+	})
+	assert.NoError(t, err)
+	defer client.Close()
+
+	active, ok := client.GetActiveRetriever()
+	assert.True(t, ok)
+	assert.Same(t, secondary, active)
+
+	user := ffcontext.NewEvaluationContext("random-key")
+	flagRes, err := client.BoolVariationDetails("my-3rd-flag", user, false)
+	assert.NoError(t, err)
+	assert.True(t, flagRes.Value)
+
+	// the flags of the retrievers after the active one are not used.
+	flagRes, _ = client.BoolVariationDetails("my-2nd-flag", user, false)
+	assert.Equal(t, flag.ErrorCodeFlagNotFound, flagRes.ErrorCode)
+}
+
+func TestInvalidRetrieverMode(t *testing.T) {
+	_, err := ffclient.New(ffclient.Config{
+		Retriever:     &fileretriever.Retriever{Path: "testdata/flag-config.yaml"},
+		RetrieverMode: "random",
+	})
+	assert.Error(t, err)
+}
+
+func TestGetRetrieversStatus(t *testing.T) {
+	client, err := ffclient.New(ffclient.Config{
+		PollingInterval: 60 * time.Second,
+		Retrievers: []retriever.Retriever{
+			retriever.WithPolicy(&fileretriever.Retriever{Path: "testdata/flag-config.yaml"},
+				retriever.Policy{PollingInterval: 10 * time.Minute}),
+		},
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	statuses := client.GetRetrieversStatus()
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "*fileretriever.Retriever", statuses[0].Name)
+	assert.Equal(t, "testdata/flag-config.yaml", statuses[0].Location)
+	assert.Equal(t, retriever.RetrieverReady, statuses[0].Status)
+	assert.Equal(t, retriever.CircuitBreakerClosed, statuses[0].CircuitBreaker)
+	assert.WithinDuration(t, time.Now().Add(10*time.Minute), statuses[0].NextAttempt, time.Minute)
+
+	client.SetOffline(true)
+	assert.Nil(t, client.GetRetrieversStatus())
+}
+
+func TestFlagConfigurationSnapshots(t *testing.T) {
+	client, err := ffclient.New(ffclient.Config{
+		PollingInterval:            60 * time.Second,
+		Retriever:                  &fileretriever.Retriever{Path: "testdata/flag-config.yaml"},
+		FlagConfigurationSnapshots: 5,
+	})
+	require.NoError(t, err)
+	defer client.Close()
+
+	snapshots, err := client.GetFlagConfigurationSnapshots()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 1)
+	assert.Equal(t, "testdata/flag-config.yaml", snapshots[0].Sources[0].Location)
+
+	snapshot, err := client.PinFlagConfigurationSnapshot(snapshots[0].ID)
+	require.NoError(t, err)
+	pinned, ok := client.GetPinnedFlagConfigurationSnapshot()
+	assert.True(t, ok)
+	assert.Equal(t, snapshot, pinned)
+	assert.False(t, client.ForceRefresh())
+
+	require.NoError(t, client.UnpinFlagConfigurationSnapshot())
+	_, ok = client.GetPinnedFlagConfigurationSnapshot()
+	assert.False(t, ok)
+	assert.True(t, client.ForceRefresh())
+}
+
 func TestStartWithMinInterval(t *testing.T) {
 	_, err := ffclient.New(ffclient.Config{
 		PollingInterval: 2,
@@ -0,0 +1,42 @@
+package ffcontext
+
+import "context"
+
+// TargetingKeyAttribute is the attribute setting the targeting key of an evaluation context built
+// with NewEvaluationContextFromAttributes.
+const TargetingKeyAttribute = "targetingKey"
+
+// evaluationContextKey is the key used to store the evaluation context in a context.Context.
+type evaluationContextKey struct{}
+
+// WithEvaluationContext returns a copy of ctx containing the evaluation context.
+// The evaluation context can be retrieved with FromContext, and it is used by the
+// variation functions with the Ctx suffix (ex: ffclient.BoolVariationCtx).
+func WithEvaluationContext(ctx context.Context, evaluationCtx Context) context.Context {
+	return context.WithValue(ctx, evaluationContextKey{}, evaluationCtx)
+}
+
+// FromContext returns the evaluation context stored in ctx with WithEvaluationContext.
+// The boolean is false if ctx does not contain any evaluation context.
+func FromContext(ctx context.Context) (Context, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	evaluationCtx, ok := ctx.Value(evaluationContextKey{}).(Context)
+	return evaluationCtx, ok && evaluationCtx != nil
+}
+
+// NewEvaluationContextFromAttributes builds an evaluation context from attributes (ex: the attributes
+// extracted from a request by the ffhttp and ffgrpc packages).
+// The attribute TargetingKeyAttribute sets the targeting key, the others are custom attributes.
+// If there is no targeting key attribute, the targeting key is empty.
+func NewEvaluationContextFromAttributes(attributes map[string]any) EvaluationContext {
+	targetingKey, _ := attributes[TargetingKeyAttribute].(string)
+	builder := NewEvaluationContextBuilder(targetingKey)
+	for key, value := range attributes {
+		if key != TargetingKeyAttribute {
+			builder.AddCustom(key, value)
+		}
+	}
+	return builder.Build()
+}
@@ -0,0 +1,69 @@
+package ffcontext_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+)
+
+func TestWithEvaluationContext(t *testing.T) {
+	evaluationCtx := ffcontext.NewEvaluationContextBuilder("user-key").AddCustom("plan", "pro").Build()
+	ctx := ffcontext.WithEvaluationContext(context.Background(), evaluationCtx)
+
+	got, ok := ffcontext.FromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, evaluationCtx, got)
+}
+
+func TestFromContext_NoEvaluationContext(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{name: "empty context", ctx: context.Background()},
+		{name: "nil context", ctx: nil},
+		{name: "nil evaluation context", ctx: ffcontext.WithEvaluationContext(context.Background(), nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ffcontext.FromContext(tt.ctx)
+			assert.False(t, ok)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestNewEvaluationContextFromAttributes(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes map[string]any
+		want       ffcontext.EvaluationContext
+	}{
+		{
+			name:       "targeting key and custom attributes",
+			attributes: map[string]any{ffcontext.TargetingKeyAttribute: "user-123", "plan": "pro"},
+			want:       ffcontext.NewEvaluationContextBuilder("user-123").AddCustom("plan", "pro").Build(),
+		},
+		{
+			name:       "no targeting key",
+			attributes: map[string]any{"plan": "pro"},
+			want:       ffcontext.NewEvaluationContextBuilder("").AddCustom("plan", "pro").Build(),
+		},
+		{
+			name:       "targeting key not a string",
+			attributes: map[string]any{ffcontext.TargetingKeyAttribute: 123},
+			want:       ffcontext.NewEvaluationContext(""),
+		},
+		{
+			name: "no attributes",
+			want: ffcontext.NewEvaluationContext(""),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ffcontext.NewEvaluationContextFromAttributes(tt.attributes))
+		})
+	}
+}
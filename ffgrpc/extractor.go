@@ -0,0 +1,68 @@
+package ffgrpc
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// Metadata extracts the value of an incoming metadata key into an attribute.
+// If the key has several values, the first one is used.
+func Metadata(key string, attribute string) Extractor {
+	return func(_ context.Context, md metadata.MD) map[string]any {
+		values := md.Get(key)
+		if len(values) == 0 || values[0] == "" {
+			return nil
+		}
+		return map[string]any{attribute: values[0]}
+	}
+}
+
+// PeerIP extracts the IP address of the peer into an attribute.
+// If the call goes through a proxy, the IP is the one of the proxy, use Metadata to read the
+// metadata set by your proxy instead.
+func PeerIP(attribute string) Extractor {
+	return func(ctx context.Context, _ metadata.MD) map[string]any {
+		p, ok := peer.FromContext(ctx)
+		if !ok || p.Addr == nil {
+			return nil
+		}
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		if net.ParseIP(host) == nil {
+			return nil
+		}
+		return map[string]any{attribute: host}
+	}
+}
+
+// ForwardedEvaluationContext extracts the evaluation context forwarded by the client interceptors
+// of the caller (see UnaryClientInterceptor and StreamClientInterceptor).
+//
+// The metadata can be set by any client, only use this extractor for calls coming from services
+// you trust.
+func ForwardedEvaluationContext() Extractor {
+	return func(_ context.Context, md metadata.MD) map[string]any {
+		values := md.Get(EvaluationContextMetadataKey)
+		if len(values) == 0 {
+			return nil
+		}
+		var forwarded forwardedEvaluationContext
+		if err := json.Unmarshal([]byte(values[0]), &forwarded); err != nil {
+			return nil
+		}
+		attributes := make(map[string]any, len(forwarded.Attributes)+1)
+		for key, value := range forwarded.Attributes {
+			attributes[key] = value
+		}
+		if forwarded.TargetingKey != "" {
+			attributes[TargetingKeyAttribute] = forwarded.TargetingKey
+		}
+		return attributes
+	}
+}
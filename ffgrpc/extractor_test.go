@@ -0,0 +1,102 @@
+package ffgrpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomaspoignant/go-feature-flag/ffgrpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestExtractors(t *testing.T) {
+	tests := []struct {
+		name      string
+		extractor ffgrpc.Extractor
+		ctx       context.Context
+		md        metadata.MD
+		want      map[string]any
+	}{
+		{
+			name:      "metadata",
+			extractor: ffgrpc.Metadata("x-country", "country"),
+			md:        metadata.Pairs("x-country", "FR", "x-country", "DE"),
+			want:      map[string]any{"country": "FR"},
+		},
+		{
+			name:      "metadata key is case insensitive",
+			extractor: ffgrpc.Metadata("X-Country", "country"),
+			md:        metadata.Pairs("x-country", "FR"),
+			want:      map[string]any{"country": "FR"},
+		},
+		{
+			name:      "missing metadata",
+			extractor: ffgrpc.Metadata("x-country", "country"),
+			md:        metadata.MD{},
+			want:      nil,
+		},
+		{
+			name:      "peer IP",
+			extractor: ffgrpc.PeerIP("ip"),
+			ctx: peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 4567},
+			}),
+			want: map[string]any{"ip": "10.0.0.1"},
+		},
+		{
+			name:      "peer IPv6",
+			extractor: ffgrpc.PeerIP("ip"),
+			ctx: peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.TCPAddr{IP: net.ParseIP("2001:db8::1"), Port: 4567},
+			}),
+			want: map[string]any{"ip": "2001:db8::1"},
+		},
+		{
+			name:      "peer without IP",
+			extractor: ffgrpc.PeerIP("ip"),
+			ctx: peer.NewContext(context.Background(), &peer.Peer{
+				Addr: &net.UnixAddr{Name: "/tmp/grpc.sock", Net: "unix"},
+			}),
+			want: nil,
+		},
+		{
+			name:      "no peer",
+			extractor: ffgrpc.PeerIP("ip"),
+			want:      nil,
+		},
+		{
+			name:      "forwarded evaluation context",
+			extractor: ffgrpc.ForwardedEvaluationContext(),
+			md: metadata.Pairs(ffgrpc.EvaluationContextMetadataKey,
+				`{"targetingKey":"user-123","attributes":{"plan":"pro"}}`),
+			want: map[string]any{ffgrpc.TargetingKeyAttribute: "user-123", "plan": "pro"},
+		},
+		{
+			name:      "invalid forwarded evaluation context",
+			extractor: ffgrpc.ForwardedEvaluationContext(),
+			md:        metadata.Pairs(ffgrpc.EvaluationContextMetadataKey, `{"targetingKey":`),
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := tt.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			assert.Equal(t, tt.want, tt.extractor(ctx, tt.md))
+		})
+	}
+}
+
+func TestEvaluationContext(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-user-id", "user-123", "x-country", "FR"))
+	got := ffgrpc.EvaluationContext(ctx,
+		ffgrpc.Metadata("x-user-id", ffgrpc.TargetingKeyAttribute),
+		ffgrpc.Metadata("x-country", "country"),
+	)
+	assert.Equal(t, "user-123", got.GetKey())
+	assert.Equal(t, map[string]any{"country": "FR"}, got.GetCustom())
+}
@@ -0,0 +1,113 @@
+package ffgrpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// TargetingKeyAttribute is the attribute used by the extractors to set the targeting key of the
+	// evaluation context.
+	TargetingKeyAttribute = ffcontext.TargetingKeyAttribute
+
+	// EvaluationContextMetadataKey is the metadata key used by the client interceptors to forward
+	// the evaluation context to the downstream services.
+	EvaluationContextMetadataKey = "goff-evaluation-context-bin"
+)
+
+// Extractor extracts attributes of the evaluation context from an incoming call.
+// md contains the incoming metadata of the call, ctx can be used to access the peer information.
+// An attribute named TargetingKeyAttribute sets the targeting key of the evaluation context.
+// It returns nil if nothing can be extracted from the call.
+type Extractor func(ctx context.Context, md metadata.MD) map[string]any
+
+// forwardedEvaluationContext is the representation of the evaluation context in the metadata.
+type forwardedEvaluationContext struct {
+	TargetingKey string         `json:"targetingKey"`
+	Attributes   map[string]any `json:"attributes,omitempty"`
+}
+
+// UnaryServerInterceptor returns a unary server interceptor storing the evaluation context built by
+// the extractors in the context of the call (see ffcontext.FromContext).
+// The extractors are called in order, if several extractors return the same attribute the last one wins.
+func UnaryServerInterceptor(extractors ...Extractor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(ffcontext.WithEvaluationContext(ctx, EvaluationContext(ctx, extractors...)), req)
+	}
+}
+
+// StreamServerInterceptor returns a stream server interceptor storing the evaluation context built by
+// the extractors in the context of the stream (see ffcontext.FromContext).
+// The extractors are called in order, if several extractors return the same attribute the last one wins.
+func StreamServerInterceptor(extractors ...Extractor) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		return handler(srv, &serverStream{
+			ServerStream: ss,
+			ctx:          ffcontext.WithEvaluationContext(ctx, EvaluationContext(ctx, extractors...)),
+		})
+	}
+}
+
+// EvaluationContext builds the evaluation context of an incoming call with the extractors.
+// If no extractor returns a targeting key, the targeting key is empty.
+func EvaluationContext(ctx context.Context, extractors ...Extractor) ffcontext.EvaluationContext {
+	md, _ := metadata.FromIncomingContext(ctx)
+	attributes := map[string]any{}
+	for _, extractor := range extractors {
+		for key, value := range extractor(ctx, md) {
+			attributes[key] = value
+		}
+	}
+	return ffcontext.NewEvaluationContextFromAttributes(attributes)
+}
+
+// UnaryClientInterceptor returns a unary client interceptor forwarding the evaluation context stored in
+// the context of the call (see ffcontext.WithEvaluationContext) in the outgoing metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(withForwardedEvaluationContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor returns a stream client interceptor forwarding the evaluation context stored in
+// the context of the stream (see ffcontext.WithEvaluationContext) in the outgoing metadata.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+		streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(withForwardedEvaluationContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+// withForwardedEvaluationContext adds the evaluation context stored in ctx to the outgoing metadata.
+// If there is no evaluation context in ctx, ctx is returned unchanged.
+func withForwardedEvaluationContext(ctx context.Context) context.Context {
+	evaluationCtx, ok := ffcontext.FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+	content, err := json.Marshal(forwardedEvaluationContext{
+		TargetingKey: evaluationCtx.GetKey(),
+		Attributes:   evaluationCtx.GetCustom(),
+	})
+	if err != nil {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, EvaluationContextMetadataKey, string(content))
+}
+
+// serverStream is a grpc.ServerStream with the context containing the evaluation context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
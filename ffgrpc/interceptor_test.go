@@ -0,0 +1,109 @@
+package ffgrpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/ffgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// recordingHealthServer records the evaluation context received by the calls.
+type recordingHealthServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	got chan ffcontext.Context
+}
+
+func (s *recordingHealthServer) Check(
+	ctx context.Context, _ *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	evaluationCtx, _ := ffcontext.FromContext(ctx)
+	s.got <- evaluationCtx
+	return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING}, nil
+}
+
+func (s *recordingHealthServer) Watch(
+	_ *grpc_health_v1.HealthCheckRequest, stream grpc_health_v1.Health_WatchServer) error {
+	evaluationCtx, _ := ffcontext.FromContext(stream.Context())
+	s.got <- evaluationCtx
+	return stream.Send(&grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_SERVING})
+}
+
+func newTestClient(t *testing.T, extractors ...ffgrpc.Extractor) (grpc_health_v1.HealthClient, chan ffcontext.Context) {
+	t.Helper()
+	listener := bufconn.Listen(1024 * 1024)
+	healthServer := &recordingHealthServer{got: make(chan ffcontext.Context, 1)}
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(ffgrpc.UnaryServerInterceptor(extractors...)),
+		grpc.ChainStreamInterceptor(ffgrpc.StreamServerInterceptor(extractors...)),
+	)
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(ffgrpc.UnaryClientInterceptor()),
+		grpc.WithChainStreamInterceptor(ffgrpc.StreamClientInterceptor()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return grpc_health_v1.NewHealthClient(conn), healthServer.got
+}
+
+func TestInterceptors_Unary(t *testing.T) {
+	client, got := newTestClient(t,
+		ffgrpc.ForwardedEvaluationContext(),
+		ffgrpc.Metadata("x-country", "country"),
+	)
+
+	ctx := ffcontext.WithEvaluationContext(context.Background(),
+		ffcontext.NewEvaluationContextBuilder("user-123").AddCustom("plan", "pro").AddCustom("beta", true).Build())
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-country", "FR")
+	_, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	evaluationCtx := <-got
+	assert.Equal(t, "user-123", evaluationCtx.GetKey())
+	assert.Equal(t, map[string]any{"plan": "pro", "beta": true, "country": "FR"}, evaluationCtx.GetCustom())
+}
+
+func TestInterceptors_Stream(t *testing.T) {
+	client, got := newTestClient(t,
+		ffgrpc.Metadata("x-user-id", ffgrpc.TargetingKeyAttribute),
+		ffgrpc.ForwardedEvaluationContext(),
+	)
+
+	ctx := ffcontext.WithEvaluationContext(context.Background(),
+		ffcontext.NewEvaluationContextBuilder("forwarded-user").AddCustom("plan", "pro").Build())
+	ctx = metadata.AppendToOutgoingContext(ctx, "x-user-id", "user-123")
+	stream, err := client.Watch(ctx, &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.NoError(t, err)
+
+	evaluationCtx := <-got
+	assert.Equal(t, "forwarded-user", evaluationCtx.GetKey(), "the last extractor wins")
+	assert.Equal(t, map[string]any{"plan": "pro"}, evaluationCtx.GetCustom())
+}
+
+func TestInterceptors_NoEvaluationContextToForward(t *testing.T) {
+	client, got := newTestClient(t, ffgrpc.ForwardedEvaluationContext())
+
+	_, err := client.Check(context.Background(), &grpc_health_v1.HealthCheckRequest{})
+	require.NoError(t, err)
+
+	evaluationCtx := <-got
+	assert.Equal(t, "", evaluationCtx.GetKey())
+	assert.Empty(t, evaluationCtx.GetCustom())
+}
@@ -0,0 +1,24 @@
+// Package ffgrpc contains gRPC interceptors building the evaluation context of each call.
+//
+// The server interceptors build the evaluation context from the incoming metadata and the peer
+// information with a list of extractors, and store it in the context of the call, so you can use the
+// variation functions with the Ctx suffix in your services.
+//
+//	server := grpc.NewServer(
+//	  grpc.ChainUnaryInterceptor(ffgrpc.UnaryServerInterceptor(
+//	    ffgrpc.ForwardedEvaluationContext(),
+//	    ffgrpc.Metadata("x-user-id", ffgrpc.TargetingKeyAttribute),
+//	    ffgrpc.PeerIP("ip"),
+//	  )),
+//	)
+//
+//	// in your service
+//	enabled, _ := ffclient.BoolVariationCtx(ctx, "new-checkout", false)
+//
+// The client interceptors forward the evaluation context stored in the context of the call to the
+// downstream services, they read it with the ForwardedEvaluationContext extractor.
+//
+//	conn, err := grpc.NewClient(target,
+//	  grpc.WithChainUnaryInterceptor(ffgrpc.UnaryClientInterceptor()),
+//	)
+package ffgrpc
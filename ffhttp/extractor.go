@@ -0,0 +1,141 @@
+package ffhttp
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Header extracts the value of a header into an attribute.
+func Header(headerName string, attribute string) Extractor {
+	return func(r *http.Request) map[string]any {
+		value := r.Header.Get(headerName)
+		if value == "" {
+			return nil
+		}
+		return map[string]any{attribute: value}
+	}
+}
+
+// Cookie extracts the value of a cookie into an attribute.
+func Cookie(cookieName string, attribute string) Extractor {
+	return func(r *http.Request) map[string]any {
+		cookie, err := r.Cookie(cookieName)
+		if err != nil || cookie.Value == "" {
+			return nil
+		}
+		return map[string]any{attribute: cookie.Value}
+	}
+}
+
+// ClientIP extracts the IP address of the client into an attribute, for a service behind one proxy.
+//
+// trustedHeaders (optional) are the headers set by your proxy containing the IP of the client
+// (ex: "X-Forwarded-For", "X-Real-IP"), they are checked in order before the remote address of the request.
+// For a list of IPs, the rightmost one is used: it is the one added by your proxy, the entries on its left
+// are sent by the client and can contain any value.
+// Use ClientIPBehindProxies if the requests go through several proxies.
+func ClientIP(attribute string, trustedHeaders ...string) Extractor {
+	return ClientIPBehindProxies(attribute, 1, trustedHeaders...)
+}
+
+// ClientIPBehindProxies extracts the IP address of the client into an attribute, for a service behind
+// a chain of trustedProxies proxies.
+//
+// For a list of IPs, the entry added by the first proxy of the chain is used, it is the trustedProxies-th
+// entry from the right. A header with fewer entries than trustedProxies, or with an invalid IP at this
+// position, is ignored.
+func ClientIPBehindProxies(attribute string, trustedProxies int, trustedHeaders ...string) Extractor {
+	return func(r *http.Request) map[string]any {
+		for _, header := range trustedHeaders {
+			value := r.Header.Get(header)
+			if value == "" {
+				continue
+			}
+			ips := strings.Split(value, ",")
+			if trustedProxies < 1 || len(ips) < trustedProxies {
+				continue
+			}
+			ip := strings.TrimSpace(ips[len(ips)-trustedProxies])
+			if net.ParseIP(ip) != nil {
+				return map[string]any{attribute: ip}
+			}
+		}
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		if net.ParseIP(host) == nil {
+			return nil
+		}
+		return map[string]any{attribute: host}
+	}
+}
+
+// JWTConfig is the configuration of the JWTClaims extractor.
+type JWTConfig struct {
+	// Claims maps the claims of the token to the attributes of the evaluation context
+	// (ex: {"sub": ffhttp.TargetingKeyAttribute, "plan": "plan"}).
+	Claims map[string]string
+
+	// Header (optional) is the header containing the token, the "Bearer " prefix is removed if present.
+	// Default: Authorization
+	Header string
+
+	// KeyFunc returns the key used to verify the signature of the token.
+	// It is required, unless SkipVerification is set.
+	KeyFunc jwt.Keyfunc
+
+	// ValidMethods are the signing algorithms accepted with KeyFunc (ex: []string{"RS256"}),
+	// a token signed with another algorithm is rejected.
+	// It is required with KeyFunc.
+	ValidMethods []string
+
+	// SkipVerification (optional) extracts the claims without verifying the signature of the token.
+	// Only set it if the token has already been verified by your authentication middleware.
+	// Default: false
+	SkipVerification bool
+}
+
+// JWTClaims extracts claims of a JWT token into attributes.
+// If the token is invalid, or if the configuration has neither a KeyFunc with ValidMethods nor
+// SkipVerification, nothing is extracted.
+func JWTClaims(config JWTConfig) Extractor {
+	header := config.Header
+	if header == "" {
+		header = "Authorization"
+	}
+	return func(r *http.Request) map[string]any {
+		token := r.Header.Get(header)
+		if len(token) > len("bearer ") && strings.EqualFold(token[:len("bearer ")], "bearer ") {
+			token = token[len("bearer "):]
+		}
+		if token == "" {
+			return nil
+		}
+
+		claims := jwt.MapClaims{}
+		var err error
+		switch {
+		case config.SkipVerification:
+			_, _, err = jwt.NewParser().ParseUnverified(token, claims)
+		case config.KeyFunc != nil && len(config.ValidMethods) > 0:
+			_, err = jwt.ParseWithClaims(token, claims, config.KeyFunc, jwt.WithValidMethods(config.ValidMethods))
+		default:
+			return nil
+		}
+		if err != nil {
+			return nil
+		}
+
+		attributes := map[string]any{}
+		for claim, attribute := range config.Claims {
+			if value, ok := claims[claim]; ok {
+				attributes[attribute] = value
+			}
+		}
+		return attributes
+	}
+}
@@ -0,0 +1,191 @@
+package ffhttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/ffhttp"
+)
+
+func signedToken(t *testing.T, key []byte, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func TestExtractors(t *testing.T) {
+	key := []byte("secret")
+	token := signedToken(t, key, jwt.MapClaims{"sub": "user-123", "plan": "pro", "admin": true})
+	claims := map[string]string{"sub": ffhttp.TargetingKeyAttribute, "plan": "plan", "missing": "missing"}
+	keyFunc := func(_ *jwt.Token) (any, error) { return key, nil }
+	wrongKeyFunc := func(_ *jwt.Token) (any, error) { return []byte("wrong"), nil }
+	hmacMethods := []string{jwt.SigningMethodHS256.Alg()}
+
+	tests := []struct {
+		name      string
+		extractor ffhttp.Extractor
+		request   func(r *http.Request)
+		want      map[string]any
+	}{
+		{
+			name:      "header",
+			extractor: ffhttp.Header("X-Country", "country"),
+			request:   func(r *http.Request) { r.Header.Set("X-Country", "FR") },
+			want:      map[string]any{"country": "FR"},
+		},
+		{
+			name:      "missing header",
+			extractor: ffhttp.Header("X-Country", "country"),
+			request:   func(_ *http.Request) {},
+			want:      nil,
+		},
+		{
+			name:      "cookie",
+			extractor: ffhttp.Cookie("session", ffhttp.TargetingKeyAttribute),
+			request:   func(r *http.Request) { r.AddCookie(&http.Cookie{Name: "session", Value: "abc"}) },
+			want:      map[string]any{ffhttp.TargetingKeyAttribute: "abc"},
+		},
+		{
+			name:      "missing cookie",
+			extractor: ffhttp.Cookie("session", ffhttp.TargetingKeyAttribute),
+			request:   func(_ *http.Request) {},
+			want:      nil,
+		},
+		{
+			name:      "client IP from the remote address",
+			extractor: ffhttp.ClientIP("ip"),
+			request: func(r *http.Request) {
+				r.RemoteAddr = "10.0.0.1:4567"
+				r.Header.Set("X-Forwarded-For", "1.2.3.4")
+			},
+			want: map[string]any{"ip": "10.0.0.1"},
+		},
+		{
+			name:      "client IP from a trusted header",
+			extractor: ffhttp.ClientIP("ip", "X-Real-IP", "X-Forwarded-For"),
+			request: func(r *http.Request) {
+				r.RemoteAddr = "10.0.0.1:4567"
+				r.Header.Set("X-Forwarded-For", "1.2.3.4, 10.0.0.2")
+			},
+			want: map[string]any{"ip": "10.0.0.2"},
+		},
+		{
+			name:      "client IP from a trusted header without a list",
+			extractor: ffhttp.ClientIP("ip", "X-Real-IP", "X-Forwarded-For"),
+			request: func(r *http.Request) {
+				r.RemoteAddr = "10.0.0.1:4567"
+				r.Header.Set("X-Real-IP", "1.2.3.4")
+			},
+			want: map[string]any{"ip": "1.2.3.4"},
+		},
+		{
+			name:      "client IP ignores the entries sent by the client",
+			extractor: ffhttp.ClientIP("ip", "X-Forwarded-For"),
+			request: func(r *http.Request) {
+				r.RemoteAddr = "10.0.0.1:4567"
+				r.Header.Set("X-Forwarded-For", "6.6.6.6, 1.2.3.4")
+			},
+			want: map[string]any{"ip": "1.2.3.4"},
+		},
+		{
+			name:      "client IP behind several proxies",
+			extractor: ffhttp.ClientIPBehindProxies("ip", 2, "X-Forwarded-For"),
+			request: func(r *http.Request) {
+				r.RemoteAddr = "10.0.0.1:4567"
+				r.Header.Set("X-Forwarded-For", "6.6.6.6, 1.2.3.4, 10.0.0.2")
+			},
+			want: map[string]any{"ip": "1.2.3.4"},
+		},
+		{
+			name:      "client IP with fewer entries than the trusted proxies",
+			extractor: ffhttp.ClientIPBehindProxies("ip", 2, "X-Forwarded-For"),
+			request: func(r *http.Request) {
+				r.RemoteAddr = "10.0.0.1:4567"
+				r.Header.Set("X-Forwarded-For", "1.2.3.4")
+			},
+			want: map[string]any{"ip": "10.0.0.1"},
+		},
+		{
+			name:      "client IP with an invalid trusted header",
+			extractor: ffhttp.ClientIP("ip", "X-Forwarded-For"),
+			request: func(r *http.Request) {
+				r.RemoteAddr = "10.0.0.1:4567"
+				r.Header.Set("X-Forwarded-For", "not-an-ip")
+			},
+			want: map[string]any{"ip": "10.0.0.1"},
+		},
+		{
+			name:      "JWT claims with verification skipped",
+			extractor: ffhttp.JWTClaims(ffhttp.JWTConfig{Claims: claims, SkipVerification: true}),
+			request:   func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+token) },
+			want:      map[string]any{ffhttp.TargetingKeyAttribute: "user-123", "plan": "pro"},
+		},
+		{
+			name:      "JWT claims without a key and without skipping the verification",
+			extractor: ffhttp.JWTClaims(ffhttp.JWTConfig{Claims: claims}),
+			request:   func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+token) },
+			want:      nil,
+		},
+		{
+			name:      "JWT claims with a valid signature",
+			extractor: ffhttp.JWTClaims(ffhttp.JWTConfig{Claims: claims, KeyFunc: keyFunc, ValidMethods: hmacMethods}),
+			request:   func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+token) },
+			want:      map[string]any{ffhttp.TargetingKeyAttribute: "user-123", "plan": "pro"},
+		},
+		{
+			name:      "JWT claims without valid methods",
+			extractor: ffhttp.JWTClaims(ffhttp.JWTConfig{Claims: claims, KeyFunc: keyFunc}),
+			request:   func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+token) },
+			want:      nil,
+		},
+		{
+			name: "JWT claims signed with an algorithm which is not valid",
+			extractor: ffhttp.JWTClaims(ffhttp.JWTConfig{
+				Claims:       claims,
+				KeyFunc:      keyFunc,
+				ValidMethods: []string{jwt.SigningMethodRS256.Alg()},
+			}),
+			request: func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+token) },
+			want:    nil,
+		},
+		{
+			name: "JWT claims with an invalid signature",
+			extractor: ffhttp.JWTClaims(ffhttp.JWTConfig{
+				Claims:       claims,
+				KeyFunc:      wrongKeyFunc,
+				ValidMethods: hmacMethods,
+			}),
+			request: func(r *http.Request) { r.Header.Set("Authorization", "Bearer "+token) },
+			want:    nil,
+		},
+		{
+			name:      "JWT claims in a custom header",
+			extractor: ffhttp.JWTClaims(ffhttp.JWTConfig{Claims: claims, Header: "X-Token", SkipVerification: true}),
+			request:   func(r *http.Request) { r.Header.Set("X-Token", token) },
+			want:      map[string]any{ffhttp.TargetingKeyAttribute: "user-123", "plan": "pro"},
+		},
+		{
+			name:      "invalid JWT",
+			extractor: ffhttp.JWTClaims(ffhttp.JWTConfig{Claims: claims, SkipVerification: true}),
+			request:   func(r *http.Request) { r.Header.Set("Authorization", "Bearer invalid") },
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			tt.request(r)
+			got := tt.extractor(r)
+			if tt.want == nil {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
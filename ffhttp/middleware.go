@@ -0,0 +1,40 @@
+package ffhttp
+
+import (
+	"net/http"
+
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+)
+
+// TargetingKeyAttribute is the attribute used by the extractors to set the targeting key of the
+// evaluation context.
+const TargetingKeyAttribute = ffcontext.TargetingKeyAttribute
+
+// Extractor extracts attributes of the evaluation context from a request.
+// An attribute named TargetingKeyAttribute sets the targeting key of the evaluation context.
+// It returns nil if nothing can be extracted from the request.
+type Extractor func(r *http.Request) map[string]any
+
+// Middleware returns a net/http middleware storing the evaluation context built by the extractors in
+// the context of the request (see ffcontext.FromContext).
+// The extractors are called in order, if several extractors return the same attribute the last one wins.
+func Middleware(extractors ...Extractor) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			evaluationCtx := EvaluationContext(r, extractors...)
+			next.ServeHTTP(w, r.WithContext(ffcontext.WithEvaluationContext(r.Context(), evaluationCtx)))
+		})
+	}
+}
+
+// EvaluationContext builds the evaluation context of a request with the extractors.
+// If no extractor returns a targeting key, the targeting key is empty.
+func EvaluationContext(r *http.Request, extractors ...Extractor) ffcontext.EvaluationContext {
+	attributes := map[string]any{}
+	for _, extractor := range extractors {
+		for key, value := range extractor(r) {
+			attributes[key] = value
+		}
+	}
+	return ffcontext.NewEvaluationContextFromAttributes(attributes)
+}
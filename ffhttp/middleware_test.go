@@ -0,0 +1,42 @@
+package ffhttp_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/ffhttp"
+)
+
+func TestMiddleware(t *testing.T) {
+	var got ffcontext.Context
+	handler := ffhttp.Middleware(
+		ffhttp.ClientIP(ffhttp.TargetingKeyAttribute),
+		ffhttp.Cookie("session", ffhttp.TargetingKeyAttribute),
+		ffhttp.Header("X-Country", "country"),
+	)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		var ok bool
+		got, ok = ffcontext.FromContext(r.Context())
+		require.True(t, ok)
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "10.0.0.1:4567"
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	r.Header.Set("X-Country", "FR")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Equal(t, "abc", got.GetKey())
+	assert.Equal(t, map[string]any{"country": "FR"}, got.GetCustom())
+}
+
+func TestEvaluationContext_NoTargetingKey(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Country", "FR")
+	got := ffhttp.EvaluationContext(r, ffhttp.Header("X-Country", "country"))
+	assert.Equal(t, "", got.GetKey())
+	assert.Equal(t, map[string]any{"country": "FR"}, got.GetCustom())
+}
@@ -0,0 +1,19 @@
+// Package ffhttp contains a net/http middleware building the evaluation context of each request.
+//
+// The evaluation context is built from the request with a list of extractors (headers, cookies,
+// JWT claims, client IP ...) and stored in the context of the request, so you can use the variation
+// functions with the Ctx suffix in your handlers.
+//
+//	handler := ffhttp.Middleware(
+//	  ffhttp.ClientIP(ffhttp.TargetingKeyAttribute),
+//	  ffhttp.JWTClaims(ffhttp.JWTConfig{
+//	    Claims:       map[string]string{"sub": ffhttp.TargetingKeyAttribute},
+//	    KeyFunc:      keyFunc,
+//	    ValidMethods: []string{"RS256"},
+//	  }),
+//	  ffhttp.Header("X-Country", "country"),
+//	)(mux)
+//
+//	// in your handler
+//	enabled, _ := ffclient.BoolVariationCtx(r.Context(), "new-checkout", false)
+package ffhttp
@@ -0,0 +1,61 @@
+package ffclient
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/retriever/fileretriever"
+)
+
+const environmentFlagConfig = `
+new-checkout:
+  variations:
+    enabled: true
+    disabled: false
+  defaultRule:
+    variation: disabled
+  environments:
+    staging:
+      defaultRule:
+        variation: enabled
+    production:
+      disable: true
+`
+
+func TestEnvironmentOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(environmentFlagConfig), 0o600))
+	evalCtx := ffcontext.NewEvaluationContext("user-key")
+
+	tests := []struct {
+		environment string
+		want        bool
+		wantReason  string
+	}{
+		{environment: "", want: false, wantReason: "STATIC"},
+		{environment: "development", want: false, wantReason: "STATIC"},
+		{environment: "staging", want: true, wantReason: "STATIC"},
+		{environment: "production", want: true, wantReason: "DISABLED"},
+	}
+	for _, tt := range tests {
+		t.Run("environment "+tt.environment, func(t *testing.T) {
+			g, err := New(Config{
+				PollingInterval: 10 * time.Minute,
+				Retriever:       &fileretriever.Retriever{Path: path},
+				Environment:     tt.environment,
+			})
+			require.NoError(t, err)
+			defer g.Close()
+
+			got, err := g.BoolVariationDetails("new-checkout", evalCtx, true)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.Value)
+			assert.Equal(t, tt.wantReason, string(got.Reason))
+		})
+	}
+}
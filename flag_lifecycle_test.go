@@ -0,0 +1,55 @@
+package ffclient
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/retriever/fileretriever"
+)
+
+func TestDeprecatedFlagEvaluation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+deprecated-flag:
+  variations:
+    enabled: true
+    disabled: false
+  defaultRule:
+    variation: enabled
+  metadata:
+    owner: team-checkout
+    deprecated: true
+active-flag:
+  variations:
+    enabled: true
+    disabled: false
+  defaultRule:
+    variation: enabled
+`), 0o600))
+	logs := &bytes.Buffer{}
+	g, err := New(Config{
+		PollingInterval: 10 * time.Minute,
+		Retriever:       &fileretriever.Retriever{Path: path},
+		LeveledLogger:   slog.New(slog.NewTextHandler(logs, nil)),
+	})
+	require.NoError(t, err)
+	defer g.Close()
+
+	evalCtx := ffcontext.NewEvaluationContext("user-key")
+	for i := 0; i < 3; i++ {
+		_, _ = g.BoolVariation("deprecated-flag", evalCtx, false)
+		_, _ = g.BoolVariation("active-flag", evalCtx, false)
+	}
+	_ = g.AllFlagsState(evalCtx)
+
+	assert.Equal(t, 1, strings.Count(logs.String(), "a deprecated flag has been evaluated"))
+	assert.Contains(t, logs.String(), "flag=deprecated-flag owner=team-checkout")
+}
@@ -0,0 +1,103 @@
+package ffclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+)
+
+const taggedFlagConfig = `
+frontend-flag:
+  variations:
+    enabled: true
+    disabled: false
+  defaultRule:
+    variation: enabled
+  tags: [frontend, checkout]
+backend-flag:
+  variations:
+    enabled: true
+    disabled: false
+  defaultRule:
+    variation: enabled
+  tags: [backend]
+untagged-flag:
+  variations:
+    enabled: true
+    disabled: false
+  defaultRule:
+    variation: enabled
+`
+
+func TestAllFlagsStateWithTags(t *testing.T) {
+	g, _ := newBindTestClient(t, taggedFlagConfig)
+	evalCtx := ffcontext.NewEvaluationContext("user-key")
+
+	tests := []struct {
+		name            string
+		tags            []string
+		flagsToEvaluate []string
+		want            []string
+	}{
+		{
+			name: "no tags",
+			want: []string{"frontend-flag", "backend-flag", "untagged-flag"},
+		},
+		{
+			name: "one tag",
+			tags: []string{"checkout"},
+			want: []string{"frontend-flag"},
+		},
+		{
+			name: "several tags",
+			tags: []string{"frontend", "backend"},
+			want: []string{"frontend-flag", "backend-flag"},
+		},
+		{
+			name: "unknown tag",
+			tags: []string{"mobile"},
+			want: []string{},
+		},
+		{
+			name:            "list of flags filtered by tags",
+			tags:            []string{"backend"},
+			flagsToEvaluate: []string{"frontend-flag", "backend-flag"},
+			want:            []string{"backend-flag"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := g.GetFlagStatesWithTags(evalCtx, tt.flagsToEvaluate, tt.tags)
+			assert.True(t, got.IsValid())
+			keys := make([]string, 0, len(got.GetFlags()))
+			for key := range got.GetFlags() {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, tt.want, keys)
+		})
+	}
+
+	allFlags := g.AllFlagsStateWithTags(evalCtx, "frontend")
+	assert.Len(t, allFlags.GetFlags(), 1)
+}
+
+func TestGetFlagTags(t *testing.T) {
+	g, _ := newBindTestClient(t, taggedFlagConfig)
+
+	tags, err := g.GetFlagTags("frontend-flag")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"frontend", "checkout"}, tags)
+
+	tags, err = g.GetFlagTags("backend-flag")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"backend"}, tags)
+
+	tags, err = g.GetFlagTags("untagged-flag")
+	require.NoError(t, err)
+	assert.Empty(t, tags)
+
+	_, err = g.GetFlagTags("missing-flag")
+	assert.Error(t, err)
+}
@@ -0,0 +1,28 @@
+package ffclient
+
+import (
+	"fmt"
+
+	"github.com/thomaspoignant/go-feature-flag/usage"
+)
+
+// GetFlagUsageReport returns the usage of the flags since the tracking started
+// (evaluations per flag and variation, unused, stale and unknown flags).
+// An error is returned if the usage tracking is not enabled (see Config.UsageTracking).
+func GetFlagUsageReport() (usage.Report, error) {
+	return ff.GetFlagUsageReport()
+}
+
+// GetFlagUsageReport returns the usage of the flags since the tracking started
+// (evaluations per flag and variation, unused, stale and unknown flags).
+// An error is returned if the usage tracking is not enabled (see Config.UsageTracking).
+func (g *GoFeatureFlag) GetFlagUsageReport() (usage.Report, error) {
+	if g == nil || g.usageTracker == nil {
+		return usage.Report{}, fmt.Errorf("the usage tracking is not enabled")
+	}
+	flags, err := g.GetFlagsFromCache()
+	if err != nil {
+		return usage.Report{}, err
+	}
+	return g.usageTracker.Report(flags), nil
+}
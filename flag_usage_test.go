@@ -0,0 +1,58 @@
+package ffclient_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ffclient "github.com/thomaspoignant/go-feature-flag"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/retriever/fileretriever"
+	"github.com/thomaspoignant/go-feature-flag/usage"
+)
+
+func TestGetFlagUsageReport(t *testing.T) {
+	goff, err := ffclient.New(ffclient.Config{
+		PollingInterval: 10 * time.Minute,
+		Retriever:       &fileretriever.Retriever{Path: "testdata/flag-config.yaml"},
+		UsageTracking:   &usage.Config{},
+	})
+	require.NoError(t, err)
+	defer goff.Close()
+
+	evaluationCtx := ffcontext.NewEvaluationContext("random-key")
+	_, _ = goff.BoolVariation("test-flag", evaluationCtx, false)
+	_, _ = goff.BoolVariation("test-flag", evaluationCtx, false)
+	_, _ = goff.BoolVariation("unknown-flag", evaluationCtx, false)
+	_ = goff.AllFlagsState(evaluationCtx)
+
+	report, err := goff.GetFlagUsageReport()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), report.Flags["test-flag"].EvaluationCount)
+	assert.Equal(t, int64(3), report.Flags["test-flag"].Variations["True"].EvaluationCount)
+	assert.Contains(t, report.SingleVariation, "test-flag")
+	assert.Equal(t, int64(1), report.UnknownFlags["unknown-flag"].EvaluationCount)
+	assert.Empty(t, report.Unused, "all the flags have been evaluated by AllFlagsState")
+}
+
+func TestGetFlagUsageReport_NotEnabled(t *testing.T) {
+	goff, err := ffclient.New(ffclient.Config{
+		PollingInterval: 10 * time.Minute,
+		Retriever:       &fileretriever.Retriever{Path: "testdata/flag-config.yaml"},
+	})
+	require.NoError(t, err)
+	defer goff.Close()
+
+	_, err = goff.GetFlagUsageReport()
+	assert.EqualError(t, err, "the usage tracking is not enabled")
+}
+
+func TestGetFlagUsageReport_InvalidConfig(t *testing.T) {
+	_, err := ffclient.New(ffclient.Config{
+		PollingInterval: 10 * time.Minute,
+		Retriever:       &fileretriever.Retriever{Path: "testdata/flag-config.yaml"},
+		UsageTracking:   &usage.Config{StaleAfter: -1},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package ffclient_test
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ffclient "github.com/thomaspoignant/go-feature-flag"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/guardedrollout"
+	"github.com/thomaspoignant/go-feature-flag/notifier"
+	"github.com/thomaspoignant/go-feature-flag/retriever/fileretriever"
+	"github.com/thomaspoignant/go-feature-flag/testutils/mock"
+)
+
+func TestGuardedRollout(t *testing.T) {
+	content := fmt.Sprintf(`
+new-checkout:
+  variations:
+    old: "old"
+    new: "new"
+  defaultRule:
+    progressiveRollout:
+      initial:
+        variation: old
+        percentage: 0
+        date: %s
+      end:
+        variation: new
+        percentage: 100
+        date: %s
+`,
+		time.Now().Add(-1*time.Hour).Format(time.RFC3339),
+		time.Now().Add(1*time.Hour).Format(time.RFC3339))
+
+	flagFile, err := os.CreateTemp("", "guarded-rollout-*.yaml")
+	require.NoError(t, err)
+	defer func() { _ = os.Remove(flagFile.Name()) }()
+	require.NoError(t, os.WriteFile(flagFile.Name(), []byte(content), os.ModePerm))
+
+	mockNotifier := &mock.Notifier{}
+	gffClient, err := ffclient.New(ffclient.Config{
+		PollingInterval:       1 * time.Minute,
+		Retriever:             &fileretriever.Retriever{Path: flagFile.Name()},
+		Notifiers:             []notifier.Notifier{mockNotifier},
+		DisableNotifierOnInit: true,
+		GuardedRollouts: []guardedrollout.Guard{
+			{
+				FlagKey:       "new-checkout",
+				Signal:        &guardedrollout.ErrorRateSignal{EventName: "checkout", MinSampleSize: 4},
+				Threshold:     0.5,
+				CheckInterval: 50 * time.Millisecond,
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer gffClient.Close()
+	assert.Empty(t, gffClient.GetGuardedRolloutStates())
+
+	for i := 0; i < 4; i++ {
+		gffClient.Track("checkout", ffcontext.NewEvaluationContext(fmt.Sprintf("user-%d", i)),
+			map[string]any{"variation": "new", "error": i > 0})
+	}
+
+	assert.Eventually(t, func() bool {
+		return len(gffClient.GetGuardedRolloutStates()) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return mockNotifier.GetNotifyCalls() == 1
+	}, time.Second, 10*time.Millisecond)
+
+	for i := 0; i < 20; i++ {
+		value, err := gffClient.StringVariation(
+			"new-checkout", ffcontext.NewEvaluationContext(fmt.Sprintf("user-%d", i)), "default")
+		assert.NoError(t, err)
+		assert.Equal(t, "old", value)
+	}
+	allFlags := gffClient.AllFlagsState(ffcontext.NewEvaluationContext("user-1"))
+	assert.Equal(t, "old", allFlags.GetFlags()["new-checkout"].Value)
+}
+
+func TestGuardedRolloutInvalidConfig(t *testing.T) {
+	_, err := ffclient.New(ffclient.Config{
+		Retriever:       &fileretriever.Retriever{Path: "testdata/flag-config.yaml"},
+		GuardedRollouts: []guardedrollout.Guard{{FlagKey: "test-flag"}},
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,135 @@
+package guardedrollout
+
+import (
+	"context"
+	"sync"
+
+	"github.com/thomaspoignant/go-feature-flag/exporter"
+)
+
+const (
+	defaultVariationDetailKey = "variation"
+	defaultErrorDetailKey     = "error"
+	defaultFlagDetailKey      = "flag"
+)
+
+var (
+	_ Signal                = (*ErrorRateSignal)(nil)
+	_ TrackingEventObserver = (*ErrorRateSignal)(nil)
+)
+
+// ErrorRateSignal is a Signal computing the ratio of errors in the events tracked with ffclient.Track.
+//
+// The tracked events should be tagged with the variation served, and with a boolean telling if the
+// event is an error:
+//
+//	ffclient.Track("checkout", evalCtx, map[string]any{"variation": "new-flow", "error": true})
+//
+// The ratio is computed on the events received since the previous check of the signal.
+type ErrorRateSignal struct {
+	// EventName (optional) is the name of the tracked events to use, if empty all the events are used.
+	EventName string
+
+	// VariationDetailKey (optional) is the key in the tracking details containing the variation served.
+	// Default: variation
+	VariationDetailKey string
+
+	// ErrorDetailKey (optional) is the key in the tracking details set to true when the event is an error.
+	// Default: error
+	ErrorDetailKey string
+
+	// FlagDetailKey (optional) is the key in the tracking details containing the flag key.
+	// If the key is present in an event, the event is used only for this flag.
+	// Default: flag
+	FlagDetailKey string
+
+	// MinSampleSize (optional) is the minimum number of events needed to compute the ratio.
+	// Default: 1
+	MinSampleSize int
+
+	mutex    sync.Mutex
+	counters map[errorRateCounterKey]*errorRateCounter
+}
+
+type errorRateCounterKey struct {
+	flagKey   string
+	variation string
+}
+
+type errorRateCounter struct {
+	total  int
+	errors int
+}
+
+// ObserveTrackingEvent counts the tracked event if it matches the configuration of the signal.
+func (s *ErrorRateSignal) ObserveTrackingEvent(event exporter.TrackingEvent) {
+	if s.EventName != "" && event.Key != s.EventName {
+		return
+	}
+	variation, ok := event.TrackingDetails[s.getVariationDetailKey()].(string)
+	if !ok || variation == "" {
+		return
+	}
+	flagKey, _ := event.TrackingDetails[s.getFlagDetailKey()].(string)
+	isError, _ := event.TrackingDetails[s.getErrorDetailKey()].(bool)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.counters == nil {
+		s.counters = make(map[errorRateCounterKey]*errorRateCounter)
+	}
+	key := errorRateCounterKey{flagKey: flagKey, variation: variation}
+	counter, ok := s.counters[key]
+	if !ok {
+		counter = &errorRateCounter{}
+		s.counters[key] = counter
+	}
+	counter.total++
+	if isError {
+		counter.errors++
+	}
+}
+
+// Value returns the ratio of errors for the variation and resets the counters of this variation.
+func (s *ErrorRateSignal) Value(_ context.Context, flagKey string, variation string) (float64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	total, errors := 0, 0
+	// events without a flag key are shared between all the flags.
+	for _, key := range []errorRateCounterKey{{flagKey: flagKey, variation: variation}, {variation: variation}} {
+		if counter, ok := s.counters[key]; ok {
+			total += counter.total
+			errors += counter.errors
+		}
+	}
+
+	minSampleSize := max(s.MinSampleSize, 1)
+	if total < minSampleSize {
+		return 0, ErrNotEnoughData
+	}
+	delete(s.counters, errorRateCounterKey{flagKey: flagKey, variation: variation})
+	delete(s.counters, errorRateCounterKey{variation: variation})
+	return float64(errors) / float64(total), nil
+}
+
+func (s *ErrorRateSignal) getVariationDetailKey() string {
+	if s.VariationDetailKey == "" {
+		return defaultVariationDetailKey
+	}
+	return s.VariationDetailKey
+}
+
+func (s *ErrorRateSignal) getErrorDetailKey() string {
+	if s.ErrorDetailKey == "" {
+		return defaultErrorDetailKey
+	}
+	return s.ErrorDetailKey
+}
+
+func (s *ErrorRateSignal) getFlagDetailKey() string {
+	if s.FlagDetailKey == "" {
+		return defaultFlagDetailKey
+	}
+	return s.FlagDetailKey
+}
@@ -0,0 +1,49 @@
+package guardedrollout_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomaspoignant/go-feature-flag/exporter"
+	"github.com/thomaspoignant/go-feature-flag/guardedrollout"
+)
+
+func trackingEvent(name string, details map[string]any) exporter.TrackingEvent {
+	return exporter.TrackingEvent{Kind: "tracking", Key: name, TrackingDetails: details}
+}
+
+func TestErrorRateSignal_Value(t *testing.T) {
+	s := &guardedrollout.ErrorRateSignal{EventName: "checkout", MinSampleSize: 4}
+	s.ObserveTrackingEvent(trackingEvent("checkout", map[string]any{"variation": "B", "error": true}))
+	s.ObserveTrackingEvent(trackingEvent("checkout", map[string]any{"variation": "B", "error": false}))
+	s.ObserveTrackingEvent(trackingEvent("checkout", map[string]any{"variation": "B"}))
+	// ignored: other event, no variation, other variation
+	s.ObserveTrackingEvent(trackingEvent("login", map[string]any{"variation": "B", "error": true}))
+	s.ObserveTrackingEvent(trackingEvent("checkout", map[string]any{"error": true}))
+	s.ObserveTrackingEvent(trackingEvent("checkout", map[string]any{"variation": "A", "error": true}))
+
+	_, err := s.Value(context.Background(), "my-flag", "B")
+	assert.ErrorIs(t, err, guardedrollout.ErrNotEnoughData)
+
+	s.ObserveTrackingEvent(trackingEvent("checkout", map[string]any{"variation": "B", "error": true, "flag": "my-flag"}))
+	// ignored: event for another flag
+	s.ObserveTrackingEvent(trackingEvent("checkout", map[string]any{"variation": "B", "error": true, "flag": "other"}))
+
+	got, err := s.Value(context.Background(), "my-flag", "B")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, got)
+
+	// the counters are reset after each value
+	_, err = s.Value(context.Background(), "my-flag", "B")
+	assert.ErrorIs(t, err, guardedrollout.ErrNotEnoughData)
+}
+
+func TestErrorRateSignal_CustomDetailKeys(t *testing.T) {
+	s := &guardedrollout.ErrorRateSignal{VariationDetailKey: "variant", ErrorDetailKey: "failed"}
+	s.ObserveTrackingEvent(trackingEvent("any", map[string]any{"variant": "on", "failed": true}))
+
+	got, err := s.Value(context.Background(), "my-flag", "on")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, got)
+}
@@ -0,0 +1,94 @@
+package guardedrollout
+
+import (
+	"fmt"
+	"time"
+)
+
+// Action is what we do on the progressive rollout when the signal crosses the threshold.
+type Action = string
+
+const (
+	// ActionPause stops the progression of the rollout, the percentage reached when the
+	// threshold has been crossed is served until the rollout configuration changes.
+	ActionPause Action = "pause"
+	// ActionRollback serves the initial variation of the rollout to everyone until the
+	// rollout configuration changes.
+	ActionRollback Action = "rollback"
+)
+
+// DefaultCheckInterval is the interval used to check the signal if none is provided.
+const DefaultCheckInterval = 1 * time.Minute
+
+// Guard is the configuration of a guarded progressive rollout.
+type Guard struct {
+	// FlagKey is the key of the flag containing the progressive rollout.
+	FlagKey string
+
+	// RuleName (optional) is the name of the targeting rule containing the progressive rollout.
+	// If empty, we guard the progressive rollout of the default rule.
+	RuleName string
+
+	// Signal is the health signal watched during the rollout.
+	Signal Signal
+
+	// Threshold is the value of the signal above which the Action is applied.
+	Threshold float64
+
+	// Action (optional) is what we do when the threshold is crossed (pause or rollback).
+	// Default: rollback
+	Action Action
+
+	// CheckInterval (optional) is the interval between two checks of the signal.
+	// Default: 1 minute
+	CheckInterval time.Duration
+}
+
+// IsValid checks that the guard is correctly configured.
+func (g *Guard) IsValid() error {
+	if g.FlagKey == "" {
+		return fmt.Errorf("invalid guarded rollout: flag key is mandatory")
+	}
+	if g.Signal == nil {
+		return fmt.Errorf("invalid guarded rollout for flag %s: signal is mandatory", g.FlagKey)
+	}
+	if action := g.GetAction(); action != ActionPause && action != ActionRollback {
+		return fmt.Errorf("invalid guarded rollout for flag %s: unknown action %s", g.FlagKey, action)
+	}
+	if g.CheckInterval < 0 {
+		return fmt.Errorf("invalid guarded rollout for flag %s: check interval should be positive", g.FlagKey)
+	}
+	return nil
+}
+
+// GetAction returns the action of the guard, or the default one if not set.
+func (g *Guard) GetAction() Action {
+	if g.Action == "" {
+		return ActionRollback
+	}
+	return g.Action
+}
+
+// GetCheckInterval returns the check interval of the guard, or the default one if not set.
+func (g *Guard) GetCheckInterval() time.Duration {
+	if g.CheckInterval <= 0 {
+		return DefaultCheckInterval
+	}
+	return g.CheckInterval
+}
+
+// State is the state of a guarded rollout after the threshold has been crossed.
+type State struct {
+	// FlagKey is the key of the guarded flag.
+	FlagKey string `json:"flagKey"`
+	// RuleName is the name of the guarded rule, empty for the default rule.
+	RuleName string `json:"ruleName,omitempty"`
+	// Action is the action applied to the rollout.
+	Action Action `json:"action"`
+	// Percentage is the percentage of the end variation served when the threshold has been crossed.
+	Percentage float64 `json:"percentage"`
+	// SignalValue is the value of the signal that crossed the threshold.
+	SignalValue float64 `json:"signalValue"`
+	// Date is the date when the threshold has been crossed.
+	Date time.Time `json:"date"`
+}
@@ -0,0 +1,66 @@
+package guardedrollout_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomaspoignant/go-feature-flag/guardedrollout"
+)
+
+func TestGuard_IsValid(t *testing.T) {
+	tests := []struct {
+		name    string
+		guard   guardedrollout.Guard
+		wantErr assert.ErrorAssertionFunc
+	}{
+		{
+			name: "valid guard",
+			guard: guardedrollout.Guard{
+				FlagKey: "my-flag",
+				Signal:  &guardedrollout.ErrorRateSignal{},
+				Action:  guardedrollout.ActionPause,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:    "missing flag key",
+			guard:   guardedrollout.Guard{Signal: &guardedrollout.ErrorRateSignal{}},
+			wantErr: assert.Error,
+		},
+		{
+			name:    "missing signal",
+			guard:   guardedrollout.Guard{FlagKey: "my-flag"},
+			wantErr: assert.Error,
+		},
+		{
+			name: "unknown action",
+			guard: guardedrollout.Guard{
+				FlagKey: "my-flag",
+				Signal:  &guardedrollout.ErrorRateSignal{},
+				Action:  "stop",
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "negative check interval",
+			guard: guardedrollout.Guard{
+				FlagKey:       "my-flag",
+				Signal:        &guardedrollout.ErrorRateSignal{},
+				CheckInterval: -1 * time.Second,
+			},
+			wantErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.wantErr(t, tt.guard.IsValid())
+		})
+	}
+}
+
+func TestGuard_Defaults(t *testing.T) {
+	g := guardedrollout.Guard{}
+	assert.Equal(t, guardedrollout.ActionRollback, g.GetAction())
+	assert.Equal(t, guardedrollout.DefaultCheckInterval, g.GetCheckInterval())
+}
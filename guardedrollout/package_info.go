@@ -0,0 +1,19 @@
+// Package guardedrollout defines the guards you can put on a progressive rollout.
+//
+// A guard is watching a health signal while a progressive rollout is running, if the signal
+// crosses the threshold the rollout is paused (the percentage stops increasing) or rolled back
+// (the initial variation is served to everyone).
+//
+//	ffclient.Init(ffclient.Config{
+//	  //...
+//	  GuardedRollouts: []guardedrollout.Guard{
+//	    {
+//	      FlagKey:   "new-checkout",
+//	      Signal:    &guardedrollout.ErrorRateSignal{EventName: "checkout", MinSampleSize: 100},
+//	      Threshold: 0.05,
+//	      Action:    guardedrollout.ActionRollback,
+//	    },
+//	  },
+//	  //...
+//	})
+package guardedrollout
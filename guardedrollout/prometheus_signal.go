@@ -0,0 +1,148 @@
+package guardedrollout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thomaspoignant/go-feature-flag/internal"
+)
+
+var _ Signal = (*PrometheusSignal)(nil)
+
+// PrometheusSignal is a Signal polling a value from a Prometheus-compatible HTTP API.
+//
+// The query is sent to the instant query endpoint (/api/v1/query) and the first sample of the result
+// is used as the value of the signal.
+// The placeholders {{flag}} and {{variation}} in the query are replaced by the flag key and the
+// variation served by the rollout.
+type PrometheusSignal struct {
+	// URL is the base URL of the Prometheus-compatible API (ex: http://localhost:9090).
+	URL string
+
+	// Query is the PromQL query returning the value of the signal.
+	// ex: sum(rate(http_errors_total{variation="{{variation}}"}[5m])) / sum(rate(http_requests_total[5m]))
+	Query string
+
+	// Header (optional) is added to the request (ex: Authorization).
+	Header http.Header
+
+	// Timeout (optional) we should wait before failing.
+	// Default: 10 seconds
+	Timeout time.Duration
+
+	httpClient internal.HTTPClient
+	mutex      sync.Mutex
+}
+
+// prometheusQueryResponse is the format of the response of the Prometheus instant query API.
+type prometheusQueryResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type prometheusSample struct {
+	Value []any `json:"value"`
+}
+
+// SetHTTPClient is here if you want to override the default http.Client we are using.
+// It is also used for the tests.
+func (s *PrometheusSignal) SetHTTPClient(client internal.HTTPClient) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.httpClient = client
+}
+
+// Value queries the Prometheus API and returns the value of the first sample.
+func (s *PrometheusSignal) Value(ctx context.Context, flagKey string, variation string) (float64, error) {
+	if s.URL == "" || s.Query == "" {
+		return 0, fmt.Errorf("prometheus signal: URL and Query are mandatory")
+	}
+	query := strings.NewReplacer("{{flag}}", flagKey, "{{variation}}", variation).Replace(s.Query)
+	endpoint := strings.TrimSuffix(s.URL, "/") + "/api/v1/query?query=" + url.QueryEscape(query)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	for name, values := range s.Header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+
+	resp, err := s.getHTTPClient().Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode > 399 {
+		return 0, fmt.Errorf("prometheus signal: request to %s failed with code %d", s.URL, resp.StatusCode)
+	}
+	return parsePrometheusResponse(body)
+}
+
+// parsePrometheusResponse extracts the value of the first sample of a vector or scalar result.
+func parsePrometheusResponse(body []byte) (float64, error) {
+	var response prometheusQueryResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return 0, fmt.Errorf("prometheus signal: invalid response: %w", err)
+	}
+	if response.Status != "success" {
+		return 0, fmt.Errorf("prometheus signal: query failed: %s", response.Error)
+	}
+
+	var value []any
+	switch response.Data.ResultType {
+	case "vector":
+		var samples []prometheusSample
+		if err := json.Unmarshal(response.Data.Result, &samples); err != nil {
+			return 0, fmt.Errorf("prometheus signal: invalid vector result: %w", err)
+		}
+		if len(samples) == 0 {
+			return 0, ErrNotEnoughData
+		}
+		value = samples[0].Value
+	case "scalar":
+		if err := json.Unmarshal(response.Data.Result, &value); err != nil {
+			return 0, fmt.Errorf("prometheus signal: invalid scalar result: %w", err)
+		}
+	default:
+		return 0, fmt.Errorf("prometheus signal: unsupported result type %s", response.Data.ResultType)
+	}
+
+	// a sample is a pair [timestamp, "value"]
+	if len(value) != 2 {
+		return 0, fmt.Errorf("prometheus signal: invalid sample %v", value)
+	}
+	str, ok := value[1].(string)
+	if !ok {
+		return 0, fmt.Errorf("prometheus signal: invalid sample value %v", value[1])
+	}
+	return strconv.ParseFloat(str, 64)
+}
+
+func (s *PrometheusSignal) getHTTPClient() internal.HTTPClient {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.httpClient == nil {
+		s.httpClient = internal.HTTPClientWithTimeout(s.Timeout)
+	}
+	return s.httpClient
+}
@@ -0,0 +1,87 @@
+package guardedrollout_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomaspoignant/go-feature-flag/guardedrollout"
+)
+
+func TestPrometheusSignal_Value(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       float64
+		wantErr    assert.ErrorAssertionFunc
+	}{
+		{
+			name:       "vector result",
+			statusCode: http.StatusOK,
+			body: `{"status":"success","data":{"resultType":"vector","result":[` +
+				`{"metric":{},"value":[1715000000.123,"0.042"]}]}}`,
+			want:    0.042,
+			wantErr: assert.NoError,
+		},
+		{
+			name:       "scalar result",
+			statusCode: http.StatusOK,
+			body:       `{"status":"success","data":{"resultType":"scalar","result":[1715000000.123,"12"]}}`,
+			want:       12,
+			wantErr:    assert.NoError,
+		},
+		{
+			name:       "empty vector",
+			statusCode: http.StatusOK,
+			body:       `{"status":"success","data":{"resultType":"vector","result":[]}}`,
+			wantErr: func(t assert.TestingT, err error, _ ...any) bool {
+				return assert.ErrorIs(t, err, guardedrollout.ErrNotEnoughData)
+			},
+		},
+		{
+			name:       "query error",
+			statusCode: http.StatusBadRequest,
+			body:       `{"status":"error","error":"parse error"}`,
+			wantErr:    assert.Error,
+		},
+		{
+			name:       "unsupported result type",
+			statusCode: http.StatusOK,
+			body:       `{"status":"success","data":{"resultType":"matrix","result":[]}}`,
+			wantErr:    assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var receivedQuery, receivedAuth string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/api/v1/query", r.URL.Path)
+				receivedQuery = r.URL.Query().Get("query")
+				receivedAuth = r.Header.Get("Authorization")
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			s := &guardedrollout.PrometheusSignal{
+				URL:    srv.URL + "/",
+				Query:  `sum(rate(errors_total{flag="{{flag}}",variation="{{variation}}"}[5m]))`,
+				Header: http.Header{"Authorization": []string{"Bearer token"}},
+			}
+			got, err := s.Value(context.Background(), "my-flag", "B")
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, `sum(rate(errors_total{flag="my-flag",variation="B"}[5m]))`, receivedQuery)
+			assert.Equal(t, "Bearer token", receivedAuth)
+		})
+	}
+}
+
+func TestPrometheusSignal_MissingConfiguration(t *testing.T) {
+	s := &guardedrollout.PrometheusSignal{}
+	_, err := s.Value(context.Background(), "my-flag", "B")
+	assert.Error(t, err)
+}
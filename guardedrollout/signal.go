@@ -0,0 +1,25 @@
+package guardedrollout
+
+import (
+	"context"
+	"errors"
+
+	"github.com/thomaspoignant/go-feature-flag/exporter"
+)
+
+// ErrNotEnoughData is returned by a Signal when it does not have enough data to compute a value.
+// In that case the rollout continues as usual.
+var ErrNotEnoughData = errors.New("not enough data to compute the signal")
+
+// Signal is the interface to represent a health signal watched during a progressive rollout.
+type Signal interface {
+	// Value returns the current value of the signal for the variation served by the rollout.
+	Value(ctx context.Context, flagKey string, variation string) (float64, error)
+}
+
+// TrackingEventObserver is the interface a Signal can implement to receive the events
+// tracked with ffclient.Track.
+type TrackingEventObserver interface {
+	// ObserveTrackingEvent is called for every tracked event.
+	ObserveTrackingEvent(event exporter.TrackingEvent)
+}
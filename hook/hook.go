@@ -0,0 +1,72 @@
+package hook
+
+import (
+	"context"
+
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/model"
+)
+
+// Context contains the information about the evaluation in progress.
+type Context struct {
+	// Context is the context.Context of the evaluation.
+	// It is context.Background() when the variation functions without the Ctx suffix are used.
+	Context context.Context
+
+	// FlagKey is the key of the flag evaluated.
+	FlagKey string
+
+	// FlagType is the type expected by the caller ("bool", "string", "int", "float64",
+	// "[]interface{}", "map[string]interface{}" or "interface{}" when the type is not known).
+	FlagType string
+
+	// DefaultValue is the default value provided by the caller.
+	// It is nil when the flag is evaluated by AllFlagsState.
+	DefaultValue any
+
+	// EvaluationContext is the evaluation context used for the evaluation.
+	// If a Before hook returns a new evaluation context, this field contains the new one
+	// for the next hooks.
+	EvaluationContext ffcontext.Context
+}
+
+// Hook is called around the evaluation of a flag.
+//
+// The Before hooks are called in the order of the configuration, the After, Error and Finally
+// hooks are called in the reverse order.
+type Hook interface {
+	// Before is called before the evaluation of the flag.
+	// If it returns an evaluation context, this context is used for the evaluation instead of the
+	// one provided by the caller, return nil to keep the current one.
+	// If it returns an error, the flag is not evaluated and the default value is returned.
+	Before(hookCtx Context) (ffcontext.Context, error)
+
+	// After is called after a successful evaluation of the flag.
+	// If it returns an error, the default value is returned instead of the evaluated value.
+	After(hookCtx Context, details model.RawVarResult) error
+
+	// Error is called when the evaluation or one of the hooks returned an error.
+	Error(hookCtx Context, err error)
+
+	// Finally is called at the end of every evaluation with the result returned to the caller.
+	Finally(hookCtx Context, details model.RawVarResult)
+}
+
+// Base is a Hook doing nothing, you can embed it in your hook to implement only the stages you need.
+type Base struct{}
+
+// Before is not changing the evaluation context.
+func (Base) Before(_ Context) (ffcontext.Context, error) {
+	return nil, nil
+}
+
+// After is doing nothing.
+func (Base) After(_ Context, _ model.RawVarResult) error {
+	return nil
+}
+
+// Error is doing nothing.
+func (Base) Error(_ Context, _ error) {}
+
+// Finally is doing nothing.
+func (Base) Finally(_ Context, _ model.RawVarResult) {}
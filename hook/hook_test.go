@@ -0,0 +1,30 @@
+package hook_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/hook"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/model"
+)
+
+func TestBase(t *testing.T) {
+	var h hook.Hook = hook.Base{}
+	hookCtx := hook.Context{
+		FlagKey:           "my-flag",
+		FlagType:          "bool",
+		DefaultValue:      false,
+		EvaluationContext: ffcontext.NewEvaluationContext("random-key"),
+	}
+
+	evaluationCtx, err := h.Before(hookCtx)
+	assert.NoError(t, err)
+	assert.Nil(t, evaluationCtx)
+	assert.NoError(t, h.After(hookCtx, model.RawVarResult{Value: true}))
+	assert.NotPanics(t, func() {
+		h.Error(hookCtx, errors.New("error"))
+		h.Finally(hookCtx, model.RawVarResult{Value: true})
+	})
+}
@@ -0,0 +1,12 @@
+// Package hook defines the hooks called around the evaluation of a flag.
+//
+// A hook can enrich or replace the evaluation context before the evaluation, observe the
+// result of the evaluation and inject errors. It is useful to add logging, validation or
+// metrics without wrapping every call to the variation functions.
+//
+//	ffclient.Init(ffclient.Config{
+//	  //...
+//	  Hooks: []hook.Hook{&myLoggingHook{}},
+//	  //...
+//	})
+package hook
@@ -0,0 +1,230 @@
+package ffclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/hook"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagstate"
+	"github.com/thomaspoignant/go-feature-flag/internal/telemetry"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/model"
+)
+
+// variationFunc is the signature of the functions evaluating a flag.
+type variationFunc[T model.JSONType] func(
+	g *GoFeatureFlag,
+	flagKey string,
+	evaluationCtx ffcontext.Context,
+	sdkDefaultValue T,
+	expectedType string,
+) (model.VariationResult[T], error)
+
+// evaluateWithHooks evaluates the flag with the hooks of the configuration around the evaluation,
+// and notifies the exporters with the final result.
+func evaluateWithHooks[T model.JSONType](
+	ctx context.Context,
+	g *GoFeatureFlag,
+	flagKey string,
+	evaluationCtx ffcontext.Context,
+	sdkDefaultValue T,
+	expectedType string,
+	evaluate variationFunc[T],
+) (model.VariationResult[T], error) {
+	var hooks []hook.Hook
+	var instrumentation *telemetry.Telemetry
+	if g != nil {
+		hooks = g.config.Hooks
+		instrumentation = g.telemetry
+	}
+	ctx, evaluation := instrumentation.StartEvaluation(ctx, flagKey)
+	res, evaluationCtx, err := runHooksAndEvaluate(
+		ctx, g, hooks, flagKey, evaluationCtx, sdkDefaultValue, expectedType, evaluate)
+	evaluation.End(evaluationCtx, toRawVarResult(res))
+	if g != nil && res.Reason != flag.ReasonOffline {
+		g.usageTracker.Record(flagKey, res.VariationType, res.ErrorCode)
+		g.deprecationLogger.Log(flagKey, res.Metadata)
+	}
+	notifyVariation(g, flagKey, evaluationCtx, res)
+	return res, err
+}
+
+// runHooksAndEvaluate evaluates the flag with the hooks around the evaluation.
+// It returns the evaluation context used, since a hook can replace it.
+func runHooksAndEvaluate[T model.JSONType](
+	ctx context.Context,
+	g *GoFeatureFlag,
+	hooks []hook.Hook,
+	flagKey string,
+	evaluationCtx ffcontext.Context,
+	sdkDefaultValue T,
+	expectedType string,
+	evaluate variationFunc[T],
+) (model.VariationResult[T], ffcontext.Context, error) {
+	if len(hooks) == 0 {
+		res, err := evaluate(g, flagKey, evaluationCtx, sdkDefaultValue, expectedType)
+		return res, evaluationCtx, err
+	}
+
+	hookCtx := hook.Context{
+		Context:           ctx,
+		FlagKey:           flagKey,
+		FlagType:          expectedType,
+		DefaultValue:      sdkDefaultValue,
+		EvaluationContext: evaluationCtx,
+	}
+	var res model.VariationResult[T]
+	hookCtx, err := runBeforeHooks(hooks, hookCtx)
+	if err != nil {
+		res = hookErrorResult(sdkDefaultValue, err)
+	} else {
+		res, err = evaluate(g, flagKey, hookCtx.EvaluationContext, sdkDefaultValue, expectedType)
+		if err == nil {
+			if err = runAfterHooks(hooks, hookCtx, toRawVarResult(res)); err != nil {
+				errorResult := hookErrorResult(sdkDefaultValue, err)
+				errorResult.TrackEvents = res.TrackEvents
+				errorResult.Version = res.Version
+				errorResult.Metadata = res.Metadata
+				res = errorResult
+			}
+		}
+	}
+	runErrorAndFinallyHooks(hooks, hookCtx, err, toRawVarResult(res))
+	return res, hookCtx.EvaluationContext, err
+}
+
+// flagStateWithHooks evaluates a flag for AllFlagsState with the hooks of the configuration
+// around the evaluation.
+// It returns the evaluation context used, since a hook can replace it.
+func (g *GoFeatureFlag) flagStateWithHooks(
+	ctx context.Context,
+	flagKey string,
+	evaluationCtx ffcontext.Context,
+	flagCtx flag.Context,
+	currentFlag flag.Flag,
+) (flagstate.FlagState, ffcontext.Context) {
+	hooks := g.config.Hooks
+	if len(hooks) == 0 {
+		return flagstate.FromFlagEvaluation(flagKey, evaluationCtx, flagCtx, currentFlag), evaluationCtx
+	}
+
+	hookCtx := hook.Context{
+		Context:           ctx,
+		FlagKey:           flagKey,
+		FlagType:          "interface{}",
+		EvaluationContext: evaluationCtx,
+	}
+	var state flagstate.FlagState
+	hookCtx, err := runBeforeHooks(hooks, hookCtx)
+	if err != nil {
+		state = hookErrorFlagState(currentFlag, err)
+	} else {
+		state = flagstate.FromFlagEvaluation(flagKey, hookCtx.EvaluationContext, flagCtx, currentFlag)
+		if state.Failed {
+			err = errors.New(state.ErrorDetails)
+			if state.ErrorDetails == "" {
+				err = fmt.Errorf("error while evaluating flag %s: %s", flagKey, state.ErrorCode)
+			}
+		} else if err = runAfterHooks(hooks, hookCtx, flagStateToRawVarResult(state)); err != nil {
+			state = hookErrorFlagState(currentFlag, err)
+		}
+	}
+	runErrorAndFinallyHooks(hooks, hookCtx, err, flagStateToRawVarResult(state))
+	return state, hookCtx.EvaluationContext
+}
+
+// runBeforeHooks calls the Before hooks in order and returns the hook context with the evaluation
+// context to use.
+func runBeforeHooks(hooks []hook.Hook, hookCtx hook.Context) (hook.Context, error) {
+	for _, h := range hooks {
+		evaluationCtx, err := h.Before(hookCtx)
+		if err != nil {
+			return hookCtx, fmt.Errorf("before hook: %w", err)
+		}
+		if evaluationCtx != nil {
+			hookCtx.EvaluationContext = evaluationCtx
+		}
+	}
+	return hookCtx, nil
+}
+
+// runAfterHooks calls the After hooks in the reverse order and stops at the first error.
+func runAfterHooks(hooks []hook.Hook, hookCtx hook.Context, details model.RawVarResult) error {
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i].After(hookCtx, details); err != nil {
+			return fmt.Errorf("after hook: %w", err)
+		}
+	}
+	return nil
+}
+
+// runErrorAndFinallyHooks calls the Error hooks if we have an error and the Finally hooks,
+// both in the reverse order.
+func runErrorAndFinallyHooks(hooks []hook.Hook, hookCtx hook.Context, err error, details model.RawVarResult) {
+	if err != nil {
+		for i := len(hooks) - 1; i >= 0; i-- {
+			hooks[i].Error(hookCtx, err)
+		}
+	}
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i].Finally(hookCtx, details)
+	}
+}
+
+// hookErrorResult is the result returned when a hook returns an error.
+func hookErrorResult[T model.JSONType](sdkDefaultValue T, err error) model.VariationResult[T] {
+	return model.VariationResult[T]{
+		Value:         sdkDefaultValue,
+		VariationType: flag.VariationSDKDefault,
+		Failed:        true,
+		Reason:        flag.ReasonError,
+		ErrorCode:     flag.ErrorCodeGeneral,
+		ErrorDetails:  err.Error(),
+		Cacheable:     false,
+	}
+}
+
+// hookErrorFlagState is the flag state returned by AllFlagsState when a hook returns an error.
+func hookErrorFlagState(currentFlag flag.Flag, err error) flagstate.FlagState {
+	return flagstate.FlagState{
+		Timestamp:     time.Now().Unix(),
+		VariationType: flag.VariationSDKDefault,
+		TrackEvents:   currentFlag.IsTrackEvents(),
+		Failed:        true,
+		ErrorCode:     flag.ErrorCodeGeneral,
+		ErrorDetails:  err.Error(),
+		Reason:        flag.ReasonError,
+		Metadata:      currentFlag.GetMetadata(),
+	}
+}
+
+func toRawVarResult[T model.JSONType](res model.VariationResult[T]) model.RawVarResult {
+	return model.RawVarResult{
+		TrackEvents:   res.TrackEvents,
+		VariationType: res.VariationType,
+		Failed:        res.Failed,
+		Version:       res.Version,
+		Reason:        res.Reason,
+		ErrorCode:     res.ErrorCode,
+		ErrorDetails:  res.ErrorDetails,
+		Value:         res.Value,
+		Cacheable:     res.Cacheable,
+		Metadata:      res.Metadata,
+	}
+}
+
+func flagStateToRawVarResult(state flagstate.FlagState) model.RawVarResult {
+	return model.RawVarResult{
+		TrackEvents:   state.TrackEvents,
+		VariationType: state.VariationType,
+		Failed:        state.Failed,
+		Reason:        state.Reason,
+		ErrorCode:     state.ErrorCode,
+		ErrorDetails:  state.ErrorDetails,
+		Value:         state.Value,
+		Metadata:      state.Metadata,
+	}
+}
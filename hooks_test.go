@@ -0,0 +1,194 @@
+package ffclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/hook"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/model"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/testutils/testconvert"
+)
+
+type recordingHook struct {
+	hook.Base
+	name       string
+	calls      *[]string
+	newContext ffcontext.Context
+	beforeErr  error
+	afterErr   error
+	errors     []error
+	finally    []model.RawVarResult
+	seenCtx    []ffcontext.Context
+}
+
+func (h *recordingHook) Before(hookCtx hook.Context) (ffcontext.Context, error) {
+	*h.calls = append(*h.calls, h.name+".before")
+	return h.newContext, h.beforeErr
+}
+
+func (h *recordingHook) After(hookCtx hook.Context, _ model.RawVarResult) error {
+	*h.calls = append(*h.calls, h.name+".after")
+	h.seenCtx = append(h.seenCtx, hookCtx.EvaluationContext)
+	return h.afterErr
+}
+
+func (h *recordingHook) Error(_ hook.Context, err error) {
+	*h.calls = append(*h.calls, h.name+".error")
+	h.errors = append(h.errors, err)
+}
+
+func (h *recordingHook) Finally(_ hook.Context, details model.RawVarResult) {
+	*h.calls = append(*h.calls, h.name+".finally")
+	h.finally = append(h.finally, details)
+}
+
+func betaFlag() *flag.InternalFlag {
+	return &flag.InternalFlag{
+		Variations: &map[string]*any{
+			"on":  testconvert.Interface(true),
+			"off": testconvert.Interface(false),
+		},
+		Rules: &[]flag.Rule{
+			{
+				Name:            testconvert.String("beta"),
+				Query:           testconvert.String(`beta eq true`),
+				VariationResult: testconvert.String("on"),
+			},
+		},
+		DefaultRule: &flag.Rule{
+			VariationResult: testconvert.String("off"),
+		},
+	}
+}
+
+func TestHooks_Variation(t *testing.T) {
+	evalCtx := ffcontext.NewEvaluationContext("random-key")
+	betaCtx := ffcontext.NewEvaluationContextBuilder("random-key").AddCustom("beta", true).Build()
+
+	t.Run("hooks are called in order", func(t *testing.T) {
+		var calls []string
+		first := &recordingHook{name: "first", calls: &calls}
+		second := &recordingHook{name: "second", calls: &calls}
+		g := newTestClient(NewCacheMock(betaFlag(), nil), false)
+		g.config.Hooks = []hook.Hook{first, second}
+
+		got, err := g.BoolVariationDetails("beta-flag", evalCtx, true)
+		require.NoError(t, err)
+		assert.False(t, got.Value)
+		assert.Equal(t, []string{
+			"first.before", "second.before", "second.after", "first.after", "second.finally", "first.finally",
+		}, calls)
+		assert.Equal(t, false, first.finally[0].Value)
+		assert.Equal(t, "off", first.finally[0].VariationType)
+	})
+
+	t.Run("before hook replaces the evaluation context", func(t *testing.T) {
+		var calls []string
+		enrich := &recordingHook{name: "enrich", calls: &calls, newContext: betaCtx}
+		observer := &recordingHook{name: "observer", calls: &calls}
+		g := newTestClient(NewCacheMock(betaFlag(), nil), false)
+		g.config.Hooks = []hook.Hook{enrich, observer}
+
+		got, err := g.BoolVariation("beta-flag", evalCtx, false)
+		require.NoError(t, err)
+		assert.True(t, got)
+		assert.Equal(t, []ffcontext.Context{betaCtx}, observer.seenCtx)
+	})
+
+	t.Run("before hook returns an error", func(t *testing.T) {
+		var calls []string
+		validation := &recordingHook{name: "validation", calls: &calls, beforeErr: errors.New("missing email")}
+		observer := &recordingHook{name: "observer", calls: &calls}
+		g := newTestClient(NewCacheMock(betaFlag(), nil), false)
+		g.config.Hooks = []hook.Hook{validation, observer}
+
+		got, err := g.BoolVariationDetails("beta-flag", betaCtx, false)
+		assert.EqualError(t, err, "before hook: missing email")
+		assert.Equal(t, model.VariationResult[bool]{
+			Value:         false,
+			VariationType: flag.VariationSDKDefault,
+			Failed:        true,
+			Reason:        flag.ReasonError,
+			ErrorCode:     flag.ErrorCodeGeneral,
+			ErrorDetails:  "before hook: missing email",
+		}, got)
+		assert.Equal(t, []string{
+			"validation.before", "observer.error", "validation.error", "observer.finally", "validation.finally",
+		}, calls)
+	})
+
+	t.Run("after hook returns an error", func(t *testing.T) {
+		var calls []string
+		validation := &recordingHook{name: "validation", calls: &calls, afterErr: errors.New("invalid value")}
+		g := newTestClient(NewCacheMock(betaFlag(), nil), false)
+		g.config.Hooks = []hook.Hook{validation}
+
+		got, err := g.BoolVariationDetails("beta-flag", betaCtx, false)
+		assert.EqualError(t, err, "after hook: invalid value")
+		assert.False(t, got.Value)
+		assert.Equal(t, flag.ErrorCodeGeneral, got.ErrorCode)
+		assert.Equal(t, flag.VariationSDKDefault, got.VariationType)
+		assert.Equal(t, []string{"validation.before", "validation.after", "validation.error", "validation.finally"}, calls)
+		assert.Equal(t, flag.ErrorCodeGeneral, validation.finally[0].ErrorCode)
+	})
+
+	t.Run("evaluation error", func(t *testing.T) {
+		var calls []string
+		observer := &recordingHook{name: "observer", calls: &calls}
+		g := newTestClient(NewCacheMock(betaFlag(), nil), false)
+		g.config.Hooks = []hook.Hook{observer}
+
+		got, err := g.StringVariationDetails("beta-flag", evalCtx, "default")
+		assert.Error(t, err)
+		assert.Equal(t, "default", got.Value)
+		assert.Equal(t, []string{"observer.before", "observer.error", "observer.finally"}, calls)
+		assert.Equal(t, flag.ErrorCodeTypeMismatch, observer.finally[0].ErrorCode)
+	})
+
+	t.Run("generic variation", func(t *testing.T) {
+		var calls []string
+		observer := &recordingHook{name: "observer", calls: &calls}
+		g := newTestClient(NewCacheMock(staticFlag(map[string]any{"title": "Hello"}), nil), false)
+		g.config.Hooks = []hook.Hook{observer}
+
+		got, err := VariationFrom(g, "banner", evalCtx, bannerConfig{})
+		require.NoError(t, err)
+		assert.Equal(t, bannerConfig{Title: "Hello"}, got)
+		assert.Equal(t, bannerConfig{Title: "Hello"}, observer.finally[0].Value)
+	})
+}
+
+func TestHooks_AllFlagsState(t *testing.T) {
+	betaCtx := ffcontext.NewEvaluationContextBuilder("random-key").AddCustom("beta", true).Build()
+
+	t.Run("before hook replaces the evaluation context", func(t *testing.T) {
+		var calls []string
+		enrich := &recordingHook{name: "enrich", calls: &calls, newContext: betaCtx}
+		g := newTestClient(NewCacheMock(betaFlag(), nil), false)
+		g.config.Hooks = []hook.Hook{enrich}
+
+		states := g.GetFlagStates(ffcontext.NewEvaluationContext("random-key"), []string{"beta-flag"})
+		assert.True(t, states.IsValid())
+		assert.Equal(t, true, states.GetFlags()["beta-flag"].Value)
+		assert.Equal(t, []string{"enrich.before", "enrich.after", "enrich.finally"}, calls)
+	})
+
+	t.Run("after hook returns an error", func(t *testing.T) {
+		var calls []string
+		validation := &recordingHook{name: "validation", calls: &calls, afterErr: errors.New("invalid value")}
+		g := newTestClient(NewCacheMock(betaFlag(), nil), false)
+		g.config.Hooks = []hook.Hook{validation}
+
+		states := g.GetFlagStates(betaCtx, []string{"beta-flag"})
+		assert.False(t, states.IsValid())
+		state := states.GetFlags()["beta-flag"]
+		assert.Nil(t, state.Value)
+		assert.Equal(t, flag.ErrorCodeGeneral, state.ErrorCode)
+		assert.Equal(t, "after hook: invalid value", state.ErrorDetails)
+		assert.Equal(t, []string{"validation.before", "validation.after", "validation.error", "validation.finally"}, calls)
+	})
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/BurntSushi/toml"
 	"github.com/google/go-cmp/cmp"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagenv"
 	"github.com/thomaspoignant/go-feature-flag/internal/notification"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/dto"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
@@ -53,9 +54,11 @@ func New(
 	}
 }
 
+// ConvertToFlagStruct parses the flag configuration, the overrides of the environment are applied to the flags.
 func ConvertToFlagStruct(
 	loadedFlags []byte,
 	fileFormat string,
+	environment string,
 ) (map[string]dto.DTO, error) {
 	var newFlags map[string]dto.DTO
 	var err error
@@ -68,7 +71,15 @@ func ConvertToFlagStruct(
 		// default unmarshaller is YAML
 		err = yaml.Unmarshal(loadedFlags, &newFlags)
 	}
-	return newFlags, err
+	if err != nil || environment == "" {
+		return newFlags, err
+	}
+	overrides, err := flagenv.Parse(loadedFlags, fileFormat)
+	if err != nil {
+		return nil, err
+	}
+	flagenv.Resolve(newFlags, overrides, environment)
+	return newFlags, nil
 }
 
 func (c *cacheManagerImpl) UpdateCache(
@@ -78,7 +89,6 @@ func (c *cacheManagerImpl) UpdateCache(
 ) error {
 	newCache := NewInMemoryCache(c.logger)
 	newCache.Init(newFlags)
-	newCacheFlags := newCache.All()
 	oldCacheFlags := map[string]flag.Flag{}
 
 	c.mutex.Lock()
@@ -86,6 +96,8 @@ func (c *cacheManagerImpl) UpdateCache(
 	if c.inMemoryCache != nil {
 		oldCacheFlags = c.inMemoryCache.All()
 	}
+	keepLastValidFlags(newCache, newFlags, oldCacheFlags, c.logger)
+	newCacheFlags := newCache.All()
 	c.inMemoryCache = newCache
 	c.latestUpdate = time.Now()
 	c.mutex.Unlock()
@@ -101,6 +113,31 @@ func (c *cacheManagerImpl) UpdateCache(
 	return nil
 }
 
+// keepLastValidFlags is adding in the new cache the previous version of the flags rejected
+// because their new configuration is invalid.
+// This allows to keep serving the last valid version of a flag instead of removing it.
+func keepLastValidFlags(
+	newCache *InMemoryCache,
+	newFlags map[string]dto.DTO,
+	oldCacheFlags map[string]flag.Flag,
+	logger *fflog.FFLogger,
+) {
+	for key := range newFlags {
+		if _, ok := newCache.Flags[key]; ok {
+			continue
+		}
+		previousFlag, ok := oldCacheFlags[key].(*flag.InternalFlag)
+		if !ok {
+			continue
+		}
+		logger.Warn("[cache] keeping the last valid version of the flag",
+			slog.String("key", key))
+		// the previous version was validated when it was added, it is not validated again
+		// (ex: it would be dropped too if its JSON schema is not reachable anymore).
+		newCache.Flags[key] = *previousFlag
+	}
+}
+
 func (c *cacheManagerImpl) Close() {
 	// Wait for the in-flight persistence goroutines: nothing must write the persistent flag
 	// configuration file after Close() returned. This cannot deadlock nor race with a new
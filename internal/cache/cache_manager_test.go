@@ -251,7 +251,7 @@ variation = "false_var"
 		t.Run(tt.name, func(t *testing.T) {
 			fCache := cache.New(notification.NewService([]notifier.Notifier{}), "",
 				&fflog.FFLogger{LeveledLogger: slog.Default()})
-			newFlags, err := cache.ConvertToFlagStruct(tt.args.loadedFlags, tt.flagFormat)
+			newFlags, err := cache.ConvertToFlagStruct(tt.args.loadedFlags, tt.flagFormat, "")
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -414,7 +414,7 @@ test-flag2:
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fCache := cache.New(notification.NewService([]notifier.Notifier{}), "", nil)
-			newFlags, err := cache.ConvertToFlagStruct(tt.args.loadedFlags, tt.flagFormat)
+			newFlags, err := cache.ConvertToFlagStruct(tt.args.loadedFlags, tt.flagFormat, "")
 			if tt.wantErr {
 				assert.Error(t, err)
 				return
@@ -456,7 +456,7 @@ func Test_cacheManagerImpl_GetLatestUpdateDate(t *testing.T) {
 
 	fCache := cache.New(notification.NewService([]notifier.Notifier{}), "", nil)
 	timeBefore := fCache.GetLatestUpdateDate()
-	newFlags, _ := cache.ConvertToFlagStruct(loadedFlags, "yaml")
+	newFlags, _ := cache.ConvertToFlagStruct(loadedFlags, "yaml", "")
 	_ = fCache.UpdateCache(newFlags, &fflog.FFLogger{LeveledLogger: slog.Default()}, true)
 	timeAfter := fCache.GetLatestUpdateDate()
 
@@ -654,3 +654,40 @@ func TestCacheManager_UpdateCache(t *testing.T) {
 		})
 	}
 }
+
+func TestCacheManager_UpdateCacheKeepLastValidFlag(t *testing.T) {
+	validFlag := dto.DTO{
+		Variations: &map[string]*any{
+			"A": testconvert.Interface("A"),
+			"B": testconvert.Interface("B"),
+		},
+		DefaultRule: &flag.Rule{VariationResult: testconvert.String("A")},
+	}
+	invalidFlag := dto.DTO{
+		Variations: &map[string]*any{
+			"A": testconvert.Interface("A"),
+			"B": testconvert.Interface(12),
+		},
+		DefaultRule: &flag.Rule{VariationResult: testconvert.String("B")},
+	}
+
+	cm := cache.New(&mock.NotificationService{}, "", &fflog.FFLogger{LeveledLogger: slog.Default()})
+	defer cm.Close()
+	assert.NoError(t, cm.UpdateCache(map[string]dto.DTO{"flag1": validFlag}, nil, false))
+
+	// the new configuration of flag1 is invalid, we keep serving the previous one
+	err := cm.UpdateCache(map[string]dto.DTO{"flag1": invalidFlag, "flag2": invalidFlag}, nil, false)
+	assert.NoError(t, err)
+	got, err := cm.GetFlag("flag1")
+	assert.NoError(t, err)
+	assert.Equal(t, "A", got.(*flag.InternalFlag).GetDefaultRule().GetVariationResult())
+
+	// an invalid flag without a previous version is not added
+	_, err = cm.GetFlag("flag2")
+	assert.Error(t, err)
+
+	// a flag removed from the configuration is removed from the cache
+	assert.NoError(t, cm.UpdateCache(map[string]dto.DTO{"flag2": validFlag}, nil, false))
+	_, err = cm.GetFlag("flag1")
+	assert.Error(t, err)
+}
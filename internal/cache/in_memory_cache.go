@@ -48,10 +48,11 @@ func (fc *InMemoryCache) keys() []string {
 	return keys
 }
 
+// Copy returns a copy of the cache, the flags are already validated and are copied as they are.
 func (fc *InMemoryCache) Copy() Cache {
 	inMemoryCache := NewInMemoryCache(fc.Logger)
 	for k, v := range fc.Flags {
-		inMemoryCache.addFlag(k, v)
+		inMemoryCache.Flags[k] = v
 	}
 	return inMemoryCache
 }
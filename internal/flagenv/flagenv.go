@@ -0,0 +1,93 @@
+package flagenv
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/dto"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"gopkg.in/yaml.v3"
+)
+
+// Key is the field of a flag containing its overrides by environment.
+const Key = "environments"
+
+// Override is the part of a flag that can be overridden for an environment.
+// The targeting, the default rule and the disable state replace the ones of the base definition, the
+// variations are merged with the ones of the base definition.
+type Override struct {
+	Disable     *bool            `json:"disable,omitempty"     yaml:"disable,omitempty"     toml:"disable,omitempty"`
+	Variations  *map[string]*any `json:"variations,omitempty"  yaml:"variations,omitempty"  toml:"variations,omitempty"`
+	Rules       *[]flag.Rule     `json:"targeting,omitempty"   yaml:"targeting,omitempty"   toml:"targeting,omitempty"`
+	DefaultRule *flag.Rule       `json:"defaultRule,omitempty" yaml:"defaultRule,omitempty" toml:"defaultRule,omitempty"`
+}
+
+// Overrides are the overrides of a flag by environment.
+type Overrides struct {
+	Environments map[string]Override `json:"environments,omitempty" yaml:"environments,omitempty" toml:"environments,omitempty"` // nolint: lll
+}
+
+// Parse returns the overrides by environment of the flags of a flag configuration.
+// Only the flags having overrides are in the result.
+func Parse(content []byte, format string) (map[string]Overrides, error) {
+	if !bytes.Contains(content, []byte(Key)) {
+		return map[string]Overrides{}, nil
+	}
+	var flags map[string]Overrides
+	var err error
+	switch strings.ToLower(format) {
+	case "toml":
+		err = toml.Unmarshal(content, &flags)
+	case "json":
+		err = json.Unmarshal(content, &flags)
+	default:
+		err = yaml.Unmarshal(content, &flags)
+	}
+	if err != nil {
+		return nil, err
+	}
+	maps.DeleteFunc(flags, func(_ string, o Overrides) bool { return len(o.Environments) == 0 })
+	return flags, nil
+}
+
+// Resolve applies the overrides of the environment to the flags.
+// The flags without override for this environment keep their base definition.
+func Resolve(flags map[string]dto.DTO, overrides map[string]Overrides, environment string) {
+	if environment == "" {
+		return
+	}
+	for key, flagOverrides := range overrides {
+		override, ok := flagOverrides.Environments[environment]
+		if !ok {
+			continue
+		}
+		if d, ok := flags[key]; ok {
+			flags[key] = override.Apply(d)
+		}
+	}
+}
+
+// Apply returns the flag with the override applied.
+func (o Override) Apply(d dto.DTO) dto.DTO {
+	if o.Disable != nil {
+		d.Disable = o.Disable
+	}
+	if o.Rules != nil {
+		d.Rules = o.Rules
+	}
+	if o.DefaultRule != nil {
+		d.DefaultRule = o.DefaultRule
+	}
+	if o.Variations != nil {
+		variations := map[string]*any{}
+		if d.Variations != nil {
+			maps.Copy(variations, *d.Variations)
+		}
+		maps.Copy(variations, *o.Variations)
+		d.Variations = &variations
+	}
+	return d
+}
@@ -0,0 +1,152 @@
+package flagenv_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagenv"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/dto"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/testutils/testconvert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		format  string
+		want    map[string]flagenv.Overrides
+		wantErr bool
+	}{
+		{
+			name:    "no environments",
+			content: "test-flag:\n  defaultRule:\n    variation: enabled\n",
+			format:  "yaml",
+			want:    map[string]flagenv.Overrides{},
+		},
+		{
+			name: "yaml",
+			content: `test-flag:
+  defaultRule:
+    variation: enabled
+  environments:
+    staging:
+      disable: true
+other-flag:
+  defaultRule:
+    variation: enabled
+`,
+			format: "yaml",
+			want: map[string]flagenv.Overrides{
+				"test-flag": {Environments: map[string]flagenv.Override{
+					"staging": {Disable: testconvert.Bool(true)},
+				}},
+			},
+		},
+		{
+			name:    "json",
+			content: `{"test-flag": {"environments": {"production": {"defaultRule": {"variation": "disabled"}}}}}`,
+			format:  "json",
+			want: map[string]flagenv.Overrides{
+				"test-flag": {Environments: map[string]flagenv.Override{
+					"production": {DefaultRule: &flag.Rule{VariationResult: testconvert.String("disabled")}},
+				}},
+			},
+		},
+		{
+			name: "toml",
+			content: `[test-flag.environments.staging.variations]
+enabled = false
+`,
+			format: "toml",
+			want: map[string]flagenv.Overrides{
+				"test-flag": {Environments: map[string]flagenv.Override{
+					"staging": {Variations: &map[string]*any{"enabled": testconvert.Interface(false)}},
+				}},
+			},
+		},
+		{
+			name:    "invalid environments",
+			content: "test-flag:\n  environments: staging\n",
+			format:  "yaml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := flagenv.Parse([]byte(tt.content), tt.format)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolve(t *testing.T) {
+	newFlags := func() map[string]dto.DTO {
+		return map[string]dto.DTO{
+			"test-flag": {
+				Variations: &map[string]*any{
+					"enabled":  testconvert.Interface(true),
+					"disabled": testconvert.Interface(false),
+				},
+				Rules: &[]flag.Rule{{
+					Name:            testconvert.String("beta"),
+					Query:           testconvert.String(`beta eq true`),
+					VariationResult: testconvert.String("enabled"),
+				}},
+				DefaultRule: &flag.Rule{VariationResult: testconvert.String("disabled")},
+			},
+			"other-flag": {
+				DefaultRule: &flag.Rule{VariationResult: testconvert.String("disabled")},
+			},
+		}
+	}
+	overrides := map[string]flagenv.Overrides{
+		"test-flag": {Environments: map[string]flagenv.Override{
+			"staging": {
+				Variations:  &map[string]*any{"disabled": testconvert.Interface(true)},
+				Rules:       &[]flag.Rule{},
+				DefaultRule: &flag.Rule{VariationResult: testconvert.String("enabled")},
+			},
+			"production": {Disable: testconvert.Bool(true)},
+		}},
+	}
+
+	t.Run("no environment", func(t *testing.T) {
+		flags := newFlags()
+		flagenv.Resolve(flags, overrides, "")
+		assert.Equal(t, newFlags(), flags)
+	})
+
+	t.Run("environment without override", func(t *testing.T) {
+		flags := newFlags()
+		flagenv.Resolve(flags, overrides, "development")
+		assert.Equal(t, newFlags(), flags)
+	})
+
+	t.Run("staging", func(t *testing.T) {
+		flags := newFlags()
+		flagenv.Resolve(flags, overrides, "staging")
+		assert.Equal(t, &map[string]*any{
+			"enabled":  testconvert.Interface(true),
+			"disabled": testconvert.Interface(true),
+		}, flags["test-flag"].Variations)
+		assert.Equal(t, &[]flag.Rule{}, flags["test-flag"].Rules)
+		assert.Equal(t, "enabled", *flags["test-flag"].DefaultRule.VariationResult)
+		assert.Nil(t, flags["test-flag"].Disable)
+		assert.Equal(t, newFlags()["other-flag"], flags["other-flag"])
+	})
+
+	t.Run("production", func(t *testing.T) {
+		flags := newFlags()
+		flagenv.Resolve(flags, overrides, "production")
+		assert.True(t, *flags["test-flag"].Disable)
+		assert.Equal(t, newFlags()["test-flag"].Variations, flags["test-flag"].Variations)
+		assert.Equal(t, newFlags()["test-flag"].Rules, flags["test-flag"].Rules)
+	})
+}
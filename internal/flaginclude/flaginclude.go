@@ -0,0 +1,719 @@
+package flaginclude
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/thomaspoignant/go-feature-flag/internal"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// Key is the top-level key of a flag configuration file listing the files to include.
+	Key = "include"
+
+	// SchemaKey is the key of the JSON schema of a flag, a schema referenced by its location (a file path or
+	// a URL) is loaded and inlined in the flag.
+	SchemaKey = "jsonSchema"
+
+	// SnippetPrefix is the prefix of the top-level keys of a YAML file containing shared snippets (ex: YAML
+	// anchors of variations or rules), they are not flags and are removed from the configuration.
+	// A key is a snippet only if it defines an anchor, the other keys starting with this prefix are flags.
+	SnippetPrefix = "."
+
+	// yamlIncludeKey is the key used to nest an included YAML file in the YAML file including it,
+	// the anchors of the included file are then available in the including file.
+	yamlIncludeKey = "__goff_include_%d__"
+
+	// httpTimeout is the timeout of the requests to the remote files.
+	httpTimeout = 10 * time.Second
+)
+
+var (
+	yamlIncludeLine = regexp.MustCompile(`^["']?include["']?\s*:`)
+	snippetKeyLine  = regexp.MustCompile(`(?m)^\s*\[?["']?\.`)
+)
+
+// Reader reads a remote file included by the configuration (ex: with the credentials of the retriever).
+type Reader func(ctx context.Context, location string) ([]byte, error)
+
+// Options are the options used to load the includes of a flag configuration.
+type Options struct {
+	// AllowedOrigins are the origins (ex: https://config.example.com) of the remote files that can be
+	// included by any file.
+	AllowedOrigins []string
+
+	// Reader (optional) reads the remote files having the same origin as the source of the configuration,
+	// the other remote files are read with an HTTP client without credentials.
+	// Default: nil
+	Reader Reader
+}
+
+// document is a flag configuration file with its includes.
+type document struct {
+	source   string
+	format   string
+	flags    map[string]any
+	includes []*document
+	// chain is the list of files including this document, it is used to detect the cycles.
+	chain []string
+	// snippets are the top-level keys of a YAML document defining an anchor.
+	snippets map[string]bool
+	// hasSnippets is true if the document had top-level snippet keys.
+	hasSnippets bool
+	// hasSchemaRefs is true if a flag of the document referenced its JSON schema by its location.
+	hasSchemaRefs bool
+	// yamlKey is the key used to nest this document in the YAML text of the document including it.
+	yamlKey string
+	// yamlText is the YAML text of the document with the YAML text of its includes nested in it.
+	yamlText string
+}
+
+// Expand resolves the includes of a flag configuration, and inlines the JSON schemas of the flags
+// referenced by their location.
+// The relative includes and schemas are resolved from the source of the configuration (a file path or a URL).
+// A remote file can be included only if it has the same origin as the file including it, or if its
+// origin (ex: https://config.example.com) is in the allowed origins of the options.
+//
+// It returns the content unchanged when the configuration has no include, no snippet and no schema location,
+// otherwise it returns the flags of all the files merged in JSON, with the format "json".
+func Expand(
+	ctx context.Context,
+	content []byte,
+	format string,
+	source string,
+	options Options,
+) ([]byte, string, error) {
+	if !bytes.Contains(content, []byte(Key)) && !bytes.Contains(content, []byte(SchemaKey)) &&
+		!snippetKeyLine.Match(content) {
+		return content, format, nil
+	}
+	doc, err := newLoader(ctx, source, options).loadAll(content, format, sourceLocation(source), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(doc.includes) == 0 && !doc.hasSnippets && !doc.hasSchemaRefs {
+		return content, format, nil
+	}
+	flags, err := doc.merge()
+	if err != nil {
+		return nil, "", err
+	}
+	merged, err := json.Marshal(flags)
+	if err != nil {
+		return nil, "", err
+	}
+	return merged, "json", nil
+}
+
+// Load parses a flag configuration and returns the flags of the configuration and of its includes.
+// The includes are resolved as in Expand.
+func Load(
+	ctx context.Context,
+	content []byte,
+	format string,
+	source string,
+	options Options,
+) (map[string]any, error) {
+	doc, err := newLoader(ctx, source, options).loadAll(content, format, sourceLocation(source), nil)
+	if err != nil {
+		return nil, err
+	}
+	return doc.merge()
+}
+
+// loader loads the flag configuration files and their includes.
+type loader struct {
+	ctx        context.Context
+	httpClient internal.HTTPClient
+	// allowedOrigins are the origins of the remote files that can be included by any file.
+	allowedOrigins []string
+	// reader reads the remote files of sourceOrigin, the origin of the source of the configuration.
+	reader       Reader
+	sourceOrigin string
+}
+
+func newLoader(ctx context.Context, source string, options Options) *loader {
+	origins := make([]string, 0, len(options.AllowedOrigins))
+	for _, allowed := range options.AllowedOrigins {
+		if u, err := url.Parse(strings.TrimSpace(allowed)); err == nil && u.Host != "" {
+			origins = append(origins, origin(u))
+		}
+	}
+	sourceOrigin := ""
+	if u, err := url.Parse(source); err == nil && isURL(source) {
+		sourceOrigin = origin(u)
+	}
+	// the redirections to another origin are refused, they would bypass the check of the origins.
+	httpClient := &http.Client{Timeout: httpTimeout, CheckRedirect: sameOriginRedirect}
+	return &loader{
+		ctx:            ctx,
+		httpClient:     httpClient,
+		allowedOrigins: origins,
+		reader:         options.Reader,
+		sourceOrigin:   sourceOrigin,
+	}
+}
+
+func sameOriginRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return fmt.Errorf("stopped after 10 redirects")
+	}
+	if origin(req.URL) != origin(via[0].URL) {
+		return fmt.Errorf("redirect to another origin (%s) is not allowed", origin(req.URL))
+	}
+	return nil
+}
+
+// loadAll loads a flag configuration file with all its includes.
+func (l *loader) loadAll(content []byte, format string, source string, chain []string) (*document, error) {
+	doc, err := l.load(content, format, source, chain)
+	if err != nil {
+		return nil, err
+	}
+	if doc.format == "yaml" {
+		if err := l.parseYAML(doc); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// load loads a flag configuration file.
+// The YAML files are not parsed, their YAML text is nested in the YAML file including them, so
+// the anchors they define can be used by the file including them. They are parsed by parseYAML.
+func (l *loader) load(content []byte, format string, source string, chain []string) (*document, error) {
+	doc := &document{
+		source: source,
+		format: normalizeFormat(format),
+		chain:  append(slices.Clone(chain), source),
+	}
+	if doc.format != "yaml" {
+		raw, err := parse(content, doc.format)
+		if err != nil {
+			return nil, doc.parseError(err)
+		}
+		return doc, l.setFlags(doc, raw)
+	}
+
+	includes, content, err := extractYAMLIncludes(content)
+	if err != nil {
+		return nil, doc.parseError(err)
+	}
+	var text strings.Builder
+	for index, include := range includes {
+		child, err := l.loadInclude(doc, include, true)
+		if err != nil {
+			return nil, err
+		}
+		if child.format == "yaml" {
+			child.yamlKey = fmt.Sprintf(yamlIncludeKey, index)
+			text.WriteString(child.yamlKey + ":\n" + indent(child.yamlText))
+		}
+		doc.includes = append(doc.includes, child)
+	}
+	text.Write(content)
+	doc.yamlText = text.String()
+	return doc, nil
+}
+
+// loadInclude resolves and loads an include of the document.
+// If nested is true, the YAML text of an included YAML file is nested in the YAML text of the document
+// and the included file is parsed with the document.
+func (l *loader) loadInclude(doc *document, include string, nested bool) (*document, error) {
+	location, err := l.resolve(doc.source, include)
+	if err != nil {
+		return nil, fmt.Errorf("impossible to include %s in %s: %w", include, doc.name(), err)
+	}
+	if slices.Contains(doc.chain, location) {
+		chain := slices.DeleteFunc(append(slices.Clone(doc.chain), location), func(s string) bool { return s == "" })
+		return nil, fmt.Errorf("include cycle detected: %s", strings.Join(chain, " -> "))
+	}
+	content, err := l.read(location)
+	if err != nil {
+		return nil, fmt.Errorf("impossible to include %s in %s: %w", include, doc.name(), err)
+	}
+	if nested {
+		return l.load(content, formatOf(location, doc.format), location, doc.chain)
+	}
+	return l.loadAll(content, formatOf(location, doc.format), location, doc.chain)
+}
+
+// parseYAML parses the YAML text of a document, and sets the flags of the document and of its
+// nested YAML includes.
+func (l *loader) parseYAML(doc *document) error {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(doc.yamlText), &node); err != nil {
+		return doc.yamlError(err)
+	}
+	raw := map[string]any{}
+	if err := node.Decode(&raw); err != nil {
+		return doc.yamlError(err)
+	}
+	return l.assign(doc, raw, mappingNode(&node))
+}
+
+// yamlError returns the parsing error of the nested YAML include in error, or of the document itself.
+func (d *document) yamlError(err error) error {
+	for _, include := range d.includes {
+		if include.yamlKey == "" {
+			continue
+		}
+		raw := map[string]any{}
+		if includeErr := yaml.Unmarshal([]byte(include.yamlText), &raw); includeErr != nil {
+			return include.yamlError(includeErr)
+		}
+	}
+	return d.parseError(err)
+}
+
+// assign sets the flags of the document and of its nested YAML includes from the parsed YAML,
+// node is the YAML mapping of the document.
+func (l *loader) assign(doc *document, raw map[string]any, node *yaml.Node) error {
+	for _, include := range doc.includes {
+		if include.yamlKey == "" {
+			continue
+		}
+		nested, ok := raw[include.yamlKey].(map[string]any)
+		if !ok && raw[include.yamlKey] != nil {
+			return include.parseError(fmt.Errorf("should be a map of flags"))
+		}
+		delete(raw, include.yamlKey)
+		if nested == nil {
+			nested = map[string]any{}
+		}
+		if err := l.assign(include, nested, mappingValue(node, include.yamlKey)); err != nil {
+			return err
+		}
+	}
+	doc.snippets = anchoredKeys(node)
+	return l.setFlags(doc, raw)
+}
+
+// setFlags sets the flags of the document, the include key is used to load the included files and
+// the snippets are removed.
+// An include key containing a map is a flag named include.
+func (l *loader) setFlags(doc *document, raw map[string]any) error {
+	if value, ok := raw[Key]; ok && !isMap(value) {
+		delete(raw, Key)
+		includes, err := includeList(value)
+		if err != nil {
+			return doc.parseError(err)
+		}
+		for _, include := range includes {
+			child, err := l.loadInclude(doc, include, false)
+			if err != nil {
+				return err
+			}
+			doc.includes = append(doc.includes, child)
+		}
+	}
+
+	doc.flags = make(map[string]any, len(raw))
+	for key, value := range raw {
+		if strings.HasPrefix(key, SnippetPrefix) && doc.snippets[key] {
+			doc.hasSnippets = true
+			continue
+		}
+		value = normalize(value)
+		if err := l.inlineSchema(doc, key, value); err != nil {
+			return err
+		}
+		doc.flags[key] = value
+	}
+	return nil
+}
+
+// inlineSchema loads the JSON schema of a flag referenced by its location, and inlines it in the flag.
+// The location is resolved like an include: from the location of the file defining the flag, and a
+// remote schema is loaded only from the same origin as this file or from an allowed origin.
+func (l *loader) inlineSchema(doc *document, key string, value any) error {
+	flag, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	location, ok := flag[SchemaKey].(string)
+	if !ok {
+		return nil
+	}
+	resolved, err := l.resolve(doc.source, location)
+	if err != nil {
+		return fmt.Errorf("impossible to load the %s %s of the flag %s: %w", SchemaKey, location, key, err)
+	}
+	content, err := l.read(resolved)
+	if err != nil {
+		return fmt.Errorf("impossible to load the %s %s of the flag %s: %w", SchemaKey, location, key, err)
+	}
+	schema, err := parse(content, formatOf(resolved, "json"))
+	if err != nil {
+		return fmt.Errorf("impossible to parse the %s %s of the flag %s: %w", SchemaKey, resolved, key, err)
+	}
+	flag[SchemaKey] = normalize(schema)
+	doc.hasSchemaRefs = true
+	return nil
+}
+
+// read returns the content of a local file or of a remote file.
+// The remote files of the origin of the source are read with the reader of the options, if any.
+func (l *loader) read(location string) ([]byte, error) {
+	if !isURL(location) {
+		return os.ReadFile(location)
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, err
+	}
+	if l.reader != nil && origin(u) == l.sourceOrigin {
+		return l.reader(l.ctx, location)
+	}
+	if !isRemote(location) {
+		return nil, fmt.Errorf("impossible to read %s, only the http(s) files can be read", location)
+	}
+	req, err := http.NewRequestWithContext(l.ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode > 399 {
+		return nil, fmt.Errorf("request to %s failed with code %d", location, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// merge returns the flags of the document and of its includes.
+// A flag defined in several files is reported as an error.
+func (d *document) merge() (map[string]any, error) {
+	flags := map[string]any{}
+	return flags, d.mergeInto(flags, map[string]string{})
+}
+
+func (d *document) mergeInto(flags map[string]any, origins map[string]string) error {
+	for _, include := range d.includes {
+		if err := include.mergeInto(flags, origins); err != nil {
+			return err
+		}
+	}
+	for key, value := range d.flags {
+		if origin, ok := origins[key]; ok {
+			return fmt.Errorf("flag %s is defined in both %s and %s", key, origin, d.name())
+		}
+		origins[key] = d.name()
+		flags[key] = value
+	}
+	return nil
+}
+
+func (d *document) name() string {
+	if d.source == "" {
+		return "the flag configuration"
+	}
+	return d.source
+}
+
+func (d *document) parseError(err error) error {
+	if d.source == "" {
+		return fmt.Errorf("impossible to parse the flag configuration: %w", err)
+	}
+	return fmt.Errorf("impossible to parse file %s: %w", d.source, err)
+}
+
+// resolve returns the location of an include.
+// The relative includes are resolved from the location of the file including them, the includes are
+// refused when this location is unknown. A remote file (a URL, ex: https:// or s3://) can only include
+// remote files, and a remote file can only be included from the same origin as the file including it
+// or from an allowed origin.
+func (l *loader) resolve(base string, include string) (string, error) {
+	switch {
+	case include == "":
+		return "", fmt.Errorf("empty include")
+	case base == "":
+		return "", fmt.Errorf("the location of the configuration is unknown, it cannot include files")
+	case isURL(base):
+		baseURL, err := url.Parse(base)
+		if err != nil {
+			return "", err
+		}
+		includeURL, err := url.Parse(include)
+		if err != nil {
+			return "", err
+		}
+		if includeURL.Scheme != "" && !isURL(include) {
+			return "", fmt.Errorf("a remote configuration can only include remote files")
+		}
+		return l.checkOrigin(baseURL.ResolveReference(includeURL), baseURL)
+	case isRemote(include):
+		includeURL, err := url.Parse(include)
+		if err != nil {
+			return "", err
+		}
+		return l.checkOrigin(includeURL, nil)
+	case strings.Contains(include, "://"):
+		return "", fmt.Errorf("only local files and http(s) files can be included")
+	case filepath.IsAbs(include):
+		return filepath.Clean(include), nil
+	default:
+		return absPath(filepath.Join(filepath.Dir(base), include)), nil
+	}
+}
+
+// checkOrigin returns the location of a remote include if it has the same origin as the remote file
+// including it (nil for a local file) or an allowed origin.
+func (l *loader) checkOrigin(include *url.URL, base *url.URL) (string, error) {
+	includeOrigin := origin(include)
+	if (base != nil && includeOrigin == origin(base)) || slices.Contains(l.allowedOrigins, includeOrigin) {
+		return include.String(), nil
+	}
+	return "", fmt.Errorf("the origin %s is not allowed, only the files of the same origin as the "+
+		"configuration or of the allowed origins can be included", includeOrigin)
+}
+
+// extractYAMLIncludes returns the files listed in the top-level include key of a YAML file and the
+// content of the file without this key.
+func extractYAMLIncludes(content []byte) ([]string, []byte, error) {
+	lines := strings.Split(string(content), "\n")
+	start, end := -1, len(lines)
+	for i, line := range lines {
+		if start < 0 {
+			if yamlIncludeLine.MatchString(line) {
+				start = i
+			}
+			continue
+		}
+		// the value of the key is indented, or is a list at the beginning of the lines.
+		if line == "" || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") ||
+			strings.HasPrefix(line, "#") || (strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---")) {
+			continue
+		}
+		end = i
+		break
+	}
+	if start < 0 {
+		return nil, content, nil
+	}
+
+	var block map[string]any
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[start:end], "\n")), &block); err != nil {
+		return nil, nil, err
+	}
+	if isMap(block[Key]) {
+		// a flag named include.
+		return nil, content, nil
+	}
+	includes, err := includeList(block[Key])
+	if err != nil {
+		return nil, nil, err
+	}
+	// the lines are emptied to keep the line numbers in the parsing errors.
+	for i := start; i < end; i++ {
+		lines[i] = ""
+	}
+	return includes, []byte(strings.Join(lines, "\n")), nil
+}
+
+// includeList returns the list of files of the include key, it can be a file or a list of files.
+func includeList(value any) ([]string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	case []any:
+		includes := make([]string, 0, len(v))
+		for _, item := range v {
+			include, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid %s: %v is not a string", Key, item)
+			}
+			includes = append(includes, include)
+		}
+		return includes, nil
+	default:
+		return nil, fmt.Errorf("invalid %s: should be a file or a list of files", Key)
+	}
+}
+
+func parse(content []byte, format string) (map[string]any, error) {
+	raw := map[string]any{}
+	var err error
+	switch format {
+	case "json":
+		err = json.Unmarshal(content, &raw)
+	case "toml":
+		err = toml.Unmarshal(content, &raw)
+	default:
+		err = yaml.Unmarshal(content, &raw)
+	}
+	return raw, err
+}
+
+// indent indents a YAML text to nest it under a key, the document markers and directives are removed.
+func indent(text string) string {
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		if line == "---" || line == "..." || strings.HasPrefix(line, "%") {
+			continue
+		}
+		b.WriteString("  " + line + "\n")
+	}
+	return b.String()
+}
+
+// normalize converts the maps with non string keys created by the YAML decoder, to be able to
+// encode them in JSON.
+func normalize(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalize(item)
+		}
+		return v
+	case map[any]any:
+		m := make(map[string]any, len(v))
+		for key, item := range v {
+			m[fmt.Sprint(key)] = normalize(item)
+		}
+		return m
+	case []any:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func normalizeFormat(format string) string {
+	switch strings.ToLower(format) {
+	case "json":
+		return "json"
+	case "toml":
+		return "toml"
+	default:
+		return "yaml"
+	}
+}
+
+// formatOf returns the format of a file from its extension, the default format is used
+// when the extension is unknown.
+func formatOf(location string, defaultFormat string) string {
+	if u, err := url.Parse(location); err == nil && isURL(location) {
+		location = u.Path
+	}
+	switch strings.ToLower(path.Ext(location)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".json":
+		return "json"
+	case ".toml":
+		return "toml"
+	default:
+		return defaultFormat
+	}
+}
+
+// sourceLocation returns the location of the source of the configuration used to resolve the includes.
+func sourceLocation(source string) string {
+	if source == "" || isURL(source) {
+		return source
+	}
+	return absPath(source)
+}
+
+func isRemote(location string) bool {
+	return strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+}
+
+// isURL returns true if the location is a URL (ex: https://, s3://), and not a local file.
+func isURL(location string) bool {
+	u, err := url.Parse(location)
+	return err == nil && u.Scheme != "" && strings.HasPrefix(location, u.Scheme+"://")
+}
+
+func isMap(value any) bool {
+	switch value.(type) {
+	case map[string]any, map[any]any:
+		return true
+	default:
+		return false
+	}
+}
+
+// mappingNode returns the mapping of a YAML document, nil if the document is not a mapping.
+func mappingNode(node *yaml.Node) *yaml.Node {
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	return node
+}
+
+// mappingValue returns the mapping of a key of a YAML mapping, nil if the key is not a mapping.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return mappingNode(node.Content[i+1])
+		}
+	}
+	return nil
+}
+
+// anchoredKeys returns the keys of a YAML mapping whose value defines an anchor.
+func anchoredKeys(node *yaml.Node) map[string]bool {
+	keys := map[string]bool{}
+	if node == nil {
+		return keys
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if hasAnchor(node.Content[i+1]) {
+			keys[node.Content[i].Value] = true
+		}
+	}
+	return keys
+}
+
+func hasAnchor(node *yaml.Node) bool {
+	if node.Anchor != "" {
+		return true
+	}
+	for _, child := range node.Content {
+		if hasAnchor(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// origin returns the scheme and the host of a URL (ex: https://config.example.com).
+func origin(u *url.URL) string {
+	return strings.ToLower(u.Scheme + "://" + u.Host)
+}
+
+func absPath(location string) string {
+	if abs, err := filepath.Abs(location); err == nil {
+		return abs
+	}
+	return filepath.Clean(location)
+}
@@ -0,0 +1,498 @@
+package flaginclude_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/internal/flaginclude"
+)
+
+const sharedSnippets = `.variations: &bool-variations
+  enabled: true
+  disabled: false
+.rules:
+  beta-rule: &beta-rule
+    name: beta
+    query: beta eq true
+    variation: enabled
+`
+
+const flagsWithIncludes = `# flags of the frontend team
+include:
+  - shared.yaml
+  - backend/flags.json
+frontend-flag:
+  variations: *bool-variations
+  targeting:
+    - *beta-rule
+  defaultRule:
+    variation: disabled
+`
+
+const backendFlags = `{
+  "include": "legacy.toml",
+  "backend-flag": {
+    "variations": {"enabled": true, "disabled": false},
+    "defaultRule": {"variation": "enabled"}
+  }
+}`
+
+const legacyFlags = `[legacy-flag.variations]
+enabled = true
+disabled = false
+
+[legacy-flag.defaultRule]
+variation = "disabled"
+`
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestExpand(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "shared.yaml", sharedSnippets)
+	writeFile(t, dir, "backend/flags.json", backendFlags)
+	writeFile(t, dir, "backend/legacy.toml", legacyFlags)
+	source := writeFile(t, dir, "flags.yaml", flagsWithIncludes)
+
+	got, format, err := flaginclude.Expand(
+		context.Background(), []byte(flagsWithIncludes), "yaml", source, flaginclude.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "json", format)
+	assert.JSONEq(t, `{
+  "frontend-flag": {
+    "variations": {"enabled": true, "disabled": false},
+    "targeting": [{"name": "beta", "query": "beta eq true", "variation": "enabled"}],
+    "defaultRule": {"variation": "disabled"}
+  },
+  "backend-flag": {
+    "variations": {"enabled": true, "disabled": false},
+    "defaultRule": {"variation": "enabled"}
+  },
+  "legacy-flag": {
+    "variations": {"enabled": true, "disabled": false},
+    "defaultRule": {"variation": "disabled"}
+  }
+}`, string(got))
+}
+
+func TestExpand_WithoutInclude(t *testing.T) {
+	content := []byte("test-flag:\n  variations:\n    enabled: true\n  defaultRule:\n    variation: enabled\n")
+	got, format, err := flaginclude.Expand(context.Background(), content, "yaml", "", flaginclude.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "yaml", format)
+	assert.Equal(t, content, got)
+
+	// the word include in a flag is not an include.
+	content = []byte("test-flag:\n  metadata:\n    description: include the new header\n")
+	got, format, err = flaginclude.Expand(context.Background(), content, "yaml", "", flaginclude.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "yaml", format)
+	assert.Equal(t, content, got)
+}
+
+func TestExpand_Errors(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "cycle-a.yaml", "include: cycle-b.yaml\n")
+	writeFile(t, dir, "cycle-b.yaml", "include: [cycle-a.yaml]\n")
+	writeFile(t, dir, "self.json", `{"include": "self.json"}`)
+	writeFile(t, dir, "flag.yaml", "test-flag:\n  defaultRule:\n    variation: enabled\n")
+	writeFile(t, dir, "duplicate.yaml", "include: flag.yaml\ntest-flag:\n  defaultRule:\n    variation: disabled\n")
+	writeFile(t, dir, "invalid.yaml", "test-flag: [\n")
+
+	tests := []struct {
+		name    string
+		content string
+		format  string
+		source  string
+		wantErr string
+	}{
+		{
+			name:    "cycle",
+			content: "include: cycle-a.yaml\n",
+			source:  filepath.Join(dir, "root.yaml"),
+			wantErr: "include cycle detected: " + filepath.Join(dir, "root.yaml") + " -> " +
+				filepath.Join(dir, "cycle-a.yaml") + " -> " + filepath.Join(dir, "cycle-b.yaml") + " -> " +
+				filepath.Join(dir, "cycle-a.yaml"),
+		},
+		{
+			name:    "file including itself",
+			content: `{"include": "self.json"}`,
+			format:  "json",
+			source:  filepath.Join(dir, "self.json"),
+			wantErr: "include cycle detected: " + filepath.Join(dir, "self.json") + " -> " +
+				filepath.Join(dir, "self.json"),
+		},
+		{
+			name:    "flag defined in several files",
+			content: "include: duplicate.yaml\n",
+			source:  filepath.Join(dir, "root.yaml"),
+			wantErr: "flag test-flag is defined in both " + filepath.Join(dir, "flag.yaml") + " and " +
+				filepath.Join(dir, "duplicate.yaml"),
+		},
+		{
+			name:    "missing file",
+			content: "include: missing.yaml\n",
+			source:  filepath.Join(dir, "root.yaml"),
+			wantErr: "impossible to include missing.yaml in " + filepath.Join(dir, "root.yaml"),
+		},
+		{
+			name:    "invalid included file",
+			content: "include: invalid.yaml\n",
+			source:  filepath.Join(dir, "root.yaml"),
+			wantErr: "impossible to parse file " + filepath.Join(dir, "invalid.yaml"),
+		},
+		{
+			name:    "relative include without source",
+			content: "include: flag.yaml\n",
+			wantErr: "impossible to include flag.yaml in the flag configuration: the location of the " +
+				"configuration is unknown, it cannot include files",
+		},
+		{
+			name:    "absolute include without source",
+			content: "include: " + filepath.Join(dir, "flag.yaml") + "\n",
+			wantErr: "the location of the configuration is unknown, it cannot include files",
+		},
+		{
+			name:    "remote include without allowed origin",
+			content: "include: https://config.example.com/flags.yaml\n",
+			source:  filepath.Join(dir, "root.yaml"),
+			wantErr: "the origin https://config.example.com is not allowed",
+		},
+		{
+			name:    "invalid include",
+			content: "include: 42\n",
+			source:  filepath.Join(dir, "root.yaml"),
+			wantErr: "impossible to parse file " + filepath.Join(dir, "root.yaml") +
+				": invalid include: should be a file or a list of files",
+		},
+		{
+			name:    "unsupported scheme",
+			content: "include: s3://bucket/flags.yaml\n",
+			source:  filepath.Join(dir, "root.yaml"),
+			wantErr: "only local files and http(s) files can be included",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := flaginclude.Expand(
+				context.Background(), []byte(tt.content), tt.format, tt.source, flaginclude.Options{})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestExpand_Remote(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"other-flag": {"variations": {"enabled": true}}}`))
+	}))
+	defer other.Close()
+
+	files := map[string]string{
+		"/config/flags.yaml":         "include:\n  - shared.yaml\n  - /other/flags.json\n",
+		"/config/shared.yaml":        sharedSnippets + "shared-flag:\n  variations: *bool-variations\n",
+		"/other/flags.json":          `{"json-flag": {"variations": {"enabled": true}}}`,
+		"/config/local-file.yaml":    "include: file:///etc/flags.yaml\n",
+		"/config/absolute-path.yaml": "include: /etc/flags.yaml\n",
+		"/config/missing-ref.yaml":   "include: missing.yaml\n",
+		"/config/other-origin.yaml":  "include: " + other.URL + "/flags.json\n",
+		"/config/redirect.yaml":      "include: redirect.json\n",
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/config/redirect.json" {
+			http.Redirect(w, r, other.URL+"/flags.json", http.StatusFound)
+			return
+		}
+		content, ok := files[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	expand := func(name string, allowedOrigins []string) ([]byte, error) {
+		got, _, err := flaginclude.Expand(context.Background(), []byte(files[name]), "yaml", server.URL+name,
+			flaginclude.Options{AllowedOrigins: allowedOrigins})
+		return got, err
+	}
+
+	got, err := expand("/config/flags.yaml", nil)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "shared-flag": {"variations": {"enabled": true, "disabled": false}},
+  "json-flag": {"variations": {"enabled": true}}
+}`, string(got))
+
+	_, err = expand("/config/local-file.yaml", nil)
+	assert.ErrorContains(t, err, "the origin file:// is not allowed")
+
+	// an absolute path is resolved from the URL of the configuration, never from the local filesystem.
+	_, err = expand("/config/absolute-path.yaml", nil)
+	assert.ErrorContains(t, err, "request to "+server.URL+"/etc/flags.yaml failed with code 404")
+
+	_, err = expand("/config/missing-ref.yaml", nil)
+	assert.ErrorContains(t, err, "request to "+server.URL+"/config/missing.yaml failed with code 404")
+
+	_, err = expand("/config/other-origin.yaml", nil)
+	assert.ErrorContains(t, err, "the origin "+other.URL+" is not allowed")
+
+	got, err = expand("/config/other-origin.yaml", []string{other.URL})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"other-flag": {"variations": {"enabled": true}}}`, string(got))
+
+	_, err = expand("/config/redirect.yaml", nil)
+	assert.ErrorContains(t, err, "redirect to another origin ("+other.URL+") is not allowed")
+}
+
+func TestExpand_Reader(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"other-flag": {"variations": {"enabled": true}}}`))
+	}))
+	defer other.Close()
+
+	files := map[string]string{
+		"s3://bucket/config/shared.yaml": sharedSnippets,
+		"s3://bucket/other/flags.json":   `{"json-flag": {"variations": {"enabled": true}}}`,
+	}
+	var read []string
+	reader := func(_ context.Context, location string) ([]byte, error) {
+		read = append(read, location)
+		content, ok := files[location]
+		if !ok {
+			return nil, fmt.Errorf("%s not found", location)
+		}
+		return []byte(content), nil
+	}
+	content := "include:\n  - shared.yaml\n  - ../other/flags.json\n  - " + other.URL + "/flags.json\n" +
+		"shared-flag:\n  variations: *bool-variations\n"
+
+	got, _, err := flaginclude.Expand(context.Background(), []byte(content), "yaml", "s3://bucket/config/flags.yaml",
+		flaginclude.Options{AllowedOrigins: []string{other.URL}, Reader: reader})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+  "shared-flag": {"variations": {"enabled": true, "disabled": false}},
+  "json-flag": {"variations": {"enabled": true}},
+  "other-flag": {"variations": {"enabled": true}}
+}`, string(got))
+	// the files of another origin are not read with the reader of the configuration.
+	assert.ElementsMatch(t, []string{"s3://bucket/config/shared.yaml", "s3://bucket/other/flags.json"}, read)
+
+	_, _, err = flaginclude.Expand(context.Background(), []byte(content), "yaml", "s3://bucket/config/flags.yaml",
+		flaginclude.Options{AllowedOrigins: []string{other.URL}})
+	assert.ErrorContains(t, err, "impossible to read s3://bucket/config/shared.yaml, only the http(s) files can be read")
+
+	_, _, err = flaginclude.Expand(context.Background(), []byte("include: s3://other-bucket/flags.yaml\n"), "yaml",
+		"s3://bucket/config/flags.yaml", flaginclude.Options{Reader: reader})
+	assert.ErrorContains(t, err, "the origin s3://other-bucket is not allowed")
+}
+
+func TestLoad_FlagNamedInclude(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		format  string
+	}{
+		{
+			name:    "yaml",
+			content: "include:\n  variations:\n    enabled: true\n  defaultRule:\n    variation: enabled\n",
+			format:  "yaml",
+		},
+		{
+			name:    "json",
+			content: `{"include": {"variations": {"enabled": true}, "defaultRule": {"variation": "enabled"}}}`,
+			format:  "json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := flaginclude.Load(context.Background(), []byte(tt.content), tt.format, "", flaginclude.Options{})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{
+				"include": map[string]any{
+					"variations":  map[string]any{"enabled": true},
+					"defaultRule": map[string]any{"variation": "enabled"},
+				},
+			}, got)
+		})
+	}
+}
+
+func TestLoad_KeysStartingWithADot(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		format   string
+		wantKeys []string
+	}{
+		{
+			name:     "yaml snippet defining an anchor",
+			content:  sharedSnippets + "test-flag:\n  variations: *bool-variations\n",
+			format:   "yaml",
+			wantKeys: []string{"test-flag"},
+		},
+		{
+			name:     "yaml key without anchor",
+			content:  ".test-flag:\n  variations:\n    enabled: true\n",
+			format:   "yaml",
+			wantKeys: []string{".test-flag"},
+		},
+		{
+			name:     "json key",
+			content:  `{".test-flag": {"variations": {"enabled": true}}}`,
+			format:   "json",
+			wantKeys: []string{".test-flag"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := flaginclude.Load(context.Background(), []byte(tt.content), tt.format, "", flaginclude.Options{})
+			require.NoError(t, err)
+			keys := make([]string, 0, len(got))
+			for key := range got {
+				keys = append(keys, key)
+			}
+			assert.ElementsMatch(t, tt.wantKeys, keys)
+		})
+	}
+}
+
+func TestExpand_LocalIncludingRemote(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"remote-flag": {"variations": {"enabled": true}}}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	content := "include: " + server.URL + "/flags.json\n"
+	source := writeFile(t, dir, "flags.yaml", content)
+
+	_, _, err := flaginclude.Expand(context.Background(), []byte(content), "yaml", source, flaginclude.Options{})
+	assert.ErrorContains(t, err, "the origin "+server.URL+" is not allowed")
+
+	got, _, err := flaginclude.Expand(context.Background(), []byte(content), "yaml", source,
+		flaginclude.Options{AllowedOrigins: []string{server.URL + "/"}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"remote-flag": {"variations": {"enabled": true}}}`, string(got))
+}
+
+const bannerSchema = `{
+  "type": "object",
+  "properties": {"title": {"type": "string"}},
+  "required": ["title"]
+}`
+
+const flagWithSchemaRef = `banner-flag:
+  variations:
+    small: {title: "Hello"}
+  defaultRule:
+    variation: small
+  jsonSchema: %s
+`
+
+func TestExpand_SchemaLocation(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(bannerSchema))
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/config/schemas/banner.json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(bannerSchema))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	writeFile(t, dir, "schemas/banner.json", bannerSchema)
+	wantSchema := `{"type": "object", "properties": {"title": {"type": "string"}}, "required": ["title"]}`
+	wantFlags := `{"banner-flag": {
+  "variations": {"small": {"title": "Hello"}},
+  "defaultRule": {"variation": "small"},
+  "jsonSchema": ` + wantSchema + `
+}}`
+
+	tests := []struct {
+		name    string
+		ref     string
+		source  string
+		wantErr string
+	}{
+		{
+			name:   "relative path resolved from the flag file",
+			ref:    "schemas/banner.json",
+			source: filepath.Join(dir, "flags.yaml"),
+		},
+		{
+			name:   "relative path resolved from the URL of the configuration",
+			ref:    "schemas/banner.json",
+			source: server.URL + "/config/flags.yaml",
+		},
+		{
+			name:   "URL of another origin",
+			ref:    other.URL + "/banner.json",
+			source: server.URL + "/config/flags.yaml",
+			wantErr: "impossible to load the jsonSchema " + other.URL + "/banner.json of the flag banner-flag: " +
+				"the origin " + other.URL + " is not allowed",
+		},
+		{
+			name:    "unknown location of the configuration",
+			ref:     filepath.Join(dir, "schemas/banner.json"),
+			wantErr: "the location of the configuration is unknown",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := []byte(fmt.Sprintf(flagWithSchemaRef, tt.ref))
+			got, format, err := flaginclude.Expand(context.Background(), content, "yaml", tt.source, flaginclude.Options{})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "json", format)
+			assert.JSONEq(t, wantFlags, string(got))
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "shared.yaml", sharedSnippets)
+	content := `include: shared.yaml
+.local-variations: &string-variations
+  a: "A"
+  b: "B"
+flag-1:
+  variations: *bool-variations
+flag-2:
+  variations: *string-variations
+`
+	got, err := flaginclude.Load(
+		context.Background(), []byte(content), "yaml", filepath.Join(dir, "flags.yaml"), flaginclude.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"flag-1": map[string]any{"variations": map[string]any{"enabled": true, "disabled": false}},
+		"flag-2": map[string]any{"variations": map[string]any{"a": "A", "b": "B"}},
+	}, got)
+
+	// the keys of the maps are converted to strings.
+	got, err = flaginclude.Load(
+		context.Background(), []byte("flag:\n  variations:\n    true: 1\n"), "yaml", "", flaginclude.Options{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"flag": map[string]any{"variations": map[string]any{"true": 1}}}, got)
+}
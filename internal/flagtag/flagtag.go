@@ -0,0 +1,94 @@
+package flagtag
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/notifier"
+)
+
+// Match returns true if the flag has at least one of the tags, or if tags is empty.
+func Match(f flag.Flag, tags []string) bool {
+	if len(tags) == 0 {
+		return true
+	}
+	if f == nil {
+		return false
+	}
+	return slices.ContainsFunc(f.GetTags(), func(tag string) bool { return slices.Contains(tags, tag) })
+}
+
+// Restrict returns the tags to use when the requested tags are restricted to the allowed tags.
+// An empty list means no restriction. It returns false if no flag can match, i.e. none of the requested
+// tags is allowed.
+func Restrict(requested []string, allowed []string) ([]string, bool) {
+	if len(allowed) == 0 {
+		return requested, true
+	}
+	if len(requested) == 0 {
+		return allowed, true
+	}
+	tags := make([]string, 0, len(requested))
+	for _, tag := range requested {
+		if slices.Contains(allowed, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags, len(tags) > 0
+}
+
+// FilterDiff keeps only the changes of the flags having at least one of the tags.
+// An updated flag leaving the tags is reported as deleted and an updated flag entering the tags is
+// reported as added, so a client only sees the flags matching its tags.
+func FilterDiff(diff notifier.DiffCache, tags []string) notifier.DiffCache {
+	if len(tags) == 0 {
+		return diff
+	}
+	filtered := notifier.DiffCache{
+		Deleted: map[string]flag.Flag{},
+		Added:   map[string]flag.Flag{},
+		Updated: map[string]notifier.DiffUpdated{},
+	}
+	for key, f := range diff.Added {
+		if Match(f, tags) {
+			filtered.Added[key] = f
+		}
+	}
+	for key, f := range diff.Deleted {
+		if Match(f, tags) {
+			filtered.Deleted[key] = f
+		}
+	}
+	for key, update := range diff.Updated {
+		before, after := Match(update.Before, tags), Match(update.After, tags)
+		switch {
+		case before && after:
+			filtered.Updated[key] = update
+		case before:
+			filtered.Deleted[key] = update.Before
+		case after:
+			filtered.Added[key] = update.After
+		}
+	}
+	return filtered
+}
+
+// ParseList parses a list of tags, each value can contain several tags separated by commas.
+func ParseList(values []string) []string {
+	tags := make([]string, 0)
+	for _, value := range values {
+		tags = append(tags, splitTags(value)...)
+	}
+	return tags
+}
+
+func splitTags(value string) []string {
+	tags := make([]string, 0)
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,146 @@
+package flagtag_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagtag"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/notifier"
+)
+
+func taggedFlag(tags ...string) flag.Flag {
+	return &flag.InternalFlag{Tags: &tags}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name string
+		flag flag.Flag
+		tags []string
+		want bool
+	}{
+		{
+			name: "no tags requested",
+			flag: &flag.InternalFlag{},
+			tags: nil,
+			want: true,
+		},
+		{
+			name: "flag without tags",
+			flag: &flag.InternalFlag{},
+			tags: []string{"frontend"},
+			want: false,
+		},
+		{
+			name: "one tag in common",
+			flag: taggedFlag("backend", "checkout"),
+			tags: []string{"frontend", "checkout"},
+			want: true,
+		},
+		{
+			name: "no tag in common",
+			flag: taggedFlag("backend"),
+			tags: []string{"frontend"},
+			want: false,
+		},
+		{
+			name: "nil flag",
+			flag: nil,
+			tags: []string{"frontend"},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, flagtag.Match(tt.flag, tt.tags))
+		})
+	}
+}
+
+func TestRestrict(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested []string
+		allowed   []string
+		want      []string
+		wantOK    bool
+	}{
+		{
+			name:      "no restriction",
+			requested: []string{"frontend"},
+			want:      []string{"frontend"},
+			wantOK:    true,
+		},
+		{
+			name:    "nothing requested",
+			allowed: []string{"frontend", "mobile"},
+			want:    []string{"frontend", "mobile"},
+			wantOK:  true,
+		},
+		{
+			name:      "requested tags restricted to the allowed ones",
+			requested: []string{"frontend", "backend"},
+			allowed:   []string{"frontend", "mobile"},
+			want:      []string{"frontend"},
+			wantOK:    true,
+		},
+		{
+			name:      "no requested tag allowed",
+			requested: []string{"backend"},
+			allowed:   []string{"frontend"},
+			want:      []string{},
+			wantOK:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := flagtag.Restrict(tt.requested, tt.allowed)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestFilterDiff(t *testing.T) {
+	diff := notifier.DiffCache{
+		Added: map[string]flag.Flag{
+			"added-frontend": taggedFlag("frontend"),
+			"added-backend":  taggedFlag("backend"),
+		},
+		Deleted: map[string]flag.Flag{
+			"deleted-frontend": taggedFlag("frontend"),
+		},
+		Updated: map[string]notifier.DiffUpdated{
+			"leaving-frontend":  {Before: taggedFlag("frontend"), After: taggedFlag("backend")},
+			"entering-frontend": {Before: taggedFlag("backend"), After: taggedFlag("frontend")},
+			"updated-frontend":  {Before: taggedFlag("frontend"), After: taggedFlag("frontend", "backend")},
+			"updated-backend":   {Before: taggedFlag("backend"), After: taggedFlag("backend")},
+		},
+	}
+
+	assert.Equal(t, diff, flagtag.FilterDiff(diff, nil))
+
+	got := flagtag.FilterDiff(diff, []string{"frontend"})
+	assert.Equal(t, map[string]flag.Flag{
+		"added-frontend": taggedFlag("frontend"),
+		// a flag entering the tags is reported as added.
+		"entering-frontend": taggedFlag("frontend"),
+	}, got.Added)
+	assert.Equal(t, map[string]flag.Flag{
+		"deleted-frontend": taggedFlag("frontend"),
+		// a flag leaving the tags is reported as deleted.
+		"leaving-frontend": taggedFlag("frontend"),
+	}, got.Deleted)
+	assert.Len(t, got.Updated, 1)
+	assert.Contains(t, got.Updated, "updated-frontend")
+
+	empty := flagtag.FilterDiff(diff, []string{"mobile"})
+	assert.False(t, empty.HasDiff())
+}
+
+func TestParseList(t *testing.T) {
+	assert.Equal(t, []string{}, flagtag.ParseList(nil))
+	assert.Equal(t, []string{"frontend", "mobile", "checkout"},
+		flagtag.ParseList([]string{"frontend,mobile", " checkout "}))
+}
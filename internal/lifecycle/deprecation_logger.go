@@ -0,0 +1,53 @@
+package lifecycle
+
+import (
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+// DeprecationLogger logs a warning when a deprecated flag is evaluated.
+// The warning is logged at most once per interval for each flag, to avoid flooding the logs.
+// A nil DeprecationLogger is valid and does nothing.
+type DeprecationLogger struct {
+	logger   *fflog.FFLogger
+	interval time.Duration
+	now      func() time.Time
+
+	mutex   sync.Mutex
+	lastLog map[string]time.Time
+}
+
+// NewDeprecationLogger creates a DeprecationLogger logging at most once per interval for each flag.
+func NewDeprecationLogger(logger *fflog.FFLogger, interval time.Duration) *DeprecationLogger {
+	return &DeprecationLogger{
+		logger:   logger,
+		interval: interval,
+		now:      time.Now,
+		lastLog:  map[string]time.Time{},
+	}
+}
+
+// Log logs a warning if the metadata of the flag marks it as deprecated.
+func (d *DeprecationLogger) Log(flagKey string, metadata map[string]any) {
+	if d == nil || !IsDeprecated(metadata) {
+		return
+	}
+	now := d.now()
+	d.mutex.Lock()
+	last, ok := d.lastLog[flagKey]
+	if ok && now.Sub(last) < d.interval {
+		d.mutex.Unlock()
+		return
+	}
+	d.lastLog[flagKey] = now
+	d.mutex.Unlock()
+
+	attributes := []any{slog.String("flag", flagKey)}
+	if owner, ok := metadata[OwnerKey].(string); ok && owner != "" {
+		attributes = append(attributes, slog.String("owner", owner))
+	}
+	d.logger.Warn("a deprecated flag has been evaluated", attributes...)
+}
@@ -0,0 +1,35 @@
+package lifecycle
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+func TestDeprecationLogger_Log(t *testing.T) {
+	logs := &bytes.Buffer{}
+	logger := &fflog.FFLogger{LeveledLogger: slog.New(slog.NewTextHandler(logs, nil))}
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	d := NewDeprecationLogger(logger, time.Minute)
+	d.now = func() time.Time { return now }
+
+	deprecated := map[string]any{DeprecatedKey: true, OwnerKey: "team-checkout"}
+	d.Log("deprecated-flag", deprecated)
+	d.Log("deprecated-flag", deprecated)
+	d.Log("active-flag", map[string]any{OwnerKey: "team-checkout"})
+	d.Log("active-flag", nil)
+	assert.Equal(t, 1, strings.Count(logs.String(), "a deprecated flag has been evaluated"))
+	assert.Contains(t, logs.String(), "flag=deprecated-flag owner=team-checkout")
+
+	now = now.Add(time.Minute)
+	d.Log("deprecated-flag", deprecated)
+	assert.Equal(t, 2, strings.Count(logs.String(), "a deprecated flag has been evaluated"))
+
+	var nilLogger *DeprecationLogger
+	assert.NotPanics(t, func() { nilLogger.Log("deprecated-flag", deprecated) })
+}
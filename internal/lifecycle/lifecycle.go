@@ -0,0 +1,138 @@
+package lifecycle
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Keys of the flag metadata containing the lifecycle of the flag.
+const (
+	// OwnerKey is the owner of the flag (ex: a team or an email).
+	OwnerKey = "owner"
+	// ExpiryKey is the date after which the flag should be removed (format: 2006-01-02 or RFC3339).
+	ExpiryKey = "expiry"
+	// TypeKey is the type of the flag, temporary or permanent.
+	TypeKey = "lifecycle"
+	// DeprecatedKey is true if the flag is deprecated and should not be evaluated anymore.
+	DeprecatedKey = "deprecated"
+	// DeprecatedSinceKey is the date since which the flag is deprecated (format: 2006-01-02 or RFC3339).
+	DeprecatedSinceKey = "deprecatedSince"
+)
+
+// Type is the type of flag, a temporary flag is meant to be removed once the feature is released.
+type Type string
+
+const (
+	Temporary Type = "temporary"
+	Permanent Type = "permanent"
+)
+
+const dateFormat = "2006-01-02"
+
+// Lifecycle contains the lifecycle information of a flag, read from its metadata.
+type Lifecycle struct {
+	Owner      string
+	Expiry     *time.Time
+	Type       Type
+	Deprecated bool
+	// DeprecatedSince is the date since which the flag is deprecated, nil if unknown.
+	DeprecatedSince *time.Time
+}
+
+// FromMetadata reads the lifecycle fields of the metadata of a flag.
+// An error is returned if a field has an invalid value, the other fields are still read.
+func FromMetadata(metadata map[string]any) (Lifecycle, error) {
+	var l Lifecycle
+	errs := make([]string, 0)
+
+	if value, ok := metadata[OwnerKey]; ok && value != nil {
+		if owner, ok := value.(string); ok {
+			l.Owner = owner
+		} else {
+			errs = append(errs, fmt.Sprintf("%s should be a string", OwnerKey))
+		}
+	}
+
+	if value, ok := metadata[ExpiryKey]; ok && value != nil {
+		expiry, err := parseDate(ExpiryKey, value)
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			l.Expiry = &expiry
+		}
+	}
+
+	if value, ok := metadata[TypeKey]; ok && value != nil {
+		t, _ := value.(string)
+		switch Type(strings.ToLower(t)) {
+		case Temporary, Permanent:
+			l.Type = Type(strings.ToLower(t))
+		default:
+			errs = append(errs, fmt.Sprintf("%s should be %s or %s", TypeKey, Temporary, Permanent))
+		}
+	}
+
+	if value, ok := metadata[DeprecatedKey]; ok && value != nil {
+		if deprecated, ok := value.(bool); ok {
+			l.Deprecated = deprecated
+		} else {
+			errs = append(errs, fmt.Sprintf("%s should be a boolean", DeprecatedKey))
+		}
+	}
+
+	if value, ok := metadata[DeprecatedSinceKey]; ok && value != nil {
+		deprecatedSince, err := parseDate(DeprecatedSinceKey, value)
+		if err != nil {
+			errs = append(errs, err.Error())
+		} else {
+			l.DeprecatedSince = &deprecatedSince
+		}
+	}
+
+	if len(errs) > 0 {
+		return l, fmt.Errorf("invalid lifecycle metadata: %s", strings.Join(errs, ", "))
+	}
+	return l, nil
+}
+
+// IsDeprecated returns true if the metadata of the flag marks it as deprecated.
+func IsDeprecated(metadata map[string]any) bool {
+	deprecated, _ := metadata[DeprecatedKey].(bool)
+	return deprecated
+}
+
+// DeprecationDate returns the date since which the flag is deprecated, false if the flag is not deprecated
+// or if the date is unknown.
+func DeprecationDate(metadata map[string]any) (time.Time, bool) {
+	if !IsDeprecated(metadata) {
+		return time.Time{}, false
+	}
+	value, ok := metadata[DeprecatedSinceKey]
+	if !ok || value == nil {
+		return time.Time{}, false
+	}
+	date, err := parseDate(DeprecatedSinceKey, value)
+	return date, err == nil
+}
+
+// IsExpired returns true if the flag has an expiry date and this date is passed.
+func (l Lifecycle) IsExpired(now time.Time) bool {
+	return l.Expiry != nil && !now.Before(*l.Expiry)
+}
+
+// parseDate parses the date of the field key, the YAML and TOML decoders already convert the dates into time.Time.
+func parseDate(key string, value any) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		if expiry, err := time.Parse(dateFormat, v); err == nil {
+			return expiry, nil
+		}
+		if expiry, err := time.Parse(time.RFC3339, v); err == nil {
+			return expiry, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%s should be a date with the format %s or RFC3339", key, dateFormat)
+}
@@ -0,0 +1,138 @@
+package lifecycle_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomaspoignant/go-feature-flag/internal/lifecycle"
+)
+
+func TestFromMetadata(t *testing.T) {
+	expiry := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	expiryWithTime := time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		metadata map[string]any
+		want     lifecycle.Lifecycle
+		wantErr  string
+	}{
+		{
+			name:     "nil metadata",
+			metadata: nil,
+			want:     lifecycle.Lifecycle{},
+		},
+		{
+			name: "all the fields",
+			metadata: map[string]any{
+				"owner":           "team-checkout",
+				"expiry":          "2025-03-01",
+				"lifecycle":       "Temporary",
+				"deprecated":      true,
+				"deprecatedSince": "2025-03-01",
+			},
+			want: lifecycle.Lifecycle{
+				Owner:           "team-checkout",
+				Expiry:          &expiry,
+				Type:            lifecycle.Temporary,
+				Deprecated:      true,
+				DeprecatedSince: &expiry,
+			},
+		},
+		{
+			name:     "RFC3339 expiry",
+			metadata: map[string]any{"expiry": "2025-03-01T10:30:00Z"},
+			want:     lifecycle.Lifecycle{Expiry: &expiryWithTime},
+		},
+		{
+			name:     "expiry decoded as a date",
+			metadata: map[string]any{"expiry": expiry, "lifecycle": "permanent"},
+			want:     lifecycle.Lifecycle{Expiry: &expiry, Type: lifecycle.Permanent},
+		},
+		{
+			name: "invalid fields",
+			metadata: map[string]any{
+				"owner":           42,
+				"expiry":          "tomorrow",
+				"lifecycle":       "forever",
+				"deprecated":      "yes",
+				"deprecatedSince": "last week",
+			},
+			want: lifecycle.Lifecycle{},
+			wantErr: "invalid lifecycle metadata: owner should be a string, " +
+				"expiry should be a date with the format 2006-01-02 or RFC3339, " +
+				"lifecycle should be temporary or permanent, deprecated should be a boolean, " +
+				"deprecatedSince should be a date with the format 2006-01-02 or RFC3339",
+		},
+		{
+			name:     "valid fields are read when another field is invalid",
+			metadata: map[string]any{"owner": "team-checkout", "deprecated": "yes"},
+			want:     lifecycle.Lifecycle{Owner: "team-checkout"},
+			wantErr:  "invalid lifecycle metadata: deprecated should be a boolean",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := lifecycle.FromMetadata(tt.metadata)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDeprecationDate(t *testing.T) {
+	date := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		metadata map[string]any
+		want     time.Time
+		wantOk   bool
+	}{
+		{name: "not deprecated", metadata: map[string]any{"deprecatedSince": "2025-03-01"}},
+		{name: "deprecated without date", metadata: map[string]any{"deprecated": true}},
+		{name: "invalid date", metadata: map[string]any{"deprecated": true, "deprecatedSince": "yesterday"}},
+		{
+			name:     "deprecated with date",
+			metadata: map[string]any{"deprecated": true, "deprecatedSince": "2025-03-01"},
+			want:     date,
+			wantOk:   true,
+		},
+		{
+			name:     "date decoded as a date",
+			metadata: map[string]any{"deprecated": true, "deprecatedSince": date},
+			want:     date,
+			wantOk:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := lifecycle.DeprecationDate(tt.metadata)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLifecycle_IsExpired(t *testing.T) {
+	expiry := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		lifecycle lifecycle.Lifecycle
+		now       time.Time
+		want      bool
+	}{
+		{name: "no expiry", lifecycle: lifecycle.Lifecycle{}, now: expiry, want: false},
+		{name: "before expiry", lifecycle: lifecycle.Lifecycle{Expiry: &expiry}, now: expiry.Add(-time.Hour)},
+		{name: "on expiry", lifecycle: lifecycle.Lifecycle{Expiry: &expiry}, now: expiry, want: true},
+		{name: "after expiry", lifecycle: lifecycle.Lifecycle{Expiry: &expiry}, now: expiry.Add(time.Hour), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.lifecycle.IsExpired(tt.now))
+		})
+	}
+}
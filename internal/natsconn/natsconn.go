@@ -0,0 +1,42 @@
+package natsconn
+
+import (
+	"errors"
+
+	"github.com/nats-io/nats.go"
+)
+
+// Auth contains the credentials and the TLS files used to connect to NATS,
+// it is shared by the NATS retriever and the NATS exporter.
+type Auth struct {
+	Token          string
+	Username       string
+	Password       string
+	ClientCertPath string
+	ClientKeyPath  string
+	CACertPath     string
+}
+
+// Connect opens a connection to the NATS servers, the options are applied after the ones built from auth.
+func Connect(url string, auth Auth, options ...nats.Option) (*nats.Conn, error) {
+	if url == "" {
+		url = nats.DefaultURL
+	}
+	if (auth.ClientCertPath == "") != (auth.ClientKeyPath == "") {
+		return nil, errors.New("client certificate and client key must be provided together")
+	}
+	opts := []nats.Option{nats.Name("GO Feature Flag")}
+	if auth.Token != "" {
+		opts = append(opts, nats.Token(auth.Token))
+	}
+	if auth.Username != "" {
+		opts = append(opts, nats.UserInfo(auth.Username, auth.Password))
+	}
+	if auth.ClientCertPath != "" {
+		opts = append(opts, nats.ClientCert(auth.ClientCertPath, auth.ClientKeyPath))
+	}
+	if auth.CACertPath != "" {
+		opts = append(opts, nats.RootCAs(auth.CACertPath))
+	}
+	return nats.Connect(url, append(opts, options...)...)
+}
@@ -15,18 +15,30 @@ var _ Service = (*notificationService)(nil)
 type Service interface {
 	Close()
 	Notify(oldCache, newCache map[string]flag.Flag, log *fflog.FFLogger)
+	// AddListener registers a listener called with the differences each time the cache changes.
+	// It returns a function to remove the listener, once it returns the listener is not called anymore.
+	AddListener(listener Listener) (remove func())
 }
 
+// Listener is called synchronously with the differences each time the cache changes,
+// it should not block.
+type Listener func(diff notifier.DiffCache)
+
 func NewService(notifiers []notifier.Notifier) Service {
 	return &notificationService{
 		Notifiers: notifiers,
 		waitGroup: &sync.WaitGroup{},
+		listeners: map[int]Listener{},
 	}
 }
 
 type notificationService struct {
 	Notifiers []notifier.Notifier
 	waitGroup *sync.WaitGroup
+
+	listenersMutex sync.Mutex
+	listeners      map[int]Listener
+	nextListenerID int
 }
 
 // Notify is sending the notification to the notifiers.
@@ -36,6 +48,7 @@ type notificationService struct {
 func (c *notificationService) Notify(oldCache, newCache map[string]flag.Flag, log *fflog.FFLogger) {
 	diff := c.getDifferences(oldCache, newCache)
 	if diff.HasDiff() {
+		c.notifyListeners(diff)
 		for _, n := range c.Notifiers {
 			c.waitGroup.Add(1)
 			notif := n
@@ -50,6 +63,30 @@ func (c *notificationService) Notify(oldCache, newCache map[string]flag.Flag, lo
 	}
 }
 
+// AddListener registers a listener called with the differences each time the cache changes.
+func (c *notificationService) AddListener(listener Listener) func() {
+	c.listenersMutex.Lock()
+	defer c.listenersMutex.Unlock()
+	id := c.nextListenerID
+	c.nextListenerID++
+	c.listeners[id] = listener
+	return func() {
+		c.listenersMutex.Lock()
+		defer c.listenersMutex.Unlock()
+		delete(c.listeners, id)
+	}
+}
+
+// notifyListeners calls all the listeners with the differences.
+// The listeners are never called concurrently, so they receive the changes in order.
+func (c *notificationService) notifyListeners(diff notifier.DiffCache) {
+	c.listenersMutex.Lock()
+	defer c.listenersMutex.Unlock()
+	for _, listener := range c.listeners {
+		listener(diff)
+	}
+}
+
 func (c *notificationService) Close() {
 	c.waitGroup.Wait()
 }
@@ -251,3 +251,29 @@ func Test_notificationService_getDifferences(t *testing.T) {
 		})
 	}
 }
+
+func Test_notificationService_listeners(t *testing.T) {
+	c := NewService(nil)
+	oldCache := map[string]flag.Flag{
+		"yo": &flag.InternalFlag{Version: testconvert.String("1.0")},
+	}
+	newCache := map[string]flag.Flag{
+		"yo": &flag.InternalFlag{Version: testconvert.String("1.1")},
+	}
+
+	var diffs []notifier.DiffCache
+	remove := c.AddListener(func(diff notifier.DiffCache) {
+		diffs = append(diffs, diff)
+	})
+
+	c.Notify(oldCache, newCache, nil)
+	assert.Len(t, diffs, 1, "the listener is called synchronously")
+	assert.Contains(t, diffs[0].Updated, "yo")
+
+	c.Notify(newCache, newCache, nil)
+	assert.Len(t, diffs, 1, "the listener is not called without difference")
+
+	remove()
+	c.Notify(newCache, oldCache, nil)
+	assert.Len(t, diffs, 1, "the listener is not called once removed")
+}
@@ -0,0 +1,349 @@
+package rolloutguard
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/thomaspoignant/go-feature-flag/exporter"
+	"github.com/thomaspoignant/go-feature-flag/guardedrollout"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+// NotifyFunc is called every time a guarded rollout is paused or rolled back,
+// with the flag before and after the action.
+type NotifyFunc func(flagKey string, before, after flag.Flag)
+
+// Controller is checking the signals of the guarded rollouts and keeps the state of the rollouts
+// that crossed their threshold.
+type Controller struct {
+	guards    []guardedrollout.Guard
+	observers []guardedrollout.TrackingEventObserver
+	getFlag   func(flagKey string) (flag.Flag, error)
+	notify    NotifyFunc
+	logger    *fflog.FFLogger
+	now       func() time.Time
+
+	mutex sync.RWMutex
+	// states contains the rollouts that crossed their threshold, by flag key and rule name.
+	states map[string]map[string]guardState
+
+	stop      chan struct{}
+	stopOnce  sync.Once
+	waitGroup sync.WaitGroup
+}
+
+// guardState is the state of a guarded rollout with the rollout configuration used when the
+// threshold has been crossed, if the configuration changes the guard is re-armed.
+type guardState struct {
+	guardedrollout.State
+	rollout flag.ProgressiveRollout
+}
+
+// NewController creates a new Controller for the guards.
+func NewController(
+	guards []guardedrollout.Guard,
+	getFlag func(flagKey string) (flag.Flag, error),
+	notify NotifyFunc,
+	logger *fflog.FFLogger,
+) *Controller {
+	return &Controller{
+		guards:    guards,
+		observers: collectObservers(guards),
+		getFlag:   getFlag,
+		notify:    notify,
+		logger:    logger,
+		now:       time.Now,
+		states:    map[string]map[string]guardState{},
+		stop:      make(chan struct{}),
+	}
+}
+
+// Start launches a goroutine per guard checking the signal every CheckInterval.
+func (c *Controller) Start(ctx context.Context) {
+	for _, guard := range c.guards {
+		c.waitGroup.Go(func() {
+			ticker := time.NewTicker(guard.GetCheckInterval())
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ticker.C:
+					c.Check(ctx, guard)
+				case <-c.stop:
+					return
+				case <-ctx.Done():
+					return
+				}
+			}
+		})
+	}
+}
+
+// Close stops the checks and waits for the goroutines to return.
+func (c *Controller) Close() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+		c.waitGroup.Wait()
+	})
+}
+
+// ObserveTrackingEvent forwards a tracked event to the signals interested in tracking events.
+func (c *Controller) ObserveTrackingEvent(event exporter.TrackingEvent) {
+	for _, observer := range c.observers {
+		observer.ObserveTrackingEvent(event)
+	}
+}
+
+// Check is checking the signal of a guard and applies the action if the threshold is crossed.
+func (c *Controller) Check(ctx context.Context, guard guardedrollout.Guard) {
+	f, err := c.getFlag(guard.FlagKey)
+	if err != nil {
+		c.logger.Debug("guarded rollout: flag not available", slog.String("flag", guard.FlagKey))
+		return
+	}
+	internalFlag, ok := f.(*flag.InternalFlag)
+	if !ok {
+		return
+	}
+	rule := findRule(internalFlag, guard.RuleName)
+	if rule == nil || rule.ProgressiveRollout == nil {
+		c.logger.Debug("guarded rollout: no progressive rollout to guard",
+			slog.String("flag", guard.FlagKey), slog.String("rule", guard.RuleName))
+		return
+	}
+	rollout := normalizeRollout(*rule.ProgressiveRollout)
+
+	if state, ok := c.getState(guard.FlagKey, guard.RuleName); ok {
+		if cmp.Equal(state.rollout, rollout) {
+			// the action is already applied on this rollout.
+			return
+		}
+		c.deleteState(guard.FlagKey, guard.RuleName)
+		c.logger.Info("guarded rollout: rollout configuration changed, the guard is re-armed",
+			slog.String("flag", guard.FlagKey), slog.String("rule", guard.RuleName))
+	}
+
+	now := c.now()
+	if rollout.Initial.Date == nil || now.Before(*rollout.Initial.Date) {
+		// the rollout has not started yet.
+		return
+	}
+
+	value, err := guard.Signal.Value(ctx, guard.FlagKey, stepVariation(rollout.End))
+	if err != nil {
+		if !errors.Is(err, guardedrollout.ErrNotEnoughData) {
+			c.logger.Error("guarded rollout: impossible to get the signal value",
+				slog.String("flag", guard.FlagKey), slog.Any("error", err.Error()))
+		}
+		return
+	}
+	if value <= guard.Threshold {
+		return
+	}
+
+	state := guardState{
+		State: guardedrollout.State{
+			FlagKey:     guard.FlagKey,
+			RuleName:    guard.RuleName,
+			Action:      guard.GetAction(),
+			Percentage:  currentPercentage(rollout, now),
+			SignalValue: value,
+			Date:        now,
+		},
+		rollout: rollout,
+	}
+	c.setState(state)
+	c.logger.Warn("guarded rollout: threshold crossed",
+		slog.String("flag", guard.FlagKey),
+		slog.String("rule", guard.RuleName),
+		slog.String("action", state.Action),
+		slog.Float64("signal", value),
+		slog.Float64("threshold", guard.Threshold),
+	)
+	if c.notify != nil {
+		c.notify(guard.FlagKey, f, c.Apply(guard.FlagKey, f))
+	}
+}
+
+// Apply returns the flag with the actions of the guarded rollouts applied.
+// If no action is applied on the flag, the flag is returned as is.
+func (c *Controller) Apply(flagKey string, f flag.Flag) flag.Flag {
+	if c == nil {
+		return f
+	}
+	c.mutex.RLock()
+	states := maps.Clone(c.states[flagKey])
+	c.mutex.RUnlock()
+	if len(states) == 0 {
+		return f
+	}
+	internalFlag, ok := f.(*flag.InternalFlag)
+	if !ok {
+		return f
+	}
+
+	// We are copying the flag and the rules we modify to avoid modifying the flag in the cache.
+	guarded := *internalFlag
+	if guarded.Rules != nil {
+		rules := slices.Clone(*guarded.Rules)
+		guarded.Rules = &rules
+	}
+	if guarded.DefaultRule != nil {
+		defaultRule := *guarded.DefaultRule
+		guarded.DefaultRule = &defaultRule
+	}
+	for ruleName, state := range states {
+		rule := findRule(&guarded, ruleName)
+		if rule == nil || rule.ProgressiveRollout == nil ||
+			!cmp.Equal(state.rollout, normalizeRollout(*rule.ProgressiveRollout)) {
+			continue
+		}
+		applyState(rule, state)
+	}
+	return &guarded
+}
+
+// States returns the states of the rollouts that crossed their threshold.
+func (c *Controller) States() []guardedrollout.State {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	states := make([]guardedrollout.State, 0)
+	for _, rules := range c.states {
+		for _, state := range rules {
+			states = append(states, state.State)
+		}
+	}
+	return states
+}
+
+func (c *Controller) getState(flagKey, ruleName string) (guardState, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	state, ok := c.states[flagKey][ruleName]
+	return state, ok
+}
+
+func (c *Controller) setState(state guardState) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.states[state.FlagKey]; !ok {
+		c.states[state.FlagKey] = map[string]guardState{}
+	}
+	c.states[state.FlagKey][state.RuleName] = state
+}
+
+func (c *Controller) deleteState(flagKey, ruleName string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.states[flagKey], ruleName)
+	if len(c.states[flagKey]) == 0 {
+		delete(c.states, flagKey)
+	}
+}
+
+// applyState modifies the rule to apply the action of the guard.
+func applyState(rule *flag.Rule, state guardState) {
+	initialVariation := stepVariation(state.rollout.Initial)
+	if state.Action == guardedrollout.ActionRollback || state.Percentage <= 0 {
+		rule.ProgressiveRollout = nil
+		rule.Percentages = nil
+		rule.VariationResult = &initialVariation
+		return
+	}
+
+	// To pause the rollout we keep the same dates, but the initial and end percentages are
+	// the percentage reached when the threshold has been crossed.
+	// The users stay in the same bucket, since the hash used by the progressive rollout does not change.
+	percentage := state.Percentage
+	initial := *state.rollout.Initial
+	initial.Percentage = &percentage
+	end := *state.rollout.End
+	end.Percentage = &percentage
+	rule.ProgressiveRollout = &flag.ProgressiveRollout{Initial: &initial, End: &end}
+}
+
+// findRule returns the rule with this name, or the default rule if the name is empty.
+func findRule(f *flag.InternalFlag, ruleName string) *flag.Rule {
+	if ruleName == "" {
+		return f.DefaultRule
+	}
+	index := f.GetRuleIndexByName(ruleName)
+	if index == nil {
+		return nil
+	}
+	return &(*f.Rules)[*index]
+}
+
+// normalizeRollout returns a copy of the rollout with the defaults applied by the evaluation,
+// an end percentage of 0 or above 100 is evaluated as 100.
+func normalizeRollout(rollout flag.ProgressiveRollout) flag.ProgressiveRollout {
+	initial := flag.ProgressiveRolloutStep{}
+	if rollout.Initial != nil {
+		initial = *rollout.Initial
+	}
+	end := flag.ProgressiveRolloutStep{}
+	if rollout.End != nil {
+		end = *rollout.End
+	}
+	if end.Percentage == nil || *end.Percentage == 0 || *end.Percentage > 100 {
+		maxPercentage := float64(100)
+		end.Percentage = &maxPercentage
+	}
+	return flag.ProgressiveRollout{Initial: &initial, End: &end}
+}
+
+// currentPercentage computes the percentage of the end variation served at this date.
+func currentPercentage(rollout flag.ProgressiveRollout, date time.Time) float64 {
+	initialPercentage := stepPercentage(rollout.Initial)
+	endPercentage := stepPercentage(rollout.End)
+	if rollout.End.Date == nil || !date.Before(*rollout.End.Date) {
+		return endPercentage
+	}
+	total := rollout.End.Date.Sub(*rollout.Initial.Date)
+	if total <= 0 {
+		return endPercentage
+	}
+	elapsed := date.Sub(*rollout.Initial.Date)
+	return initialPercentage + (endPercentage-initialPercentage)*elapsed.Seconds()/total.Seconds()
+}
+
+func stepVariation(step *flag.ProgressiveRolloutStep) string {
+	if step == nil || step.Variation == nil {
+		return ""
+	}
+	return *step.Variation
+}
+
+func stepPercentage(step *flag.ProgressiveRolloutStep) float64 {
+	if step == nil || step.Percentage == nil {
+		return 0
+	}
+	return *step.Percentage
+}
+
+// collectObservers returns the signals interested in the tracking events, a signal shared
+// between several guards is returned only once.
+func collectObservers(guards []guardedrollout.Guard) []guardedrollout.TrackingEventObserver {
+	observers := make([]guardedrollout.TrackingEventObserver, 0)
+	for _, guard := range guards {
+		observer, ok := guard.Signal.(guardedrollout.TrackingEventObserver)
+		if !ok {
+			continue
+		}
+		if reflect.ValueOf(observer).Kind() == reflect.Pointer &&
+			slices.ContainsFunc(observers, func(o guardedrollout.TrackingEventObserver) bool {
+				return reflect.ValueOf(o).Kind() == reflect.Pointer && o == observer
+			}) {
+			continue
+		}
+		observers = append(observers, observer)
+	}
+	return observers
+}
@@ -0,0 +1,265 @@
+package rolloutguard_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/exporter"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/guardedrollout"
+	"github.com/thomaspoignant/go-feature-flag/internal/rolloutguard"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/testutils/testconvert"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+type signalMock struct {
+	value    float64
+	err      error
+	observed int
+}
+
+func (s *signalMock) Value(_ context.Context, _ string, _ string) (float64, error) {
+	return s.value, s.err
+}
+
+func (s *signalMock) ObserveTrackingEvent(_ exporter.TrackingEvent) {
+	s.observed++
+}
+
+func progressiveFlag(start, end time.Time) *flag.InternalFlag {
+	return &flag.InternalFlag{
+		Variations: &map[string]*any{
+			"old": testconvert.Interface("old"),
+			"new": testconvert.Interface("new"),
+		},
+		Rules: &[]flag.Rule{
+			{
+				Name:            testconvert.String("beta"),
+				Query:           testconvert.String(`beta eq true`),
+				VariationResult: testconvert.String("new"),
+			},
+		},
+		DefaultRule: &flag.Rule{
+			ProgressiveRollout: &flag.ProgressiveRollout{
+				Initial: &flag.ProgressiveRolloutStep{
+					Variation:  testconvert.String("old"),
+					Percentage: testconvert.Float64(0),
+					Date:       testconvert.Time(start),
+				},
+				End: &flag.ProgressiveRolloutStep{
+					Variation:  testconvert.String("new"),
+					Percentage: testconvert.Float64(100),
+					Date:       testconvert.Time(end),
+				},
+			},
+		},
+	}
+}
+
+type notification struct {
+	flagKey       string
+	before, after flag.Flag
+}
+
+func newController(
+	guard guardedrollout.Guard,
+	f *flag.InternalFlag,
+) (*rolloutguard.Controller, *[]notification) {
+	notifications := &[]notification{}
+	mutex := sync.Mutex{}
+	c := rolloutguard.NewController(
+		[]guardedrollout.Guard{guard},
+		func(flagKey string) (flag.Flag, error) {
+			if flagKey != guard.FlagKey {
+				return nil, errors.New("not found")
+			}
+			return f, nil
+		},
+		func(flagKey string, before, after flag.Flag) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			*notifications = append(*notifications, notification{flagKey: flagKey, before: before, after: after})
+		},
+		&fflog.FFLogger{},
+	)
+	return c, notifications
+}
+
+func evaluate(f flag.Flag, key string) any {
+	value, _ := f.Value("my-flag", ffcontext.NewEvaluationContext(key), flag.Context{})
+	return value
+}
+
+func TestController_Rollback(t *testing.T) {
+	f := progressiveFlag(time.Now().Add(-1*time.Hour), time.Now().Add(1*time.Hour))
+	signal := &signalMock{value: 0.2}
+	guard := guardedrollout.Guard{FlagKey: "my-flag", Signal: signal, Threshold: 0.1}
+	c, notifications := newController(guard, f)
+
+	c.Check(context.Background(), guard)
+
+	states := c.States()
+	require.Len(t, states, 1)
+	assert.Equal(t, guardedrollout.ActionRollback, states[0].Action)
+	assert.Equal(t, 0.2, states[0].SignalValue)
+	assert.InDelta(t, 50, states[0].Percentage, 1)
+
+	require.Len(t, *notifications, 1)
+	assert.Equal(t, "my-flag", (*notifications)[0].flagKey)
+	assert.Equal(t, f, (*notifications)[0].before)
+
+	guarded := c.Apply("my-flag", f)
+	for _, key := range []string{"user-1", "user-2", "user-3", "user-4", "user-5", "user-6"} {
+		assert.Equal(t, "old", evaluate(guarded, key))
+	}
+	// the flag in the cache is not modified
+	assert.NotNil(t, f.DefaultRule.ProgressiveRollout)
+	// the other rules are still applied
+	value, _ := guarded.Value("my-flag",
+		ffcontext.NewEvaluationContextBuilder("user-1").AddCustom("beta", true).Build(), flag.Context{})
+	assert.Equal(t, "new", value)
+}
+
+func TestController_Pause(t *testing.T) {
+	f := progressiveFlag(time.Now().Add(-1*time.Hour), time.Now().Add(1*time.Hour))
+	guard := guardedrollout.Guard{
+		FlagKey:   "my-flag",
+		Signal:    &signalMock{value: 0.2},
+		Threshold: 0.1,
+		Action:    guardedrollout.ActionPause,
+	}
+	c, _ := newController(guard, f)
+	c.Check(context.Background(), guard)
+
+	guarded, ok := c.Apply("my-flag", f).(*flag.InternalFlag)
+	require.True(t, ok)
+	rollout := guarded.DefaultRule.ProgressiveRollout
+	require.NotNil(t, rollout)
+	assert.InDelta(t, 50, *rollout.Initial.Percentage, 1)
+	assert.Equal(t, *rollout.Initial.Percentage, *rollout.End.Percentage)
+	assert.Equal(t, f.DefaultRule.ProgressiveRollout.End.Date, rollout.End.Date)
+	assert.Equal(t, float64(100), *f.DefaultRule.ProgressiveRollout.End.Percentage)
+
+	// once paused the users keep the variation they had when the rollout was paused
+	afterEnd := time.Now().Add(2 * time.Hour)
+	for _, key := range []string{"user-1", "user-2", "user-3", "user-4", "user-5", "user-6"} {
+		ctx := ffcontext.NewEvaluationContextBuilder(key).
+			AddCustom("gofeatureflag", ffcontext.GoffContextSpecifics{CurrentDateTime: &afterEnd}).
+			Build()
+		paused, _ := guarded.Value("my-flag", ctx, flag.Context{})
+		assert.Equal(t, evaluate(guarded, key), paused)
+	}
+}
+
+func TestController_NoAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		flag   *flag.InternalFlag
+		signal *signalMock
+		guard  guardedrollout.Guard
+	}{
+		{
+			name:   "signal below the threshold",
+			flag:   progressiveFlag(time.Now().Add(-1*time.Hour), time.Now().Add(1*time.Hour)),
+			signal: &signalMock{value: 0.1},
+		},
+		{
+			name:   "not enough data",
+			flag:   progressiveFlag(time.Now().Add(-1*time.Hour), time.Now().Add(1*time.Hour)),
+			signal: &signalMock{value: 1, err: guardedrollout.ErrNotEnoughData},
+		},
+		{
+			name:   "signal in error",
+			flag:   progressiveFlag(time.Now().Add(-1*time.Hour), time.Now().Add(1*time.Hour)),
+			signal: &signalMock{value: 1, err: errors.New("random error")},
+		},
+		{
+			name:   "rollout not started",
+			flag:   progressiveFlag(time.Now().Add(1*time.Hour), time.Now().Add(2*time.Hour)),
+			signal: &signalMock{value: 1},
+		},
+		{
+			name:   "rule without progressive rollout",
+			flag:   progressiveFlag(time.Now().Add(-1*time.Hour), time.Now().Add(1*time.Hour)),
+			signal: &signalMock{value: 1},
+			guard:  guardedrollout.Guard{RuleName: "beta"},
+		},
+		{
+			name:   "unknown rule",
+			flag:   progressiveFlag(time.Now().Add(-1*time.Hour), time.Now().Add(1*time.Hour)),
+			signal: &signalMock{value: 1},
+			guard:  guardedrollout.Guard{RuleName: "unknown"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guard := tt.guard
+			guard.FlagKey = "my-flag"
+			guard.Signal = tt.signal
+			guard.Threshold = 0.1
+			c, notifications := newController(guard, tt.flag)
+			c.Check(context.Background(), guard)
+			assert.Empty(t, c.States())
+			assert.Empty(t, *notifications)
+			assert.Same(t, tt.flag, c.Apply("my-flag", tt.flag))
+		})
+	}
+}
+
+func TestController_RearmWhenRolloutChanges(t *testing.T) {
+	f := progressiveFlag(time.Now().Add(-1*time.Hour), time.Now().Add(1*time.Hour))
+	signal := &signalMock{value: 0.2}
+	guard := guardedrollout.Guard{FlagKey: "my-flag", Signal: signal, Threshold: 0.1}
+	c, notifications := newController(guard, f)
+	c.Check(context.Background(), guard)
+	require.Len(t, c.States(), 1)
+
+	// a second check on the same rollout does nothing
+	c.Check(context.Background(), guard)
+	assert.Len(t, *notifications, 1)
+
+	// a new rollout configuration is not affected by the previous state
+	f.DefaultRule.ProgressiveRollout.End.Date = testconvert.Time(time.Now().Add(24 * time.Hour))
+	assert.Equal(t, f, c.Apply("my-flag", f))
+
+	signal.value = 0
+	c.Check(context.Background(), guard)
+	assert.Empty(t, c.States())
+}
+
+func TestController_StartAndObserve(t *testing.T) {
+	f := progressiveFlag(time.Now().Add(-1*time.Hour), time.Now().Add(1*time.Hour))
+	signal := &signalMock{value: 0.2}
+	guard := guardedrollout.Guard{
+		FlagKey:       "my-flag",
+		Signal:        signal,
+		Threshold:     0.1,
+		CheckInterval: 10 * time.Millisecond,
+	}
+	c := rolloutguard.NewController(
+		[]guardedrollout.Guard{guard, {FlagKey: "other-flag", Signal: signal}},
+		func(_ string) (flag.Flag, error) { return f, nil },
+		nil,
+		&fflog.FFLogger{},
+	)
+	c.ObserveTrackingEvent(exporter.TrackingEvent{Key: "checkout"})
+	// the signal is shared by the 2 guards but receives the event only once
+	assert.Equal(t, 1, signal.observed)
+
+	c.Start(context.Background())
+	assert.Eventually(t, func() bool { return len(c.States()) > 0 }, time.Second, 10*time.Millisecond)
+	c.Close()
+	c.Close()
+}
+
+func TestController_NilController(t *testing.T) {
+	var c *rolloutguard.Controller
+	f := progressiveFlag(time.Now(), time.Now().Add(1*time.Hour))
+	assert.Same(t, f, c.Apply("my-flag", f))
+}
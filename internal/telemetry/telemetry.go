@@ -0,0 +1,246 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/model"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const (
+	// InstrumentationName is the name of the tracer and of the meter used by GO Feature Flag.
+	InstrumentationName = "github.com/thomaspoignant/go-feature-flag"
+	// ProviderName is the name of the feature flag provider in the semantic conventions.
+	ProviderName = "go-feature-flag"
+
+	// EvaluationSpanName is the name of the span created for each flag evaluation.
+	EvaluationSpanName = "feature_flag.evaluation"
+	// AllFlagsSpanName is the name of the span created when evaluating all the flags.
+	AllFlagsSpanName = "feature_flag.evaluation.all"
+	// EvaluationEventName is the name of the span event added for each flag evaluated by AllFlagsState.
+	EvaluationEventName = "feature_flag.evaluation"
+
+	// RetrieverKey is the attribute containing the type of the retriever in error.
+	RetrieverKey = attribute.Key("gofeatureflag.retriever")
+	// RefreshStatusKey is the attribute containing the status of a cache refresh (success or error).
+	RefreshStatusKey = attribute.Key("gofeatureflag.refresh.status")
+)
+
+// Telemetry records the traces and the metrics of GO Feature Flag.
+// A nil *Telemetry is valid and does not record anything.
+// The spans are only created as children of the span of the context, an evaluation without a parent span
+// (ex: called without context.Context) records only the metrics.
+type Telemetry struct {
+	tracer             trace.Tracer
+	flagSetName        string
+	recordContextID    bool
+	evaluations        metric.Int64Counter
+	evaluationDuration metric.Float64Histogram
+	cacheRefreshes     metric.Int64Counter
+	retrieverErrors    metric.Int64Counter
+}
+
+// New creates the instruments, if a provider is nil the global one is used.
+// If recordContextID is true, the targeting key of the evaluation context is added to the spans.
+func New(
+	tracerProvider trace.TracerProvider,
+	meterProvider metric.MeterProvider,
+	flagSetName string,
+	recordContextID bool,
+) (*Telemetry, error) {
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = otel.GetMeterProvider()
+	}
+	meter := meterProvider.Meter(InstrumentationName)
+
+	t := &Telemetry{
+		tracer:          tracerProvider.Tracer(InstrumentationName),
+		flagSetName:     flagSetName,
+		recordContextID: recordContextID,
+	}
+	var err error
+	if t.evaluations, err = meter.Int64Counter(
+		"gofeatureflag.evaluations",
+		metric.WithDescription("Number of flag evaluations."),
+		metric.WithUnit("{evaluation}"),
+	); err != nil {
+		return nil, fmt.Errorf("impossible to create the evaluations counter: %w", err)
+	}
+	if t.evaluationDuration, err = meter.Float64Histogram(
+		"gofeatureflag.evaluation.duration",
+		metric.WithDescription("Duration of the flag evaluations."),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, fmt.Errorf("impossible to create the evaluation duration histogram: %w", err)
+	}
+	if t.cacheRefreshes, err = meter.Int64Counter(
+		"gofeatureflag.cache.refreshes",
+		metric.WithDescription("Number of refreshes of the flags cache."),
+		metric.WithUnit("{refresh}"),
+	); err != nil {
+		return nil, fmt.Errorf("impossible to create the cache refreshes counter: %w", err)
+	}
+	if t.retrieverErrors, err = meter.Int64Counter(
+		"gofeatureflag.retriever.errors",
+		metric.WithDescription("Number of errors returned by the retrievers."),
+		metric.WithUnit("{error}"),
+	); err != nil {
+		return nil, fmt.Errorf("impossible to create the retriever errors counter: %w", err)
+	}
+	return t, nil
+}
+
+// Evaluation is an evaluation in progress.
+type Evaluation struct {
+	telemetry *Telemetry
+	ctx       context.Context
+	span      trace.Span
+	flagKey   string
+	start     time.Time
+}
+
+// StartEvaluation starts the span of the evaluation of a flag, if the context contains a span.
+func (t *Telemetry) StartEvaluation(ctx context.Context, flagKey string) (context.Context, *Evaluation) {
+	if t == nil {
+		return ctx, nil
+	}
+	var span trace.Span = noop.Span{}
+	if hasParentSpan(ctx) {
+		ctx, span = t.tracer.Start(ctx, EvaluationSpanName,
+			trace.WithSpanKind(trace.SpanKindInternal),
+			trace.WithAttributes(semconv.FeatureFlagKey(flagKey)),
+		)
+	}
+	return ctx, &Evaluation{telemetry: t, ctx: ctx, span: span, flagKey: flagKey, start: time.Now()}
+}
+
+// End records the result of the evaluation and ends the span.
+func (e *Evaluation) End(evaluationCtx ffcontext.Context, details model.RawVarResult) {
+	if e == nil {
+		return
+	}
+	attributes := e.telemetry.evaluationAttributes(e.flagKey, evaluationCtx, details)
+	e.span.SetAttributes(attributes...)
+	if details.ErrorCode != "" {
+		e.span.SetStatus(codes.Error, details.ErrorDetails)
+	}
+	e.span.End()
+	e.telemetry.recordEvaluation(e.ctx, e.flagKey, details, time.Since(e.start))
+}
+
+// StartAllFlagsEvaluation starts the span of the evaluation of multiple flags, if the context contains a span.
+// Each flag evaluated is added as an event of the span with RecordFlagState.
+func (t *Telemetry) StartAllFlagsEvaluation(ctx context.Context) (context.Context, trace.Span) {
+	if t == nil || !hasParentSpan(ctx) {
+		return ctx, noop.Span{}
+	}
+	return t.tracer.Start(ctx, AllFlagsSpanName, trace.WithSpanKind(trace.SpanKindInternal))
+}
+
+// hasParentSpan returns true if the context contains a span, the evaluations are never traced as root spans.
+func hasParentSpan(ctx context.Context) bool {
+	return trace.SpanContextFromContext(ctx).IsValid()
+}
+
+// RecordFlagState records the evaluation of a flag done while evaluating all the flags.
+func (t *Telemetry) RecordFlagState(
+	ctx context.Context,
+	span trace.Span,
+	flagKey string,
+	evaluationCtx ffcontext.Context,
+	details model.RawVarResult,
+	duration time.Duration,
+) {
+	if t == nil {
+		return
+	}
+	span.AddEvent(EvaluationEventName,
+		trace.WithAttributes(t.evaluationAttributes(flagKey, evaluationCtx, details)...))
+	t.recordEvaluation(ctx, flagKey, details, duration)
+}
+
+// RecordCacheRefresh records a refresh of the flags cache.
+func (t *Telemetry) RecordCacheRefresh(ctx context.Context, err error) {
+	if t == nil {
+		return
+	}
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	t.cacheRefreshes.Add(ctx, 1, metric.WithAttributes(RefreshStatusKey.String(status)))
+}
+
+// RecordRetrieverError records an error returned by a retriever.
+func (t *Telemetry) RecordRetrieverError(ctx context.Context, retrieverName string) {
+	if t == nil {
+		return
+	}
+	t.retrieverErrors.Add(ctx, 1, metric.WithAttributes(RetrieverKey.String(retrieverName)))
+}
+
+// evaluationAttributes returns the attributes of an evaluation following the feature flag semantic conventions.
+func (t *Telemetry) evaluationAttributes(
+	flagKey string,
+	evaluationCtx ffcontext.Context,
+	details model.RawVarResult,
+) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		semconv.FeatureFlagKey(flagKey),
+		semconv.FeatureFlagProviderName(ProviderName),
+		semconv.FeatureFlagResultVariant(details.VariationType),
+		semconv.FeatureFlagResultReasonKey.String(strings.ToLower(details.Reason)),
+	}
+	if t.recordContextID && evaluationCtx != nil && evaluationCtx.GetKey() != "" {
+		attributes = append(attributes, semconv.FeatureFlagContextID(evaluationCtx.GetKey()))
+	}
+	if details.Version != "" {
+		attributes = append(attributes, semconv.FeatureFlagVersion(details.Version))
+	}
+	if t.flagSetName != "" {
+		attributes = append(attributes, semconv.FeatureFlagSetID(t.flagSetName))
+	}
+	if details.ErrorCode != "" {
+		attributes = append(attributes, semconv.ErrorTypeKey.String(strings.ToLower(details.ErrorCode)))
+	}
+	return attributes
+}
+
+// recordEvaluation records the metrics of an evaluation.
+// We use only low cardinality attributes for the metrics: the key of a flag not found is not recorded,
+// it is a value sent by the caller and not a flag of the configuration.
+func (t *Telemetry) recordEvaluation(
+	ctx context.Context,
+	flagKey string,
+	details model.RawVarResult,
+	duration time.Duration,
+) {
+	var keyAttributes []attribute.KeyValue
+	if details.ErrorCode != flag.ErrorCodeFlagNotFound {
+		keyAttributes = append(keyAttributes, semconv.FeatureFlagKey(flagKey))
+	}
+	attributes := append(slices.Clone(keyAttributes),
+		semconv.FeatureFlagResultVariant(details.VariationType),
+		semconv.FeatureFlagResultReasonKey.String(strings.ToLower(details.Reason)),
+	)
+	if details.ErrorCode != "" {
+		attributes = append(attributes, semconv.ErrorTypeKey.String(strings.ToLower(details.ErrorCode)))
+	}
+	t.evaluations.Add(ctx, 1, metric.WithAttributes(attributes...))
+	t.evaluationDuration.Record(ctx, duration.Seconds(), metric.WithAttributes(keyAttributes...))
+}
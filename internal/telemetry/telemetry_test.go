@@ -0,0 +1,189 @@
+package telemetry_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/internal/telemetry"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/model"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTestTelemetry(
+	t *testing.T,
+	recordContextID bool,
+) (*telemetry.Telemetry, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+	spanRecorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	tel, err := telemetry.New(
+		sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		"my-flagset",
+		recordContextID,
+	)
+	require.NoError(t, err)
+	return tel, spanRecorder, reader
+}
+
+// parentContext returns a context containing a span, the evaluations are only traced under a parent span.
+func parentContext(t *testing.T) context.Context {
+	t.Helper()
+	tracerProvider := sdktrace.NewTracerProvider()
+	ctx, span := tracerProvider.Tracer("test").Start(context.Background(), "request")
+	t.Cleanup(func() { span.End() })
+	return ctx
+}
+
+func collectSums(t *testing.T, reader *sdkmetric.ManualReader) map[string][]metricdata.DataPoint[int64] {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	res := map[string][]metricdata.DataPoint[int64]{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if sum, ok := m.Data.(metricdata.Sum[int64]); ok {
+				res[m.Name] = sum.DataPoints
+			}
+		}
+	}
+	return res
+}
+
+func TestTelemetry_Evaluation(t *testing.T) {
+	tel, spanRecorder, reader := newTestTelemetry(t, false)
+	evaluationCtx := ffcontext.NewEvaluationContext("user-key")
+	ctx := parentContext(t)
+
+	_, evaluation := tel.StartEvaluation(ctx, "my-flag")
+	evaluation.End(evaluationCtx, model.RawVarResult{
+		VariationType: "enabled",
+		Reason:        flag.ReasonTargetingMatch,
+		Version:       "1.0.0",
+		Value:         true,
+	})
+	_, evaluation = tel.StartEvaluation(ctx, "unknown-flag")
+	evaluation.End(evaluationCtx, model.RawVarResult{
+		VariationType: flag.VariationSDKDefault,
+		Reason:        flag.ReasonError,
+		ErrorCode:     flag.ErrorCodeFlagNotFound,
+		ErrorDetails:  "flag unknown-flag is not present or disabled",
+		Failed:        true,
+	})
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, telemetry.EvaluationSpanName, spans[0].Name())
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("feature_flag.key", "my-flag"),
+		attribute.String("feature_flag.provider.name", "go-feature-flag"),
+		attribute.String("feature_flag.result.variant", "enabled"),
+		attribute.String("feature_flag.result.reason", "targeting_match"),
+		attribute.String("feature_flag.version", "1.0.0"),
+		attribute.String("feature_flag.set.id", "my-flagset"),
+	}, spans[0].Attributes())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Contains(t, spans[1].Attributes(), attribute.String("error.type", "flag_not_found"))
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+
+	sums := collectSums(t, reader)
+	require.Len(t, sums["gofeatureflag.evaluations"], 2)
+	flagKeys := []string{}
+	for _, point := range sums["gofeatureflag.evaluations"] {
+		assert.Equal(t, int64(1), point.Value)
+		_, hasContextID := point.Attributes.Value("feature_flag.context.id")
+		assert.False(t, hasContextID, "context id should not be used in the metrics")
+		if key, ok := point.Attributes.Value("feature_flag.key"); ok {
+			flagKeys = append(flagKeys, key.AsString())
+		}
+	}
+	assert.Equal(t, []string{"my-flag"}, flagKeys, "the key of a flag not found should not be used in the metrics")
+}
+
+func TestTelemetry_EvaluationWithContextID(t *testing.T) {
+	tel, spanRecorder, _ := newTestTelemetry(t, true)
+	_, evaluation := tel.StartEvaluation(parentContext(t), "my-flag")
+	evaluation.End(ffcontext.NewEvaluationContext("user-key"), model.RawVarResult{VariationType: "enabled"})
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Contains(t, spans[0].Attributes(), attribute.String("feature_flag.context.id", "user-key"))
+}
+
+func TestTelemetry_EvaluationWithoutParentSpan(t *testing.T) {
+	tel, spanRecorder, reader := newTestTelemetry(t, false)
+	evaluationCtx := ffcontext.NewEvaluationContext("user-key")
+
+	_, evaluation := tel.StartEvaluation(context.Background(), "my-flag")
+	evaluation.End(evaluationCtx, model.RawVarResult{VariationType: "enabled"})
+	ctx, span := tel.StartAllFlagsEvaluation(context.Background())
+	tel.RecordFlagState(ctx, span, "flag-1", evaluationCtx, model.RawVarResult{VariationType: "on"}, time.Millisecond)
+	span.End()
+
+	assert.Empty(t, spanRecorder.Ended(), "no root span should be created")
+	sums := collectSums(t, reader)
+	require.Len(t, sums["gofeatureflag.evaluations"], 2)
+}
+
+func TestTelemetry_AllFlagsEvaluation(t *testing.T) {
+	tel, spanRecorder, reader := newTestTelemetry(t, false)
+	evaluationCtx := ffcontext.NewEvaluationContext("user-key")
+
+	ctx, span := tel.StartAllFlagsEvaluation(parentContext(t))
+	tel.RecordFlagState(ctx, span, "flag-1", evaluationCtx,
+		model.RawVarResult{VariationType: "on", Reason: flag.ReasonStatic}, time.Millisecond)
+	tel.RecordFlagState(ctx, span, "flag-2", evaluationCtx,
+		model.RawVarResult{VariationType: "off", Reason: flag.ReasonDefault}, time.Millisecond)
+	span.End()
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, telemetry.AllFlagsSpanName, spans[0].Name())
+	require.Len(t, spans[0].Events(), 2)
+	assert.Equal(t, telemetry.EvaluationEventName, spans[0].Events()[0].Name)
+	assert.Contains(t, spans[0].Events()[1].Attributes, attribute.String("feature_flag.key", "flag-2"))
+	assert.Len(t, collectSums(t, reader)["gofeatureflag.evaluations"], 2)
+}
+
+func TestTelemetry_Retrievers(t *testing.T) {
+	tel, _, reader := newTestTelemetry(t, false)
+	tel.RecordCacheRefresh(context.Background(), nil)
+	tel.RecordCacheRefresh(context.Background(), nil)
+	tel.RecordCacheRefresh(context.Background(), errors.New("error"))
+	tel.RecordRetrieverError(context.Background(), "*fileretriever.Retriever")
+
+	sums := collectSums(t, reader)
+	values := map[string]int64{}
+	for _, point := range sums["gofeatureflag.cache.refreshes"] {
+		status, _ := point.Attributes.Value(telemetry.RefreshStatusKey)
+		values[status.AsString()] = point.Value
+	}
+	assert.Equal(t, map[string]int64{"success": 2, "error": 1}, values)
+	require.Len(t, sums["gofeatureflag.retriever.errors"], 1)
+	retrieverName, _ := sums["gofeatureflag.retriever.errors"][0].Attributes.Value(telemetry.RetrieverKey)
+	assert.Equal(t, "*fileretriever.Retriever", retrieverName.AsString())
+}
+
+func TestTelemetry_Nil(t *testing.T) {
+	var tel *telemetry.Telemetry
+	assert.NotPanics(t, func() {
+		ctx, evaluation := tel.StartEvaluation(context.Background(), "my-flag")
+		evaluation.End(ffcontext.NewEvaluationContext("user-key"), model.RawVarResult{})
+		ctx, span := tel.StartAllFlagsEvaluation(ctx)
+		tel.RecordFlagState(ctx, span, "my-flag", nil, model.RawVarResult{}, time.Millisecond)
+		span.End()
+		tel.RecordCacheRefresh(ctx, nil)
+		tel.RecordRetrieverError(ctx, "retriever")
+	})
+}
@@ -0,0 +1,345 @@
+package usagetracker
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/usage"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+// Tracker keeps the usage of the flags.
+// A nil Tracker is valid and does nothing, it is used when the usage tracking is disabled.
+//
+// Record is called on every evaluation, the counters of the known flags are updated
+// without lock, only the creation of the unknown flags is done under a mutex.
+type Tracker struct {
+	config usage.Config
+	logger *fflog.FFLogger
+	now    func() time.Time
+
+	trackingSince time.Time
+	// flags contains a *flagState for each flag key.
+	flags sync.Map
+
+	unknownFlagsMutex sync.Mutex
+	unknownFlags      map[string]*counter
+
+	stop      chan struct{}
+	stopOnce  sync.Once
+	waitGroup sync.WaitGroup
+}
+
+type flagState struct {
+	counter
+	// variations contains a *counter for each variation.
+	variations sync.Map
+}
+
+type counter struct {
+	evaluationCount atomic.Int64
+	// lastEvaluation is the date of the last evaluation in unix nanoseconds, 0 if never evaluated.
+	lastEvaluation atomic.Int64
+}
+
+func (c *counter) record(date time.Time) {
+	c.evaluationCount.Add(1)
+	n := date.UnixNano()
+	for {
+		last := c.lastEvaluation.Load()
+		if n <= last || c.lastEvaluation.CompareAndSwap(last, n) {
+			return
+		}
+	}
+}
+
+func (c *counter) lastEvaluationDate() time.Time {
+	n := c.lastEvaluation.Load()
+	if n == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, n).UTC()
+}
+
+func (c *counter) toUsage() usage.Usage {
+	lastEvaluation := c.lastEvaluationDate()
+	return usage.Usage{EvaluationCount: c.evaluationCount.Load(), LastEvaluation: &lastEvaluation}
+}
+
+func (c *counter) toPersisted() persistedCounter {
+	return persistedCounter{EvaluationCount: c.evaluationCount.Load(), LastEvaluation: c.lastEvaluationDate()}
+}
+
+// restore sets the counter from its persisted value.
+func (c *counter) restore(p persistedCounter) {
+	c.evaluationCount.Store(p.EvaluationCount)
+	if !p.LastEvaluation.IsZero() {
+		c.lastEvaluation.Store(p.LastEvaluation.UnixNano())
+	}
+}
+
+// persistedState is the usage of the flags, it is the content of the persistent file.
+type persistedState struct {
+	TrackingSince time.Time                   `json:"trackingSince"`
+	Flags         map[string]persistedFlag    `json:"flags"`
+	UnknownFlags  map[string]persistedCounter `json:"unknownFlags"`
+}
+
+type persistedFlag struct {
+	persistedCounter
+	Variations map[string]persistedCounter `json:"variations"`
+}
+
+type persistedCounter struct {
+	EvaluationCount int64     `json:"evaluationCount"`
+	LastEvaluation  time.Time `json:"lastEvaluation"`
+}
+
+// loadOrCreate returns the value of the key in the map, a new one is stored if the key does not exist.
+func loadOrCreate[T any](m *sync.Map, key string) *T {
+	if v, ok := m.Load(key); ok {
+		return v.(*T)
+	}
+	v, _ := m.LoadOrStore(key, new(T))
+	return v.(*T)
+}
+
+// New creates a Tracker, the usage of the persistent file is loaded if it exists.
+func New(config usage.Config, logger *fflog.FFLogger) *Tracker {
+	t := &Tracker{
+		config:       config,
+		logger:       logger,
+		now:          time.Now,
+		unknownFlags: map[string]*counter{},
+		stop:         make(chan struct{}),
+	}
+	t.trackingSince = t.now()
+	if config.PersistentFile != "" {
+		if err := t.load(); err != nil {
+			logger.Error("usage tracking: impossible to load the persistent file",
+				slog.String("file", config.PersistentFile), slog.Any("error", err.Error()))
+		}
+	}
+	return t
+}
+
+// Start launches a goroutine persisting the usage every PersistInterval, if a persistent file is configured.
+func (t *Tracker) Start() {
+	if t == nil || t.config.PersistentFile == "" {
+		return
+	}
+	t.waitGroup.Go(func() {
+		ticker := time.NewTicker(t.config.GetPersistInterval())
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				t.persist()
+			case <-t.stop:
+				return
+			}
+		}
+	})
+}
+
+// Close stops the persistence goroutine and persists the usage a last time.
+func (t *Tracker) Close() {
+	if t == nil {
+		return
+	}
+	t.stopOnce.Do(func() {
+		close(t.stop)
+		t.waitGroup.Wait()
+		if t.config.PersistentFile != "" {
+			t.persist()
+		}
+	})
+}
+
+// Record records an evaluation of a flag.
+// The evaluations of flags not found are recorded as unknown flags.
+func (t *Tracker) Record(flagKey string, variation string, errorCode flag.ErrorCode) {
+	if t == nil {
+		return
+	}
+	now := t.now()
+	if errorCode == flag.ErrorCodeFlagNotFound {
+		t.recordUnknownFlag(flagKey, now)
+		return
+	}
+
+	f := loadOrCreate[flagState](&t.flags, flagKey)
+	f.record(now)
+	loadOrCreate[counter](&f.variations, variation).record(now)
+}
+
+// recordUnknownFlag records an evaluation of a flag not found.
+// The number of unknown flags is capped, the one evaluated the longest time ago is forgotten
+// to make room for a new one, so evaluating random flag keys cannot make the memory grow.
+func (t *Tracker) recordUnknownFlag(flagKey string, now time.Time) {
+	t.unknownFlagsMutex.Lock()
+	defer t.unknownFlagsMutex.Unlock()
+	c, ok := t.unknownFlags[flagKey]
+	if !ok {
+		t.evictUnknownFlags(t.config.GetMaxUnknownFlags() - 1)
+		c = &counter{}
+		t.unknownFlags[flagKey] = c
+	}
+	c.record(now)
+}
+
+// evictUnknownFlags forgets the unknown flags evaluated the longest time ago until there are
+// at most maxFlags of them. The caller must hold the unknownFlagsMutex.
+func (t *Tracker) evictUnknownFlags(maxFlags int) {
+	for len(t.unknownFlags) > maxFlags {
+		oldestKey := ""
+		oldest := int64(math.MaxInt64)
+		for key, c := range t.unknownFlags {
+			if last := c.lastEvaluation.Load(); last < oldest {
+				oldestKey, oldest = key, last
+			}
+		}
+		delete(t.unknownFlags, oldestKey)
+	}
+}
+
+// Report builds the usage report of the flags of the configuration.
+// The unknown flags that are now in the configuration are not reported as unknown anymore.
+func (t *Tracker) Report(flags map[string]flag.Flag) usage.Report {
+	now := t.now()
+	report := usage.Report{
+		GeneratedAt:     now,
+		Flags:           make(map[string]usage.FlagUsage, len(flags)),
+		UnknownFlags:    map[string]usage.Usage{},
+		Unused:          []string{},
+		Stale:           []string{},
+		SingleVariation: []string{},
+	}
+
+	report.TrackingSince = t.trackingSince
+	for key := range flags {
+		v, ok := t.flags.Load(key)
+		if !ok {
+			report.Flags[key] = usage.FlagUsage{Variations: map[string]usage.Usage{}}
+			report.Unused = append(report.Unused, key)
+			continue
+		}
+		f := v.(*flagState)
+		flagUsage := usage.FlagUsage{
+			Usage:      f.toUsage(),
+			Variations: map[string]usage.Usage{},
+		}
+		f.variations.Range(func(variation, c any) bool {
+			flagUsage.Variations[variation.(string)] = c.(*counter).toUsage()
+			return true
+		})
+		report.Flags[key] = flagUsage
+		if now.Sub(f.lastEvaluationDate()) > t.config.GetStaleAfter() {
+			report.Stale = append(report.Stale, key)
+		}
+		if len(flagUsage.Variations) == 1 {
+			report.SingleVariation = append(report.SingleVariation, key)
+		}
+	}
+	t.unknownFlagsMutex.Lock()
+	for key, c := range t.unknownFlags {
+		if _, ok := flags[key]; !ok {
+			report.UnknownFlags[key] = c.toUsage()
+		}
+	}
+	t.unknownFlagsMutex.Unlock()
+	slices.Sort(report.Unused)
+	slices.Sort(report.Stale)
+	slices.Sort(report.SingleVariation)
+	return report
+}
+
+// load reads the usage from the persistent file, nothing is done if the file does not exist.
+func (t *Tracker) load() error {
+	content, err := os.ReadFile(t.config.PersistentFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var loaded persistedState
+	if err := json.Unmarshal(content, &loaded); err != nil {
+		return err
+	}
+	for key, persisted := range loaded.Flags {
+		f := &flagState{}
+		f.restore(persisted.persistedCounter)
+		for variation, persistedVariation := range persisted.Variations {
+			c := &counter{}
+			c.restore(persistedVariation)
+			f.variations.Store(variation, c)
+		}
+		t.flags.Store(key, f)
+	}
+	for key, persisted := range loaded.UnknownFlags {
+		c := &counter{}
+		c.restore(persisted)
+		t.unknownFlags[key] = c
+	}
+	t.evictUnknownFlags(t.config.GetMaxUnknownFlags())
+	if !loaded.TrackingSince.IsZero() {
+		t.trackingSince = loaded.TrackingSince
+	}
+	return nil
+}
+
+// persist writes the usage in the persistent file.
+// The file is written in a temporary file first, so a crash never leaves a partial file.
+func (t *Tracker) persist() {
+	content, err := json.Marshal(t.snapshot())
+	if err != nil {
+		t.logger.Error("usage tracking: impossible to marshal the usage", slog.Any("error", err.Error()))
+		return
+	}
+
+	tmpFile := filepath.Join(filepath.Dir(t.config.PersistentFile), "."+filepath.Base(t.config.PersistentFile)+".tmp")
+	if err := os.WriteFile(tmpFile, content, 0600); err != nil {
+		t.logger.Error("usage tracking: impossible to write the persistent file",
+			slog.String("file", t.config.PersistentFile), slog.Any("error", err.Error()))
+		return
+	}
+	if err := os.Rename(tmpFile, t.config.PersistentFile); err != nil {
+		t.logger.Error("usage tracking: impossible to write the persistent file",
+			slog.String("file", t.config.PersistentFile), slog.Any("error", err.Error()))
+	}
+}
+
+// snapshot returns the current usage of the flags in the format of the persistent file.
+func (t *Tracker) snapshot() persistedState {
+	snapshot := persistedState{
+		TrackingSince: t.trackingSince,
+		Flags:         map[string]persistedFlag{},
+		UnknownFlags:  map[string]persistedCounter{},
+	}
+	t.flags.Range(func(key, v any) bool {
+		f := v.(*flagState)
+		persisted := persistedFlag{persistedCounter: f.toPersisted(), Variations: map[string]persistedCounter{}}
+		f.variations.Range(func(variation, c any) bool {
+			persisted.Variations[variation.(string)] = c.(*counter).toPersisted()
+			return true
+		})
+		snapshot.Flags[key.(string)] = persisted
+		return true
+	})
+	t.unknownFlagsMutex.Lock()
+	defer t.unknownFlagsMutex.Unlock()
+	for key, c := range t.unknownFlags {
+		snapshot.UnknownFlags[key] = c.toPersisted()
+	}
+	return snapshot
+}
@@ -0,0 +1,142 @@
+package usagetracker
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/usage"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+func newTestTracker(config usage.Config, now *time.Time) *Tracker {
+	t := New(config, &fflog.FFLogger{})
+	t.now = func() time.Time { return *now }
+	t.trackingSince = *now
+	return t
+}
+
+func TestTracker_Report(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(usage.Config{StaleAfter: 24 * time.Hour}, &now)
+	start := now
+
+	tracker.Record("old-flag", "enabled", "")
+	now = now.Add(48 * time.Hour)
+	tracker.Record("checkout", "enabled", "")
+	tracker.Record("checkout", "disabled", "")
+	tracker.Record("checkout", "enabled", "")
+	tracker.Record("missing-flag", flag.VariationSDKDefault, flag.ErrorCodeFlagNotFound)
+	tracker.Record("missing-flag", flag.VariationSDKDefault, flag.ErrorCodeFlagNotFound)
+	tracker.Record("removed-flag", "enabled", "")
+
+	flags := map[string]flag.Flag{
+		"old-flag": &flag.InternalFlag{},
+		"checkout": &flag.InternalFlag{},
+		"never":    &flag.InternalFlag{},
+	}
+	report := tracker.Report(flags)
+
+	assert.Equal(t, start, report.TrackingSince)
+	assert.Equal(t, now, report.GeneratedAt)
+	assert.Equal(t, usage.FlagUsage{
+		Usage: usage.Usage{EvaluationCount: 3, LastEvaluation: &now},
+		Variations: map[string]usage.Usage{
+			"enabled":  {EvaluationCount: 2, LastEvaluation: &now},
+			"disabled": {EvaluationCount: 1, LastEvaluation: &now},
+		},
+	}, report.Flags["checkout"])
+	assert.Equal(t, usage.FlagUsage{Variations: map[string]usage.Usage{}}, report.Flags["never"])
+	assert.Len(t, report.Flags, 3, "the flags not in the configuration are not reported")
+	assert.Equal(t, map[string]usage.Usage{
+		"missing-flag": {EvaluationCount: 2, LastEvaluation: &now},
+	}, report.UnknownFlags)
+	assert.Equal(t, []string{"never"}, report.Unused)
+	assert.Equal(t, []string{"old-flag"}, report.Stale)
+	assert.Equal(t, []string{"old-flag"}, report.SingleVariation)
+
+	flags["missing-flag"] = &flag.InternalFlag{}
+	assert.Empty(t, tracker.Report(flags).UnknownFlags, "a flag added in the configuration is not unknown anymore")
+}
+
+func TestTracker_Persistence(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	file := filepath.Join(t.TempDir(), "usage.json")
+	config := usage.Config{PersistentFile: file, PersistInterval: 10 * time.Millisecond}
+
+	tracker := newTestTracker(config, &now)
+	tracker.Start()
+	tracker.Record("checkout", "enabled", "")
+	tracker.Record("missing-flag", flag.VariationSDKDefault, flag.ErrorCodeFlagNotFound)
+	assert.Eventually(t, func() bool {
+		_, err := os.Stat(file)
+		return err == nil
+	}, time.Second, 10*time.Millisecond, "the usage is persisted every PersistInterval")
+	tracker.Record("checkout", "enabled", "")
+	tracker.Close()
+
+	later := now.Add(time.Hour)
+	restarted := New(config, &fflog.FFLogger{})
+	restarted.now = func() time.Time { return later }
+	report := restarted.Report(map[string]flag.Flag{"checkout": &flag.InternalFlag{}})
+	assert.True(t, now.Equal(report.TrackingSince), "the tracking start date is kept after a restart")
+	assert.Equal(t, int64(2), report.Flags["checkout"].EvaluationCount)
+	assert.Equal(t, int64(1), report.UnknownFlags["missing-flag"].EvaluationCount)
+}
+
+func TestTracker_InvalidPersistentFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "usage.json")
+	require.NoError(t, os.WriteFile(file, []byte("invalid json"), 0o600))
+	tracker := New(usage.Config{PersistentFile: file}, &fflog.FFLogger{})
+	assert.Empty(t, tracker.Report(map[string]flag.Flag{}).Flags, "we start with an empty usage")
+}
+
+func TestTracker_MaxUnknownFlags(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := newTestTracker(usage.Config{MaxUnknownFlags: 2}, &now)
+
+	tracker.Record("unknown-1", flag.VariationSDKDefault, flag.ErrorCodeFlagNotFound)
+	now = now.Add(time.Minute)
+	tracker.Record("unknown-2", flag.VariationSDKDefault, flag.ErrorCodeFlagNotFound)
+	now = now.Add(time.Minute)
+	tracker.Record("unknown-1", flag.VariationSDKDefault, flag.ErrorCodeFlagNotFound)
+	now = now.Add(time.Minute)
+	tracker.Record("unknown-3", flag.VariationSDKDefault, flag.ErrorCodeFlagNotFound)
+
+	report := tracker.Report(map[string]flag.Flag{})
+	assert.Len(t, report.UnknownFlags, 2)
+	assert.Contains(t, report.UnknownFlags, "unknown-1")
+	assert.Contains(t, report.UnknownFlags, "unknown-3")
+	assert.NotContains(t, report.UnknownFlags, "unknown-2", "the flag evaluated the longest time ago is forgotten")
+}
+
+func TestTracker_ConcurrentRecord(t *testing.T) {
+	tracker := New(usage.Config{}, &fflog.FFLogger{})
+	var waitGroup sync.WaitGroup
+	for range 10 {
+		waitGroup.Go(func() {
+			for range 100 {
+				tracker.Record("checkout", "enabled", "")
+				tracker.Record("missing-flag", flag.VariationSDKDefault, flag.ErrorCodeFlagNotFound)
+			}
+		})
+	}
+	waitGroup.Wait()
+
+	report := tracker.Report(map[string]flag.Flag{"checkout": &flag.InternalFlag{}})
+	assert.Equal(t, int64(1000), report.Flags["checkout"].EvaluationCount)
+	assert.Equal(t, int64(1000), report.Flags["checkout"].Variations["enabled"].EvaluationCount)
+	assert.Equal(t, int64(1000), report.UnknownFlags["missing-flag"].EvaluationCount)
+}
+
+func TestTracker_Nil(t *testing.T) {
+	var tracker *Tracker
+	tracker.Start()
+	tracker.Record("checkout", "enabled", "")
+	tracker.Close()
+}
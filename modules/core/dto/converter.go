@@ -25,6 +25,8 @@ func ConvertDtoToInternalFlag(dto DTO) flag.InternalFlag {
 		Scheduled:       dto.Scheduled,
 		Experimentation: experimentation,
 		Metadata:        dto.Metadata,
+		Tags:            dto.Tags,
+		JSONSchema:      dto.JSONSchema,
 	}
 }
 
@@ -48,5 +50,7 @@ func ConvertInternalFlagToDto(f flag.InternalFlag) DTO {
 		Scheduled:       f.Scheduled,
 		Experimentation: experimentation,
 		Metadata:        f.Metadata,
+		Tags:            f.Tags,
+		JSONSchema:      f.JSONSchema,
 	}
 }
@@ -72,6 +72,7 @@ func TestConvertV1DtoToInternalFlag(t *testing.T) {
 					End:   testconvert.Time(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
 				},
 				Metadata:    &map[string]any{"key": "value"},
+				Tags:        &[]string{"checkout"},
 				TrackEvents: testconvert.Bool(true),
 				Disable:     testconvert.Bool(false),
 				Version:     testconvert.String("v1"),
@@ -133,6 +134,7 @@ func TestConvertV1DtoToInternalFlag(t *testing.T) {
 					End:   testconvert.Time(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
 				},
 				Metadata: &map[string]any{"key": "value"},
+				Tags:     &[]string{"checkout"},
 			},
 		},
 		{
@@ -209,6 +211,7 @@ func TestConvertInternalFlagToDto(t *testing.T) {
 					End:   testconvert.Time(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
 				},
 				Metadata:    &map[string]any{"key": "value"},
+				Tags:        &[]string{"checkout"},
 				TrackEvents: testconvert.Bool(true),
 				Disable:     testconvert.Bool(false),
 				Version:     testconvert.String("v1"),
@@ -265,6 +268,7 @@ func TestConvertInternalFlagToDto(t *testing.T) {
 					End:   testconvert.Time(time.Date(2022, 1, 2, 0, 0, 0, 0, time.UTC)),
 				},
 				Metadata:    &map[string]any{"key": "value"},
+				Tags:        &[]string{"checkout"},
 				TrackEvents: testconvert.Bool(true),
 				Disable:     testconvert.Bool(false),
 				Version:     testconvert.String("v1"),
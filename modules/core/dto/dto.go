@@ -50,6 +50,15 @@ type DTO struct {
 
 	// Metadata is a field containing information about your flag such as an issue tracker link, a description, etc ...
 	Metadata *map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty" toml:"metadata,omitempty" jsonschema:"title=metadata,description=A field containing information about your flag such as an issue tracker link a description etc..."` // nolint: lll
+
+	// Tags (optional) are used to group the flags,
+	// ex: to evaluate only the flags of a team or of a part of the application.
+	Tags *[]string `json:"tags,omitempty" yaml:"tags,omitempty" toml:"tags,omitempty" jsonschema:"title=tags,description=A list of tags used to group the flags. A tag cannot be empty or contain a comma."` // nolint: lll
+
+	// JSONSchema (optional) is a JSON Schema used to validate the values of the variations.
+	// It can be an inline schema, or a string with the location (URL or file path) of the schema in a flag file,
+	// the location is replaced by the content of the schema when the flag file is loaded.
+	JSONSchema *any `json:"jsonSchema,omitempty" yaml:"jsonSchema,omitempty" toml:"jsonSchema,omitempty" jsonschema:"title=jsonSchema,description=A JSON Schema used to validate the values of the variations. It can be an inline schema or the location (URL or file path) of the schema."` // nolint: lll
 }
 
 // Convert is converting the DTO into a flag.InternalFlag.
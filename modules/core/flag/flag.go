@@ -29,4 +29,7 @@ type Flag interface {
 
 	// GetMetadata return the metadata associated to the flag
 	GetMetadata() map[string]any
+
+	// GetTags return the tags of the flag
+	GetTags() []string
 }
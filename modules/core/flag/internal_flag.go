@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"maps"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/thomaspoignant/go-feature-flag/modules/core/ffcontext"
@@ -58,6 +59,15 @@ type InternalFlag struct {
 
 	// Metadata is a field containing information about your flag such as an issue tracker link, a description, etc ...
 	Metadata *map[string]any `json:"metadata,omitempty" yaml:"metadata,omitempty" toml:"metadata,omitempty"`
+
+	// Tags (optional) are used to group the flags,
+	// ex: to evaluate only the flags of a team or of a part of the application.
+	Tags *[]string `json:"tags,omitempty" yaml:"tags,omitempty" toml:"tags,omitempty"`
+
+	// JSONSchema (optional) is a JSON Schema used to validate the values of the variations.
+	// It can be an inline schema, or a string with the location (URL or file path) of the schema in a flag file,
+	// the location is replaced by the content of the schema when the flag file is loaded.
+	JSONSchema *any `json:"jsonSchema,omitempty" yaml:"jsonSchema,omitempty" toml:"jsonSchema,omitempty"`
 }
 
 // Value is returning the Value associate to the flag
@@ -153,10 +163,21 @@ func selectEvaluationReason(
 }
 
 func (f *InternalFlag) isCacheable() bool {
-	isDynamic := (f.Scheduled != nil && len(*f.Scheduled) > 0) || f.Experimentation != nil
+	isDynamic := (f.Scheduled != nil && len(*f.Scheduled) > 0) || f.Experimentation != nil || f.hasRuleSchedule()
 	return !isDynamic
 }
 
+// hasRuleSchedule checks if one of the targeting rules is restricted to recurring time windows,
+// in that case the result of the evaluation depends on the evaluation date.
+func (f *InternalFlag) hasRuleSchedule() bool {
+	for _, rule := range f.GetRules() {
+		if rule.Schedule != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // selectVariation is doing the magic to select the variation that should be used for this specific user
 // to always affect the user to the same segment we are using a hash of the flag name + key
 func (f *InternalFlag) selectVariation(
@@ -312,6 +333,23 @@ func (f *InternalFlag) IsValid() error {
 		}
 	}
 
+	if err := validateTags(f.GetTags()); err != nil {
+		return err
+	}
+
+	// Validate the variations against the JSON schema
+	if f.JSONSchema != nil {
+		variationSets := []map[string]*any{f.GetVariations()}
+		if f.Scheduled != nil {
+			for _, step := range *f.Scheduled {
+				variationSets = append(variationSets, step.GetVariations())
+			}
+		}
+		if err := validateVariationsSchema(*f.JSONSchema, variationSets...); err != nil {
+			return err
+		}
+	}
+
 	// Validate that we have a default Rule
 	if f.GetDefaultRule() == nil {
 		return fmt.Errorf("missing default rule")
@@ -497,6 +535,35 @@ func (f *InternalFlag) GetMetadata() map[string]any {
 	return *f.Metadata
 }
 
+// GetTags is the getter of the field Tags
+func (f *InternalFlag) GetTags() []string {
+	if f.Tags == nil {
+		return nil
+	}
+	return *f.Tags
+}
+
+// validateTags checks that the tags can be used in a comma separated list of tags.
+func validateTags(tags []string) error {
+	for _, tag := range tags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("invalid tags: a tag cannot be empty")
+		}
+		if strings.Contains(tag, ",") {
+			return fmt.Errorf("invalid tags: the tag %q cannot contain a comma", tag)
+		}
+	}
+	return nil
+}
+
+// GetJSONSchema is the getter of the field JSONSchema
+func (f *InternalFlag) GetJSONSchema() any {
+	if f.JSONSchema == nil {
+		return nil
+	}
+	return *f.JSONSchema
+}
+
 func DateFromContextOrDefault(ctx ffcontext.Context, defaultDate time.Time) time.Time {
 	if ctx == nil || ctx.ExtractGOFFProtectedFields().CurrentDateTime == nil {
 		return defaultDate
@@ -2633,6 +2633,7 @@ func TestInternalFlag_IsValid(t *testing.T) {
 		Experimentation *flag.ExperimentationRollout
 		Scheduled       *[]flag.ScheduledStep
 		Metadata        *map[string]any
+		Tags            *[]string
 	}
 	tests := []struct {
 		name     string
@@ -2648,6 +2649,35 @@ func TestInternalFlag_IsValid(t *testing.T) {
 			wantErr:  assert.Error,
 			errorMsg: "no variation available",
 		},
+		{
+			name: "valid tags",
+			fields: fields{
+				Variations:  &map[string]*any{"A": testconvert.Interface("A")},
+				DefaultRule: &flag.Rule{VariationResult: testconvert.String("A")},
+				Tags:        &[]string{"frontend", "checkout"},
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "empty tag",
+			fields: fields{
+				Variations:  &map[string]*any{"A": testconvert.Interface("A")},
+				DefaultRule: &flag.Rule{VariationResult: testconvert.String("A")},
+				Tags:        &[]string{"frontend", " "},
+			},
+			wantErr:  assert.Error,
+			errorMsg: "invalid tags: a tag cannot be empty",
+		},
+		{
+			name: "tag with a comma",
+			fields: fields{
+				Variations:  &map[string]*any{"A": testconvert.Interface("A")},
+				DefaultRule: &flag.Rule{VariationResult: testconvert.String("A")},
+				Tags:        &[]string{"frontend,checkout"},
+			},
+			wantErr:  assert.Error,
+			errorMsg: "invalid tags: the tag \"frontend,checkout\" cannot contain a comma",
+		},
 		{
 			name: "different types in variation",
 			fields: fields{
@@ -3177,6 +3207,7 @@ func TestInternalFlag_IsValid(t *testing.T) {
 				Version:         tt.fields.Version,
 				Scheduled:       tt.fields.Scheduled,
 				Experimentation: tt.fields.Experimentation,
+				Tags:            tt.fields.Tags,
 			}
 			err := f.IsValid()
 			errMsg := ""
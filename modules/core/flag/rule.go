@@ -48,6 +48,11 @@ type Rule struct {
 
 	// Disable indicates that this rule is disabled.
 	Disable *bool `json:"disable,omitempty" yaml:"disable,omitempty" toml:"disable,omitempty" jsonschema:"title=disable,description=Indicates that this rule is disabled."` // nolint: lll
+
+	// Schedule restricts the rule to recurring time windows (ex: weekdays 09:00-18:00 in Europe/Paris).
+	// Outside the windows the rule does not apply.
+	// Note: in the defaultRule field schedule is ignored.
+	Schedule *RuleSchedule `json:"schedule,omitempty" yaml:"schedule,omitempty" toml:"schedule,omitempty" jsonschema:"title=schedule,description=Restrict the rule to recurring time windows. Note: in the defaultRule field schedule is ignored."` // nolint: lll
 }
 
 // RequiresBucketing checks if this rule requires a bucketing key for evaluation
@@ -85,6 +90,9 @@ func (r *Rule) Evaluate(key string, ctx ffcontext.Context, flagName string, isDe
 	if !ruleApply || (!isDefault && r.IsDisable()) {
 		return "", &internalerror.RuleNotApplyError{Context: ctx}
 	}
+	if !isDefault && r.Schedule != nil && !r.Schedule.IsActive(evaluationDate) {
+		return "", &internalerror.RuleNotApplyError{Context: ctx}
+	}
 	if r.ProgressiveRollout != nil {
 		if key == "" {
 			return "", fmt.Errorf("progressive rollout requires a bucketing key")
@@ -312,6 +320,10 @@ func (r *Rule) MergeRules(updatedRule Rule) {
 		r.VariationResult = updatedRule.VariationResult
 	}
 
+	if updatedRule.Schedule != nil {
+		r.Schedule = updatedRule.Schedule
+	}
+
 	if updatedRule.ProgressiveRollout != nil {
 		c := r.GetProgressiveRollout()
 		if updatedRule.ProgressiveRollout.Initial != nil {
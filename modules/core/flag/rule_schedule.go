@@ -0,0 +1,246 @@
+package flag
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// timeOfDayLayout is the layout used to express the start and end of a time window.
+const timeOfDayLayout = "15:04"
+
+// RuleSchedule restricts a rule to recurring time windows.
+// A schedule is either a time window (days of the week + start/end time of the day)
+// or a cron expression with a duration, both evaluated in the configured timezone.
+type RuleSchedule struct {
+	// Timezone is the IANA name of the timezone used to evaluate the schedule (ex: Europe/Paris).
+	// Default: UTC
+	Timezone *string `json:"timezone,omitempty" yaml:"timezone,omitempty" toml:"timezone,omitempty" jsonschema:"title=timezone,description=IANA name of the timezone used to evaluate the schedule (default: UTC)."` // nolint: lll
+
+	// Days is the list of days of the week where the rule is active (ex: monday, tuesday).
+	// If empty the rule is active every day.
+	Days *[]string `json:"days,omitempty" yaml:"days,omitempty" toml:"days,omitempty" jsonschema:"title=days,description=Days of the week where the rule is active. If empty the rule is active every day."` // nolint: lll
+
+	// StartTime is the time of the day (format HH:MM) when the rule starts to be active.
+	StartTime *string `json:"startTime,omitempty" yaml:"startTime,omitempty" toml:"startTime,omitempty" jsonschema:"title=startTime,description=Time of the day (HH:MM) when the rule starts to be active."` // nolint: lll
+
+	// EndTime is the time of the day (format HH:MM) when the rule stops to be active.
+	// If EndTime is before StartTime the window goes over midnight.
+	EndTime *string `json:"endTime,omitempty" yaml:"endTime,omitempty" toml:"endTime,omitempty" jsonschema:"title=endTime,description=Time of the day (HH:MM) when the rule stops to be active. If before startTime the window goes over midnight."` // nolint: lll
+
+	// Cron is a standard cron expression (5 fields) describing when a window starts.
+	// It should be used with the field Duration.
+	Cron *string `json:"cron,omitempty" yaml:"cron,omitempty" toml:"cron,omitempty" jsonschema:"title=cron,description=Standard cron expression (5 fields) describing when a window starts. Should be used with duration."` // nolint: lll
+
+	// Duration is how long the window stays open after each cron occurrence (ex: 2h, 30m).
+	Duration *string `json:"duration,omitempty" yaml:"duration,omitempty" toml:"duration,omitempty" jsonschema:"title=duration,description=How long the window stays open after each cron occurrence (ex: 2h or 30m)."` // nolint: lll
+}
+
+// IsActive checks if the evaluation date is inside one of the windows of the schedule.
+// An invalid schedule is never active.
+func (s *RuleSchedule) IsActive(evaluationDate time.Time) bool {
+	loc, err := loadScheduleLocation(s.getTimezone())
+	if err != nil {
+		return false
+	}
+	localDate := evaluationDate.In(loc)
+
+	if s.Cron != nil {
+		return s.isInCronWindow(localDate)
+	}
+	return s.isInTimeWindow(localDate)
+}
+
+// isInCronWindow checks if a cron occurrence happened during the last Duration.
+func (s *RuleSchedule) isInCronWindow(date time.Time) bool {
+	schedule, err := parseScheduleCron(s.getCron())
+	if err != nil {
+		return false
+	}
+	duration, err := time.ParseDuration(s.getDuration())
+	if err != nil || duration <= 0 {
+		return false
+	}
+	// Next returns the first occurrence strictly after the given date, so if the first
+	// occurrence after (date - duration) is not after date, we are inside a window.
+	return !schedule.Next(date.Add(-duration)).After(date)
+}
+
+// isInTimeWindow checks if the date is inside the days / time of the day window.
+func (s *RuleSchedule) isInTimeWindow(date time.Time) bool {
+	start, end, err := s.getTimeWindow()
+	if err != nil {
+		return false
+	}
+	timeOfDay := time.Duration(date.Hour())*time.Hour + time.Duration(date.Minute())*time.Minute +
+		time.Duration(date.Second())*time.Second
+
+	if start <= end {
+		return s.isActiveDay(date.Weekday()) && timeOfDay >= start && timeOfDay < end
+	}
+
+	// The window goes over midnight, the part after midnight belongs to the window of the previous day.
+	if timeOfDay >= start {
+		return s.isActiveDay(date.Weekday())
+	}
+	return timeOfDay < end && s.isActiveDay(date.AddDate(0, 0, -1).Weekday())
+}
+
+// isActiveDay checks if the rule is active for this day of the week.
+func (s *RuleSchedule) isActiveDay(day time.Weekday) bool {
+	if s.Days == nil || len(*s.Days) == 0 {
+		return true
+	}
+	for _, d := range *s.Days {
+		if weekday, ok := parseWeekday(d); ok && weekday == day {
+			return true
+		}
+	}
+	return false
+}
+
+// getTimeWindow returns the start and end of the window as durations since midnight.
+// If no start time is set the window starts at midnight, and if no end time is set it ends at midnight.
+func (s *RuleSchedule) getTimeWindow() (time.Duration, time.Duration, error) {
+	start, err := parseTimeOfDay(s.StartTime, 0)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid startTime: %w", err)
+	}
+	end, err := parseTimeOfDay(s.EndTime, 24*time.Hour)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid endTime: %w", err)
+	}
+	return start, end, nil
+}
+
+// IsValid is checking if the schedule is valid.
+func (s *RuleSchedule) IsValid() error {
+	if _, err := loadScheduleLocation(s.getTimezone()); err != nil {
+		return fmt.Errorf("invalid schedule: unknown timezone %s", s.getTimezone())
+	}
+
+	if s.Cron != nil {
+		if s.Days != nil || s.StartTime != nil || s.EndTime != nil {
+			return fmt.Errorf("invalid schedule: cron can't be used with days, startTime or endTime")
+		}
+		if _, err := parseScheduleCron(s.getCron()); err != nil {
+			return fmt.Errorf("invalid schedule: invalid cron expression %s: %w", s.getCron(), err)
+		}
+		duration, err := time.ParseDuration(s.getDuration())
+		if err != nil || duration <= 0 {
+			return fmt.Errorf("invalid schedule: a positive duration is mandatory with a cron expression")
+		}
+		return nil
+	}
+
+	if s.Duration != nil {
+		return fmt.Errorf("invalid schedule: duration can only be used with a cron expression")
+	}
+	if s.Days == nil && s.StartTime == nil && s.EndTime == nil {
+		return fmt.Errorf("invalid schedule: should have a cron expression or a time window")
+	}
+	for _, d := range s.getDays() {
+		if _, ok := parseWeekday(d); !ok {
+			return fmt.Errorf("invalid schedule: unknown day %s", d)
+		}
+	}
+	start, end, err := s.getTimeWindow()
+	if err != nil {
+		return fmt.Errorf("invalid schedule: %w", err)
+	}
+	if start == end {
+		return fmt.Errorf("invalid schedule: startTime and endTime should be different")
+	}
+	return nil
+}
+
+func (s *RuleSchedule) getTimezone() string {
+	if s.Timezone == nil || *s.Timezone == "" {
+		return "UTC"
+	}
+	return *s.Timezone
+}
+
+func (s *RuleSchedule) getCron() string {
+	if s.Cron == nil {
+		return ""
+	}
+	return *s.Cron
+}
+
+func (s *RuleSchedule) getDuration() string {
+	if s.Duration == nil {
+		return ""
+	}
+	return *s.Duration
+}
+
+func (s *RuleSchedule) getDays() []string {
+	if s.Days == nil {
+		return []string{}
+	}
+	return *s.Days
+}
+
+// parseTimeOfDay converts a HH:MM string into a duration since midnight.
+// "24:00" is accepted to express the end of the day.
+func parseTimeOfDay(value *string, defaultValue time.Duration) (time.Duration, error) {
+	if value == nil {
+		return defaultValue, nil
+	}
+	if *value == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	t, err := time.Parse(timeOfDayLayout, *value)
+	if err != nil {
+		return 0, fmt.Errorf("%s should be in the format HH:MM", *value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// parseWeekday converts a day name (full or 3 letters, case-insensitive) into a time.Weekday.
+func parseWeekday(day string) (time.Weekday, bool) {
+	d := strings.ToLower(strings.TrimSpace(day))
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if d == name || d == name[:3] {
+			return weekday, true
+		}
+	}
+	return time.Sunday, false
+}
+
+// scheduleLocationCache memoizes the timezones used by the schedules, loading a
+// location reads the timezone database, and we don't want to do it for every evaluation.
+var scheduleLocationCache sync.Map // map[string]*time.Location
+
+func loadScheduleLocation(name string) (*time.Location, error) {
+	if v, ok := scheduleLocationCache.Load(name); ok {
+		return v.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := scheduleLocationCache.LoadOrStore(name, loc)
+	return actual.(*time.Location), nil
+}
+
+// scheduleCronCache memoizes the parsed cron expressions.
+// A cron.Schedule is read-only once parsed, so it is safe to share it between evaluations.
+var scheduleCronCache sync.Map // map[string]cron.Schedule
+
+func parseScheduleCron(expression string) (cron.Schedule, error) {
+	if v, ok := scheduleCronCache.Load(expression); ok {
+		return v.(cron.Schedule), nil
+	}
+	schedule, err := cron.ParseStandard(expression)
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := scheduleCronCache.LoadOrStore(expression, schedule)
+	return actual.(cron.Schedule), nil
+}
@@ -0,0 +1,370 @@
+package flag_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/internalerror"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/testutils/testconvert"
+)
+
+func TestRuleSchedule_IsActive(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.NoError(t, err)
+
+	weekdays := &[]string{"monday", "tuesday", "wednesday", "thursday", "friday"}
+	tests := []struct {
+		name     string
+		schedule flag.RuleSchedule
+		date     time.Time
+		want     bool
+	}{
+		{
+			name: "inside a weekday window",
+			schedule: flag.RuleSchedule{
+				Timezone:  testconvert.String("Europe/Paris"),
+				Days:      weekdays,
+				StartTime: testconvert.String("09:00"),
+				EndTime:   testconvert.String("18:00"),
+			},
+			// Wednesday
+			date: time.Date(2024, 5, 15, 10, 30, 0, 0, paris),
+			want: true,
+		},
+		{
+			name: "before the start of the window",
+			schedule: flag.RuleSchedule{
+				Timezone:  testconvert.String("Europe/Paris"),
+				Days:      weekdays,
+				StartTime: testconvert.String("09:00"),
+				EndTime:   testconvert.String("18:00"),
+			},
+			date: time.Date(2024, 5, 15, 8, 59, 0, 0, paris),
+			want: false,
+		},
+		{
+			name: "end of the window is excluded",
+			schedule: flag.RuleSchedule{
+				Timezone:  testconvert.String("Europe/Paris"),
+				Days:      weekdays,
+				StartTime: testconvert.String("09:00"),
+				EndTime:   testconvert.String("18:00"),
+			},
+			date: time.Date(2024, 5, 15, 18, 0, 0, 0, paris),
+			want: false,
+		},
+		{
+			name: "day not in the schedule",
+			schedule: flag.RuleSchedule{
+				Timezone:  testconvert.String("Europe/Paris"),
+				Days:      weekdays,
+				StartTime: testconvert.String("09:00"),
+				EndTime:   testconvert.String("18:00"),
+			},
+			// Saturday
+			date: time.Date(2024, 5, 18, 10, 0, 0, 0, paris),
+			want: false,
+		},
+		{
+			name: "timezone is used to evaluate the window",
+			schedule: flag.RuleSchedule{
+				Timezone:  testconvert.String("Europe/Paris"),
+				StartTime: testconvert.String("09:00"),
+				EndTime:   testconvert.String("18:00"),
+			},
+			// 08:30 UTC is 10:30 in Paris during summer time
+			date: time.Date(2024, 5, 15, 8, 30, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name: "default timezone is UTC",
+			schedule: flag.RuleSchedule{
+				StartTime: testconvert.String("09:00"),
+				EndTime:   testconvert.String("18:00"),
+			},
+			date: time.Date(2024, 5, 15, 8, 30, 0, 0, time.UTC),
+			want: false,
+		},
+		{
+			name: "days only, active the whole day",
+			schedule: flag.RuleSchedule{
+				Days: &[]string{"Sat", "sun"},
+			},
+			date: time.Date(2024, 5, 19, 23, 59, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name: "window over midnight, after the start",
+			schedule: flag.RuleSchedule{
+				Days:      &[]string{"friday"},
+				StartTime: testconvert.String("22:00"),
+				EndTime:   testconvert.String("06:00"),
+			},
+			// Friday
+			date: time.Date(2024, 5, 17, 23, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name: "window over midnight, part after midnight belongs to the previous day",
+			schedule: flag.RuleSchedule{
+				Days:      &[]string{"friday"},
+				StartTime: testconvert.String("22:00"),
+				EndTime:   testconvert.String("06:00"),
+			},
+			// Saturday
+			date: time.Date(2024, 5, 18, 5, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name: "window over midnight, outside of the window",
+			schedule: flag.RuleSchedule{
+				Days:      &[]string{"friday"},
+				StartTime: testconvert.String("22:00"),
+				EndTime:   testconvert.String("06:00"),
+			},
+			// Friday early morning, belongs to Thursday's window
+			date: time.Date(2024, 5, 17, 5, 0, 0, 0, time.UTC),
+			want: false,
+		},
+		{
+			name: "inside a cron window",
+			schedule: flag.RuleSchedule{
+				Timezone: testconvert.String("Europe/Paris"),
+				Cron:     testconvert.String("0 2 * * SUN"),
+				Duration: testconvert.String("2h"),
+			},
+			// Sunday
+			date: time.Date(2024, 5, 19, 3, 0, 0, 0, paris),
+			want: true,
+		},
+		{
+			name: "cron window starts at the occurrence",
+			schedule: flag.RuleSchedule{
+				Cron:     testconvert.String("0 2 * * SUN"),
+				Duration: testconvert.String("2h"),
+			},
+			date: time.Date(2024, 5, 19, 2, 0, 0, 0, time.UTC),
+			want: true,
+		},
+		{
+			name: "after the cron window",
+			schedule: flag.RuleSchedule{
+				Cron:     testconvert.String("0 2 * * SUN"),
+				Duration: testconvert.String("2h"),
+			},
+			date: time.Date(2024, 5, 19, 4, 0, 0, 0, time.UTC),
+			want: false,
+		},
+		{
+			name: "invalid timezone is never active",
+			schedule: flag.RuleSchedule{
+				Timezone:  testconvert.String("Mars/Olympus"),
+				StartTime: testconvert.String("00:00"),
+				EndTime:   testconvert.String("24:00"),
+			},
+			date: time.Date(2024, 5, 19, 4, 0, 0, 0, time.UTC),
+			want: false,
+		},
+		{
+			name: "invalid cron is never active",
+			schedule: flag.RuleSchedule{
+				Cron:     testconvert.String("not a cron"),
+				Duration: testconvert.String("2h"),
+			},
+			date: time.Date(2024, 5, 19, 2, 0, 0, 0, time.UTC),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.schedule.IsActive(tt.date))
+		})
+	}
+}
+
+func TestRuleSchedule_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule flag.RuleSchedule
+		wantErr  assert.ErrorAssertionFunc
+		errMsg   string
+	}{
+		{
+			name: "valid time window",
+			schedule: flag.RuleSchedule{
+				Timezone:  testconvert.String("Europe/Paris"),
+				Days:      &[]string{"monday", "fri"},
+				StartTime: testconvert.String("09:00"),
+				EndTime:   testconvert.String("18:00"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "valid cron",
+			schedule: flag.RuleSchedule{
+				Timezone: testconvert.String("America/New_York"),
+				Cron:     testconvert.String("*/15 1-3 * * MON-FRI"),
+				Duration: testconvert.String("10m"),
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name: "unknown timezone",
+			schedule: flag.RuleSchedule{
+				Timezone:  testconvert.String("Europe/Atlantis"),
+				StartTime: testconvert.String("09:00"),
+			},
+			wantErr: assert.Error,
+			errMsg:  "invalid schedule: unknown timezone Europe/Atlantis",
+		},
+		{
+			name: "invalid cron",
+			schedule: flag.RuleSchedule{
+				Cron:     testconvert.String("0 2 * *"),
+				Duration: testconvert.String("10m"),
+			},
+			wantErr: assert.Error,
+			errMsg: "invalid schedule: invalid cron expression 0 2 * *: " +
+				"expected exactly 5 fields, found 4: [0 2 * *]",
+		},
+		{
+			name: "cron without duration",
+			schedule: flag.RuleSchedule{
+				Cron: testconvert.String("0 2 * * *"),
+			},
+			wantErr: assert.Error,
+			errMsg:  "invalid schedule: a positive duration is mandatory with a cron expression",
+		},
+		{
+			name: "cron with a time window",
+			schedule: flag.RuleSchedule{
+				Cron:      testconvert.String("0 2 * * *"),
+				Duration:  testconvert.String("1h"),
+				StartTime: testconvert.String("09:00"),
+			},
+			wantErr: assert.Error,
+			errMsg:  "invalid schedule: cron can't be used with days, startTime or endTime",
+		},
+		{
+			name: "duration without cron",
+			schedule: flag.RuleSchedule{
+				StartTime: testconvert.String("09:00"),
+				Duration:  testconvert.String("1h"),
+			},
+			wantErr: assert.Error,
+			errMsg:  "invalid schedule: duration can only be used with a cron expression",
+		},
+		{
+			name:     "empty schedule",
+			schedule: flag.RuleSchedule{},
+			wantErr:  assert.Error,
+			errMsg:   "invalid schedule: should have a cron expression or a time window",
+		},
+		{
+			name: "unknown day",
+			schedule: flag.RuleSchedule{
+				Days: &[]string{"funday"},
+			},
+			wantErr: assert.Error,
+			errMsg:  "invalid schedule: unknown day funday",
+		},
+		{
+			name: "invalid time format",
+			schedule: flag.RuleSchedule{
+				StartTime: testconvert.String("9h"),
+			},
+			wantErr: assert.Error,
+			errMsg:  "invalid schedule: invalid startTime: 9h should be in the format HH:MM",
+		},
+		{
+			name: "same start and end time",
+			schedule: flag.RuleSchedule{
+				StartTime: testconvert.String("09:00"),
+				EndTime:   testconvert.String("09:00"),
+			},
+			wantErr: assert.Error,
+			errMsg:  "invalid schedule: startTime and endTime should be different",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.IsValid()
+			tt.wantErr(t, err)
+			if tt.errMsg != "" {
+				assert.EqualError(t, err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestRule_EvaluateWithSchedule(t *testing.T) {
+	rule := flag.Rule{
+		Query:           testconvert.String(`targetingKey eq "user-key"`),
+		VariationResult: testconvert.String("variation_A"),
+		Schedule: &flag.RuleSchedule{
+			Days:      &[]string{"monday"},
+			StartTime: testconvert.String("09:00"),
+			EndTime:   testconvert.String("18:00"),
+		},
+	}
+	ctxAt := func(date time.Time) ffcontext.Context {
+		return ffcontext.NewEvaluationContextBuilder("user-key").
+			AddCustom("gofeatureflag", ffcontext.GoffContextSpecifics{CurrentDateTime: &date}).
+			Build()
+	}
+
+	got, err := rule.Evaluate("user-key", ctxAt(time.Date(2024, 5, 13, 10, 0, 0, 0, time.UTC)), "my-flag", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "variation_A", got)
+
+	_, err = rule.Evaluate("user-key", ctxAt(time.Date(2024, 5, 14, 10, 0, 0, 0, time.UTC)), "my-flag", false)
+	assert.IsType(t, &internalerror.RuleNotApplyError{}, err)
+
+	// the schedule is ignored for the default rule
+	got, err = rule.Evaluate("user-key", ctxAt(time.Date(2024, 5, 14, 10, 0, 0, 0, time.UTC)), "my-flag", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "variation_A", got)
+}
+
+func TestInternalFlag_ValueWithRuleSchedule(t *testing.T) {
+	f := flag.InternalFlag{
+		Variations: &map[string]*any{
+			"on":  testconvert.Interface(true),
+			"off": testconvert.Interface(false),
+		},
+		Rules: &[]flag.Rule{
+			{
+				Name:            testconvert.String("maintenance"),
+				VariationResult: testconvert.String("on"),
+				Query:           testconvert.String(`targetingKey eq "user-key"`),
+				Schedule: &flag.RuleSchedule{
+					Cron:     testconvert.String("0 2 * * SUN"),
+					Duration: testconvert.String("2h"),
+				},
+			},
+		},
+		DefaultRule: &flag.Rule{VariationResult: testconvert.String("off")},
+	}
+	assert.NoError(t, f.IsValid())
+
+	sunday := time.Date(2024, 5, 19, 3, 0, 0, 0, time.UTC)
+	ctx := ffcontext.NewEvaluationContextBuilder("user-key").
+		AddCustom("gofeatureflag", ffcontext.GoffContextSpecifics{CurrentDateTime: &sunday}).
+		Build()
+	got, details := f.Value("my-flag", ctx, flag.Context{DefaultSdkValue: false})
+	assert.Equal(t, true, got)
+	assert.Equal(t, flag.ReasonTargetingMatch, details.Reason)
+	assert.False(t, details.Cacheable)
+
+	monday := time.Date(2024, 5, 20, 3, 0, 0, 0, time.UTC)
+	ctx = ffcontext.NewEvaluationContextBuilder("user-key").
+		AddCustom("gofeatureflag", ffcontext.GoffContextSpecifics{CurrentDateTime: &monday}).
+		Build()
+	got, details = f.Value("my-flag", ctx, flag.Context{DefaultSdkValue: false})
+	assert.Equal(t, false, got)
+	assert.Equal(t, flag.ReasonDefault, details.Reason)
+	assert.False(t, details.Cacheable)
+}
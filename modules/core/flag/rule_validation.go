@@ -34,9 +34,22 @@ func (r *Rule) IsValid(defaultRule bool, variations map[string]*any) error {
 		return err
 	}
 
+	if err := r.validateSchedule(defaultRule); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// validateSchedule validates the schedule configuration of the rule.
+// The schedule is ignored for the default rule, so we don't validate it.
+func (r *Rule) validateSchedule(defaultRule bool) error {
+	if defaultRule || r.Schedule == nil {
+		return nil
+	}
+	return r.Schedule.IsValid()
+}
+
 // validatePercentages validates the percentage configuration of the rule.
 // It checks that percentages are not empty, the sum is not zero, and all
 // referenced variations exist in the provided variations map.
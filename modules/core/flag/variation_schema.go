@@ -0,0 +1,136 @@
+package flag
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+)
+
+// inlineSchemaLocation is the location used to register an inline schema in the compiler.
+const inlineSchemaLocation = "goff://inline-schema.json"
+
+// maxCompiledSchemas is the maximum number of compiled JSON schemas kept in the cache, the oldest
+// schema is evicted when a new schema is compiled.
+const maxCompiledSchemas = 256
+
+// compiledSchemas caches the compiled JSON schemas by content, the flags are validated on every refresh.
+var compiledSchemas = newSchemaCache(maxCompiledSchemas)
+
+// schemaCache is a cache of compiled JSON schemas bounded in size.
+type schemaCache struct {
+	mutex   sync.Mutex
+	maxSize int
+	schemas map[string]*jsonschema.Schema
+	// keys are the keys of the schemas in the order they were added, to evict the oldest one.
+	keys []string
+}
+
+func newSchemaCache(maxSize int) *schemaCache {
+	return &schemaCache{maxSize: maxSize, schemas: make(map[string]*jsonschema.Schema, maxSize)}
+}
+
+func (c *schemaCache) get(key string) (*jsonschema.Schema, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	schema, ok := c.schemas[key]
+	return schema, ok
+}
+
+func (c *schemaCache) add(key string, schema *jsonschema.Schema) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if _, ok := c.schemas[key]; ok {
+		return
+	}
+	if len(c.keys) >= c.maxSize {
+		delete(c.schemas, c.keys[0])
+		c.keys = c.keys[1:]
+	}
+	c.schemas[key] = schema
+	c.keys = append(c.keys, key)
+}
+
+// cachedJSONSchema returns the compiled JSON schema of the flag, the schema is compiled only the first time
+// it is used.
+func cachedJSONSchema(schema any) (*jsonschema.Schema, error) {
+	if _, ok := schema.(string); ok {
+		return nil, fmt.Errorf("the schema should be an inline schema, the locations are resolved " +
+			"when the flag configuration file is loaded")
+	}
+	content, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	key := string(content)
+	if compiled, ok := compiledSchemas.get(key); ok {
+		return compiled, nil
+	}
+	compiled, err := compileJSONSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	compiledSchemas.add(key, compiled)
+	return compiled, nil
+}
+
+// compileJSONSchema compiles an inline JSON schema.
+// The compiler has no loader, the schema cannot reference an external schema (file or URL).
+func compileJSONSchema(schema any) (*jsonschema.Schema, error) {
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(jsonschema.SchemeURLLoader{})
+
+	doc, err := toJSONDocument(schema)
+	if err != nil {
+		return nil, err
+	}
+	if err := compiler.AddResource(inlineSchemaLocation, doc); err != nil {
+		return nil, err
+	}
+	return compiler.Compile(inlineSchemaLocation)
+}
+
+// validateVariationsSchema checks that all the variations are valid against the JSON schema.
+// Several sets of variations can be validated at once (e.g. the variations of the scheduled steps).
+func validateVariationsSchema(schema any, variationSets ...map[string]*any) error {
+	compiledSchema, err := cachedJSONSchema(schema)
+	if err != nil {
+		return fmt.Errorf("invalid jsonSchema: %w", err)
+	}
+
+	for _, variations := range variationSets {
+		// we sort the variations to always return the same error
+		names := make([]string, 0, len(variations))
+		for name := range variations {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if variations[name] == nil {
+				continue
+			}
+			value, err := toJSONDocument(*variations[name])
+			if err != nil {
+				return fmt.Errorf("impossible to validate variation %s: %w", name, err)
+			}
+			if err := compiledSchema.Validate(value); err != nil {
+				return fmt.Errorf("variation %s does not match the jsonSchema: %w", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// toJSONDocument converts a value loaded from YAML, TOML or JSON into a document usable by the
+// JSON schema validator (numbers as json.Number and only map[string]any objects).
+func toJSONDocument(value any) (any, error) {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return jsonschema.UnmarshalJSON(bytes.NewReader(content))
+}
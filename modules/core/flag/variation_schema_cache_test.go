@@ -0,0 +1,23 @@
+package flag
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCache_EvictsTheOldestSchema(t *testing.T) {
+	cache := newSchemaCache(2)
+	for i := range 3 {
+		schema, err := compileJSONSchema(map[string]any{"type": "integer", "maximum": i})
+		require.NoError(t, err)
+		cache.add(strconv.Itoa(i), schema)
+	}
+	assert.Len(t, cache.schemas, 2)
+	_, ok := cache.get("0")
+	assert.False(t, ok, "the oldest schema should be evicted")
+	_, ok = cache.get("2")
+	assert.True(t, ok)
+}
@@ -0,0 +1,155 @@
+package flag_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/testutils/testconvert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestInternalFlag_IsValidWithJSONSchema(t *testing.T) {
+	tests := []struct {
+		name     string
+		flag     string
+		wantErr  assert.ErrorAssertionFunc
+		errorMsg string
+	}{
+		{
+			name: "inline schema with valid variations",
+			flag: `
+variations:
+  small: {title: "Hello", maxItems: 2}
+  big: {title: "Hello", maxItems: 10}
+defaultRule:
+  variation: small
+jsonSchema:
+  type: object
+  properties:
+    title: {type: string}
+    maxItems: {type: integer, minimum: 1}
+  required: [title]
+`,
+			wantErr: assert.NoError,
+		},
+		{
+			name: "inline schema with an invalid variation",
+			flag: `
+variations:
+  small: {title: "Hello", maxItems: 2}
+  big: {titel: "Hello", maxItems: 10}
+defaultRule:
+  variation: small
+jsonSchema:
+  type: object
+  required: [title]
+`,
+			wantErr:  assert.Error,
+			errorMsg: "variation big does not match the jsonSchema",
+		},
+		{
+			name: "inline schema with a number instead of an integer",
+			flag: `
+variations:
+  small: {title: "Hello", maxItems: 2.5}
+defaultRule:
+  variation: small
+jsonSchema:
+  type: object
+  properties:
+    maxItems: {type: integer}
+`,
+			wantErr:  assert.Error,
+			errorMsg: "variation small does not match the jsonSchema",
+		},
+		{
+			name: "schema referenced by location",
+			flag: `
+variations:
+  small: {title: "Hello", maxItems: 2}
+defaultRule:
+  variation: small
+jsonSchema: https://example.com/banner.json`,
+			wantErr:  assert.Error,
+			errorMsg: "invalid jsonSchema: the schema should be an inline schema",
+		},
+		{
+			name: "inline schema referencing an external schema",
+			flag: `
+variations:
+  small: {title: "Hello", maxItems: 2}
+defaultRule:
+  variation: small
+jsonSchema:
+  $ref: file:///etc/banner.json`,
+			wantErr:  assert.Error,
+			errorMsg: "invalid jsonSchema",
+		},
+		{
+			name: "invalid inline schema",
+			flag: `
+variations:
+  small: {title: "Hello"}
+defaultRule:
+  variation: small
+jsonSchema:
+  type: not-a-type
+`,
+			wantErr:  assert.Error,
+			errorMsg: "invalid jsonSchema",
+		},
+		{
+			name: "invalid variation in a scheduled step",
+			flag: `
+variations:
+  small: {title: "Hello"}
+defaultRule:
+  variation: small
+scheduledRollout:
+  - date: 2020-04-10T00:00:00.1+02:00
+    variations:
+      small: {subtitle: "Hello"}
+jsonSchema:
+  type: object
+  required: [title]
+`,
+			wantErr:  assert.Error,
+			errorMsg: "variation small does not match the jsonSchema",
+		},
+		{
+			name: "schema on scalar variations",
+			flag: `
+variations:
+  low: 10
+  high: 1000
+defaultRule:
+  variation: low
+jsonSchema:
+  type: integer
+  maximum: 100
+`,
+			wantErr:  assert.Error,
+			errorMsg: "variation high does not match the jsonSchema",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var f flag.InternalFlag
+			assert.NoError(t, yaml.Unmarshal([]byte(tt.flag), &f))
+			err := f.IsValid()
+			tt.wantErr(t, err)
+			if tt.errorMsg != "" {
+				assert.ErrorContains(t, err, tt.errorMsg)
+			}
+		})
+	}
+}
+
+func TestInternalFlag_GetJSONSchema(t *testing.T) {
+	f := flag.InternalFlag{}
+	assert.Nil(t, f.GetJSONSchema())
+
+	f.JSONSchema = testconvert.Interface("https://example.com/schema.json")
+	assert.Equal(t, "https://example.com/schema.json", f.GetJSONSchema())
+}
@@ -0,0 +1,73 @@
+package ffclient_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ffclient "github.com/thomaspoignant/go-feature-flag"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/retriever/fileretriever"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestOpenTelemetry(t *testing.T) {
+	spanRecorder := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+	gffClient, err := ffclient.New(ffclient.Config{
+		PollingInterval: 1 * time.Minute,
+		Retriever:       &fileretriever.Retriever{Path: "testdata/flag-config.yaml"},
+		OpenTelemetry: &ffclient.OpenTelemetryConfig{
+			TracerProvider: tracerProvider,
+			MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+		},
+	})
+	require.NoError(t, err)
+	defer gffClient.Close()
+
+	// without a span in the context, the evaluations are not traced
+	value, err := gffClient.BoolVariation("test-flag", ffcontext.NewEvaluationContext("random-key"), false)
+	require.NoError(t, err)
+	assert.True(t, value)
+	assert.Empty(t, spanRecorder.Ended())
+
+	ctx, parent := tracerProvider.Tracer("test").Start(
+		ffcontext.WithEvaluationContext(context.Background(), ffcontext.NewEvaluationContext("random-key")),
+		"request",
+	)
+	value, err = gffClient.BoolVariationCtx(ctx, "test-flag", false)
+	require.NoError(t, err)
+	assert.True(t, value)
+	_ = gffClient.AllFlagsStateCtx(ctx)
+	parent.End()
+
+	spans := spanRecorder.Ended()
+	require.Len(t, spans, 3)
+	assert.Equal(t, "feature_flag.evaluation", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("feature_flag.key", "test-flag"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("feature_flag.result.variant", "True"))
+	assert.Contains(t, spans[0].Attributes(), attribute.String("feature_flag.result.reason", "targeting_match"))
+	assert.NotContains(t, spans[0].Attributes(), attribute.String("feature_flag.context.id", "random-key"))
+	assert.Equal(t, "feature_flag.evaluation.all", spans[1].Name())
+	assert.NotEmpty(t, spans[1].Events())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	metricNames := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metricNames[m.Name] = true
+		}
+	}
+	assert.True(t, metricNames["gofeatureflag.evaluations"])
+	assert.True(t, metricNames["gofeatureflag.evaluation.duration"])
+	assert.True(t, metricNames["gofeatureflag.cache.refreshes"])
+}
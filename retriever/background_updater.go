@@ -8,39 +8,38 @@ import (
 // backgroundUpdater contains what is needed to manage the
 // background update of the flags.
 type backgroundUpdater struct {
-	ticker      *time.Ticker
+	timer       *time.Timer
 	updaterChan chan struct{}
 }
 
-// newBackgroundUpdater init default value for the ticker and the channel.
-func newBackgroundUpdater(pollingInterval time.Duration, useJitter bool) backgroundUpdater {
-	tickerDuration := pollingInterval
-	if useJitter {
-		// we accept a deviation of maximum 10% of the polling interval
-		maxJitter := float64(pollingInterval) * 0.1
-		jitter := time.Duration(0)
-		if int64(maxJitter) > 0 {
-			jitter = time.Duration(rand.Int63n(int64(maxJitter))) // nolint: gosec
-		}
-		if jitter%2 == 0 {
-			tickerDuration = pollingInterval + jitter
-		} else {
-			tickerDuration = pollingInterval - jitter
-		}
-	}
-
+// newBackgroundUpdater init default value for the timer and the channel.
+// The timer fires after the delay, it is reset to the next call of a retriever after each refresh.
+func newBackgroundUpdater(delay time.Duration) backgroundUpdater {
 	return backgroundUpdater{
-		ticker:      time.NewTicker(tickerDuration),
+		timer:       time.NewTimer(delay),
 		updaterChan: make(chan struct{}),
 	}
 }
 
-// close stops the ticker and closes the channel.
+// applyPollingJitter returns the polling interval with a random deviation of maximum 10%.
+func applyPollingJitter(pollingInterval time.Duration) time.Duration {
+	maxJitter := float64(pollingInterval) * 0.1
+	jitter := time.Duration(0)
+	if int64(maxJitter) > 0 {
+		jitter = time.Duration(rand.Int63n(int64(maxJitter))) // nolint: gosec
+	}
+	if jitter%2 == 0 {
+		return pollingInterval + jitter
+	}
+	return pollingInterval - jitter
+}
+
+// close stops the timer and closes the channel.
 func (bgu *backgroundUpdater) close() {
 	if bgu.updaterChan != nil {
 		close(bgu.updaterChan)
 	}
-	if bgu.ticker != nil {
-		bgu.ticker.Stop()
+	if bgu.timer != nil {
+		bgu.timer.Stop()
 	}
 }
@@ -15,17 +15,13 @@ func TestClose(t *testing.T) {
 		{
 			name: "Close nil background updater",
 			backgroundUpdater: backgroundUpdater{
-				ticker:      nil,
+				timer:       nil,
 				updaterChan: nil,
 			},
 		},
 		{
 			name:              "Close new background updater",
-			backgroundUpdater: newBackgroundUpdater(500*time.Millisecond, false),
-		},
-		{
-			name:              "Close new background updater with jitter",
-			backgroundUpdater: newBackgroundUpdater(500*time.Millisecond, true),
+			backgroundUpdater: newBackgroundUpdater(500 * time.Millisecond),
 		},
 	}
 	for _, tt := range tests {
@@ -36,3 +32,13 @@ func TestClose(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyPollingJitter(t *testing.T) {
+	pollingInterval := 10 * time.Second
+	for range 100 {
+		got := applyPollingJitter(pollingInterval)
+		assert.GreaterOrEqual(t, got, 9*time.Second)
+		assert.LessOrEqual(t, got, 11*time.Second)
+	}
+	assert.Equal(t, time.Nanosecond, applyPollingJitter(time.Nanosecond))
+}
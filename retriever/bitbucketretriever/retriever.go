@@ -36,14 +36,41 @@ func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
 			r.RepositorySlug,
 		)
 	}
+	return r.readFile(ctx, r.FilePath)
+}
 
-	header := http.Header{}
-	header.Add("Accept", "application/json")
+// Location returns the URL of the file in the Bitbucket API, the includes of the configuration are
+// resolved from it.
+func (r *Retriever) Location() string {
+	return r.root() + "/" + strings.TrimPrefix(r.FilePath, "/")
+}
+
+// ReadInclude reads a file of the repository included by the configuration, in the same branch.
+func (r *Retriever) ReadInclude(ctx context.Context, location string) ([]byte, error) {
+	filePath, err := shared.IncludePath(r.root(), location)
+	if err != nil {
+		return nil, err
+	}
+	return r.readFile(ctx, filePath)
+}
 
+// root returns the URL of the source of the branch in the Bitbucket API.
+func (r *Retriever) root() string {
+	baseURL := r.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.bitbucket.org"
+	}
 	branch := r.Branch
 	if branch == "" {
 		branch = "main"
 	}
+	return fmt.Sprintf("%s/2.0/repositories/%s/src/%s", baseURL, r.RepositorySlug, branch)
+}
+
+// readFile gets the content of a file of the repository from the Bitbucket API.
+func (r *Retriever) readFile(ctx context.Context, filePath string) ([]byte, error) {
+	header := http.Header{}
+	header.Add("Accept", "application/json")
 
 	if r.BitBucketToken != "" {
 		header.Add("Authorization", fmt.Sprintf("Bearer %s", r.BitBucketToken))
@@ -53,16 +80,7 @@ func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
 		return nil, fmt.Errorf("rate limit exceeded. Next call will be after %s", r.rateLimitReset)
 	}
 
-	if r.BaseURL == "" {
-		r.BaseURL = "https://api.bitbucket.org"
-	}
-
-	URL := fmt.Sprintf(
-		"%s/2.0/repositories/%s/src/%s/%s",
-		r.BaseURL,
-		r.RepositorySlug,
-		branch,
-		r.FilePath)
+	URL := r.root() + "/" + filePath
 
 	resp, err := shared.CallHTTPAPI(ctx, URL, http.MethodGet, "", r.Timeout, header, r.httpClient)
 	if err != nil {
@@ -153,3 +153,25 @@ func Test_bitbucket_Retrieve(t *testing.T) {
 		})
 	}
 }
+
+func Test_bitbucket_ReadInclude(t *testing.T) {
+	httpClient := mock.HTTP{}
+	r := bitbucketretriever.Retriever{
+		RepositorySlug: "myorg/myrepo",
+		FilePath:       "config/flags.yaml",
+		Branch:         "dev",
+		BitBucketToken: "XXX",
+	}
+	r.SetHTTPClient(&httpClient)
+	assert.Equal(t, "https://api.bitbucket.org/2.0/repositories/myorg/myrepo/src/dev/config/flags.yaml", r.Location())
+
+	_, err := r.ReadInclude(context.Background(),
+		"https://api.bitbucket.org/2.0/repositories/myorg/myrepo/src/dev/shared/flags.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.bitbucket.org/2.0/repositories/myorg/myrepo/src/dev/shared/flags.yaml",
+		httpClient.Req.URL.String())
+	assert.Equal(t, "Bearer XXX", httpClient.Req.Header.Get("Authorization"))
+
+	_, err = r.ReadInclude(context.Background(), "https://api.bitbucket.org/2.0/repositories/myorg/other/src/dev/a.yaml")
+	assert.ErrorContains(t, err, "is outside of https://api.bitbucket.org/2.0/repositories/myorg/myrepo/src/dev")
+}
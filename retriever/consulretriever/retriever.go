@@ -0,0 +1,253 @@
+package consulretriever
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+const (
+	// watchWaitTime is the maximum duration of a blocking query used to watch the flags.
+	watchWaitTime = 5 * time.Minute
+	// watchRetryDelay is the time before watching the flags again after an error.
+	watchRetryDelay = 5 * time.Second
+)
+
+// Retriever is a configuration struct for a retriever reading the flags from the Consul KV store.
+// The flags are read from a single key containing the whole flag configuration, or from a prefix
+// with one key per flag. Blocking queries are used to refresh the flags as soon as they change in Consul.
+type Retriever struct {
+	// Address is the address of the Consul agent (ex: http://localhost:8500), use an https:// address for TLS.
+	// Default: the CONSUL_HTTP_ADDR environment variable, or 127.0.0.1:8500
+	Address string
+
+	// Key is the key containing the flag configuration file (JSON, YAML or TOML).
+	// Key or Prefix is mandatory.
+	Key string
+
+	// Prefix is the prefix of the keys containing the flags, each key contains the JSON configuration of one flag.
+	// The name of the flag is the key without the prefix.
+	// Key or Prefix is mandatory.
+	Prefix string
+
+	// Token (optional) is the ACL token used to read the keys.
+	// Default: the CONSUL_HTTP_TOKEN environment variable
+	Token string
+
+	// ClientCertPath and ClientKeyPath (optional) are the paths of the client certificate and its key used for mTLS.
+	ClientCertPath string
+	ClientKeyPath  string
+
+	// CACertPath (optional) is the path of the CA certificate used to verify the certificate of Consul.
+	CACertPath string
+
+	logger      *fflog.FFLogger
+	status      retriever.Status
+	client      *api.Client
+	index       atomic.Uint64
+	updates     chan struct{}
+	updatesOnce sync.Once
+	stopWatch   context.CancelFunc
+	watchWg     sync.WaitGroup
+}
+
+// Init connects to Consul and starts watching the flags.
+func (r *Retriever) Init(ctx context.Context, logger *fflog.FFLogger) error {
+	r.status = retriever.RetrieverNotReady
+	r.logger = logger
+	if r.logger == nil {
+		r.logger = &fflog.FFLogger{}
+	}
+	if r.client != nil {
+		r.status = retriever.RetrieverReady
+		return nil
+	}
+	client, err := r.newClient(ctx)
+	if err != nil {
+		r.status = retriever.RetrieverError
+		return err
+	}
+	r.client = client
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	r.stopWatch = cancel
+	r.watchWg.Go(func() { r.watch(watchCtx, client) })
+	r.status = retriever.RetrieverReady
+	return nil
+}
+
+// newClient creates the Consul client and checks that the keys can be read.
+func (r *Retriever) newClient(ctx context.Context) (*api.Client, error) {
+	if (r.Key == "") == (r.Prefix == "") {
+		return nil, errors.New("consul retriever: exactly one of key or prefix should be set")
+	}
+	if (r.ClientCertPath == "") != (r.ClientKeyPath == "") {
+		return nil, errors.New("client certificate and client key must be provided together")
+	}
+	config := api.DefaultConfig()
+	if r.Address != "" {
+		config.Address = r.Address
+	}
+	if r.Token != "" {
+		config.Token = r.Token
+	}
+	if r.ClientCertPath != "" || r.CACertPath != "" {
+		config.TLSConfig = api.TLSConfig{
+			CertFile: r.ClientCertPath,
+			KeyFile:  r.ClientKeyPath,
+			CAFile:   r.CACertPath,
+		}
+	}
+	client, err := api.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("impossible to connect to consul: %w", err)
+	}
+	if _, _, err := r.read(client, (&api.QueryOptions{}).WithContext(ctx)); err != nil {
+		return nil, fmt.Errorf("impossible to connect to consul: %w", err)
+	}
+	return client, nil
+}
+
+// Status is the function returning the internal state of the retriever.
+func (r *Retriever) Status() retriever.Status {
+	if r == nil || r.status == "" {
+		return retriever.RetrieverNotReady
+	}
+	return r.status
+}
+
+// Shutdown stops the watch of the flags.
+func (r *Retriever) Shutdown(_ context.Context) error {
+	if r == nil {
+		return nil
+	}
+	if r.stopWatch != nil {
+		r.stopWatch()
+		r.watchWg.Wait()
+		r.stopWatch = nil
+	}
+	r.client = nil
+	r.status = retriever.RetrieverNotReady
+	return nil
+}
+
+// Retrieve is the function in charge of fetching the flag configuration.
+func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
+	if r.client == nil {
+		return nil, errors.New("consul client is not initialized")
+	}
+	pairs, _, err := r.read(r.client, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("impossible to retrieve the flags from consul: %w", err)
+	}
+	if r.Prefix == "" {
+		if len(pairs) == 0 {
+			return nil, fmt.Errorf("key %q not found in consul", r.Key)
+		}
+		r.index.Store(pairs[0].ModifyIndex)
+		return pairs[0].Value, nil
+	}
+
+	flagsData := make(map[string]any, len(pairs))
+	var index uint64
+	for _, pair := range pairs {
+		flagName := strings.TrimPrefix(pair.Key, r.Prefix)
+		// the keys ending with a / are folders.
+		if flagName == "" || strings.HasSuffix(flagName, "/") {
+			continue
+		}
+		var flagData any
+		if err := json.Unmarshal(pair.Value, &flagData); err != nil {
+			return nil, fmt.Errorf("error unmarshalling flag '%s': %v", pair.Key, err)
+		}
+		flagsData[flagName] = flagData
+		index = max(index, pair.ModifyIndex)
+	}
+	content, err := json.Marshal(flagsData)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling flags data: %v", err)
+	}
+	r.index.Store(index)
+	return content, nil
+}
+
+// OutputFormat declares that the flags read from a prefix are JSON-encoded, the format of the
+// flags read from a key is the file format of GO Feature Flag.
+func (r *Retriever) OutputFormat() string {
+	if r.Prefix != "" {
+		return "json"
+	}
+	return ""
+}
+
+// Version returns the highest modify index of the keys returned by the last call to Retrieve.
+func (r *Retriever) Version() string {
+	index := r.index.Load()
+	if index == 0 {
+		return ""
+	}
+	return strconv.FormatUint(index, 10)
+}
+
+// Updates returns the channel notified each time the flags change in Consul.
+func (r *Retriever) Updates() <-chan struct{} {
+	r.updatesOnce.Do(func() { r.updates = make(chan struct{}, 1) })
+	return r.updates
+}
+
+// read reads the key or the keys under the prefix.
+func (r *Retriever) read(client *api.Client, opts *api.QueryOptions) (api.KVPairs, *api.QueryMeta, error) {
+	if r.Prefix != "" {
+		return client.KV().List(r.Prefix, opts)
+	}
+	pair, meta, err := client.KV().Get(r.Key, opts)
+	if err != nil || pair == nil {
+		return nil, meta, err
+	}
+	return api.KVPairs{pair}, meta, nil
+}
+
+// watch uses blocking queries to notify the updates until the context is done.
+func (r *Retriever) watch(ctx context.Context, client *api.Client) {
+	var index uint64
+	for ctx.Err() == nil {
+		opts := (&api.QueryOptions{WaitIndex: index, WaitTime: watchWaitTime}).WithContext(ctx)
+		_, meta, err := r.read(client, opts)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			r.logger.Warn("The consul watch is interrupted, retrying.", "error", err.Error())
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchRetryDelay):
+				continue
+			}
+		}
+		// the index can go backwards (ex: after a snapshot restore), any other index means a change.
+		if index != 0 && meta.LastIndex != index {
+			r.notify()
+		}
+		index = meta.LastIndex
+	}
+}
+
+// notify sends an update without blocking, an update already pending is enough.
+func (r *Retriever) notify() {
+	r.Updates()
+	select {
+	case r.updates <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,300 @@
+package consulretriever_test
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/consulretriever"
+)
+
+func TestConsulRetriever_Key(t *testing.T) {
+	consul := newFakeConsul("")
+	server := httptest.NewServer(consul)
+	defer server.Close()
+	content, err := os.ReadFile(filepath.Join("testdata", "flag-config.yaml"))
+	require.NoError(t, err)
+	consul.put("goff/flags", content)
+
+	r := &consulretriever.Retriever{Address: server.URL, Key: "goff/flags"}
+	require.NoError(t, r.Init(context.TODO(), nil))
+	defer func() { assert.NoError(t, r.Shutdown(context.TODO())) }()
+	assert.Equal(t, retriever.RetrieverReady, r.Status())
+
+	got, err := r.Retrieve(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, string(content), string(got))
+	assert.Empty(t, r.OutputFormat(), "the format of the key is the file format of GO Feature Flag")
+	assert.Equal(t, "1", r.Version())
+
+	r2 := &consulretriever.Retriever{Address: server.URL, Key: "goff/unknown"}
+	require.NoError(t, r2.Init(context.TODO(), nil))
+	defer func() { assert.NoError(t, r2.Shutdown(context.TODO())) }()
+	_, err = r2.Retrieve(context.TODO())
+	assert.EqualError(t, err, `key "goff/unknown" not found in consul`)
+}
+
+func TestConsulRetriever_Prefix(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		assertErr assert.ErrorAssertionFunc
+		want      []string
+	}{
+		{
+			name:      "one key per flag",
+			files:     map[string]string{"flag1": "flag1.json", "flag2": "flag2.json"},
+			assertErr: assert.NoError,
+			want:      []string{"flag1", "flag2"},
+		},
+		{
+			name:      "no flag",
+			files:     map[string]string{},
+			assertErr: assert.NoError,
+			want:      []string{},
+		},
+		{
+			name:      "invalid json",
+			files:     map[string]string{"flag1": "flag1.json", "flag2": "flag-config.yaml"},
+			assertErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consul := newFakeConsul("")
+			server := httptest.NewServer(consul)
+			defer server.Close()
+			for flagName, file := range tt.files {
+				content, err := os.ReadFile(filepath.Join("testdata", file))
+				require.NoError(t, err)
+				consul.put("goff/flags/"+flagName, content)
+			}
+			// folders and keys outside the prefix are ignored
+			consul.put("goff/flags/", nil)
+			consul.put("goff/other", []byte("{}"))
+
+			r := &consulretriever.Retriever{Address: server.URL, Prefix: "goff/flags/"}
+			require.NoError(t, r.Init(context.TODO(), nil))
+			defer func() { assert.NoError(t, r.Shutdown(context.TODO())) }()
+
+			got, err := r.Retrieve(context.TODO())
+			tt.assertErr(t, err)
+			if err != nil {
+				return
+			}
+			var flags map[string]any
+			require.NoError(t, json.Unmarshal(got, &flags))
+			assert.ElementsMatch(t, tt.want, keys(flags))
+			assert.Equal(t, "json", r.OutputFormat())
+		})
+	}
+}
+
+func TestConsulRetriever_Watch(t *testing.T) {
+	consul := newFakeConsul("")
+	server := httptest.NewServer(consul)
+	defer server.Close()
+	consul.put("goff/flags/flag1", []byte("{}"))
+
+	r := &consulretriever.Retriever{Address: server.URL, Prefix: "goff/flags/"}
+	require.NoError(t, r.Init(context.TODO(), nil))
+	defer func() { assert.NoError(t, r.Shutdown(context.TODO())) }()
+	require.Eventually(t, consul.isWatched, time.Second, 10*time.Millisecond, "the flags are not watched")
+
+	consul.put("goff/other", []byte("{}"))
+	select {
+	case <-r.Updates():
+		t.Fatal("a change outside the prefix should not be notified")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	consul.put("goff/flags/flag1", []byte(`{"variations":{}}`))
+	select {
+	case <-r.Updates():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the change of the flag is not notified")
+	}
+}
+
+func TestConsulRetriever_Token(t *testing.T) {
+	consul := newFakeConsul("secret-token")
+	server := httptest.NewServer(consul)
+	defer server.Close()
+	consul.put("goff/flags", []byte("{}"))
+
+	r := &consulretriever.Retriever{Address: server.URL, Key: "goff/flags", Token: "invalid-token"}
+	assert.ErrorContains(t, r.Init(context.TODO(), nil), "Permission denied")
+	assert.Equal(t, retriever.RetrieverError, r.Status())
+
+	r = &consulretriever.Retriever{Address: server.URL, Key: "goff/flags", Token: "secret-token"}
+	require.NoError(t, r.Init(context.TODO(), nil))
+	defer func() { assert.NoError(t, r.Shutdown(context.TODO())) }()
+	got, err := r.Retrieve(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(got))
+}
+
+func TestConsulRetriever_TLS(t *testing.T) {
+	consul := newFakeConsul("")
+	server := httptest.NewTLSServer(consul)
+	defer server.Close()
+	consul.put("goff/flags", []byte("{}"))
+
+	caCertPath := filepath.Join(t.TempDir(), "ca.crt")
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caCertPath, caCert, 0o600))
+
+	r := &consulretriever.Retriever{Address: server.URL, Key: "goff/flags"}
+	assert.Error(t, r.Init(context.TODO(), nil), "the certificate of the server is not trusted")
+
+	r = &consulretriever.Retriever{Address: server.URL, Key: "goff/flags", CACertPath: caCertPath}
+	require.NoError(t, r.Init(context.TODO(), nil))
+	defer func() { assert.NoError(t, r.Shutdown(context.TODO())) }()
+	got, err := r.Retrieve(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(got))
+}
+
+// TestRetrieverErrorHandling tests various error conditions in the Consul retriever
+func TestRetrieverErrorHandling(t *testing.T) {
+	t.Run("Init - no key and no prefix", func(t *testing.T) {
+		r := &consulretriever.Retriever{Address: "http://localhost:8500"}
+		assert.EqualError(t, r.Init(context.TODO(), nil), "consul retriever: exactly one of key or prefix should be set")
+		assert.Equal(t, retriever.RetrieverError, r.Status())
+	})
+
+	t.Run("Init - key and prefix", func(t *testing.T) {
+		r := &consulretriever.Retriever{Address: "http://localhost:8500", Key: "goff", Prefix: "goff/"}
+		assert.Error(t, r.Init(context.TODO(), nil))
+	})
+
+	t.Run("Init - client certificate without key", func(t *testing.T) {
+		r := &consulretriever.Retriever{Address: "https://localhost:8500", Key: "goff", ClientCertPath: "client.crt"}
+		assert.EqualError(t, r.Init(context.TODO(), nil), "client certificate and client key must be provided together")
+	})
+
+	t.Run("Init - consul not reachable", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+		r := &consulretriever.Retriever{Address: server.URL, Key: "goff"}
+		assert.Error(t, r.Init(context.TODO(), nil))
+		assert.Equal(t, retriever.RetrieverError, r.Status())
+	})
+
+	t.Run("Retrieve - nil client", func(t *testing.T) {
+		r := &consulretriever.Retriever{Key: "goff"}
+		_, err := r.Retrieve(context.TODO())
+		assert.EqualError(t, err, "consul client is not initialized")
+	})
+
+	t.Run("Status - nil receiver", func(t *testing.T) {
+		var r *consulretriever.Retriever
+		assert.Equal(t, retriever.RetrieverNotReady, r.Status())
+	})
+
+	t.Run("Shutdown - not initialized", func(t *testing.T) {
+		r := &consulretriever.Retriever{Key: "goff"}
+		assert.NoError(t, r.Shutdown(context.TODO()))
+	})
+}
+
+// fakeConsul is a minimal implementation of the KV API of Consul, with the blocking queries.
+type fakeConsul struct {
+	token   string
+	mutex   sync.Mutex
+	changed chan struct{}
+	index   uint64
+	pairs   map[string]kvPair
+	watched bool
+}
+
+type kvPair struct {
+	Key         string
+	Value       []byte
+	CreateIndex uint64
+	ModifyIndex uint64
+}
+
+func newFakeConsul(token string) *fakeConsul {
+	return &fakeConsul{token: token, changed: make(chan struct{}), pairs: map[string]kvPair{}}
+}
+
+func (f *fakeConsul) put(key string, value []byte) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.index++
+	f.pairs[key] = kvPair{Key: key, Value: value, CreateIndex: f.index, ModifyIndex: f.index}
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+func (f *fakeConsul) isWatched() bool {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.watched
+}
+
+func (f *fakeConsul) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if f.token != "" && req.Header.Get("X-Consul-Token") != f.token {
+		http.Error(w, "Permission denied", http.StatusForbidden)
+		return
+	}
+	key := strings.TrimPrefix(req.URL.Path, "/v1/kv/")
+	_, recurse := req.URL.Query()["recurse"]
+	waitIndex, _ := strconv.ParseUint(req.URL.Query().Get("index"), 10, 64)
+	for {
+		pairs, index, changed := f.read(key, recurse, waitIndex != 0)
+		if waitIndex == 0 || index > waitIndex {
+			w.Header().Set("X-Consul-Index", strconv.FormatUint(index, 10))
+			if len(pairs) == 0 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(pairs)
+			return
+		}
+		select {
+		case <-changed:
+		case <-req.Context().Done():
+			return
+		}
+	}
+}
+
+// read returns the pairs matching the key, and the highest modify index of these pairs.
+func (f *fakeConsul) read(key string, recurse bool, blocking bool) ([]kvPair, uint64, chan struct{}) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.watched = f.watched || blocking
+	pairs := []kvPair{}
+	index := uint64(1)
+	for k, pair := range f.pairs {
+		if k == key || (recurse && strings.HasPrefix(k, key)) {
+			pairs = append(pairs, pair)
+			index = max(index, pair.ModifyIndex)
+		}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].Key < pairs[j].Key })
+	return pairs, index, f.changed
+}
+
+func keys(m map[string]any) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	return res
+}
@@ -0,0 +1,127 @@
+package dirretriever
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/thomaspoignant/go-feature-flag/internal/flaginclude"
+)
+
+// Retriever is a configuration struct for a retriever loading all the flag configuration files
+// of a directory, or all the files matching a glob pattern.
+//
+// The files are loaded in the alphabetical order of their path and merged, the format of each file
+// is detected from its extension (.yaml, .yml, .json or .toml).
+// A flag defined in several files is reported as an error.
+type Retriever struct {
+	// Path is a directory or a glob pattern (ex: /etc/goff/flags/*.yaml).
+	// When it is a directory, all the files with a supported extension are loaded, the
+	// subdirectories are ignored. When it is a glob pattern, the matching files without a supported
+	// extension are ignored.
+	Path string
+	// IncludeAllowedOrigins (optional) are the origins (ex: https://config.example.com) of the remote
+	// files that can be included by the files.
+	IncludeAllowedOrigins []string
+}
+
+// Retrieve is loading all the files and returns the merged flag configuration in JSON.
+func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
+	files, err := r.files()
+	if err != nil {
+		return nil, err
+	}
+
+	flags := map[string]any{}
+	flagFiles := map[string]string{}
+	for _, file := range files {
+		fileFlags, err := readFile(ctx, file, r.IncludeAllowedOrigins)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range fileFlags {
+			if previousFile, ok := flagFiles[key]; ok {
+				return nil, fmt.Errorf("flag %s is defined in several files: %s and %s", key, previousFile, file)
+			}
+			flagFiles[key] = file
+			flags[key] = value
+		}
+	}
+	return json.Marshal(flags)
+}
+
+// OutputFormat declares that this retriever always returns JSON-encoded data,
+// so the manager can pick the JSON parser regardless of the global FileFormat.
+func (r *Retriever) OutputFormat() string {
+	return "json"
+}
+
+// files returns the sorted list of files to load.
+func (r *Retriever) files() ([]string, error) {
+	if r.Path == "" {
+		return nil, fmt.Errorf("missing mandatory information path")
+	}
+
+	info, err := os.Stat(r.Path)
+	if err == nil && info.IsDir() {
+		entries, err := os.ReadDir(r.Path)
+		if err != nil {
+			return nil, err
+		}
+		files := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			if !entry.IsDir() && fileFormat(entry.Name()) != "" {
+				files = append(files, filepath.Join(r.Path, entry.Name()))
+			}
+		}
+		return files, nil
+	}
+
+	matches, err := filepath.Glob(r.Path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid glob pattern %s: %w", r.Path, err)
+	}
+	if len(matches) == 0 && !strings.ContainsAny(r.Path, "*?[") {
+		return nil, fmt.Errorf("impossible to find %s: no such file or directory", r.Path)
+	}
+	files := make([]string, 0, len(matches))
+	for _, match := range matches {
+		if info, err := os.Stat(match); err == nil && !info.IsDir() && fileFormat(match) != "" {
+			files = append(files, match)
+		}
+	}
+	slices.Sort(files)
+	return files, nil
+}
+
+// readFile reads the flags of a file and of its includes, the format is detected from the extension.
+func readFile(ctx context.Context, file string, includeAllowedOrigins []string) (map[string]any, error) {
+	format := fileFormat(file)
+	if format == "" {
+		return nil, fmt.Errorf("unsupported format for file %s: should be .yaml, .yml, .json or .toml", file)
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return flaginclude.Load(ctx, content, format, file, flaginclude.Options{AllowedOrigins: includeAllowedOrigins})
+}
+
+// fileFormat returns the format of the file based on its extension, an empty string
+// if the extension is not supported.
+func fileFormat(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".json":
+		return "json"
+	case ".toml":
+		return "toml"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,183 @@
+package dirretriever_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/retriever/dirretriever"
+)
+
+const yamlFlag = `yaml-flag:
+  variations:
+    enabled: true
+    disabled: false
+  defaultRule:
+    variation: enabled
+`
+
+const jsonFlag = `{
+  "json-flag": {
+    "variations": {"enabled": true, "disabled": false},
+    "defaultRule": {"variation": "enabled"}
+  }
+}`
+
+const tomlFlag = `[toml-flag.variations]
+enabled = true
+disabled = false
+
+[toml-flag.defaultRule]
+variation = "enabled"
+`
+
+func writeFile(t *testing.T, dir string, name string, content string) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o750))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestRetriever_Retrieve(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.yaml", yamlFlag)
+	writeFile(t, dir, "b.json", jsonFlag)
+	writeFile(t, dir, "c.toml", tomlFlag)
+	writeFile(t, dir, "README.md", "not a flag file")
+	writeFile(t, dir, "sub/d.yaml", "sub-flag:\n  variations:\n    enabled: true\n")
+
+	tests := []struct {
+		name    string
+		path    string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "directory",
+			path: dir,
+			want: `{
+  "json-flag": {"defaultRule": {"variation": "enabled"}, "variations": {"disabled": false, "enabled": true}},
+  "toml-flag": {"defaultRule": {"variation": "enabled"}, "variations": {"disabled": false, "enabled": true}},
+  "yaml-flag": {"defaultRule": {"variation": "enabled"}, "variations": {"disabled": false, "enabled": true}}
+}`,
+		},
+		{
+			name: "glob pattern",
+			path: filepath.Join(dir, "*.yaml"),
+			want: `{"yaml-flag": {"defaultRule": {"variation": "enabled"}, "variations": {"disabled": false, "enabled": true}}}`,
+		},
+		{
+			name: "glob pattern in subdirectories",
+			path: filepath.Join(dir, "*", "*.yaml"),
+			want: `{"sub-flag": {"variations": {"enabled": true}}}`,
+		},
+		{
+			name: "glob pattern without match",
+			path: filepath.Join(dir, "*.yml"),
+			want: `{}`,
+		},
+		{
+			name: "glob pattern matching an unsupported file",
+			path: filepath.Join(dir, "*.md"),
+			want: `{}`,
+		},
+		{
+			name: "glob pattern matching all the files",
+			path: filepath.Join(dir, "*"),
+			want: `{
+  "json-flag": {"defaultRule": {"variation": "enabled"}, "variations": {"disabled": false, "enabled": true}},
+  "toml-flag": {"defaultRule": {"variation": "enabled"}, "variations": {"disabled": false, "enabled": true}},
+  "yaml-flag": {"defaultRule": {"variation": "enabled"}, "variations": {"disabled": false, "enabled": true}}
+}`,
+		},
+		{
+			name:    "directory not found",
+			path:    filepath.Join(dir, "missing"),
+			wantErr: "impossible to find " + filepath.Join(dir, "missing"),
+		},
+		{
+			name:    "missing path",
+			wantErr: "missing mandatory information path",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &dirretriever.Retriever{Path: tt.path}
+			got, err := r.Retrieve(context.Background())
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+			assert.Equal(t, "json", r.OutputFormat())
+		})
+	}
+}
+
+func TestRetriever_DuplicateFlag(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.yaml", yamlFlag)
+	writeFile(t, dir, "b.yml", yamlFlag)
+
+	r := &dirretriever.Retriever{Path: dir}
+	_, err := r.Retrieve(context.Background())
+	assert.EqualError(t, err, "flag yaml-flag is defined in several files: "+
+		filepath.Join(dir, "a.yaml")+" and "+filepath.Join(dir, "b.yml"))
+}
+
+func TestRetriever_InvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.json", "{invalid json")
+
+	r := &dirretriever.Retriever{Path: dir}
+	_, err := r.Retrieve(context.Background())
+	assert.ErrorContains(t, err, "impossible to parse file "+filepath.Join(dir, "a.json"))
+}
+
+func TestRetriever_RemoteInclude(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(jsonFlag))
+	}))
+	defer server.Close()
+	dir := t.TempDir()
+	writeFile(t, dir, "a.yaml", "include: "+server.URL+"/flags.json\n"+yamlFlag)
+
+	r := &dirretriever.Retriever{Path: dir}
+	_, err := r.Retrieve(context.Background())
+	assert.ErrorContains(t, err, "the origin "+server.URL+" is not allowed")
+
+	r = &dirretriever.Retriever{Path: dir, IncludeAllowedOrigins: []string{server.URL}}
+	got, err := r.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "json-flag")
+	assert.Contains(t, string(got), "yaml-flag")
+}
+
+func TestRetriever_FilesAddedAndRemoved(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "a.yaml", yamlFlag)
+	r := &dirretriever.Retriever{Path: dir}
+
+	got, err := r.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "yaml-flag")
+	assert.NotContains(t, string(got), "json-flag")
+
+	writeFile(t, dir, "b.json", jsonFlag)
+	got, err = r.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, string(got), "yaml-flag")
+	assert.Contains(t, string(got), "json-flag")
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "a.yaml")))
+	got, err = r.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.NotContains(t, string(got), "yaml-flag")
+	assert.Contains(t, string(got), "json-flag")
+}
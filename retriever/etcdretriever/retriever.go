@@ -0,0 +1,280 @@
+package etcdretriever
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+	"go.etcd.io/etcd/client/pkg/v3/transport"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultDialTimeout = 5 * time.Second
+	// watchRetryDelay is the time before watching the key again after the watch was interrupted.
+	watchRetryDelay = 5 * time.Second
+)
+
+// Retriever is a configuration struct for a retriever reading the flags from etcd.
+// The flags are read from a single key containing the whole flag configuration, or from a prefix
+// with one key per flag. A watch is used to refresh the flags as soon as they change in etcd.
+type Retriever struct {
+	// Endpoints is the list of the etcd endpoints (ex: http://localhost:2379).
+	Endpoints []string
+
+	// Key is the key containing the flag configuration file (JSON, YAML or TOML).
+	// Key or Prefix is mandatory.
+	Key string
+
+	// Prefix is the prefix of the keys containing the flags, each key contains the JSON configuration of one flag.
+	// The name of the flag is the key without the prefix.
+	// Key or Prefix is mandatory.
+	Prefix string
+
+	// Username and Password (optional) are used to authenticate to etcd when the authentication is enabled.
+	Username string
+	Password string
+
+	// ClientCertPath and ClientKeyPath (optional) are the paths of the client certificate and its key used for mTLS.
+	ClientCertPath string
+	ClientKeyPath  string
+
+	// CACertPath (optional) is the path of the CA certificate used to verify the certificate of etcd.
+	CACertPath string
+
+	// DialTimeout (optional) is the timeout to connect to etcd.
+	// Default: 5 seconds
+	DialTimeout time.Duration
+
+	logger      *fflog.FFLogger
+	status      retriever.Status
+	client      *clientv3.Client
+	revision    atomic.Int64
+	updates     chan struct{}
+	updatesOnce sync.Once
+	stopWatch   context.CancelFunc
+	watchWg     sync.WaitGroup
+}
+
+// Init connects to etcd and starts watching the flags.
+func (r *Retriever) Init(ctx context.Context, logger *fflog.FFLogger) error {
+	r.status = retriever.RetrieverNotReady
+	r.logger = logger
+	if r.logger == nil {
+		r.logger = &fflog.FFLogger{}
+	}
+	if r.client != nil {
+		r.status = retriever.RetrieverReady
+		return nil
+	}
+	client, err := r.newClient(ctx)
+	if err != nil {
+		r.status = retriever.RetrieverError
+		return err
+	}
+	r.client = client
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	r.stopWatch = cancel
+	r.watchWg.Go(func() { r.watch(watchCtx, client) })
+	r.status = retriever.RetrieverReady
+	return nil
+}
+
+// newClient creates the etcd client and checks that etcd is reachable.
+func (r *Retriever) newClient(ctx context.Context) (*clientv3.Client, error) {
+	if (r.Key == "") == (r.Prefix == "") {
+		return nil, errors.New("etcd retriever: exactly one of key or prefix should be set")
+	}
+	dialTimeout := r.DialTimeout
+	if dialTimeout <= 0 {
+		dialTimeout = defaultDialTimeout
+	}
+	tlsConfig, err := r.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   r.Endpoints,
+		Username:    r.Username,
+		Password:    r.Password,
+		DialTimeout: dialTimeout,
+		TLS:         tlsConfig,
+		Logger:      zap.NewNop(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("impossible to connect to etcd: %w", err)
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	key, opts := r.keyAndOptions()
+	if _, err := client.Get(checkCtx, key, append(opts, clientv3.WithCountOnly())...); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("impossible to connect to etcd: %w", err)
+	}
+	return client, nil
+}
+
+// tlsConfig returns the TLS configuration of the client, nil if TLS is not used.
+func (r *Retriever) tlsConfig() (*tls.Config, error) {
+	if r.ClientCertPath == "" && r.ClientKeyPath == "" && r.CACertPath == "" {
+		for _, endpoint := range r.Endpoints {
+			if strings.HasPrefix(endpoint, "https://") {
+				return &tls.Config{MinVersion: tls.VersionTLS12}, nil
+			}
+		}
+		return nil, nil
+	}
+	if (r.ClientCertPath == "") != (r.ClientKeyPath == "") {
+		return nil, errors.New("client certificate and client key must be provided together")
+	}
+	tlsInfo := transport.TLSInfo{
+		CertFile:      r.ClientCertPath,
+		KeyFile:       r.ClientKeyPath,
+		TrustedCAFile: r.CACertPath,
+	}
+	return tlsInfo.ClientConfig()
+}
+
+// Status is the function returning the internal state of the retriever.
+func (r *Retriever) Status() retriever.Status {
+	if r == nil || r.status == "" {
+		return retriever.RetrieverNotReady
+	}
+	return r.status
+}
+
+// Shutdown stops the watch and closes the connection to etcd.
+func (r *Retriever) Shutdown(_ context.Context) error {
+	if r == nil {
+		return nil
+	}
+	if r.stopWatch != nil {
+		r.stopWatch()
+		r.watchWg.Wait()
+		r.stopWatch = nil
+	}
+	r.status = retriever.RetrieverNotReady
+	if r.client == nil {
+		return nil
+	}
+	err := r.client.Close()
+	r.client = nil
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	return nil
+}
+
+// Retrieve is the function in charge of fetching the flag configuration.
+func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
+	if r.client == nil {
+		return nil, errors.New("etcd client is not initialized")
+	}
+	key, opts := r.keyAndOptions()
+	resp, err := r.client.Get(ctx, key, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("impossible to retrieve the flags from etcd: %w", err)
+	}
+	r.revision.Store(resp.Header.Revision)
+	if r.Prefix == "" {
+		if len(resp.Kvs) == 0 {
+			return nil, fmt.Errorf("key %q not found in etcd", r.Key)
+		}
+		return resp.Kvs[0].Value, nil
+	}
+
+	flagsData := make(map[string]any, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		flagName := strings.TrimPrefix(string(kv.Key), r.Prefix)
+		if flagName == "" {
+			continue
+		}
+		var flagData any
+		if err := json.Unmarshal(kv.Value, &flagData); err != nil {
+			return nil, fmt.Errorf("error unmarshalling flag '%s': %v", kv.Key, err)
+		}
+		flagsData[flagName] = flagData
+	}
+	content, err := json.Marshal(flagsData)
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling flags data: %v", err)
+	}
+	return content, nil
+}
+
+// OutputFormat declares that the flags read from a prefix are JSON-encoded, the format of the
+// flags read from a key is the file format of GO Feature Flag.
+func (r *Retriever) OutputFormat() string {
+	if r.Prefix != "" {
+		return "json"
+	}
+	return ""
+}
+
+// Version returns the etcd revision of the flag configuration returned by the last call to Retrieve.
+func (r *Retriever) Version() string {
+	revision := r.revision.Load()
+	if revision == 0 {
+		return ""
+	}
+	return strconv.FormatInt(revision, 10)
+}
+
+// Updates returns the channel notified each time the flags change in etcd.
+func (r *Retriever) Updates() <-chan struct{} {
+	r.updatesOnce.Do(func() { r.updates = make(chan struct{}, 1) })
+	return r.updates
+}
+
+// keyAndOptions returns the key to read and watch, with the options to use the prefix.
+func (r *Retriever) keyAndOptions() (string, []clientv3.OpOption) {
+	if r.Prefix != "" {
+		return r.Prefix, []clientv3.OpOption{clientv3.WithPrefix()}
+	}
+	return r.Key, nil
+}
+
+// watch notifies the updates until the context is done, the watch is restarted if it is interrupted.
+func (r *Retriever) watch(ctx context.Context, client *clientv3.Client) {
+	key, opts := r.keyAndOptions()
+	for ctx.Err() == nil {
+		watchCtx, cancel := context.WithCancel(clientv3.WithRequireLeader(ctx))
+		for resp := range client.Watch(watchCtx, key, opts...) {
+			if err := resp.Err(); err != nil {
+				r.logger.Warn("The etcd watch is interrupted, retrying.", "error", err.Error())
+				break
+			}
+			if len(resp.Events) > 0 {
+				r.notify()
+			}
+		}
+		cancel()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryDelay):
+			// changes may have been missed while the watch was interrupted.
+			r.notify()
+		}
+	}
+}
+
+// notify sends an update without blocking, an update already pending is enough.
+func (r *Retriever) notify() {
+	r.Updates()
+	select {
+	case r.updates <- struct{}{}:
+	default:
+	}
+}
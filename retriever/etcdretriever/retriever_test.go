@@ -0,0 +1,244 @@
+package etcdretriever_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/etcdretriever"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.etcd.io/etcd/server/v3/embed"
+)
+
+func TestEtcdRetriever_Key(t *testing.T) {
+	endpoint, client := startEtcd(t)
+	content, err := os.ReadFile(filepath.Join("testdata", "flag-config.yaml"))
+	require.NoError(t, err)
+	_, err = client.Put(context.TODO(), "goff/flags", string(content))
+	require.NoError(t, err)
+
+	r := &etcdretriever.Retriever{Endpoints: []string{endpoint}, Key: "goff/flags"}
+	require.NoError(t, r.Init(context.TODO(), nil))
+	defer func() { assert.NoError(t, r.Shutdown(context.TODO())) }()
+	assert.Equal(t, retriever.RetrieverReady, r.Status())
+
+	got, err := r.Retrieve(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, string(content), string(got))
+	assert.Empty(t, r.OutputFormat(), "the format of the key is the file format of GO Feature Flag")
+	assert.NotEmpty(t, r.Version())
+
+	r2 := &etcdretriever.Retriever{Endpoints: []string{endpoint}, Key: "goff/unknown"}
+	require.NoError(t, r2.Init(context.TODO(), nil))
+	defer func() { assert.NoError(t, r2.Shutdown(context.TODO())) }()
+	_, err = r2.Retrieve(context.TODO())
+	assert.EqualError(t, err, `key "goff/unknown" not found in etcd`)
+}
+
+func TestEtcdRetriever_Prefix(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     map[string]string
+		assertErr assert.ErrorAssertionFunc
+		want      []string
+	}{
+		{
+			name:      "one key per flag",
+			files:     map[string]string{"flag1": "flag1.json", "flag2": "flag2.json"},
+			assertErr: assert.NoError,
+			want:      []string{"flag1", "flag2"},
+		},
+		{
+			name:      "no flag",
+			files:     map[string]string{},
+			assertErr: assert.NoError,
+			want:      []string{},
+		},
+		{
+			name:      "invalid json",
+			files:     map[string]string{"flag1": "flag1.json", "flag2": "flag-config.yaml"},
+			assertErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, client := startEtcd(t)
+			for flagName, file := range tt.files {
+				content, err := os.ReadFile(filepath.Join("testdata", file))
+				require.NoError(t, err)
+				_, err = client.Put(context.TODO(), "goff/flags/"+flagName, string(content))
+				require.NoError(t, err)
+			}
+			// a key outside the prefix is ignored
+			_, err := client.Put(context.TODO(), "goff/other", "{}")
+			require.NoError(t, err)
+
+			r := &etcdretriever.Retriever{Endpoints: []string{endpoint}, Prefix: "goff/flags/"}
+			require.NoError(t, r.Init(context.TODO(), nil))
+			defer func() { assert.NoError(t, r.Shutdown(context.TODO())) }()
+
+			got, err := r.Retrieve(context.TODO())
+			tt.assertErr(t, err)
+			if err != nil {
+				return
+			}
+			var flags map[string]any
+			require.NoError(t, json.Unmarshal(got, &flags))
+			assert.ElementsMatch(t, tt.want, keys(flags))
+			assert.Equal(t, "json", r.OutputFormat())
+		})
+	}
+}
+
+func TestEtcdRetriever_Watch(t *testing.T) {
+	endpoint, client := startEtcd(t)
+	r := &etcdretriever.Retriever{Endpoints: []string{endpoint}, Prefix: "goff/flags/"}
+	require.NoError(t, r.Init(context.TODO(), nil))
+	defer func() { assert.NoError(t, r.Shutdown(context.TODO())) }()
+
+	_, err := client.Put(context.TODO(), "goff/other", "{}")
+	require.NoError(t, err)
+	select {
+	case <-r.Updates():
+		t.Fatal("a change outside the prefix should not be notified")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	_, err = client.Put(context.TODO(), "goff/flags/flag1", "{}")
+	require.NoError(t, err)
+	select {
+	case <-r.Updates():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the change of the flag is not notified")
+	}
+}
+
+func TestEtcdRetriever_Authentication(t *testing.T) {
+	endpoint, client := startEtcd(t)
+	_, err := client.Put(context.TODO(), "goff/flags", "{}")
+	require.NoError(t, err)
+	_, err = client.UserAdd(context.TODO(), "root", "secret")
+	require.NoError(t, err)
+	_, err = client.UserGrantRole(context.TODO(), "root", "root")
+	require.NoError(t, err)
+	_, err = client.AuthEnable(context.TODO())
+	require.NoError(t, err)
+
+	r := &etcdretriever.Retriever{Endpoints: []string{endpoint}, Key: "goff/flags", DialTimeout: time.Second}
+	assert.Error(t, r.Init(context.TODO(), nil))
+	assert.Equal(t, retriever.RetrieverError, r.Status())
+
+	r = &etcdretriever.Retriever{
+		Endpoints: []string{endpoint},
+		Key:       "goff/flags",
+		Username:  "root",
+		Password:  "secret",
+	}
+	require.NoError(t, r.Init(context.TODO(), nil))
+	defer func() { assert.NoError(t, r.Shutdown(context.TODO())) }()
+	got, err := r.Retrieve(context.TODO())
+	require.NoError(t, err)
+	assert.Equal(t, "{}", string(got))
+}
+
+// TestRetrieverErrorHandling tests various error conditions in the etcd retriever
+func TestRetrieverErrorHandling(t *testing.T) {
+	t.Run("Init - no key and no prefix", func(t *testing.T) {
+		r := &etcdretriever.Retriever{Endpoints: []string{"http://localhost:2379"}}
+		assert.EqualError(t, r.Init(context.TODO(), nil), "etcd retriever: exactly one of key or prefix should be set")
+		assert.Equal(t, retriever.RetrieverError, r.Status())
+	})
+
+	t.Run("Init - key and prefix", func(t *testing.T) {
+		r := &etcdretriever.Retriever{Endpoints: []string{"http://localhost:2379"}, Key: "goff", Prefix: "goff/"}
+		assert.Error(t, r.Init(context.TODO(), nil))
+	})
+
+	t.Run("Init - client certificate without key", func(t *testing.T) {
+		r := &etcdretriever.Retriever{
+			Endpoints:      []string{"https://localhost:2379"},
+			Key:            "goff",
+			ClientCertPath: "client.crt",
+		}
+		assert.EqualError(t, r.Init(context.TODO(), nil), "client certificate and client key must be provided together")
+	})
+
+	t.Run("Init - etcd not reachable", func(t *testing.T) {
+		r := &etcdretriever.Retriever{
+			Endpoints:   []string{"http://" + freeHost(t)},
+			Key:         "goff",
+			DialTimeout: 200 * time.Millisecond,
+		}
+		assert.Error(t, r.Init(context.TODO(), nil))
+		assert.Equal(t, retriever.RetrieverError, r.Status())
+	})
+
+	t.Run("Retrieve - nil client", func(t *testing.T) {
+		r := &etcdretriever.Retriever{Key: "goff"}
+		_, err := r.Retrieve(context.TODO())
+		assert.EqualError(t, err, "etcd client is not initialized")
+	})
+
+	t.Run("Status - nil receiver", func(t *testing.T) {
+		var r *etcdretriever.Retriever
+		assert.Equal(t, retriever.RetrieverNotReady, r.Status())
+	})
+
+	t.Run("Shutdown - nil client", func(t *testing.T) {
+		r := &etcdretriever.Retriever{Key: "goff"}
+		assert.NoError(t, r.Shutdown(context.TODO()))
+	})
+}
+
+// startEtcd starts an embedded etcd server and returns its endpoint and a client.
+func startEtcd(t *testing.T) (string, *clientv3.Client) {
+	t.Helper()
+	cfg := embed.NewConfig()
+	cfg.Dir = t.TempDir()
+	cfg.LogLevel = "error"
+	clientURL := url.URL{Scheme: "http", Host: freeHost(t)}
+	peerURL := url.URL{Scheme: "http", Host: freeHost(t)}
+	cfg.ListenClientUrls, cfg.AdvertiseClientUrls = []url.URL{clientURL}, []url.URL{clientURL}
+	cfg.ListenPeerUrls, cfg.AdvertisePeerUrls = []url.URL{peerURL}, []url.URL{peerURL}
+	cfg.InitialCluster = fmt.Sprintf("%s=%s", cfg.Name, peerURL.String())
+
+	server, err := embed.StartEtcd(cfg)
+	require.NoError(t, err)
+	t.Cleanup(server.Close)
+	select {
+	case <-server.Server.ReadyNotify():
+	case <-time.After(10 * time.Second):
+		t.Fatal("etcd is not ready")
+	}
+
+	client, err := clientv3.New(clientv3.Config{Endpoints: []string{clientURL.String()}})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+	return clientURL.String(), client
+}
+
+// freeHost returns a local address with a port available.
+func freeHost(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = listener.Close() }()
+	return listener.Addr().String()
+}
+
+func keys(m map[string]any) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	return res
+}
@@ -18,3 +18,8 @@ func (r *Retriever) Retrieve(_ context.Context) ([]byte, error) {
 	}
 	return content, nil
 }
+
+// Location returns the path of the file, the includes of the file are resolved from it.
+func (r *Retriever) Location() string {
+	return r.Path
+}
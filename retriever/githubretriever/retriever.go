@@ -45,7 +45,35 @@ func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
 			r.RepositorySlug,
 		)
 	}
+	return r.readFile(ctx, r.FilePath)
+}
+
+// Location returns the URL of the file in the GitHub API (without the branch), the includes of the
+// configuration are resolved from it.
+func (r *Retriever) Location() string {
+	return r.root() + "/" + strings.TrimPrefix(r.FilePath, "/")
+}
+
+// ReadInclude reads a file of the repository included by the configuration, in the same branch.
+func (r *Retriever) ReadInclude(ctx context.Context, location string) ([]byte, error) {
+	filePath, err := shared.IncludePath(r.root(), location)
+	if err != nil {
+		return nil, err
+	}
+	return r.readFile(ctx, filePath)
+}
+
+// root returns the URL of the content of the repository in the GitHub API.
+func (r *Retriever) root() string {
+	baseURL := r.BaseURL
+	if baseURL == "" {
+		baseURL = "https://api.github.com"
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/repos/" + r.RepositorySlug + "/contents"
+}
 
+// readFile fetches a file of the repository.
+func (r *Retriever) readFile(ctx context.Context, filePath string) ([]byte, error) {
 	// default branch is main
 	branch := r.Branch
 	if branch == "" {
@@ -58,7 +86,7 @@ func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
 		return nil, err
 	}
 
-	URL, err := r.buildURL(branch)
+	URL, err := r.buildURL(branch, filePath)
 	if err != nil {
 		return nil, err
 	}
@@ -102,9 +130,9 @@ func (r *Retriever) checkRateLimit() error {
 }
 
 // buildURL constructs the GitHub API URL for retrieving the file.
-func (r *Retriever) buildURL(branch string) (string, error) {
+func (r *Retriever) buildURL(branch string, filePath string) (string, error) {
 	// Validate inputs to prevent path traversal attacks
-	if strings.Contains(r.FilePath, "..") {
+	if strings.Contains(filePath, "..") {
 		return "", fmt.Errorf("filepath must not contain '..'")
 	}
 	if strings.Contains(r.RepositorySlug, "..") {
@@ -120,7 +148,7 @@ func (r *Retriever) buildURL(branch string) (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("invalid base URL: %w", err)
 	}
-	u.Path = path.Join(u.Path, "repos", r.RepositorySlug, "contents", r.FilePath)
+	u.Path = path.Join(u.Path, "repos", r.RepositorySlug, "contents", filePath)
 
 	q := u.Query()
 	q.Set("ref", branch)
@@ -381,3 +381,26 @@ func TestRateLimiting(t *testing.T) {
 	assert.Error(t, err)
 	assert.False(t, httpClient.HasBeenCalled)
 }
+
+func Test_github_ReadInclude(t *testing.T) {
+	httpClient := mock.HTTP{}
+	r := githubretriever.Retriever{
+		RepositorySlug: "myorg/myrepo",
+		FilePath:       "config/flags.yaml",
+		Branch:         "release",
+		GithubToken:    "ghp_token",
+		BaseURL:        "https://github.acme.com/api/v3",
+	}
+	r.SetHTTPClient(&httpClient)
+	assert.Equal(t, "https://github.acme.com/api/v3/repos/myorg/myrepo/contents/config/flags.yaml", r.Location())
+
+	_, err := r.ReadInclude(context.Background(),
+		"https://github.acme.com/api/v3/repos/myorg/myrepo/contents/shared/flags.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.acme.com/api/v3/repos/myorg/myrepo/contents/shared/flags.yaml?ref=release",
+		httpClient.Req.URL.String())
+	assert.Equal(t, "Bearer ghp_token", httpClient.Req.Header.Get("Authorization"))
+
+	_, err = r.ReadInclude(context.Background(), "https://github.acme.com/api/v3/repos/myorg/other/contents/flags.yaml")
+	assert.ErrorContains(t, err, "is outside of https://github.acme.com/api/v3/repos/myorg/myrepo/contents")
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/thomaspoignant/go-feature-flag/internal"
 	httpretriever "github.com/thomaspoignant/go-feature-flag/retriever/httpretriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/shared"
 )
 
 // Retriever is a configuration struct for a GitHub retriever.
@@ -48,19 +49,35 @@ func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
 			r.RepositorySlug,
 		)
 	}
-	branch := r.Branch
-	if branch == "" {
-		branch = "main"
-	}
-	if r.BaseURL == "" {
-		r.BaseURL = "https://gitlab.com"
+	return r.readFile(ctx, r.FilePath)
+}
+
+// Location returns the URL of the raw file in GitLab, the includes of the configuration are resolved from it.
+func (r *Retriever) Location() string {
+	return r.root() + "/" + strings.TrimPrefix(r.FilePath, "/")
+}
+
+// ReadInclude reads a file of the repository included by the configuration, in the same branch.
+func (r *Retriever) ReadInclude(ctx context.Context, location string) ([]byte, error) {
+	filePath, err := shared.IncludePath(r.root(), location)
+	if err != nil {
+		return nil, err
 	}
+	return r.readFile(ctx, filePath)
+}
 
+// root returns the URL of the raw files of the branch in GitLab.
+func (r *Retriever) root() string {
+	return strings.TrimSuffix(r.baseURL(), "/") + "/" + r.RepositorySlug + "/-/raw/" + r.branch()
+}
+
+// readFile fetches a file of the repository with the GitLab API.
+func (r *Retriever) readFile(ctx context.Context, filePath string) ([]byte, error) {
 	path := strings.Join([]string{
-		r.BaseURL, "api/v4/projects",
+		r.baseURL(), "api/v4/projects",
 		url.QueryEscape(r.RepositorySlug),
 		"repository/files",
-		url.QueryEscape(r.FilePath), "raw"}, "/")
+		url.QueryEscape(filePath), "raw"}, "/")
 
 	parsedURL, err := url.Parse(path)
 	if err != nil {
@@ -68,7 +85,7 @@ func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
 	}
 
 	rawQuery := parsedURL.Query()
-	rawQuery.Set("ref", branch)
+	rawQuery.Set("ref", r.branch())
 	parsedURL.RawQuery = rawQuery.Encode()
 
 	// add header for Gitlab Token if specified
@@ -90,6 +107,20 @@ func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
 	return httpRetriever.Retrieve(ctx)
 }
 
+func (r *Retriever) branch() string {
+	if r.Branch == "" {
+		return "main"
+	}
+	return r.Branch
+}
+
+func (r *Retriever) baseURL() string {
+	if r.BaseURL == "" {
+		return "https://gitlab.com"
+	}
+	return r.BaseURL
+}
+
 // SetHTTPClient is here if you want to override the default http.Client we are using.
 // It is also used for the tests.
 func (r *Retriever) SetHTTPClient(client internal.HTTPClient) {
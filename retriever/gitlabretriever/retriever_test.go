@@ -173,3 +173,24 @@ func Test_gitlab_Retrieve(t *testing.T) {
 		})
 	}
 }
+
+func Test_gitlab_ReadInclude(t *testing.T) {
+	httpClient := mock.HTTP{}
+	r := gitlabretriever.Retriever{
+		RepositorySlug: "myorg/myrepo",
+		FilePath:       "config/flags.yaml",
+		GitlabToken:    "XXX",
+	}
+	r.SetHTTPClient(&httpClient)
+	assert.Equal(t, "https://gitlab.com/myorg/myrepo/-/raw/main/config/flags.yaml", r.Location())
+
+	_, err := r.ReadInclude(context.Background(), "https://gitlab.com/myorg/myrepo/-/raw/main/shared/flags.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"https://gitlab.com/api/v4/projects/myorg%2Fmyrepo/repository/files/shared%2Fflags.yaml/raw?ref=main",
+		httpClient.Req.URL.String())
+	assert.Equal(t, "XXX", httpClient.Req.Header.Get("PRIVATE-TOKEN"))
+
+	_, err = r.ReadInclude(context.Background(), "https://gitlab.com/myorg/myrepo/-/raw/other-branch/flags.yaml")
+	assert.ErrorContains(t, err, "is outside of https://gitlab.com/myorg/myrepo/-/raw/main")
+}
@@ -0,0 +1,391 @@
+package gitretriever
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/shared"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+const (
+	defaultRef      = "main"
+	defaultUsername = "git"
+	defaultTimeout  = 10 * time.Second
+	remoteName      = "origin"
+	// fetchedRef is the local reference where the ref of the remote repository is fetched.
+	fetchedRef = plumbing.ReferenceName("refs/goff/fetched")
+)
+
+// Retriever is a configuration struct for a retriever reading the flags from any git repository.
+// It fetches the repository in a local working copy with go-git, so it works with any git server
+// (Gitea, plain SSH server, ...) without needing the git command line.
+// Only the local repositories (file:// URLs) need git to be installed.
+type Retriever struct {
+	// URL is the URL of the git repository, it supports https, ssh and file:// URLs
+	// (ex: https://gitea.example.com/org/repo.git, git@example.com:org/repo.git, file:///srv/git/repo.git).
+	URL string
+
+	// Ref is the branch, tag or commit SHA to read the file from.
+	// Default: main
+	Ref string
+
+	// FilePath is the path of the flag configuration file inside the repository.
+	FilePath string
+
+	// AuthToken is used to authenticate the https requests with basic auth.
+	AuthToken string
+
+	// Username is the username used with the AuthToken.
+	// Default: git
+	Username string
+
+	// SSHKeyPath is the path of the private key used to authenticate with ssh.
+	// If empty, the default ssh configuration of the system is used.
+	SSHKeyPath string
+
+	// Directory is the local directory where the repository is fetched, it should be dedicated to the retriever.
+	// If empty, a temporary directory is created and removed at shutdown.
+	Directory string
+
+	// Timeout is the timeout of each retrieval of the file.
+	// Default: 10 seconds
+	Timeout time.Duration
+
+	status     retriever.Status
+	repository *git.Repository
+	workingDir string
+	tmpDir     bool
+	version    string
+	mutex      sync.RWMutex
+}
+
+// Init is initializing the local working copy of the repository.
+func (r *Retriever) Init(ctx context.Context, _ *fflog.FFLogger) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.status = retriever.RetrieverNotReady
+	if err := r.validate(); err != nil {
+		r.status = retriever.RetrieverError
+		return err
+	}
+
+	workingDir := r.Directory
+	if workingDir == "" {
+		dir, err := os.MkdirTemp("", "goff-git-retriever-")
+		if err != nil {
+			r.status = retriever.RetrieverError
+			return fmt.Errorf("impossible to create the git working directory: %w", err)
+		}
+		workingDir = dir
+		r.tmpDir = true
+	} else if err := os.MkdirAll(workingDir, 0o750); err != nil {
+		r.status = retriever.RetrieverError
+		return fmt.Errorf("impossible to create the git working directory: %w", err)
+	}
+	r.workingDir = workingDir
+
+	if err := r.initRepository(); err != nil {
+		r.status = retriever.RetrieverError
+		return err
+	}
+	r.status = retriever.RetrieverReady
+	return nil
+}
+
+// Status is the function returning the internal state of the retriever.
+func (r *Retriever) Status() retriever.Status {
+	if r == nil {
+		return retriever.RetrieverNotReady
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.status == "" {
+		return retriever.RetrieverNotReady
+	}
+	return r.status
+}
+
+// Shutdown set the status as not ready and removes the working copy if it is a temporary directory.
+func (r *Retriever) Shutdown(_ context.Context) error {
+	if r == nil {
+		return nil
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.status = retriever.RetrieverNotReady
+	r.repository = nil
+	if r.tmpDir && r.workingDir != "" {
+		if err := os.RemoveAll(r.workingDir); err != nil {
+			return err
+		}
+		r.workingDir = ""
+		r.tmpDir = false
+	}
+	return nil
+}
+
+// Retrieve fetches the ref from the remote repository and returns the content of the file.
+func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.repository == nil {
+		return nil, errors.New("git retriever is not initialized")
+	}
+
+	timeout := r.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	hash, err := r.fetch(ctx)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := r.commit(hash)
+	if err != nil {
+		return nil, err
+	}
+	content, err := readFile(commit, r.FilePath)
+	if err != nil {
+		return nil, err
+	}
+	r.version = commit.Hash.String()
+	return content, nil
+}
+
+// Location returns the URL of the repository followed by the path of the file (ex:
+// ssh://example.com/org/repo.git/flags.yaml), the includes of the configuration are resolved from it.
+// The credentials of the URL of the repository are not part of the location.
+func (r *Retriever) Location() string {
+	return r.root() + "/" + strings.TrimPrefix(r.FilePath, "/")
+}
+
+// ReadInclude reads a file of the repository included by the configuration, from the commit of the
+// last configuration retrieved.
+func (r *Retriever) ReadInclude(_ context.Context, location string) ([]byte, error) {
+	filePath, err := shared.IncludePath(r.root(), location)
+	if err != nil {
+		return nil, err
+	}
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if r.repository == nil || r.version == "" {
+		return nil, errors.New("git retriever has not retrieved the configuration")
+	}
+	commit, err := r.repository.CommitObject(plumbing.NewHash(r.version))
+	if err != nil {
+		return nil, err
+	}
+	return readFile(commit, filePath)
+}
+
+// root returns the URL of the repository without its credentials, the scp-like URLs
+// (git@example.com:org/repo.git) are converted to ssh URLs.
+func (r *Retriever) root() string {
+	endpoint, err := transport.NewEndpoint(r.URL)
+	if err != nil {
+		return r.URL
+	}
+	host := endpoint.Host
+	if endpoint.Port != 0 {
+		host = fmt.Sprintf("%s:%d", host, endpoint.Port)
+	}
+	return endpoint.Protocol + "://" + host + "/" + strings.Trim(endpoint.Path, "/")
+}
+
+// readFile returns the content of a file of the commit.
+func readFile(commit *object.Commit, filePath string) ([]byte, error) {
+	file, err := commit.File(path.Clean(filePath))
+	if err != nil {
+		return nil, fmt.Errorf("impossible to find the file %s in the commit %s: %w", filePath, commit.Hash, err)
+	}
+	reader, err := file.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	return io.ReadAll(reader)
+}
+
+// Version returns the SHA of the commit of the last configuration retrieved.
+func (r *Retriever) Version() string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.version
+}
+
+// validate checks that the configuration is usable.
+func (r *Retriever) validate() error {
+	if r.URL == "" || r.FilePath == "" {
+		return fmt.Errorf("missing mandatory information url=%s, filePath=%s", r.URL, r.FilePath)
+	}
+	// values starting with a dash are not valid git urls and refs.
+	if strings.HasPrefix(r.URL, "-") {
+		return fmt.Errorf("invalid git url: %s", r.URL)
+	}
+	if strings.HasPrefix(r.ref(), "-") {
+		return fmt.Errorf("invalid git ref: %s", r.ref())
+	}
+	if strings.Contains(r.FilePath, "..") {
+		return fmt.Errorf("filepath must not contain '..'")
+	}
+	return nil
+}
+
+// initRepository opens the bare repository of the working directory, or creates it, and points it to the remote.
+func (r *Retriever) initRepository() error {
+	repository, err := git.PlainOpen(r.workingDir)
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		repository, err = git.PlainInit(r.workingDir, true)
+	}
+	if err != nil {
+		return fmt.Errorf("impossible to open the git working directory: %w", err)
+	}
+	if err := repository.DeleteRemote(remoteName); err != nil && !errors.Is(err, git.ErrRemoteNotFound) {
+		return err
+	}
+	if _, err := repository.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{r.URL}}); err != nil {
+		return err
+	}
+	r.repository = repository
+	return nil
+}
+
+// fetch fetches the ref of the remote repository with a depth of 1 and returns its hash.
+func (r *Retriever) fetch(ctx context.Context) (plumbing.Hash, error) {
+	remote, err := r.repository.Remote(remoteName)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	auth, err := r.auth()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	source := r.ref()
+	if !plumbing.IsHash(source) {
+		refs, err := remote.ListContext(ctx, &git.ListOptions{Auth: auth})
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("impossible to list the refs of %s: %w", r.URL, err)
+		}
+		name, ok := findRef(refs, r.ref())
+		if !ok {
+			return plumbing.ZeroHash, fmt.Errorf("git ref %s not found in %s", r.ref(), r.URL)
+		}
+		source = name.String()
+	}
+
+	err = fetchRefSpecs(ctx, remote, auth, 1, config.RefSpec("+"+source+":"+fetchedRef.String()))
+	if errors.Is(err, git.ErrExactSHA1NotSupported) {
+		// the server does not allow to fetch a commit directly, the whole history is fetched to find it.
+		return plumbing.NewHash(source), fetchRefSpecs(ctx, remote, auth, 0,
+			"+refs/heads/*:refs/goff/heads/*", "+refs/tags/*:refs/goff/tags/*")
+	}
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("impossible to fetch %s from %s: %w", r.ref(), r.URL, err)
+	}
+	reference, err := r.repository.Reference(fetchedRef, true)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return reference.Hash(), nil
+}
+
+// fetchRefSpecs fetches the refspecs from the remote, a depth of 0 fetches the whole history.
+func fetchRefSpecs(
+	ctx context.Context, remote *git.Remote, auth transport.AuthMethod, depth int, refSpecs ...config.RefSpec,
+) error {
+	err := remote.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: remoteName,
+		RefSpecs:   refSpecs,
+		Depth:      depth,
+		Auth:       auth,
+		Tags:       git.NoTags,
+		Force:      true,
+	})
+	if errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return nil
+	}
+	return err
+}
+
+// commit returns the commit of the hash, the annotated tags are resolved to their commit.
+func (r *Retriever) commit(hash plumbing.Hash) (*object.Commit, error) {
+	if tag, err := r.repository.TagObject(hash); err == nil {
+		return tag.Commit()
+	}
+	return r.repository.CommitObject(hash)
+}
+
+// auth returns the authentication method used to reach the remote repository.
+// Without AuthToken and SSHKeyPath, the ssh URLs use the ssh agent of the system.
+func (r *Retriever) auth() (transport.AuthMethod, error) {
+	if r.AuthToken != "" {
+		username := r.Username
+		if username == "" {
+			username = defaultUsername
+		}
+		return &http.BasicAuth{Username: username, Password: r.AuthToken}, nil
+	}
+	if r.SSHKeyPath != "" {
+		endpoint, err := transport.NewEndpoint(r.URL)
+		if err != nil {
+			return nil, err
+		}
+		username := endpoint.User
+		if username == "" {
+			username = defaultUsername
+		}
+		auth, err := ssh.NewPublicKeysFromFile(username, r.SSHKeyPath, "")
+		if err != nil {
+			return nil, fmt.Errorf("impossible to read the ssh key %s: %w", r.SSHKeyPath, err)
+		}
+		return auth, nil
+	}
+	return nil, nil
+}
+
+// findRef returns the name of the ref of the remote repository, a branch is preferred to a tag with the same name.
+func findRef(refs []*plumbing.Reference, ref string) (plumbing.ReferenceName, bool) {
+	for _, name := range refNames(ref) {
+		for _, reference := range refs {
+			if reference.Name() == name {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// refNames returns the names of the references a ref can be (ex: main gives refs/heads/main and refs/tags/main).
+func refNames(ref string) []plumbing.ReferenceName {
+	if strings.HasPrefix(ref, "refs/") {
+		return []plumbing.ReferenceName{plumbing.ReferenceName(ref)}
+	}
+	return []plumbing.ReferenceName{plumbing.NewBranchReferenceName(ref), plumbing.NewTagReferenceName(ref)}
+}
+
+func (r *Retriever) ref() string {
+	if r.Ref == "" {
+		return defaultRef
+	}
+	return r.Ref
+}
@@ -100,6 +100,45 @@ func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
 	return body, nil
 }
 
+// Location returns the URL of the endpoint, the includes of the configuration are resolved from it.
+func (r *Retriever) Location() string {
+	return r.URL
+}
+
+// ReadInclude reads a file of the same origin included by the configuration, with the headers and the
+// TLS configuration of the retriever.
+func (r *Retriever) ReadInclude(ctx context.Context, location string) ([]byte, error) {
+	httpClient, err := r.getHTTPClient()
+	if err != nil {
+		return nil, err
+	}
+	if client, ok := httpClient.(*http.Client); ok {
+		// the headers of the retriever are only sent to its origin, the redirections to another origin are refused.
+		includeClient := *client
+		includeClient.CheckRedirect = sameHostRedirect
+		httpClient = &includeClient
+	}
+	resp, err := shared.CallHTTPAPI(ctx, location, http.MethodGet, "", r.Timeout, r.Header, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode > 399 {
+		return nil, fmt.Errorf("request to %s failed with code %d", location, resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func sameHostRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return fmt.Errorf("stopped after 10 redirects")
+	}
+	if req.URL.Scheme != via[0].URL.Scheme || req.URL.Host != via[0].URL.Host {
+		return fmt.Errorf("redirect to another origin (%s://%s) is not allowed", req.URL.Scheme, req.URL.Host)
+	}
+	return nil
+}
+
 func (r *Retriever) getHTTPClient() (internal.HTTPClient, error) {
 	if r.httpClient != nil {
 		return r.httpClient, nil
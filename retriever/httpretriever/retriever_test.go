@@ -3,6 +3,7 @@ package httpretriever_test
 import (
 	"context"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -157,3 +158,32 @@ func Test_httpRetriever_Retrieve(t *testing.T) {
 		})
 	}
 }
+
+func Test_httpRetriever_ReadInclude(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	defer other.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/config/redirect.yaml":
+			http.Redirect(w, r, other.URL+"/flags.yaml", http.StatusFound)
+		case r.Header.Get("Authorization") != "Bearer token":
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			_, _ = w.Write([]byte("shared-flag: {}"))
+		}
+	}))
+	defer server.Close()
+
+	r := httpretriever.Retriever{
+		URL:    server.URL + "/config/flags.yaml",
+		Header: http.Header{"Authorization": []string{"Bearer token"}},
+	}
+	got, err := r.ReadInclude(context.Background(), server.URL+"/config/shared.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "shared-flag: {}", string(got))
+
+	_, err = r.ReadInclude(context.Background(), server.URL+"/config/redirect.yaml")
+	assert.ErrorContains(t, err, "redirect to another origin")
+}
@@ -2,19 +2,45 @@ package retriever
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/thomaspoignant/go-feature-flag/internal/cache"
+	"github.com/thomaspoignant/go-feature-flag/internal/flaginclude"
+	"github.com/thomaspoignant/go-feature-flag/internal/telemetry"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/dto"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
 	"github.com/thomaspoignant/go-feature-flag/retriever/fileretriever"
 	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
 )
 
+// Mode is the way the manager uses the retrievers.
+type Mode string
+
+const (
+	// MergeMode calls all the retrievers and merges their flags, if the same flag is defined by
+	// several retrievers, the last retriever of the list wins. This is the default mode.
+	MergeMode Mode = "merge"
+	// FallbackMode tries the retrievers in order (primary, secondary, ...), the flags of the first
+	// retriever answering are used.
+	FallbackMode Mode = "fallback"
+)
+
+// IsValidMode returns an error if the mode is not supported, an empty mode is the merge mode.
+func IsValidMode(mode Mode) error {
+	switch mode {
+	case "", MergeMode, FallbackMode:
+		return nil
+	default:
+		return fmt.Errorf("invalid retriever mode %q: should be %s or %s", mode, MergeMode, FallbackMode)
+	}
+}
+
 // ManagerConfig is the configuration of the retriever manager.
 type ManagerConfig struct {
 	FileFormat                      string
@@ -24,6 +50,20 @@ type ManagerConfig struct {
 	EnablePollingJitter             bool
 	PollingInterval                 time.Duration
 	Name                            *string
+	// Environment (optional) is used to apply the overrides of the flags for this environment.
+	Environment string
+	// Mode (optional) is the way the retrievers are used, merge mode by default.
+	Mode Mode
+	// Telemetry (optional) records the refreshes of the cache and the errors of the retrievers.
+	Telemetry *telemetry.Telemetry
+	// Snapshots (optional) is the number of versions of the flag configuration kept to be pinned.
+	// The snapshots are stored next to the persistent flag configuration file if it is set.
+	// Default: 0 (no snapshot)
+	Snapshots int
+	// IncludeAllowedOrigins (optional) are the origins (ex: https://config.example.com) of the remote
+	// files that can be included by the flag configuration, in addition to the origin of a remote
+	// configuration.
+	IncludeAllowedOrigins []string
 }
 
 // Manager is a struct that managed the retrievers.
@@ -34,22 +74,50 @@ type Manager struct {
 	cacheManager     cache.Manager
 	config           ManagerConfig
 	bgUpdater        backgroundUpdater
-	// pollingWg is done when the polling goroutine started by Init has returned.
+	// pollingWg is done when the polling and watching goroutines started by Init have returned.
 	pollingWg sync.WaitGroup
 	// stopPollingOnce guarantees that we close the background updater only once,
 	// StopPolling is reachable both from SetOffline() and from Shutdown().
 	stopPollingOnce sync.Once
+	// activeRetriever is the index of the retriever which served the current flag configuration
+	// in fallback mode, -1 if none.
+	activeRetriever      int
+	activeRetrieverMutex sync.RWMutex
+	// states contains the refresh state of each retriever, in the same order as the retrievers.
+	states []*retrieverState
+	// refreshMutex prevents concurrent refreshes between the polling and ForceRefresh.
+	refreshMutex sync.Mutex
+	// snapshots keeps the last versions of the flag configuration, nil if the snapshots are disabled.
+	snapshots *snapshotStore
 }
 
 // NewManager create a new Manager.
 func NewManager(
 	config ManagerConfig, retrievers []Retriever, cacheManager cache.Manager, logger *fflog.FFLogger) *Manager {
+	unwrapped := make([]Retriever, 0, len(retrievers))
+	states := make([]*retrieverState, 0, len(retrievers))
+	for _, r := range retrievers {
+		r, policy := unwrapPolicy(r)
+		unwrapped = append(unwrapped, r)
+		states = append(states, newRetrieverState(policy, config.PollingInterval, config.EnablePollingJitter))
+	}
+	var snapshots *snapshotStore
+	if config.Snapshots > 0 {
+		file := ""
+		if config.PersistentFlagConfigurationFile != "" {
+			file = config.PersistentFlagConfigurationFile + snapshotFileSuffix
+		}
+		snapshots = newSnapshotStore(config.Snapshots, file)
+	}
 	return &Manager{
-		retrievers:       retrievers,
+		retrievers:       unwrapped,
+		states:           states,
 		onErrorRetriever: make([]Retriever, 0),
 		logger:           logger,
 		cacheManager:     cacheManager,
 		config:           config,
+		activeRetriever:  -1,
+		snapshots:        snapshots,
 	}
 }
 
@@ -57,44 +125,119 @@ func NewManager(
 // This function will call the Init function of the retrievers that implements the InitializableRetriever interface.
 func (m *Manager) Init(ctx context.Context) error {
 	if err := m.initRetrievers(ctx, m.retrievers); err != nil {
+		// in fallback mode, we can start as long as one retriever is initialized.
+		if m.config.Mode != FallbackMode || len(m.onErrorRetriever) == len(m.retrievers) {
+			return err
+		}
+		m.logger.Warn("Some retrievers are not initialized, they will be used once initialized.",
+			slog.Any("error", err.Error()))
+	}
+	pinned, err := m.initSnapshots()
+	if err != nil {
 		return err
 	}
-	if err := m.retrieveFlagsAndUpdateCache(ctx, true); err != nil {
-		if err := m.handleFirstRetrieverError(ctx, err); err != nil {
-			return err
+	if !pinned {
+		if err := m.retrieveFlagsAndUpdateCache(ctx, true, true); err != nil {
+			if err := m.handleFirstRetrieverError(ctx, err); err != nil {
+				return err
+			}
 		}
 	}
 
-	if m.config.PollingInterval > 0 {
-		m.bgUpdater = newBackgroundUpdater(m.config.PollingInterval, m.config.EnablePollingJitter)
+	delay, polled := m.nextPollingDelay()
+	watched := m.watchedRetrievers()
+	switch {
+	case polled:
+		m.bgUpdater = newBackgroundUpdater(delay)
 		m.pollingWg.Go(func() { m.StartPolling(ctx) })
+	case len(watched) > 0:
+		// no retriever is polled, we only need the channel to stop watching.
+		m.bgUpdater = backgroundUpdater{updaterChan: make(chan struct{})}
+	}
+	for index, updates := range watched {
+		m.pollingWg.Go(func() { m.watchRetriever(ctx, index, updates) })
 	}
 	return nil
 }
 
-// StartPolling is the daemon that refreshes the cache every X seconds.
+// watchedRetrievers returns the channels of the retrievers pushing the changes of their configuration,
+// by index of retriever.
+func (m *Manager) watchedRetrievers() map[int]<-chan struct{} {
+	watched := map[int]<-chan struct{}{}
+	for index, r := range m.retrievers {
+		if w, ok := r.(WatchableRetriever); ok {
+			if updates := w.Updates(); updates != nil {
+				watched[index] = updates
+			}
+		}
+	}
+	return watched
+}
+
+// watchRetriever refreshes the flags each time the retriever at this index notifies a change,
+// until the background updater is closed.
+func (m *Manager) watchRetriever(ctx context.Context, index int, updates <-chan struct{}) {
+	for {
+		select {
+		case _, ok := <-updates:
+			if !ok {
+				return
+			}
+			m.states[index].markDue(time.Now())
+			if err := m.retrieveFlagsAndUpdateCache(ctx, false, false); err != nil {
+				m.logger.Error(
+					"Error while updating the cache after a change notified by a retriever.",
+					slog.Int("index", index),
+					slog.Any("error", err.Error()),
+				)
+			}
+		case <-m.bgUpdater.updaterChan:
+			return
+		}
+	}
+}
+
+// StartPolling is the daemon that refreshes the cache, every retriever is called at its own polling interval.
 func (m *Manager) StartPolling(ctx context.Context) {
-	if m.bgUpdater.ticker == nil {
+	if m.bgUpdater.timer == nil {
 		// polling is disabled (PollingInterval <= 0), there is nothing to poll.
 		return
 	}
 	for {
 		select {
-		case <-m.bgUpdater.ticker.C:
-			err := m.retrieveFlagsAndUpdateCache(ctx, false)
+		case <-m.bgUpdater.timer.C:
+			err := m.retrieveFlagsAndUpdateCache(ctx, false, false)
 			if err != nil {
 				m.logger.Error(
 					"Error while updating the cache.",
 					slog.Any("error", err.Error()),
 				)
 			}
+			if delay, ok := m.nextPollingDelay(); ok {
+				m.bgUpdater.timer.Reset(delay)
+			}
 		case <-m.bgUpdater.updaterChan:
 			return
 		}
 	}
 }
 
-// StopPolling stops the background updater and waits for the polling goroutine to return.
+// nextPollingDelay returns the time until the next call of a retriever, and false if no retriever is polled.
+func (m *Manager) nextPollingDelay() (time.Duration, bool) {
+	next := time.Time{}
+	for _, state := range m.states {
+		nextAttempt := state.getNextAttempt()
+		if !nextAttempt.IsZero() && (next.IsZero() || nextAttempt.Before(next)) {
+			next = nextAttempt
+		}
+	}
+	if next.IsZero() {
+		return 0, false
+	}
+	return max(time.Until(next), 0), true
+}
+
+// StopPolling stops the background updater and waits for the polling and watching goroutines to return.
 // It is safe to call it several times: the underlying channel is closed only once, since
 // StopPolling is called both by SetOffline() and by Shutdown().
 func (m *Manager) StopPolling() {
@@ -187,15 +330,191 @@ func (m *Manager) Shutdown(ctx context.Context) error {
 }
 
 // retrieveFlagsAndUpdateCache is a function that will retrieve the flags from the retrievers and update the cache.
-func (m *Manager) retrieveFlagsAndUpdateCache(ctx context.Context, isInit bool) error {
+// Only the retrievers due are called, unless force is true.
+func (m *Manager) retrieveFlagsAndUpdateCache(ctx context.Context, isInit bool, force bool) error {
+	m.refreshMutex.Lock()
+	defer m.refreshMutex.Unlock()
+	if m.postponeIfPinned() {
+		return nil
+	}
 	if len(m.onErrorRetriever) > 0 {
 		_ = m.initRetrievers(ctx, m.onErrorRetriever)
 	}
-	newFlags, err := retrieve(ctx, m.retrievers, m.config.FileFormat)
+	var newFlags map[string]dto.DTO
+	var updated bool
+	var err error
+	source := -1
+	if m.config.Mode == FallbackMode {
+		newFlags, source, err = m.retrieveWithFallback(ctx, force)
+		updated = source >= 0
+	} else {
+		newFlags, updated, err = m.retrieveWithMerge(ctx, force)
+	}
+	if updated {
+		if errUpdate := m.updateCacheWithRetriever(newFlags, isInit); errUpdate != nil {
+			err = errors.Join(err, errUpdate)
+		} else {
+			if source >= 0 {
+				m.setActiveRetriever(source)
+			}
+			m.takeSnapshot(newFlags)
+		}
+	}
+	if updated || err != nil {
+		m.config.Telemetry.RecordCacheRefresh(ctx, err)
+	}
+	return err
+}
+
+// retrieveWithMerge calls the retrievers due in parallel, and merges the flags of all the retrievers.
+// A retriever in error keeps the flags of its last successful call, the cache is not updated until every
+// retriever has retrieved its flags once.
+// It returns false if no retriever was called.
+func (m *Manager) retrieveWithMerge(ctx context.Context, force bool) (map[string]dto.DTO, bool, error) {
+	now := time.Now()
+	refreshed := force
+	errs := make([]error, len(m.retrievers))
+	var wg sync.WaitGroup
+	for index, state := range m.states {
+		if !force && !state.isDue(now) {
+			continue
+		}
+		refreshed = true
+		wg.Go(func() { errs[index] = m.refreshRetriever(ctx, index) })
+	}
+	wg.Wait()
+	if !refreshed {
+		return nil, false, nil
+	}
+
+	newFlags := map[string]dto.DTO{}
+	for index, state := range m.states {
+		flags, served := state.getFlags()
+		if !served {
+			if errs[index] == nil {
+				errs[index] = fmt.Errorf("retriever #%d (%T) has not retrieved the flags yet", index, m.retrievers[index])
+			}
+			return nil, false, errors.Join(errs...)
+		}
+		maps.Copy(newFlags, flags)
+	}
+	return newFlags, true, errors.Join(errs...)
+}
+
+// retrieveWithFallback retrieves the flags from the first retriever answering, the retrievers
+// are tried in order. A retriever waiting before a new attempt after an error is skipped, a retriever
+// which is not serving the flags is called even if it is not due, to take over from the retrievers in error.
+// It returns the index of the retriever which retrieved the flags, -1 if the retriever serving the flags
+// is not due. The retriever is recorded as active once the cache is updated with its flags.
+func (m *Manager) retrieveWithFallback(ctx context.Context, force bool) (map[string]dto.DTO, int, error) {
+	now := time.Now()
+	active := m.activeIndex()
+	errs := make([]error, 0, len(m.retrievers))
+	for index, r := range m.retrievers {
+		state := m.states[index]
+		if rr, ok := r.(CommonInitializableRetriever); ok && rr.Status() != RetrieverReady {
+			errs = append(errs, fmt.Errorf("retriever #%d (%T) is not ready", index, r))
+			continue
+		}
+		due := force || state.isDue(now)
+		if !due && state.isFailing() {
+			errs = append(errs, fmt.Errorf("retriever #%d (%T) is waiting before a new attempt", index, r))
+			continue
+		}
+		if !due && index == active {
+			// this retriever is serving the flags and is not due yet, there is nothing to refresh.
+			if len(errs) > 0 {
+				m.logger.Warn("Some retrievers are in error, the flags are still served by another retriever.",
+					slog.Int("index", index), slog.Any("error", errors.Join(errs...).Error()))
+			}
+			return nil, -1, nil
+		}
+		if err := m.refreshRetriever(ctx, index); err != nil {
+			errs = append(errs, fmt.Errorf("retriever #%d (%T): %w", index, r, err))
+			continue
+		}
+		newFlags, _ := state.getFlags()
+		return newFlags, index, nil
+	}
+	return nil, -1, fmt.Errorf("none of the retrievers is available: %w", errors.Join(errs...))
+}
+
+// refreshRetriever calls the retriever at this index and records the result in its state.
+func (m *Manager) refreshRetriever(ctx context.Context, index int) error {
+	r, state := m.retrievers[index], m.states[index]
+	// If the retriever is not ready, we ignore it
+	if rr, ok := r.(CommonInitializableRetriever); ok && rr.Status() != RetrieverReady {
+		state.recordNotReady(time.Now())
+		return nil
+	}
+	if state.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, state.policy.Timeout)
+		defer cancel()
+	}
+	newFlags, err := retrieveOne(ctx, r, m.config, m.config.Telemetry)
 	if err != nil {
+		if state.recordError(time.Now(), err) {
+			m.logger.Warn("Too many errors, the circuit breaker of the retriever is open.",
+				slog.Int("index", index), slog.String("retriever", fmt.Sprintf("%T", r)),
+				slog.Time("nextAttempt", state.getNextAttempt()))
+		}
 		return err
 	}
-	return m.updateCacheWithRetriever(newFlags, isInit)
+	state.recordSuccess(time.Now(), newFlags)
+	return nil
+}
+
+// RetrieversStatus returns the refresh status of each retriever, in the order of the retrievers.
+func (m *Manager) RetrieversStatus() []RetrieverStatus {
+	if m == nil {
+		return nil
+	}
+	now := time.Now()
+	statuses := make([]RetrieverStatus, 0, len(m.retrievers))
+	for index, r := range m.retrievers {
+		status := m.states[index].status(now)
+		status.Name = fmt.Sprintf("%T", r)
+		status.Location = getLocation(r)
+		if rr, ok := r.(CommonInitializableRetriever); ok && rr.Status() != RetrieverReady {
+			status.Status = rr.Status()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// setActiveRetriever records the retriever which served the current flag configuration.
+func (m *Manager) setActiveRetriever(index int) {
+	m.activeRetrieverMutex.Lock()
+	previous := m.activeRetriever
+	m.activeRetriever = index
+	m.activeRetrieverMutex.Unlock()
+	if previous != index {
+		m.logger.Info("The flag configuration is served by a new retriever.",
+			slog.Int("index", index), slog.String("retriever", fmt.Sprintf("%T", m.retrievers[index])))
+	}
+}
+
+// activeIndex returns the index of the retriever which served the current flag configuration, -1 if none.
+func (m *Manager) activeIndex() int {
+	m.activeRetrieverMutex.RLock()
+	defer m.activeRetrieverMutex.RUnlock()
+	return m.activeRetriever
+}
+
+// ActiveRetriever returns the retriever which served the current flag configuration in fallback mode.
+// It returns false in merge mode, or if no retriever served the flag configuration yet.
+func (m *Manager) ActiveRetriever() (Retriever, bool) {
+	if m == nil {
+		return nil, false
+	}
+	m.activeRetrieverMutex.RLock()
+	defer m.activeRetrieverMutex.RUnlock()
+	if m.activeRetriever < 0 {
+		return nil, false
+	}
+	return m.retrievers[m.activeRetriever], true
 }
 
 // updateCacheWithRetriever is a function that will update the cache with the new flags received from the retriever.
@@ -248,7 +567,7 @@ func (m *Manager) retrievePersistentLocalDisk(ctx context.Context) error {
 		if _, err := os.Stat(m.config.PersistentFlagConfigurationFile); err == nil {
 			// we found the configuration file on the disk
 			r := &fileretriever.Retriever{Path: m.config.PersistentFlagConfigurationFile}
-			newFlags, err := retrieve(ctx, []Retriever{r}, m.config.FileFormat)
+			newFlags, err := retrieveOne(ctx, r, m.config, nil)
 			if err != nil {
 				return err
 			}
@@ -280,8 +599,13 @@ func (m *Manager) GetFlagsFromCache(_ context.Context) (map[string]flag.Flag, er
 	return m.cacheManager.AllFlags()
 }
 
+// ForceRefresh calls all the retrievers, whatever their polling interval, and updates the cache.
 func (m *Manager) ForceRefresh(ctx context.Context) bool {
-	err := m.retrieveFlagsAndUpdateCache(ctx, false)
+	if _, pinned := m.PinnedSnapshot(); pinned {
+		m.logger.Warn("A flag configuration snapshot is pinned, the cache is not refreshed.")
+		return false
+	}
+	err := m.retrieveFlagsAndUpdateCache(ctx, false, true)
 	if err != nil {
 		m.logger.Error(
 			"Error while force updating the cache.",
@@ -292,66 +616,28 @@ func (m *Manager) ForceRefresh(ctx context.Context) bool {
 	return true
 }
 
-// retrieve is a function that will retrieve the flags from all the retrievers in parallel.
-func retrieve(ctx context.Context, retrievers []Retriever, fileFormat string) (map[string]dto.DTO, error) {
-	// Results is the type that will receive the results when calling
-	// all the retrievers.
-	type Results struct {
-		Error error
-		Value map[string]dto.DTO
-		Index int
-	}
-
-	// resultsChan is the channel that will receive all the results.
-	resultsChan := make(chan Results)
-	var wg sync.WaitGroup
-	wg.Add(len(retrievers))
-
-	// Launching a goroutine that will wait until the waiting group is complete.
-	// It closes the channel when ready
-	go func() {
-		wg.Wait()
-		close(resultsChan)
-	}()
-
-	for index, r := range retrievers {
-		// Launching GO routines to retrieve all files in parallel.
-		go func(r Retriever, format string, index int, ctx context.Context) {
-			defer wg.Done()
-
-			// If the retriever is not ready, we ignore it
-			if rr, ok := r.(CommonInitializableRetriever); ok &&
-				rr.Status() != RetrieverReady {
-				resultsChan <- Results{Error: nil, Value: map[string]dto.DTO{}, Index: index}
-				return
-			}
-
-			rawValue, err := r.Retrieve(ctx)
-			if err != nil {
-				resultsChan <- Results{Error: err, Value: nil, Index: index}
-				return
-			}
-			convertedFlag, err := cache.ConvertToFlagStruct(rawValue, getOutputFormat(r, format))
-			resultsChan <- Results{Error: err, Value: convertedFlag, Index: index}
-		}(r, fileFormat, index, ctx)
-	}
-
-	retrieversResults := make([]map[string]dto.DTO, len(retrievers))
-	for v := range resultsChan {
-		if v.Error != nil {
-			return nil, v.Error
-		}
-		retrieversResults[v.Index] = v.Value
+// retrieveOne retrieves the flags from a retriever and converts them.
+// The error of the retriever is recorded in the telemetry if not nil.
+func retrieveOne(
+	ctx context.Context,
+	r Retriever,
+	config ManagerConfig,
+	instrumentation *telemetry.Telemetry,
+) (map[string]dto.DTO, error) {
+	rawValue, err := r.Retrieve(ctx)
+	if err != nil {
+		instrumentation.RecordRetrieverError(ctx, fmt.Sprintf("%T", r))
+		return nil, err
 	}
-
-	// merge all the flags
-	newFlags := map[string]dto.DTO{}
-	for _, flags := range retrieversResults {
-		for flagName, value := range flags {
-			newFlags[flagName] = value
-		}
+	rawValue, outputFormat, err := flaginclude.Expand(
+		ctx, rawValue, getOutputFormat(r, config.FileFormat), getLocation(r), flaginclude.Options{
+			AllowedOrigins: config.IncludeAllowedOrigins,
+			Reader:         getIncludeReader(r),
+		})
+	if err != nil {
+		return nil, err
 	}
-	return newFlags, nil
+	return cache.ConvertToFlagStruct(rawValue, outputFormat, config.Environment)
 }
 
 // getOutputFormat returns the output format of the retriever.
@@ -365,3 +651,21 @@ func getOutputFormat(r Retriever, fileFormat string) string {
 	}
 	return fileFormat
 }
+
+// getLocation returns the location of the configuration retrieved by the retriever, if the retriever
+// implements the LocatedRetriever interface. It is used to resolve the relative includes.
+func getLocation(r Retriever) string {
+	if located, ok := r.(LocatedRetriever); ok {
+		return located.Location()
+	}
+	return ""
+}
+
+// getIncludeReader returns the function reading the included files with the retriever, if the retriever
+// implements the IncludeReader interface.
+func getIncludeReader(r Retriever) flaginclude.Reader {
+	if reader, ok := r.(IncludeReader); ok {
+		return reader.ReadInclude
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package retriever_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -10,8 +12,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/thomaspoignant/go-feature-flag/internal/cache"
 	"github.com/thomaspoignant/go-feature-flag/internal/notification"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/dto"
 	"github.com/thomaspoignant/go-feature-flag/notifier"
 	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/fileretriever"
 	"github.com/thomaspoignant/go-feature-flag/testutils/mock/mockretriever"
 	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
 )
@@ -264,6 +268,64 @@ variation = "A"
 	}
 }
 
+func TestManagerInit_ResolvesIncludes(t *testing.T) {
+	ctx := context.Background()
+	logger := fflog.FFLogger{}
+	cacheManager := cache.New(notification.NewService([]notifier.Notifier{}), "", &logger)
+	r := &fileretriever.Retriever{Path: "testdata/include/flags.yaml"}
+	manager := retriever.NewManager(retriever.ManagerConfig{FileFormat: "yaml"},
+		[]retriever.Retriever{r}, cacheManager, &logger)
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	flags, err := manager.GetFlagsFromCache(ctx)
+	require.NoError(t, err)
+	assert.Len(t, flags, 2)
+	assert.Contains(t, flags, "test-flag")
+	assert.Contains(t, flags, "team-flag")
+	assert.Equal(t, true, flags["test-flag"].GetVariationValue("enabled"))
+}
+
+// includeReaderRetriever is a located retriever reading the included files from a map.
+type includeReaderRetriever struct {
+	files map[string]string
+}
+
+func (r *includeReaderRetriever) Retrieve(_ context.Context) ([]byte, error) {
+	return []byte("include: shared.yaml\n"), nil
+}
+
+func (r *includeReaderRetriever) Location() string {
+	return "s3://bucket/config/flags.yaml"
+}
+
+func (r *includeReaderRetriever) ReadInclude(_ context.Context, location string) ([]byte, error) {
+	content, ok := r.files[location]
+	if !ok {
+		return nil, fmt.Errorf("%s not found", location)
+	}
+	return []byte(content), nil
+}
+
+func TestManagerInit_ReadsIncludesWithTheRetriever(t *testing.T) {
+	ctx := context.Background()
+	logger := fflog.FFLogger{}
+	cacheManager := cache.New(notification.NewService([]notifier.Notifier{}), "", &logger)
+	r := &includeReaderRetriever{files: map[string]string{
+		"s3://bucket/config/shared.yaml": "shared-flag:\n  variations:\n    enabled: true\n" +
+			"  defaultRule:\n    variation: enabled\n",
+	}}
+	manager := retriever.NewManager(retriever.ManagerConfig{FileFormat: "yaml"},
+		[]retriever.Retriever{r}, cacheManager, &logger)
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	flags, err := manager.GetFlagsFromCache(ctx)
+	require.NoError(t, err)
+	assert.Len(t, flags, 1)
+	assert.Contains(t, flags, "shared-flag")
+}
+
 // countingRetriever counts how many times Retrieve was called. It is read from the test
 // goroutine while the background updater writes to it, hence the mutex.
 type countingRetriever struct {
@@ -348,3 +410,413 @@ func TestManagerStopPollingWithPollingDisabled(t *testing.T) {
 		_ = manager.Shutdown(ctx)
 	})
 }
+
+func TestManagerFallbackMode(t *testing.T) {
+	primaryFlags := []byte(`{"primary-flag":{"variations":{"A":true,"B":false},"defaultRule":{"variation":"A"}}}`)
+	secondaryFlags := []byte(`{"secondary-flag":{"variations":{"A":true,"B":false},"defaultRule":{"variation":"A"}}}`)
+	failingRetriever := func() retriever.Retriever {
+		r := mockretriever.NewSimpleRetriever("failing")
+		r.ShouldFail = true
+		return r
+	}
+	notInitializedRetriever := func() retriever.Retriever {
+		r := mockretriever.NewInitializableRetriever("not-initialized")
+		r.InitShouldFail = true
+		return r
+	}
+
+	tests := []struct {
+		name           string
+		retrievers     []retriever.Retriever
+		expectError    bool
+		expectedFlag   string
+		expectedActive int
+	}{
+		{
+			name: "primary answering is used",
+			retrievers: []retriever.Retriever{
+				&formatHintingRetriever{format: "json", content: primaryFlags},
+				&formatHintingRetriever{format: "json", content: secondaryFlags},
+			},
+			expectedFlag:   "primary-flag",
+			expectedActive: 0,
+		},
+		{
+			name: "secondary is used when the primary fails",
+			retrievers: []retriever.Retriever{
+				failingRetriever(),
+				&formatHintingRetriever{format: "json", content: secondaryFlags},
+			},
+			expectedFlag:   "secondary-flag",
+			expectedActive: 1,
+		},
+		{
+			name: "secondary is used when the primary is not initialized",
+			retrievers: []retriever.Retriever{
+				notInitializedRetriever(),
+				&formatHintingRetriever{format: "json", content: secondaryFlags},
+			},
+			expectedFlag:   "secondary-flag",
+			expectedActive: 1,
+		},
+		{
+			name:        "error when all the retrievers fail",
+			retrievers:  []retriever.Retriever{failingRetriever(), failingRetriever()},
+			expectError: true,
+		},
+		{
+			name:        "error when none of the retrievers is initialized",
+			retrievers:  []retriever.Retriever{notInitializedRetriever(), notInitializedRetriever()},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			logger := fflog.FFLogger{}
+			cacheManager := cache.New(notification.NewService([]notifier.Notifier{}), "", &logger)
+			manager := retriever.NewManager(retriever.ManagerConfig{
+				FileFormat:      "yaml",
+				PollingInterval: 0,
+				Mode:            retriever.FallbackMode,
+			}, tt.retrievers, cacheManager, &logger)
+			err := manager.Init(ctx)
+			defer func() { _ = manager.Shutdown(ctx) }()
+
+			if tt.expectError {
+				assert.Error(t, err)
+				_, ok := manager.ActiveRetriever()
+				assert.False(t, ok)
+				return
+			}
+			require.NoError(t, err)
+			flags, err := manager.GetFlagsFromCache(ctx)
+			require.NoError(t, err)
+			assert.Len(t, flags, 1)
+			assert.Contains(t, flags, tt.expectedFlag)
+			active, ok := manager.ActiveRetriever()
+			assert.True(t, ok)
+			assert.Same(t, tt.retrievers[tt.expectedActive], active)
+		})
+	}
+}
+
+func TestManagerFallbackMode_FailoverAndFailback(t *testing.T) {
+	ctx := context.Background()
+	primary := newToggleRetriever("primary-flag")
+	backup := newToggleRetriever("backup-flag")
+	logger := fflog.FFLogger{}
+	cacheManager := cache.New(notification.NewService([]notifier.Notifier{}), "", &logger)
+	manager := retriever.NewManager(retriever.ManagerConfig{
+		FileFormat:      "json",
+		PollingInterval: 20 * time.Millisecond,
+		Mode:            retriever.FallbackMode,
+	}, []retriever.Retriever{primary, backup}, cacheManager, &logger)
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+	assert.Equal(t, 0, backup.Calls(), "the backup is called while the primary is answering")
+
+	// the backup takes over when the primary fails.
+	primary.setFailing(true)
+	require.Eventually(t, func() bool {
+		active, ok := manager.ActiveRetriever()
+		return ok && active == backup
+	}, time.Second, 10*time.Millisecond, "the backup retriever did not take over")
+	flags, err := manager.GetFlagsFromCache(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, flags, "backup-flag")
+	assert.NotContains(t, flags, "primary-flag")
+
+	// the primary serves the flags again when it recovers.
+	primary.setFailing(false)
+	require.Eventually(t, func() bool {
+		active, ok := manager.ActiveRetriever()
+		return ok && active == primary
+	}, time.Second, 10*time.Millisecond, "the primary retriever did not take over again")
+	flags, err = manager.GetFlagsFromCache(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, flags, "primary-flag")
+	assert.NotContains(t, flags, "backup-flag")
+}
+
+// rejectingCache refuses the flag configurations containing the flag "rejected-flag".
+type rejectingCache struct {
+	cache.Manager
+}
+
+func (c *rejectingCache) UpdateCache(newFlags map[string]dto.DTO, log *fflog.FFLogger, notifyChanges bool) error {
+	if _, ok := newFlags["rejected-flag"]; ok {
+		return errors.New("flag configuration rejected")
+	}
+	return c.Manager.UpdateCache(newFlags, log, notifyChanges)
+}
+
+func TestManagerFallbackMode_RejectedConfigurationKeepsTheActiveRetriever(t *testing.T) {
+	ctx := context.Background()
+	primary := newToggleRetriever("primary-flag")
+	backup := newToggleRetriever("rejected-flag")
+	logger := fflog.FFLogger{}
+	cacheManager := &rejectingCache{Manager: cache.New(notification.NewService([]notifier.Notifier{}), "", &logger)}
+	manager := retriever.NewManager(retriever.ManagerConfig{
+		FileFormat: "json",
+		Mode:       retriever.FallbackMode,
+	}, []retriever.Retriever{primary, backup}, cacheManager, &logger)
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	primary.setFailing(true)
+	assert.False(t, manager.ForceRefresh(ctx))
+	assert.Equal(t, 1, backup.Calls())
+	active, ok := manager.ActiveRetriever()
+	require.True(t, ok)
+	assert.Same(t, primary, active)
+}
+
+func TestManagerMergeModeHasNoActiveRetriever(t *testing.T) {
+	ctx := context.Background()
+	logger := fflog.FFLogger{}
+	cacheManager := cache.New(notification.NewService([]notifier.Notifier{}), "", &logger)
+	manager := retriever.NewManager(retriever.ManagerConfig{FileFormat: "json"},
+		[]retriever.Retriever{mockretriever.NewSimpleRetriever("simple")}, cacheManager, &logger)
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	_, ok := manager.ActiveRetriever()
+	assert.False(t, ok)
+}
+
+func TestIsValidMode(t *testing.T) {
+	assert.NoError(t, retriever.IsValidMode(""))
+	assert.NoError(t, retriever.IsValidMode(retriever.MergeMode))
+	assert.NoError(t, retriever.IsValidMode(retriever.FallbackMode))
+	assert.EqualError(t, retriever.IsValidMode("random"),
+		`invalid retriever mode "random": should be merge or fallback`)
+}
+
+// toggleRetriever returns its content, or an error when failing is set. It is read from the
+// test goroutine while the background updater calls it, hence the mutex.
+type toggleRetriever struct {
+	mu      sync.Mutex
+	calls   int
+	failing bool
+	content string
+}
+
+func (r *toggleRetriever) Retrieve(ctx context.Context) ([]byte, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls++
+	if r.failing {
+		return nil, errors.New("retriever is down")
+	}
+	return []byte(r.content), nil
+}
+
+func (r *toggleRetriever) setFailing(failing bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.failing = failing
+}
+
+func (r *toggleRetriever) Calls() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.calls
+}
+
+func newToggleRetriever(flagName string) *toggleRetriever {
+	return &toggleRetriever{
+		content: `{"` + flagName + `":{"variations":{"A":true,"B":false},"defaultRule":{"variation":"A"}}}`,
+	}
+}
+
+func newPolicyManager(t *testing.T, retrievers ...retriever.Retriever) *retriever.Manager {
+	t.Helper()
+	logger := fflog.FFLogger{}
+	cacheManager := cache.New(notification.NewService([]notifier.Notifier{}), "", &logger)
+	return retriever.NewManager(retriever.ManagerConfig{
+		FileFormat:      "json",
+		PollingInterval: time.Hour,
+	}, retrievers, cacheManager, &logger)
+}
+
+func TestManagerPolicy_PollingInterval(t *testing.T) {
+	ctx := context.Background()
+	fast := newToggleRetriever("fast-flag")
+	slow := newToggleRetriever("slow-flag")
+	manager := newPolicyManager(t,
+		retriever.WithPolicy(fast, retriever.Policy{PollingInterval: 10 * time.Millisecond}),
+		slow,
+	)
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	require.Eventually(t, func() bool { return fast.Calls() > 3 },
+		time.Second, 10*time.Millisecond, "the retriever with its own polling interval is not polled")
+	assert.Equal(t, 1, slow.Calls())
+
+	flags, err := manager.GetFlagsFromCache(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, flags, "fast-flag")
+	assert.Contains(t, flags, "slow-flag")
+}
+
+func TestManagerPolicy_FailingRetrieverKeepsItsFlags(t *testing.T) {
+	ctx := context.Background()
+	failing := newToggleRetriever("failing-flag")
+	healthy := newToggleRetriever("healthy-flag")
+	manager := newPolicyManager(t,
+		retriever.WithPolicy(failing, retriever.Policy{PollingInterval: 10 * time.Millisecond}),
+		retriever.WithPolicy(healthy, retriever.Policy{PollingInterval: 10 * time.Millisecond}),
+	)
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	failing.setFailing(true)
+	refreshDate := manager.GetCacheRefreshDate()
+	require.Eventually(t, func() bool { return manager.GetCacheRefreshDate().After(refreshDate) },
+		time.Second, 10*time.Millisecond, "the cache is not refreshed while a retriever is in error")
+
+	flags, err := manager.GetFlagsFromCache(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, flags, "failing-flag")
+	assert.Contains(t, flags, "healthy-flag")
+
+	statuses := manager.RetrieversStatus()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, retriever.RetrieverError, statuses[0].Status)
+	assert.Equal(t, "retriever is down", statuses[0].LastErrorMessage)
+	assert.False(t, statuses[0].LastError.IsZero())
+	assert.False(t, statuses[0].LastSuccess.IsZero())
+	assert.Equal(t, retriever.RetrieverReady, statuses[1].Status)
+	assert.Equal(t, "*retriever_test.toggleRetriever", statuses[1].Name)
+}
+
+func TestManagerPolicy_CircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	r := newToggleRetriever("test-flag")
+	manager := newPolicyManager(t, retriever.WithPolicy(r, retriever.Policy{
+		PollingInterval:         10 * time.Millisecond,
+		CircuitBreakerThreshold: 3,
+		CircuitBreakerCooldown:  time.Hour,
+	}))
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	r.setFailing(true)
+	require.Eventually(t, func() bool { return r.Calls() == 4 },
+		time.Second, 10*time.Millisecond, "the retriever is not called until the circuit breaker opens")
+	require.Never(t, func() bool { return r.Calls() > 4 },
+		200*time.Millisecond, 10*time.Millisecond, "the retriever is called while the circuit breaker is open")
+
+	status := manager.RetrieversStatus()[0]
+	assert.Equal(t, retriever.CircuitBreakerOpen, status.CircuitBreaker)
+	assert.Equal(t, 3, status.ConsecutiveErrors)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), status.NextAttempt, time.Minute)
+
+	// a forced refresh still calls the retriever and closes the circuit breaker when it is back.
+	r.setFailing(false)
+	assert.True(t, manager.ForceRefresh(ctx))
+	status = manager.RetrieversStatus()[0]
+	assert.Equal(t, retriever.CircuitBreakerClosed, status.CircuitBreaker)
+	assert.Equal(t, retriever.RetrieverReady, status.Status)
+}
+
+func TestManagerPolicy_Backoff(t *testing.T) {
+	ctx := context.Background()
+	r := newToggleRetriever("test-flag")
+	manager := newPolicyManager(t, retriever.WithPolicy(r, retriever.Policy{
+		PollingInterval:        10 * time.Millisecond,
+		BackoffInitialInterval: 20 * time.Minute,
+		BackoffMaxInterval:     time.Hour,
+	}))
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	r.setFailing(true)
+	require.Eventually(t, func() bool { return r.Calls() == 2 },
+		time.Second, 10*time.Millisecond, "the retriever is not polled")
+	require.Never(t, func() bool { return r.Calls() > 2 },
+		200*time.Millisecond, 10*time.Millisecond, "the retriever is called while backing off")
+
+	// the first backoff delay is between half and the full initial interval.
+	status := manager.RetrieversStatus()[0]
+	assert.Equal(t, retriever.RetrieverError, status.Status)
+	assert.WithinRange(t, status.NextAttempt, time.Now().Add(9*time.Minute), time.Now().Add(20*time.Minute))
+}
+
+// blockingRetriever answers only when its context is done.
+type blockingRetriever struct{}
+
+func (r *blockingRetriever) Retrieve(ctx context.Context) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestManagerPolicy_Timeout(t *testing.T) {
+	ctx := context.Background()
+	manager := newPolicyManager(t, retriever.WithPolicy(&blockingRetriever{},
+		retriever.Policy{Timeout: 10 * time.Millisecond}))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	start := time.Now()
+	err := manager.Init(ctx)
+	assert.ErrorContains(t, err, "context deadline exceeded")
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+// watchingRetriever notifies the manager when its content changes.
+type watchingRetriever struct {
+	*toggleRetriever
+	updates chan struct{}
+}
+
+func (r *watchingRetriever) Updates() <-chan struct{} {
+	return r.updates
+}
+
+func (r *watchingRetriever) setContent(content string) {
+	r.mu.Lock()
+	r.content = content
+	r.mu.Unlock()
+	r.updates <- struct{}{}
+}
+
+func TestManagerWatchableRetriever(t *testing.T) {
+	tests := []struct {
+		name            string
+		pollingInterval time.Duration
+	}{
+		{name: "with polling", pollingInterval: time.Hour},
+		{name: "without polling", pollingInterval: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := &watchingRetriever{toggleRetriever: newToggleRetriever("flag-1"), updates: make(chan struct{})}
+			other := newToggleRetriever("other-flag")
+			logger := fflog.FFLogger{}
+			cacheManager := cache.New(notification.NewService([]notifier.Notifier{}), "", &logger)
+			manager := retriever.NewManager(retriever.ManagerConfig{
+				FileFormat:      "json",
+				PollingInterval: tt.pollingInterval,
+			}, []retriever.Retriever{r, other}, cacheManager, &logger)
+			require.NoError(t, manager.Init(ctx))
+			defer func() { _ = manager.Shutdown(ctx) }()
+
+			r.setContent(`{"flag-2":{"variations":{"A":true,"B":false},"defaultRule":{"variation":"A"}}}`)
+			require.Eventually(t, func() bool {
+				flags, err := manager.GetFlagsFromCache(ctx)
+				return err == nil && flags["flag-2"] != nil
+			}, time.Second, 10*time.Millisecond, "the change notified by the retriever is not applied")
+
+			flags, err := manager.GetFlagsFromCache(ctx)
+			require.NoError(t, err)
+			assert.NotContains(t, flags, "flag-1")
+			assert.Contains(t, flags, "other-flag")
+			assert.Equal(t, 2, r.Calls())
+			assert.Equal(t, 1, other.Calls(), "only the retriever notifying a change is called")
+		})
+	}
+}
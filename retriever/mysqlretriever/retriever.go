@@ -0,0 +1,68 @@
+package mysqlretriever
+
+import (
+	"context"
+	"database/sql"
+
+	_ "github.com/go-sql-driver/mysql" // register the MySQL driver
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/shared/sqlretriever"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+// Retriever is a configuration struct for a MySQL (or MariaDB) retriever.
+type Retriever struct {
+	// URI is the data source name of the database (ex: user:password@tcp(localhost:3306)/dbname).
+	URI string
+	// Table is the name of the table containing the flags.
+	Table string
+	// Columns (optional) is the mapping of the column names, if they are not the default ones
+	// (flag_name, flagset, config).
+	Columns map[string]string
+
+	core sqlretriever.Core
+}
+
+// Init initializes the connection to the database.
+func (r *Retriever) Init(ctx context.Context, logger *fflog.FFLogger, flagset *string) error {
+	r.core.Dialect = r.dialect()
+	r.core.Table = r.Table
+	r.core.Columns = r.Columns
+	return r.core.Init(ctx, logger, flagset)
+}
+
+// Status returns the current status of the retriever
+func (r *Retriever) Status() retriever.Status {
+	if r == nil {
+		return retriever.RetrieverNotReady
+	}
+	return r.core.Status()
+}
+
+// Shutdown closes the database connection.
+func (r *Retriever) Shutdown(_ context.Context) error {
+	return r.core.Shutdown()
+}
+
+// Retrieve fetches flag configuration from MySQL.
+func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
+	return r.core.Retrieve(ctx)
+}
+
+// OutputFormat declares that this retriever always returns JSON-encoded data,
+// so the manager can pick the JSON parser regardless of the global FileFormat.
+func (r *Retriever) OutputFormat() string {
+	return "json"
+}
+
+// dialect returns the MySQL dialect, the identifiers are quoted with backticks to prevent SQL injection.
+func (r *Retriever) dialect() sqlretriever.Dialect {
+	return sqlretriever.Dialect{
+		Name:            "MySQL",
+		QuoteIdentifier: sqlretriever.QuoteWithBackticks,
+		Placeholder:     sqlretriever.QuestionMarkPlaceholder,
+		Open: func(ctx context.Context) (*sql.DB, error) {
+			return sqlretriever.OpenDB(ctx, "mysql", r.URI)
+		},
+	}
+}
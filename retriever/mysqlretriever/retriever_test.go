@@ -0,0 +1,190 @@
+//go:build docker
+
+package mysqlretriever_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	testcontainerMySQL "github.com/testcontainers/testcontainers-go/modules/mysql"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/mysqlretriever"
+)
+
+func TestMySQLRetriever(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     []string
+		tableName string
+		columns   map[string]string
+		flagset   string
+		assertErr assert.ErrorAssertionFunc
+		want      string
+	}{
+		{
+			name: "valid set of flag",
+			files: []string{
+				"sql/init.sql",
+				"sql/insert_data.sql",
+			},
+			tableName: "go_feature_flag",
+			assertErr: assert.NoError,
+			want:      "response/valid.json",
+		},
+		{
+			name: "invalid table name",
+			files: []string{
+				"sql/init.sql",
+				"sql/insert_data.sql",
+			},
+			tableName: "invalid_table_name",
+			assertErr: assert.Error,
+		},
+		{
+			name: "invalid column names",
+			files: []string{
+				"sql/init.sql",
+				"sql/insert_data.sql",
+			},
+			tableName: "go_feature_flag",
+			assertErr: assert.Error,
+			columns: map[string]string{
+				"flag_name": "invalid_flag_name",
+				"flagset":   "invalid_flagset",
+				"config":    "invalid_config",
+			},
+		},
+		{
+			name: "valid set of flag with flagset",
+			files: []string{
+				"sql/init.sql",
+				"sql/insert_data.sql",
+				"sql/insert_alternative_flagset.sql",
+			},
+			tableName: "go_feature_flag",
+			assertErr: assert.NoError,
+			flagset:   "team-A",
+			want:      "response/valid_alternative_flagset.json",
+		},
+		{
+			name: "valid set of flag with empty flagset",
+			files: []string{
+				"sql/init.sql",
+				"sql/insert_data.sql",
+			},
+			tableName: "go_feature_flag",
+			assertErr: assert.NoError,
+			flagset:   "empty-flagset",
+			want:      "response/empty-flagset.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connectionString := startMySQLAndAddData(t, tt.files)
+
+			r := mysqlretriever.Retriever{
+				URI:     connectionString,
+				Table:   tt.tableName,
+				Columns: tt.columns,
+			}
+			assert.NoError(t, r.Init(context.TODO(), nil, &tt.flagset))
+			defer func() {
+				assert.NoError(t, r.Shutdown(context.TODO()))
+			}()
+
+			assert.Equal(t, r.Status(), retriever.RetrieverReady)
+			got, err := r.Retrieve(context.TODO())
+			tt.assertErr(t, err)
+			if err != nil {
+				return
+			}
+
+			// the expected responses are shared by the SQL retrievers
+			want, err := os.ReadFile(filepath.Join("..", "shared", "sqlretriever", "testdata", tt.want))
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(want), string(got))
+		})
+	}
+}
+
+func startMySQLAndAddData(t *testing.T, files []string) string {
+	ctx := context.TODO()
+	mysqlContainer, err := testcontainerMySQL.Run(ctx, "mysql:8.4")
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, mysqlContainer.Terminate(context.TODO())) })
+
+	connectionString, err := mysqlContainer.ConnectionString(ctx, "multiStatements=true")
+	require.NoError(t, err)
+	db, err := sql.Open("mysql", connectionString)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join("testdata", file))
+		require.NoError(t, err)
+		_, err = db.ExecContext(ctx, string(content))
+		require.NoError(t, err)
+	}
+	return connectionString
+}
+
+// TestRetrieverErrorHandling tests various error conditions in the MySQL retriever
+func TestRetrieverErrorHandling(t *testing.T) {
+	t.Run("Init - Invalid connection URI", func(t *testing.T) {
+		r := mysqlretriever.Retriever{
+			URI:   "invalid-connection-string",
+			Table: "test_table",
+		}
+
+		err := r.Init(context.Background(), nil, nil)
+		assert.Error(t, err)
+		assert.Equal(t, retriever.RetrieverError, r.Status())
+	})
+
+	t.Run("Init - Connection timeout", func(t *testing.T) {
+		// Use a non-routable IP address to simulate connection timeout
+		r := mysqlretriever.Retriever{
+			URI:   "user:pass@tcp(192.0.2.1:3306)/db", // RFC5737 test address
+			Table: "test_table",
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+		defer cancel()
+
+		err := r.Init(ctx, nil, nil)
+		assert.Error(t, err)
+		assert.Equal(t, retriever.RetrieverError, r.Status())
+	})
+
+	t.Run("Retrieve - nil connection", func(t *testing.T) {
+		r := mysqlretriever.Retriever{
+			URI:   "user:pass@tcp(localhost:3306)/db",
+			Table: "test_table",
+		}
+		// Don't call Init() to ensure db is nil
+
+		_, err := r.Retrieve(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "database connection is not initialized")
+	})
+
+	t.Run("Status - nil receiver", func(t *testing.T) {
+		var r *mysqlretriever.Retriever
+		assert.Equal(t, retriever.RetrieverNotReady, r.Status())
+	})
+
+	t.Run("Shutdown - nil connection", func(t *testing.T) {
+		r := mysqlretriever.Retriever{
+			URI:   "user:pass@tcp(localhost:3306)/db",
+			Table: "test_table",
+		}
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+}
@@ -0,0 +1,17 @@
+-- ----------------------------------------------------------------------------
+-- GO FEATURE FLAG MYSQL RETRIEVER INITIALIZATION SCRIPT
+-- This script is used to initialize the MySQL database for the Go Feature Flag retriever.
+-- This is a very minimal setup for the retriever, you can add more columns or constraints to the table if you need to.
+-- ----------------------------------------------------------------------------
+
+-- Create the go_feature_flag table
+CREATE TABLE IF NOT EXISTS go_feature_flag (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    flag_name VARCHAR(255) NOT NULL,
+    flagset VARCHAR(255) NOT NULL,
+    config JSON NOT NULL,
+    -- Prevent duplicate flags in the same flagset
+    UNIQUE KEY unique_flag_per_flagset (flag_name, flagset),
+    -- Index for better query performance
+    KEY idx_go_feature_flag_flagset (flagset)
+);
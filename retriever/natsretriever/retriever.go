@@ -0,0 +1,256 @@
+package natsretriever
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/thomaspoignant/go-feature-flag/internal/natsconn"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/utils"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+// watchRetryDelay is the time before watching the bucket again after the watch was interrupted.
+const watchRetryDelay = 5 * time.Second
+
+var _ retriever.InitializableRetrieverWithFlagset = &Retriever{}
+
+// Retriever is a configuration struct for a retriever reading the flags from a NATS JetStream Key-Value bucket.
+// Each key of the bucket is a flag, its value is the JSON configuration of the flag.
+// When a flagset is used, only the keys prefixed by "<flagset name>." are read.
+// The bucket is watched, a change of a flag is applied without waiting for the next polling.
+type Retriever struct {
+	// URL is the URL of the NATS servers, separated by commas (ex: nats://localhost:4222).
+	// Default: nats://127.0.0.1:4222
+	URL string
+
+	// Bucket is the name of the JetStream Key-Value bucket containing the flags.
+	Bucket string
+
+	// Token (optional) is the token used to authenticate to NATS.
+	Token string
+
+	// Username and Password (optional) are used to authenticate to NATS.
+	Username string
+	Password string
+
+	// ClientCertPath and ClientKeyPath (optional) are the paths of the client certificate and its key used for mTLS.
+	ClientCertPath string
+	ClientKeyPath  string
+
+	// CACertPath (optional) is the path of the CA certificate used to verify the certificate of NATS.
+	CACertPath string
+
+	// Options (optional) are additional options of the connection to NATS (ex: nats.UserCredentials).
+	Options []nats.Option
+
+	logger      *fflog.FFLogger
+	status      retriever.Status
+	flagset     *string
+	conn        *nats.Conn
+	kv          jetstream.KeyValue
+	revision    atomic.Uint64
+	updates     chan struct{}
+	updatesOnce sync.Once
+	stopWatch   context.CancelFunc
+	watchWg     sync.WaitGroup
+}
+
+// Init connects to NATS, opens the bucket and starts watching the flags.
+func (r *Retriever) Init(ctx context.Context, logger *fflog.FFLogger, flagset *string) error {
+	r.status = retriever.RetrieverNotReady
+	r.logger = logger
+	if r.logger == nil {
+		r.logger = &fflog.FFLogger{}
+	}
+	r.flagset = flagset
+	if r.kv != nil {
+		r.status = retriever.RetrieverReady
+		return nil
+	}
+	if err := r.open(ctx); err != nil {
+		r.status = retriever.RetrieverError
+		return err
+	}
+
+	watchCtx, cancel := context.WithCancel(context.Background())
+	r.stopWatch = cancel
+	kv := r.kv
+	r.watchWg.Go(func() { r.watch(watchCtx, kv) })
+	r.status = retriever.RetrieverReady
+	return nil
+}
+
+// open connects to NATS and opens the Key-Value bucket.
+func (r *Retriever) open(ctx context.Context) error {
+	if r.Bucket == "" {
+		return errors.New("nats retriever: bucket is mandatory")
+	}
+	conn, err := natsconn.Connect(r.URL, natsconn.Auth{
+		Token:          r.Token,
+		Username:       r.Username,
+		Password:       r.Password,
+		ClientCertPath: r.ClientCertPath,
+		ClientKeyPath:  r.ClientKeyPath,
+		CACertPath:     r.CACertPath,
+	}, r.Options...)
+	if err != nil {
+		return fmt.Errorf("impossible to connect to nats: %w", err)
+	}
+	js, err := jetstream.New(conn)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("impossible to use jetstream: %w", err)
+	}
+	kv, err := js.KeyValue(ctx, r.Bucket)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("impossible to open the key-value bucket %q: %w", r.Bucket, err)
+	}
+	r.conn = conn
+	r.kv = kv
+	return nil
+}
+
+// Status is the function returning the internal state of the retriever.
+func (r *Retriever) Status() retriever.Status {
+	if r == nil || r.status == "" {
+		return retriever.RetrieverNotReady
+	}
+	return r.status
+}
+
+// Shutdown stops the watch and closes the connection to NATS.
+func (r *Retriever) Shutdown(_ context.Context) error {
+	if r == nil {
+		return nil
+	}
+	if r.stopWatch != nil {
+		r.stopWatch()
+		r.watchWg.Wait()
+		r.stopWatch = nil
+	}
+	if r.conn != nil {
+		r.conn.Close()
+		r.conn = nil
+	}
+	r.kv = nil
+	r.status = retriever.RetrieverNotReady
+	return nil
+}
+
+// Retrieve is the function in charge of fetching the flag configuration.
+func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
+	if r.kv == nil {
+		return nil, errors.New("nats connection is not initialized")
+	}
+	// a watcher returns the last value of every key, then a nil entry.
+	watcher, err := r.kv.Watch(ctx, r.keysFilter(), jetstream.IgnoreDeletes())
+	if err != nil {
+		return nil, fmt.Errorf("impossible to read the key-value bucket %q: %w", r.Bucket, err)
+	}
+	defer func() { _ = watcher.Stop() }()
+
+	flagsData := make(map[string]any)
+	var revision uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case entry, ok := <-watcher.Updates():
+			if !ok {
+				return nil, fmt.Errorf("impossible to read the key-value bucket %q: watch closed", r.Bucket)
+			}
+			if entry == nil {
+				content, err := json.Marshal(flagsData)
+				if err != nil {
+					return nil, fmt.Errorf("error marshalling flags data: %v", err)
+				}
+				r.revision.Store(revision)
+				return content, nil
+			}
+			var flagData any
+			if err := json.Unmarshal(entry.Value(), &flagData); err != nil {
+				return nil, fmt.Errorf("error unmarshalling flag '%s': %v", entry.Key(), err)
+			}
+			flagsData[strings.TrimPrefix(entry.Key(), r.keyPrefix())] = flagData
+			revision = max(revision, entry.Revision())
+		}
+	}
+}
+
+// OutputFormat declares that this retriever always returns JSON-encoded data,
+// so the manager can pick the JSON parser regardless of the global FileFormat.
+func (r *Retriever) OutputFormat() string {
+	return "json"
+}
+
+// Version returns the highest revision of the keys returned by the last call to Retrieve.
+func (r *Retriever) Version() string {
+	revision := r.revision.Load()
+	if revision == 0 {
+		return ""
+	}
+	return strconv.FormatUint(revision, 10)
+}
+
+// Updates returns the channel notified each time a flag changes in the bucket.
+func (r *Retriever) Updates() <-chan struct{} {
+	r.updatesOnce.Do(func() { r.updates = make(chan struct{}, 1) })
+	return r.updates
+}
+
+// keyPrefix returns the prefix of the keys of the flagset, empty if no flagset is used.
+func (r *Retriever) keyPrefix() string {
+	if r.flagset != nil && *r.flagset != "" && *r.flagset != utils.DefaultFlagSetName {
+		return *r.flagset + "."
+	}
+	return ""
+}
+
+// keysFilter returns the filter of the keys to read.
+func (r *Retriever) keysFilter() string {
+	return r.keyPrefix() + jetstream.AllKeys
+}
+
+// watch notifies the updates until the context is done, the watch is restarted if it is interrupted.
+func (r *Retriever) watch(ctx context.Context, kv jetstream.KeyValue) {
+	for ctx.Err() == nil {
+		watcher, err := kv.Watch(ctx, r.keysFilter(), jetstream.UpdatesOnly())
+		if err == nil {
+			for entry := range watcher.Updates() {
+				if entry != nil {
+					r.notify()
+				}
+			}
+			_ = watcher.Stop()
+		} else if ctx.Err() == nil {
+			r.logger.Warn("Impossible to watch the nats key-value bucket, retrying.", "error", err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(watchRetryDelay):
+			// changes may have been missed while the watch was interrupted.
+			r.notify()
+		}
+	}
+}
+
+// notify sends an update without blocking, an update already pending is enough.
+func (r *Retriever) notify() {
+	r.Updates()
+	select {
+	case r.updates <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,217 @@
+package natsretriever_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/natsretriever"
+	"github.com/thomaspoignant/go-feature-flag/utils"
+)
+
+func TestNATSRetriever(t *testing.T) {
+	tests := []struct {
+		name      string
+		keys      map[string]string
+		deleted   []string
+		flagset   string
+		assertErr assert.ErrorAssertionFunc
+		want      []string
+	}{
+		{
+			name:      "one key per flag",
+			keys:      map[string]string{"flag1": "flag1.json", "flag2": "flag2.json"},
+			assertErr: assert.NoError,
+			want:      []string{"flag1", "flag2"},
+		},
+		{
+			name:      "default flagset reads all the keys",
+			keys:      map[string]string{"flag1": "flag1.json", "team-A.flag2": "flag2.json"},
+			flagset:   utils.DefaultFlagSetName,
+			assertErr: assert.NoError,
+			want:      []string{"flag1", "team-A.flag2"},
+		},
+		{
+			name:      "flagset reads only its keys",
+			keys:      map[string]string{"flag1": "flag1.json", "team-A.flag1": "flag1.json", "team-A.flag2": "flag2.json"},
+			flagset:   "team-A",
+			assertErr: assert.NoError,
+			want:      []string{"flag1", "flag2"},
+		},
+		{
+			name:      "deleted keys are ignored",
+			keys:      map[string]string{"flag1": "flag1.json", "flag2": "flag2.json"},
+			deleted:   []string{"flag2"},
+			assertErr: assert.NoError,
+			want:      []string{"flag1"},
+		},
+		{
+			name:      "empty bucket",
+			keys:      map[string]string{},
+			assertErr: assert.NoError,
+			want:      []string{},
+		},
+		{
+			name:      "invalid json",
+			keys:      map[string]string{"flag1": "flag1.json", "flag2": "../retriever_test.go"},
+			assertErr: assert.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url, kv := startNATS(t, "flags")
+			for key, file := range tt.keys {
+				content, err := os.ReadFile(filepath.Join("testdata", file))
+				require.NoError(t, err)
+				_, err = kv.Put(context.TODO(), key, content)
+				require.NoError(t, err)
+			}
+			for _, key := range tt.deleted {
+				require.NoError(t, kv.Delete(context.TODO(), key))
+			}
+
+			r := &natsretriever.Retriever{URL: url, Bucket: "flags"}
+			require.NoError(t, r.Init(context.TODO(), nil, &tt.flagset))
+			defer func() { assert.NoError(t, r.Shutdown(context.TODO())) }()
+			assert.Equal(t, retriever.RetrieverReady, r.Status())
+
+			got, err := r.Retrieve(context.TODO())
+			tt.assertErr(t, err)
+			if err != nil {
+				return
+			}
+			var flags map[string]any
+			require.NoError(t, json.Unmarshal(got, &flags))
+			assert.ElementsMatch(t, tt.want, keys(flags))
+			assert.Equal(t, "json", r.OutputFormat())
+			if len(tt.want) > 0 {
+				assert.NotEmpty(t, r.Version())
+			}
+		})
+	}
+}
+
+func TestNATSRetriever_Watch(t *testing.T) {
+	url, kv := startNATS(t, "flags")
+	flagset := "team-A"
+	r := &natsretriever.Retriever{URL: url, Bucket: "flags"}
+	require.NoError(t, r.Init(context.TODO(), nil, &flagset))
+	defer func() { assert.NoError(t, r.Shutdown(context.TODO())) }()
+
+	_, err := kv.Put(context.TODO(), "team-B.flag1", []byte("{}"))
+	require.NoError(t, err)
+	select {
+	case <-r.Updates():
+		t.Fatal("a change in another flagset should not be notified")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	_, err = kv.Put(context.TODO(), "team-A.flag1", []byte("{}"))
+	require.NoError(t, err)
+	select {
+	case <-r.Updates():
+	case <-time.After(5 * time.Second):
+		t.Fatal("the change of the flag is not notified")
+	}
+}
+
+// TestRetrieverErrorHandling tests various error conditions in the NATS retriever
+func TestRetrieverErrorHandling(t *testing.T) {
+	t.Run("Init - no bucket", func(t *testing.T) {
+		r := &natsretriever.Retriever{URL: "nats://localhost:4222"}
+		assert.EqualError(t, r.Init(context.TODO(), nil, nil), "nats retriever: bucket is mandatory")
+		assert.Equal(t, retriever.RetrieverError, r.Status())
+	})
+
+	t.Run("Init - bucket does not exist", func(t *testing.T) {
+		url, _ := startNATS(t, "flags")
+		r := &natsretriever.Retriever{URL: url, Bucket: "unknown"}
+		assert.ErrorContains(t, r.Init(context.TODO(), nil, nil), `impossible to open the key-value bucket "unknown"`)
+		assert.Equal(t, retriever.RetrieverError, r.Status())
+	})
+
+	t.Run("Init - client certificate without key", func(t *testing.T) {
+		r := &natsretriever.Retriever{URL: "tls://localhost:4222", Bucket: "flags", ClientCertPath: "client.crt"}
+		assert.ErrorContains(t, r.Init(context.TODO(), nil, nil),
+			"client certificate and client key must be provided together")
+	})
+
+	t.Run("Init - nats not reachable", func(t *testing.T) {
+		r := &natsretriever.Retriever{
+			URL:     "nats://127.0.0.1:1",
+			Bucket:  "flags",
+			Options: []nats.Option{nats.Timeout(100 * time.Millisecond)},
+		}
+		assert.ErrorContains(t, r.Init(context.TODO(), nil, nil), "impossible to connect to nats")
+		assert.Equal(t, retriever.RetrieverError, r.Status())
+	})
+
+	t.Run("Init - authentication", func(t *testing.T) {
+		url, _ := startNATSWithOptions(t, "flags", func(opts *server.Options) { opts.Authorization = "secret-token" })
+		r := &natsretriever.Retriever{URL: url, Bucket: "flags"}
+		assert.Error(t, r.Init(context.TODO(), nil, nil))
+
+		r = &natsretriever.Retriever{URL: url, Bucket: "flags", Token: "secret-token"}
+		require.NoError(t, r.Init(context.TODO(), nil, nil))
+		assert.NoError(t, r.Shutdown(context.TODO()))
+	})
+
+	t.Run("Retrieve - not initialized", func(t *testing.T) {
+		r := &natsretriever.Retriever{Bucket: "flags"}
+		_, err := r.Retrieve(context.TODO())
+		assert.EqualError(t, err, "nats connection is not initialized")
+	})
+
+	t.Run("Status - nil receiver", func(t *testing.T) {
+		var r *natsretriever.Retriever
+		assert.Equal(t, retriever.RetrieverNotReady, r.Status())
+	})
+
+	t.Run("Shutdown - not initialized", func(t *testing.T) {
+		r := &natsretriever.Retriever{Bucket: "flags"}
+		assert.NoError(t, r.Shutdown(context.TODO()))
+	})
+}
+
+// startNATS starts an embedded NATS server with JetStream, creates the bucket and returns the URL of the server.
+func startNATS(t *testing.T, bucket string) (string, jetstream.KeyValue) {
+	t.Helper()
+	return startNATSWithOptions(t, bucket, func(*server.Options) {})
+}
+
+func startNATSWithOptions(t *testing.T, bucket string, setOptions func(*server.Options)) (string, jetstream.KeyValue) {
+	t.Helper()
+	opts := &server.Options{Host: "127.0.0.1", Port: server.RANDOM_PORT, JetStream: true, StoreDir: t.TempDir()}
+	setOptions(opts)
+	s, err := server.NewServer(opts)
+	require.NoError(t, err)
+	go s.Start()
+	t.Cleanup(s.Shutdown)
+	require.True(t, s.ReadyForConnections(10*time.Second), "nats is not ready")
+
+	conn, err := nats.Connect(s.ClientURL(), nats.Token(opts.Authorization))
+	require.NoError(t, err)
+	t.Cleanup(conn.Close)
+	js, err := jetstream.New(conn)
+	require.NoError(t, err)
+	kv, err := js.CreateKeyValue(context.TODO(), jetstream.KeyValueConfig{Bucket: bucket})
+	require.NoError(t, err)
+	return s.ClientURL(), kv
+}
+
+func keys(m map[string]any) []string {
+	res := make([]string, 0, len(m))
+	for k := range m {
+		res = append(res, k)
+	}
+	return res
+}
@@ -0,0 +1,280 @@
+package retriever
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/thomaspoignant/go-feature-flag/modules/core/dto"
+)
+
+const (
+	// defaultBackoffMaxInterval is the maximum delay between two attempts when backing off,
+	// if Policy.BackoffMaxInterval is not set.
+	defaultBackoffMaxInterval = 5 * time.Minute
+	// defaultCircuitBreakerCooldown is the time a circuit breaker stays open,
+	// if Policy.CircuitBreakerCooldown is not set.
+	defaultCircuitBreakerCooldown = time.Minute
+)
+
+// Policy is the refresh policy of a retriever.
+// It allows a retriever to be refreshed on its own schedule, independently of the other retrievers.
+type Policy struct {
+	// PollingInterval (optional) is the time between two calls of the retriever.
+	// Default: the polling interval of GO Feature Flag
+	PollingInterval time.Duration
+
+	// Timeout (optional) is the maximum duration of a call of the retriever.
+	// Default: no timeout
+	Timeout time.Duration
+
+	// BackoffInitialInterval (optional) is the time before calling the retriever again after an error.
+	// It doubles after each consecutive error (with a jitter) until BackoffMaxInterval.
+	// Default: no backoff, the retriever is called again after its polling interval.
+	BackoffInitialInterval time.Duration
+
+	// BackoffMaxInterval (optional) is the maximum time between two calls of the retriever when backing off.
+	// Default: 5 minutes
+	BackoffMaxInterval time.Duration
+
+	// CircuitBreakerThreshold (optional) is the number of consecutive errors opening the circuit breaker.
+	// When the circuit breaker is open, the retriever is not called until the end of CircuitBreakerCooldown,
+	// then it is called once again to check if it is back.
+	// Default: 0 (no circuit breaker)
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown (optional) is the time the circuit breaker stays open.
+	// Default: 1 minute
+	CircuitBreakerCooldown time.Duration
+}
+
+// PolicyRetriever is a Retriever with its own refresh policy, use WithPolicy to create it.
+type PolicyRetriever struct {
+	Retriever
+	Policy Policy
+}
+
+// WithPolicy returns the retriever with its own refresh policy.
+// The manager uses this policy instead of the polling interval of GO Feature Flag for this retriever.
+func WithPolicy(r Retriever, policy Policy) Retriever {
+	return &PolicyRetriever{Retriever: r, Policy: policy}
+}
+
+// CircuitBreakerState is the state of the circuit breaker of a retriever.
+type CircuitBreakerState = string
+
+const (
+	// CircuitBreakerClosed is the state when the retriever is called normally.
+	CircuitBreakerClosed CircuitBreakerState = "CLOSED"
+	// CircuitBreakerOpen is the state when the retriever is not called anymore until the end of the cooldown.
+	CircuitBreakerOpen CircuitBreakerState = "OPEN"
+	// CircuitBreakerHalfOpen is the state when the cooldown is over and the next call decides if the
+	// circuit breaker closes or opens again.
+	CircuitBreakerHalfOpen CircuitBreakerState = "HALF_OPEN"
+)
+
+// RetrieverStatus is the refresh status of a retriever.
+type RetrieverStatus struct {
+	// Name is the type of the retriever.
+	Name string
+	// Location is the location of the configuration retrieved, if the retriever exposes it.
+	Location string
+	// Status is READY if the last call of the retriever succeeded, ERROR if it failed.
+	Status Status
+	// LastSuccess is the date of the last successful call of the retriever.
+	LastSuccess time.Time
+	// LastError is the date of the last failed call of the retriever.
+	LastError time.Time
+	// LastErrorMessage is the error of the last failed call of the retriever.
+	LastErrorMessage string
+	// ConsecutiveErrors is the number of failed calls since the last successful one.
+	ConsecutiveErrors int
+	// NextAttempt is the date of the next call of the retriever, zero if the retriever is not polled.
+	NextAttempt time.Time
+	// CircuitBreaker is the state of the circuit breaker of the retriever.
+	CircuitBreaker CircuitBreakerState
+}
+
+// unwrapPolicy returns the retriever wrapped by WithPolicy and its policy.
+func unwrapPolicy(r Retriever) (Retriever, Policy) {
+	if pr, ok := r.(*PolicyRetriever); ok {
+		return pr.Retriever, pr.Policy
+	}
+	return r, Policy{}
+}
+
+// retrieverState is the refresh state of a retriever, it keeps the last flags successfully retrieved
+// to be able to merge them with the flags of the other retrievers.
+type retrieverState struct {
+	policy Policy
+	// pollingInterval is the polling interval of the retriever, the retriever is not polled if <= 0.
+	pollingInterval time.Duration
+	useJitter       bool
+
+	mutex             sync.RWMutex
+	flags             map[string]dto.DTO
+	served            bool
+	lastSuccess       time.Time
+	lastError         time.Time
+	lastErrorMessage  string
+	consecutiveErrors int
+	nextAttempt       time.Time
+}
+
+// newRetrieverState creates the state of a retriever, pollingInterval is used if the policy has no
+// polling interval.
+func newRetrieverState(policy Policy, pollingInterval time.Duration, useJitter bool) *retrieverState {
+	if policy.PollingInterval != 0 {
+		pollingInterval = policy.PollingInterval
+	}
+	return &retrieverState{
+		policy:          policy,
+		pollingInterval: pollingInterval,
+		useJitter:       useJitter,
+	}
+}
+
+// isDue returns true if the retriever should be called.
+func (s *retrieverState) isDue(now time.Time) bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return !s.nextAttempt.IsZero() && !now.Before(s.nextAttempt)
+}
+
+// isFailing returns true if the last call of the retriever failed.
+func (s *retrieverState) isFailing() bool {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.consecutiveErrors > 0
+}
+
+// getFlags returns the last flags successfully retrieved, and false if the retriever never served any flag.
+func (s *retrieverState) getFlags() (map[string]dto.DTO, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.flags, s.served
+}
+
+// getNextAttempt returns the date of the next call of the retriever, zero if the retriever is not polled.
+func (s *retrieverState) getNextAttempt() time.Time {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return s.nextAttempt
+}
+
+// recordNotReady records a retriever not ready yet, it does not provide any flag.
+func (s *retrieverState) recordNotReady(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.flags = map[string]dto.DTO{}
+	s.served = true
+	s.nextAttempt = s.afterPollingInterval(now)
+}
+
+// postpone skips a call of the retriever, the retriever is called again after its polling interval.
+func (s *retrieverState) postpone(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.nextAttempt = s.afterPollingInterval(now)
+}
+
+// markDue makes the retriever due, it is used when the retriever notified a change of its configuration.
+func (s *retrieverState) markDue(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.nextAttempt = now
+}
+
+// recordSuccess records a successful call of the retriever.
+func (s *retrieverState) recordSuccess(now time.Time, flags map[string]dto.DTO) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.flags = flags
+	s.served = true
+	s.lastSuccess = now
+	s.consecutiveErrors = 0
+	s.nextAttempt = s.afterPollingInterval(now)
+}
+
+// recordError records a failed call of the retriever, it returns true if this error opened the circuit breaker.
+func (s *retrieverState) recordError(now time.Time, err error) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.lastError = now
+	s.lastErrorMessage = err.Error()
+	s.consecutiveErrors++
+	switch {
+	case s.pollingInterval <= 0:
+		s.nextAttempt = time.Time{}
+	case s.policy.CircuitBreakerThreshold > 0 && s.consecutiveErrors >= s.policy.CircuitBreakerThreshold:
+		cooldown := s.policy.CircuitBreakerCooldown
+		if cooldown <= 0 {
+			cooldown = defaultCircuitBreakerCooldown
+		}
+		s.nextAttempt = now.Add(cooldown)
+		return s.consecutiveErrors == s.policy.CircuitBreakerThreshold
+	case s.policy.BackoffInitialInterval > 0:
+		s.nextAttempt = now.Add(s.backoffDelay())
+	default:
+		s.nextAttempt = s.afterPollingInterval(now)
+	}
+	return false
+}
+
+// afterPollingInterval returns the date of the next call of the retriever when it is healthy.
+func (s *retrieverState) afterPollingInterval(now time.Time) time.Time {
+	if s.pollingInterval <= 0 {
+		return time.Time{}
+	}
+	if s.useJitter {
+		return now.Add(applyPollingJitter(s.pollingInterval))
+	}
+	return now.Add(s.pollingInterval)
+}
+
+// backoffDelay returns the exponential delay before the next call after consecutive errors.
+// We use an "equal jitter": the delay is between half and the full exponential delay.
+func (s *retrieverState) backoffDelay() time.Duration {
+	maxInterval := s.policy.BackoffMaxInterval
+	if maxInterval <= 0 {
+		maxInterval = defaultBackoffMaxInterval
+	}
+	delay := s.policy.BackoffInitialInterval
+	for i := 1; i < s.consecutiveErrors && delay < maxInterval; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxInterval)
+	if half := int64(delay / 2); half > 0 {
+		delay = time.Duration(half + rand.Int63n(half)) // nolint: gosec
+	}
+	return delay
+}
+
+// status returns the refresh status of the retriever.
+func (s *retrieverState) status(now time.Time) RetrieverStatus {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	status := RetrieverStatus{
+		LastSuccess:       s.lastSuccess,
+		LastError:         s.lastError,
+		LastErrorMessage:  s.lastErrorMessage,
+		ConsecutiveErrors: s.consecutiveErrors,
+		NextAttempt:       s.nextAttempt,
+		CircuitBreaker:    CircuitBreakerClosed,
+	}
+	switch {
+	case s.consecutiveErrors > 0:
+		status.Status = RetrieverError
+	case s.served:
+		status.Status = RetrieverReady
+	default:
+		status.Status = RetrieverNotReady
+	}
+	if s.policy.CircuitBreakerThreshold > 0 && s.consecutiveErrors >= s.policy.CircuitBreakerThreshold {
+		status.CircuitBreaker = CircuitBreakerHalfOpen
+		if now.Before(s.nextAttempt) {
+			status.CircuitBreaker = CircuitBreakerOpen
+		}
+	}
+	return status
+}
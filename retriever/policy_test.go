@@ -0,0 +1,70 @@
+package retriever
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetrieverState_BackoffDelay(t *testing.T) {
+	state := newRetrieverState(Policy{
+		BackoffInitialInterval: time.Second,
+		BackoffMaxInterval:     10 * time.Second,
+	}, time.Minute, false)
+
+	tests := []struct {
+		consecutiveErrors int
+		maxDelay          time.Duration
+	}{
+		{consecutiveErrors: 1, maxDelay: time.Second},
+		{consecutiveErrors: 2, maxDelay: 2 * time.Second},
+		{consecutiveErrors: 3, maxDelay: 4 * time.Second},
+		{consecutiveErrors: 4, maxDelay: 8 * time.Second},
+		{consecutiveErrors: 5, maxDelay: 10 * time.Second},
+		{consecutiveErrors: 100, maxDelay: 10 * time.Second},
+	}
+	for _, tt := range tests {
+		state.consecutiveErrors = tt.consecutiveErrors
+		for range 10 {
+			delay := state.backoffDelay()
+			assert.GreaterOrEqual(t, delay, tt.maxDelay/2, "consecutive errors: %d", tt.consecutiveErrors)
+			assert.LessOrEqual(t, delay, tt.maxDelay, "consecutive errors: %d", tt.consecutiveErrors)
+		}
+	}
+}
+
+func TestRetrieverState_CircuitBreaker(t *testing.T) {
+	now := time.Now()
+	state := newRetrieverState(Policy{CircuitBreakerThreshold: 2}, time.Minute, false)
+	state.recordSuccess(now, nil)
+	assert.Equal(t, CircuitBreakerClosed, state.status(now).CircuitBreaker)
+
+	assert.False(t, state.recordError(now, errors.New("error 1")))
+	assert.Equal(t, CircuitBreakerClosed, state.status(now).CircuitBreaker)
+	assert.Equal(t, now.Add(time.Minute), state.getNextAttempt())
+
+	assert.True(t, state.recordError(now, errors.New("error 2")), "the circuit breaker should open")
+	assert.Equal(t, CircuitBreakerOpen, state.status(now).CircuitBreaker)
+	assert.Equal(t, now.Add(defaultCircuitBreakerCooldown), state.getNextAttempt())
+	assert.Equal(t, CircuitBreakerHalfOpen, state.status(now.Add(defaultCircuitBreakerCooldown)).CircuitBreaker)
+
+	assert.False(t, state.recordError(now, errors.New("error 3")), "the circuit breaker is already open")
+	assert.Equal(t, CircuitBreakerOpen, state.status(now).CircuitBreaker)
+
+	state.recordSuccess(now, nil)
+	assert.Equal(t, CircuitBreakerClosed, state.status(now).CircuitBreaker)
+	assert.Equal(t, RetrieverReady, state.status(now).Status)
+}
+
+func TestRetrieverState_NotPolled(t *testing.T) {
+	now := time.Now()
+	state := newRetrieverState(Policy{BackoffInitialInterval: time.Second}, -1, false)
+	assert.Equal(t, RetrieverNotReady, state.status(now).Status)
+	state.recordSuccess(now, nil)
+	assert.True(t, state.getNextAttempt().IsZero())
+	state.recordError(now, errors.New("error"))
+	assert.True(t, state.getNextAttempt().IsZero())
+	assert.False(t, state.isDue(now.Add(time.Hour)))
+}
@@ -107,7 +107,8 @@ func TestPostgreSQLRetriever(t *testing.T) {
 				return
 			}
 
-			want, err := os.ReadFile(filepath.Join("testdata", tt.want))
+			// the expected responses are shared by the SQL retrievers
+			want, err := os.ReadFile(filepath.Join("..", "shared", "sqlretriever", "testdata", tt.want))
 			assert.NoError(t, err)
 			assert.JSONEq(t, string(want), string(got))
 
@@ -215,7 +216,7 @@ func TestRetrieverErrorHandling(t *testing.T) {
 
 		_, err := r.Retrieve(context.Background())
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "database connection pool is not initialized")
+		assert.Contains(t, err.Error(), "database connection is not initialized")
 	})
 
 	t.Run("Status - nil receiver", func(t *testing.T) {
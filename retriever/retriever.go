@@ -24,6 +24,34 @@ type FormatHinter interface {
 	OutputFormat() string
 }
 
+// VersionedRetriever is an optional interface a Retriever can implement to expose
+// the version of the configuration returned by the last call to Retrieve (ex: a commit SHA).
+type VersionedRetriever interface {
+	Version() string
+}
+
+// LocatedRetriever is an optional interface a Retriever can implement to expose the location
+// of the configuration it retrieves (a file path or a URL).
+// The relative includes of the configuration are resolved from this location.
+type LocatedRetriever interface {
+	Location() string
+}
+
+// IncludeReader is an optional interface a LocatedRetriever can implement to read the files included by
+// its configuration with its own credentials and client configuration (ex: headers, token, TLS).
+// It is called for the included files having the same origin as the location of the retriever.
+type IncludeReader interface {
+	ReadInclude(ctx context.Context, location string) ([]byte, error)
+}
+
+// WatchableRetriever is an optional interface a Retriever can implement to push the changes of the
+// configuration instead of waiting for the next polling (ex: a watch on a key-value store).
+// The manager calls the retriever again each time a value is received on the channel, the polling
+// keeps running as a safety net.
+type WatchableRetriever interface {
+	Updates() <-chan struct{}
+}
+
 // InitializableRetrieverLegacy is an extended version of the retriever that can be initialized and shutdown.
 type InitializableRetrieverLegacy interface {
 	CommonInitializableRetriever
@@ -3,6 +3,7 @@ package s3retrieverv2
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -10,6 +11,7 @@ import (
 	"github.com/aws/aws-sdk-go-v2/feature/s3/transfermanager/types"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/shared"
 	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
 )
 
@@ -81,18 +83,38 @@ func (s *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
 		return nil, fmt.Errorf("downloader is not initialized")
 	}
 
-	// Download the item from the bucket.
-	writerAt := types.NewWriteAtBuffer([]byte{})
+	return s.download(ctx, s.Item)
+}
+
+// Location returns the S3 URI of the item (ex: s3://bucket/flags.yaml), the includes of the configuration
+// are resolved from it.
+func (s *Retriever) Location() string {
+	return "s3://" + s.Bucket + "/" + strings.TrimPrefix(s.Item, "/")
+}
 
+// ReadInclude reads an item of the bucket included by the configuration.
+func (s *Retriever) ReadInclude(ctx context.Context, location string) ([]byte, error) {
+	if s.downloader == nil {
+		return nil, fmt.Errorf("downloader is not initialized")
+	}
+	item, err := shared.IncludePath("s3://"+s.Bucket, location)
+	if err != nil {
+		return nil, err
+	}
+	return s.download(ctx, item)
+}
+
+// download downloads an item from the bucket.
+func (s *Retriever) download(ctx context.Context, item string) ([]byte, error) {
+	writerAt := types.NewWriteAtBuffer([]byte{})
 	_, err := s.downloader.DownloadObject(ctx, &transfermanager.DownloadObjectInput{
 		Bucket:   aws.String(s.Bucket),
-		Key:      aws.String(s.Item),
+		Key:      aws.String(item),
 		WriterAt: writerAt,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("unable to download item from S3 %q, %v", s.Item, err)
+		return nil, fmt.Errorf("unable to download item from S3 %q, %v", item, err)
 	}
-
 	return writerAt.Bytes(), nil
 }
 
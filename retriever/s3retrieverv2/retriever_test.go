@@ -217,3 +217,21 @@ func TestRetriever_Init(t *testing.T) {
 		assert.Len(t, s.S3ClientOptions, 1, "S3ClientOptions should have one option")
 	})
 }
+
+func TestRetriever_ReadInclude(t *testing.T) {
+	s := Retriever{Bucket: "Bucket", Item: "config/flags.yaml"}
+	assert.Equal(t, "s3://Bucket/config/flags.yaml", s.Location())
+
+	_, err := s.ReadInclude(context.Background(), "s3://Bucket/valid")
+	assert.ErrorContains(t, err, "downloader is not initialized")
+
+	s.SetDownloader(&testutils.S3ManagerV2Mock{TestDataLocation: "./testdata"})
+	got, err := s.ReadInclude(context.Background(), "s3://Bucket/valid")
+	assert.NoError(t, err)
+	want, err := os.ReadFile("./testdata/flag-config.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+
+	_, err = s.ReadInclude(context.Background(), "s3://Other/valid")
+	assert.ErrorContains(t, err, "the included file s3://Other/valid is outside of s3://Bucket")
+}
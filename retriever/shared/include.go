@@ -0,0 +1,28 @@
+package shared
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// IncludePath returns the path of an included file relative to root, the location of the root directory
+// of the retriever (ex: the root of a repository or of a bucket).
+// It returns an error if the location is not inside root.
+func IncludePath(root string, location string) (string, error) {
+	rootURL, err := url.Parse(root)
+	if err != nil {
+		return "", err
+	}
+	locationURL, err := url.Parse(location)
+	if err != nil {
+		return "", err
+	}
+	prefix := strings.TrimSuffix(rootURL.Path, "/") + "/"
+	filePath, ok := strings.CutPrefix(locationURL.Path, prefix)
+	if !ok || filePath == "" || strings.Contains(filePath, "..") ||
+		!strings.EqualFold(rootURL.Scheme, locationURL.Scheme) || !strings.EqualFold(rootURL.Host, locationURL.Host) {
+		return "", fmt.Errorf("the included file %s is outside of %s", location, root)
+	}
+	return filePath, nil
+}
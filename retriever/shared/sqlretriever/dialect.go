@@ -0,0 +1,36 @@
+package sqlretriever
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+// QuoteWithBackticks quotes an identifier with backticks (MySQL, SQLite).
+func QuoteWithBackticks(identifier string) string {
+	return "`" + strings.ReplaceAll(identifier, "`", "``") + "`"
+}
+
+// QuestionMarkPlaceholder returns the placeholder ? (MySQL, SQLite).
+func QuestionMarkPlaceholder(_ int) string {
+	return "?"
+}
+
+// DollarPlaceholder returns the numbered placeholder $n (PostgreSQL).
+func DollarPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// OpenDB opens a database with a database/sql driver and checks that it is reachable.
+func OpenDB(ctx context.Context, driverName string, dataSourceName string) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dataSourceName)
+	if err != nil {
+		return nil, err
+	}
+	if err := db.PingContext(ctx); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
@@ -0,0 +1,169 @@
+package sqlretriever
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/utils"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+var defaultColumns = map[string]string{
+	"flag_name": "flag_name",
+	"flagset":   "flagset",
+	"config":    "config",
+}
+
+// Dialect contains what differs between the SQL databases.
+type Dialect struct {
+	// Name is the name of the database used in the logs (ex: MySQL).
+	Name string
+	// QuoteIdentifier quotes a table or a column name to prevent SQL injection.
+	QuoteIdentifier func(identifier string) string
+	// Placeholder returns the placeholder of the n-th argument of a query, starting at 1.
+	Placeholder func(n int) string
+	// Open opens the connection to the database from the data source name of the retriever.
+	Open func(ctx context.Context) (*sql.DB, error)
+}
+
+// Core is the implementation shared by the retrievers reading the flags in a SQL table,
+// the table contains one flag per row (flag name, flagset and JSON configuration of the flag).
+type Core struct {
+	Dialect Dialect
+	// Table is the name of the table containing the flags.
+	Table string
+	// Columns (optional) is the mapping of the column names, if they are not the default ones
+	// (flag_name, flagset, config).
+	Columns map[string]string
+
+	logger  *fflog.FFLogger
+	status  retriever.Status
+	columns map[string]string
+	db      *sql.DB
+	flagset *string
+}
+
+// Init opens the connection to the database, if it is not already opened.
+func (c *Core) Init(ctx context.Context, logger *fflog.FFLogger, flagset *string) error {
+	c.status = retriever.RetrieverNotReady
+	c.logger = logger
+	if c.logger == nil {
+		c.logger = &fflog.FFLogger{}
+	}
+	c.columns = c.getColumnNames()
+	c.flagset = flagset
+
+	if c.db == nil {
+		c.logger.Info(fmt.Sprintf("Initializing %s retriever", c.Dialect.Name))
+		c.logger.Debug("Using columns", "columns", c.columns)
+		db, err := c.Dialect.Open(ctx)
+		if err != nil {
+			c.status = retriever.RetrieverError
+			return err
+		}
+		c.db = db
+	}
+	c.status = retriever.RetrieverReady
+	return nil
+}
+
+// Status returns the current status of the retriever
+func (c *Core) Status() retriever.Status {
+	if c == nil || c.status == "" {
+		return retriever.RetrieverNotReady
+	}
+	return c.status
+}
+
+// Shutdown closes the database connection.
+func (c *Core) Shutdown() error {
+	if c.db != nil {
+		err := c.db.Close()
+		c.db = nil
+		return err
+	}
+	return nil
+}
+
+// Retrieve fetches the flags of the flagset and returns them as a JSON object with the flag name as key.
+func (c *Core) Retrieve(ctx context.Context) ([]byte, error) {
+	if c.db == nil {
+		return nil, fmt.Errorf("database connection is not initialized")
+	}
+
+	query, args := c.BuildQuery()
+	c.logger.Debug(fmt.Sprintf("Executing %s query", c.Dialect.Name),
+		slog.String("query", query), slog.Any("args", args))
+	rows, err := c.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	// Map to store flag configurations with flag_name as key
+	flagConfigs := make(map[string]any)
+	for rows.Next() {
+		var flagName string
+		var configData []byte
+		if err := rows.Scan(&flagName, &configData); err != nil {
+			c.logger.Error("Failed to scan row", "error", err)
+			continue
+		}
+
+		var config map[string]any
+		if err := json.Unmarshal(configData, &config); err != nil {
+			c.logger.Error("Failed to unmarshal config data", "error", err, "flagName", flagName)
+			continue
+		}
+		flagConfigs[flagName] = config
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	result, err := json.Marshal(flagConfigs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal flag configurations: %w", err)
+	}
+	c.logger.Debug(fmt.Sprintf("Retrieved flags from %s", c.Dialect.Name), "count", len(flagConfigs))
+	return result, nil
+}
+
+// BuildQuery constructs the SQL query and its arguments based on whether a flagset is specified.
+func (c *Core) BuildQuery() (string, []any) {
+	columns := c.columns
+	if columns == nil {
+		columns = c.getColumnNames()
+	}
+	flagNameCol := c.Dialect.QuoteIdentifier(columns["flag_name"])
+	configCol := c.Dialect.QuoteIdentifier(columns["config"])
+	table := c.Dialect.QuoteIdentifier(c.Table)
+	flagsetCol := c.Dialect.QuoteIdentifier(columns["flagset"])
+
+	if flagset := c.getFlagset(); flagset != "" {
+		return fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s = %s ORDER BY %s",
+			flagNameCol, configCol, table, flagsetCol, c.Dialect.Placeholder(1), flagNameCol), []any{flagset}
+	}
+	return fmt.Sprintf("SELECT %s, %s FROM %s ORDER BY %s", flagNameCol, configCol, table, flagNameCol), []any{}
+}
+
+// getColumnNames returns the column names to use for database queries.
+// If c.Columns is provided, it merges those values with defaultColumns,
+// using defaults for any missing entries.
+func (c *Core) getColumnNames() map[string]string {
+	columns := maps.Clone(defaultColumns)
+	maps.Copy(columns, c.Columns)
+	return columns
+}
+
+func (c *Core) getFlagset() string {
+	if c.flagset != nil && *c.flagset != "" && *c.flagset != utils.DefaultFlagSetName {
+		return *c.flagset
+	}
+	return ""
+}
@@ -0,0 +1,88 @@
+package sqlretriever_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/shared/sqlretriever"
+)
+
+func TestCore_BuildQuery(t *testing.T) {
+	backticks := sqlretriever.Dialect{
+		QuoteIdentifier: sqlretriever.QuoteWithBackticks,
+		Placeholder:     sqlretriever.QuestionMarkPlaceholder,
+	}
+	dollar := sqlretriever.Dialect{
+		QuoteIdentifier: sqlretriever.QuoteWithBackticks,
+		Placeholder:     sqlretriever.DollarPlaceholder,
+	}
+	tests := []struct {
+		name      string
+		core      sqlretriever.Core
+		flagset   string
+		wantQuery string
+		wantArgs  []any
+	}{
+		{
+			name:      "default columns without flagset",
+			core:      sqlretriever.Core{Dialect: backticks, Table: "flags"},
+			wantQuery: "SELECT `flag_name`, `config` FROM `flags` ORDER BY `flag_name`",
+			wantArgs:  []any{},
+		},
+		{
+			name:      "default flagset",
+			core:      sqlretriever.Core{Dialect: backticks, Table: "flags"},
+			flagset:   "default",
+			wantQuery: "SELECT `flag_name`, `config` FROM `flags` ORDER BY `flag_name`",
+			wantArgs:  []any{},
+		},
+		{
+			name:      "flagset with question mark placeholder",
+			core:      sqlretriever.Core{Dialect: backticks, Table: "flags"},
+			flagset:   "team-A",
+			wantQuery: "SELECT `flag_name`, `config` FROM `flags` WHERE `flagset` = ? ORDER BY `flag_name`",
+			wantArgs:  []any{"team-A"},
+		},
+		{
+			name:      "flagset with dollar placeholder",
+			core:      sqlretriever.Core{Dialect: dollar, Table: "flags"},
+			flagset:   "team-A",
+			wantQuery: "SELECT `flag_name`, `config` FROM `flags` WHERE `flagset` = $1 ORDER BY `flag_name`",
+			wantArgs:  []any{"team-A"},
+		},
+		{
+			name: "custom columns are quoted",
+			core: sqlretriever.Core{
+				Dialect: backticks,
+				Table:   "my`flags",
+				Columns: map[string]string{"flag_name": "name", "config": "settings"},
+			},
+			wantQuery: "SELECT `name`, `settings` FROM `my``flags` ORDER BY `name`",
+			wantArgs:  []any{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.core.Dialect.Open = func(_ context.Context) (*sql.DB, error) { return nil, nil }
+			require.NoError(t, tt.core.Init(context.TODO(), nil, &tt.flagset))
+			query, args := tt.core.BuildQuery()
+			assert.Equal(t, tt.wantQuery, query)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
+
+func TestCore_NotInitialized(t *testing.T) {
+	var nilCore *sqlretriever.Core
+	assert.Equal(t, retriever.RetrieverNotReady, nilCore.Status())
+
+	core := sqlretriever.Core{}
+	assert.Equal(t, retriever.RetrieverNotReady, core.Status())
+	_, err := core.Retrieve(context.TODO())
+	assert.ErrorContains(t, err, "database connection is not initialized")
+	assert.NoError(t, core.Shutdown())
+}
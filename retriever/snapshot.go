@@ -0,0 +1,325 @@
+package retriever
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/thomaspoignant/go-feature-flag/modules/core/dto"
+)
+
+var (
+	// ErrSnapshotsDisabled is returned when using the snapshots while they are not enabled.
+	ErrSnapshotsDisabled = errors.New("the flag configuration snapshots are not enabled")
+	// ErrSnapshotNotFound is returned when pinning a snapshot that does not exist.
+	ErrSnapshotNotFound = errors.New("flag configuration snapshot not found")
+	// ErrNoPinnedSnapshot is returned when removing the pin while no snapshot is pinned.
+	ErrNoPinnedSnapshot = errors.New("no flag configuration snapshot is pinned")
+)
+
+// snapshotFileSuffix is added to the persistent flag configuration file to store the snapshots.
+const snapshotFileSuffix = ".snapshots.json"
+
+// Snapshot is a version of the flag configuration applied to the cache.
+type Snapshot struct {
+	// ID is the identifier of the snapshot, it increases with every new version of the flag configuration.
+	ID int `json:"id"`
+	// Date is the date when this version of the flag configuration was applied.
+	Date time.Time `json:"date"`
+	// Sources are the retrievers which provided this version of the flag configuration.
+	Sources []SnapshotSource `json:"sources"`
+	// NumberOfFlags is the number of flags in this version of the flag configuration.
+	NumberOfFlags int `json:"numberOfFlags"`
+}
+
+// SnapshotSource is a retriever which provided a version of the flag configuration.
+type SnapshotSource struct {
+	// Name is the type of the retriever.
+	Name string `json:"name"`
+	// Location is the location of the configuration, if the retriever exposes it.
+	Location string `json:"location,omitempty"`
+	// Version is the version of the configuration (ex: a commit SHA), if the retriever exposes it.
+	Version string `json:"version,omitempty"`
+}
+
+// storedSnapshot is a snapshot with its flags.
+type storedSnapshot struct {
+	Snapshot
+	Flags map[string]dto.DTO `json:"flags"`
+}
+
+// snapshotFile is the content of the file storing the snapshots.
+type snapshotFile struct {
+	Pinned    int              `json:"pinned,omitempty"`
+	Snapshots []storedSnapshot `json:"snapshots"`
+}
+
+// snapshotStore keeps the last versions of the flag configuration, and the version pinned if any.
+// The snapshots are stored in a file if file is set, to be kept across restarts.
+type snapshotStore struct {
+	mutex        sync.RWMutex
+	maxSnapshots int
+	file         string
+	// snapshots are sorted from the oldest to the newest.
+	snapshots []storedSnapshot
+	// pinned is the ID of the snapshot pinned, 0 if none.
+	pinned int
+}
+
+// newSnapshotStore creates a store keeping maxSnapshots versions of the flag configuration.
+func newSnapshotStore(maxSnapshots int, file string) *snapshotStore {
+	return &snapshotStore{
+		maxSnapshots: maxSnapshots,
+		file:         file,
+	}
+}
+
+// load reads the snapshots from the file, if the file exists.
+func (s *snapshotStore) load() error {
+	if s.file == "" {
+		return nil
+	}
+	content, err := os.ReadFile(s.file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	var f snapshotFile
+	if err := json.Unmarshal(content, &f); err != nil {
+		return fmt.Errorf("impossible to read the flag configuration snapshots %s: %w", s.file, err)
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.snapshots = f.Snapshots
+	if len(s.snapshots) > s.maxSnapshots {
+		s.snapshots = s.snapshots[len(s.snapshots)-s.maxSnapshots:]
+	}
+	if s.indexOf(f.Pinned) >= 0 {
+		s.pinned = f.Pinned
+	}
+	return nil
+}
+
+// add records a new version of the flag configuration.
+// It returns false if the flags are the same as the latest snapshot.
+func (s *snapshotStore) add(flags map[string]dto.DTO, sources []SnapshotSource, now time.Time) (bool, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	id := 1
+	if len(s.snapshots) > 0 {
+		latest := s.snapshots[len(s.snapshots)-1]
+		if sameFlags(latest.Flags, flags) {
+			return false, nil
+		}
+		id = latest.ID + 1
+	}
+	s.snapshots = append(s.snapshots, storedSnapshot{
+		Snapshot: Snapshot{ID: id, Date: now, Sources: sources, NumberOfFlags: len(flags)},
+		Flags:    flags,
+	})
+	if len(s.snapshots) > s.maxSnapshots {
+		s.snapshots = slices.Delete(s.snapshots, 0, len(s.snapshots)-s.maxSnapshots)
+	}
+	return true, s.save()
+}
+
+// list returns the snapshots, from the newest to the oldest.
+func (s *snapshotStore) list() []Snapshot {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	snapshots := make([]Snapshot, 0, len(s.snapshots))
+	for i := len(s.snapshots) - 1; i >= 0; i-- {
+		snapshots = append(snapshots, s.snapshots[i].Snapshot)
+	}
+	return snapshots
+}
+
+// pin pins the snapshot with this ID and returns it.
+func (s *snapshotStore) pin(id int) (storedSnapshot, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	index := s.indexOf(id)
+	if index < 0 {
+		return storedSnapshot{}, fmt.Errorf("%w: %d", ErrSnapshotNotFound, id)
+	}
+	s.pinned = id
+	return s.snapshots[index], s.save()
+}
+
+// unpin removes the pin.
+func (s *snapshotStore) unpin() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.pinned == 0 {
+		return ErrNoPinnedSnapshot
+	}
+	s.pinned = 0
+	return s.save()
+}
+
+// pinnedSnapshot returns the snapshot pinned, and false if no snapshot is pinned.
+func (s *snapshotStore) pinnedSnapshot() (storedSnapshot, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	if s.pinned == 0 {
+		return storedSnapshot{}, false
+	}
+	return s.snapshots[s.indexOf(s.pinned)], true
+}
+
+// indexOf returns the index of the snapshot with this ID, -1 if not found.
+// The caller must hold the mutex.
+func (s *snapshotStore) indexOf(id int) int {
+	return slices.IndexFunc(s.snapshots, func(snapshot storedSnapshot) bool { return snapshot.ID == id })
+}
+
+// save writes the snapshots in the file, if the file is set.
+// The caller must hold the mutex.
+func (s *snapshotStore) save() error {
+	if s.file == "" {
+		return nil
+	}
+	content, err := json.Marshal(snapshotFile{Pinned: s.pinned, Snapshots: s.snapshots})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.file, content, 0600)
+}
+
+// sameFlags compares 2 flag configurations, the JSON representation is used to ignore the differences of
+// types between the formats (ex: an int in YAML is a float in JSON).
+func sameFlags(a, b map[string]dto.DTO) bool {
+	contentA, errA := json.Marshal(a)
+	contentB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(contentA, contentB)
+}
+
+// initSnapshots loads the snapshots stored, and applies the snapshot pinned if any.
+// It returns true if a snapshot is pinned, in that case the retrievers are not called.
+func (m *Manager) initSnapshots() (bool, error) {
+	if m.snapshots == nil {
+		return false, nil
+	}
+	if err := m.snapshots.load(); err != nil {
+		m.logger.Warn("Impossible to load the flag configuration snapshots.", slog.Any("error", err.Error()))
+		return false, nil
+	}
+	snapshot, pinned := m.snapshots.pinnedSnapshot()
+	if !pinned {
+		return false, nil
+	}
+	m.logger.Warn("A flag configuration snapshot is pinned, the retrievers are not called until it is unpinned.",
+		slog.Int("snapshot", snapshot.ID))
+	if err := m.updateCacheWithRetriever(snapshot.Flags, true); err != nil {
+		return true, err
+	}
+	m.postponeIfPinned()
+	return true, nil
+}
+
+// postponeIfPinned postpones the calls of the retrievers if a snapshot is pinned, it returns true if
+// a snapshot is pinned. The caller must hold the refreshMutex.
+func (m *Manager) postponeIfPinned() bool {
+	if m.snapshots == nil {
+		return false
+	}
+	if _, pinned := m.snapshots.pinnedSnapshot(); !pinned {
+		return false
+	}
+	now := time.Now()
+	for _, state := range m.states {
+		state.postpone(now)
+	}
+	return true
+}
+
+// takeSnapshot records the flag configuration applied to the cache, if the snapshots are enabled.
+func (m *Manager) takeSnapshot(flags map[string]dto.DTO) {
+	if m.snapshots == nil {
+		return
+	}
+	if _, err := m.snapshots.add(flags, m.snapshotSources(), time.Now()); err != nil {
+		m.logger.Warn("Impossible to store the flag configuration snapshot.", slog.Any("error", err.Error()))
+	}
+}
+
+// snapshotSources returns the retrievers which provided the current flag configuration.
+func (m *Manager) snapshotSources() []SnapshotSource {
+	retrievers := m.retrievers
+	if active, ok := m.ActiveRetriever(); ok {
+		retrievers = []Retriever{active}
+	}
+	sources := make([]SnapshotSource, 0, len(retrievers))
+	for _, r := range retrievers {
+		source := SnapshotSource{Name: fmt.Sprintf("%T", r), Location: getLocation(r)}
+		if versioned, ok := r.(VersionedRetriever); ok {
+			source.Version = versioned.Version()
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
+// Snapshots returns the versions of the flag configuration kept, from the newest to the oldest.
+func (m *Manager) Snapshots() ([]Snapshot, error) {
+	if m == nil || m.snapshots == nil {
+		return nil, ErrSnapshotsDisabled
+	}
+	return m.snapshots.list(), nil
+}
+
+// PinnedSnapshot returns the snapshot pinned, and false if no snapshot is pinned.
+func (m *Manager) PinnedSnapshot() (Snapshot, bool) {
+	if m == nil || m.snapshots == nil {
+		return Snapshot{}, false
+	}
+	snapshot, pinned := m.snapshots.pinnedSnapshot()
+	return snapshot.Snapshot, pinned
+}
+
+// PinSnapshot applies the snapshot with this ID to the cache, and freezes the retrieval of the flags
+// until UnpinSnapshot is called.
+func (m *Manager) PinSnapshot(id int) (Snapshot, error) {
+	if m == nil || m.snapshots == nil {
+		return Snapshot{}, ErrSnapshotsDisabled
+	}
+	m.refreshMutex.Lock()
+	defer m.refreshMutex.Unlock()
+	snapshot, err := m.snapshots.pin(id)
+	if snapshot.ID == 0 {
+		return Snapshot{}, err
+	}
+	if err != nil {
+		m.logger.Warn("Impossible to store the pinned flag configuration snapshot.", slog.Any("error", err.Error()))
+	}
+	m.logger.Warn("A flag configuration snapshot is pinned, the retrievers are not called until it is unpinned.",
+		slog.Int("snapshot", snapshot.ID))
+	if err := m.updateCacheWithRetriever(snapshot.Flags, false); err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot.Snapshot, nil
+}
+
+// UnpinSnapshot removes the pin and refreshes the cache with the retrievers.
+func (m *Manager) UnpinSnapshot(ctx context.Context) error {
+	if m == nil || m.snapshots == nil {
+		return ErrSnapshotsDisabled
+	}
+	if err := m.snapshots.unpin(); err != nil {
+		if errors.Is(err, ErrNoPinnedSnapshot) {
+			return err
+		}
+		m.logger.Warn("Impossible to store the flag configuration snapshots.", slog.Any("error", err.Error()))
+	}
+	m.logger.Info("The flag configuration snapshot is unpinned, the retrievers are called again.")
+	return m.retrieveFlagsAndUpdateCache(ctx, false, true)
+}
@@ -0,0 +1,154 @@
+package retriever_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/internal/cache"
+	"github.com/thomaspoignant/go-feature-flag/internal/notification"
+	"github.com/thomaspoignant/go-feature-flag/notifier"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+func (r *toggleRetriever) setFlag(flagName string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.content = newToggleRetriever(flagName).content
+}
+
+func newSnapshotManager(
+	t *testing.T, config retriever.ManagerConfig, retrievers ...retriever.Retriever) *retriever.Manager {
+	t.Helper()
+	logger := fflog.FFLogger{}
+	cacheManager := cache.New(notification.NewService([]notifier.Notifier{}), "", &logger)
+	config.FileFormat = "json"
+	return retriever.NewManager(config, retrievers, cacheManager, &logger)
+}
+
+func assertFlagsInCache(t *testing.T, manager *retriever.Manager, expected ...string) {
+	t.Helper()
+	flags, err := manager.GetFlagsFromCache(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, flags, len(expected))
+	for _, flagName := range expected {
+		assert.Contains(t, flags, flagName)
+	}
+}
+
+func TestManagerSnapshots_Disabled(t *testing.T) {
+	ctx := context.Background()
+	manager := newSnapshotManager(t, retriever.ManagerConfig{}, newToggleRetriever("flag-a"))
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	_, err := manager.Snapshots()
+	assert.ErrorIs(t, err, retriever.ErrSnapshotsDisabled)
+	_, err = manager.PinSnapshot(1)
+	assert.ErrorIs(t, err, retriever.ErrSnapshotsDisabled)
+	assert.ErrorIs(t, manager.UnpinSnapshot(ctx), retriever.ErrSnapshotsDisabled)
+	_, pinned := manager.PinnedSnapshot()
+	assert.False(t, pinned)
+}
+
+func TestManagerSnapshots_KeepsTheLastVersions(t *testing.T) {
+	ctx := context.Background()
+	r := newToggleRetriever("flag-a")
+	manager := newSnapshotManager(t, retriever.ManagerConfig{Snapshots: 2}, r)
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	// same flags, no new snapshot
+	require.True(t, manager.ForceRefresh(ctx))
+	r.setFlag("flag-b")
+	require.True(t, manager.ForceRefresh(ctx))
+	r.setFlag("flag-c")
+	require.True(t, manager.ForceRefresh(ctx))
+
+	snapshots, err := manager.Snapshots()
+	require.NoError(t, err)
+	require.Len(t, snapshots, 2)
+	assert.Equal(t, 3, snapshots[0].ID)
+	assert.Equal(t, 2, snapshots[1].ID)
+	assert.Equal(t, 1, snapshots[0].NumberOfFlags)
+	require.Len(t, snapshots[0].Sources, 1)
+	assert.Equal(t, "*retriever_test.toggleRetriever", snapshots[0].Sources[0].Name)
+	assert.False(t, snapshots[0].Date.IsZero())
+}
+
+func TestManagerSnapshots_PinAndUnpin(t *testing.T) {
+	ctx := context.Background()
+	r := newToggleRetriever("flag-a")
+	manager := newSnapshotManager(t, retriever.ManagerConfig{
+		Snapshots:       5,
+		PollingInterval: 10 * time.Millisecond,
+	}, r)
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	r.setFlag("flag-b")
+	require.Eventually(t, func() bool {
+		snapshots, _ := manager.Snapshots()
+		return len(snapshots) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	_, err := manager.PinSnapshot(42)
+	assert.ErrorIs(t, err, retriever.ErrSnapshotNotFound)
+
+	snapshot, err := manager.PinSnapshot(1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, snapshot.ID)
+	assertFlagsInCache(t, manager, "flag-a")
+	pinnedSnapshot, pinned := manager.PinnedSnapshot()
+	assert.True(t, pinned)
+	assert.Equal(t, 1, pinnedSnapshot.ID)
+
+	// the retrieval is frozen while the snapshot is pinned
+	calls := r.Calls()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, calls, r.Calls())
+	assert.False(t, manager.ForceRefresh(ctx))
+	assertFlagsInCache(t, manager, "flag-a")
+
+	require.NoError(t, manager.UnpinSnapshot(ctx))
+	assertFlagsInCache(t, manager, "flag-b")
+	_, pinned = manager.PinnedSnapshot()
+	assert.False(t, pinned)
+	assert.ErrorIs(t, manager.UnpinSnapshot(ctx), retriever.ErrNoPinnedSnapshot)
+
+	// the polling is back
+	calls = r.Calls()
+	require.Eventually(t, func() bool { return r.Calls() > calls }, time.Second, 10*time.Millisecond)
+}
+
+func TestManagerSnapshots_PinIsKeptAcrossRestarts(t *testing.T) {
+	ctx := context.Background()
+	config := retriever.ManagerConfig{
+		Snapshots:                       5,
+		PersistentFlagConfigurationFile: filepath.Join(t.TempDir(), "flags.json"),
+	}
+	r := newToggleRetriever("flag-a")
+	manager := newSnapshotManager(t, config, r)
+	require.NoError(t, manager.Init(ctx))
+	_, err := manager.PinSnapshot(1)
+	require.NoError(t, err)
+	require.NoError(t, manager.Shutdown(ctx))
+
+	restarted := newToggleRetriever("flag-b")
+	manager = newSnapshotManager(t, config, restarted)
+	require.NoError(t, manager.Init(ctx))
+	defer func() { _ = manager.Shutdown(ctx) }()
+
+	assertFlagsInCache(t, manager, "flag-a")
+	assert.Equal(t, 0, restarted.Calls())
+	snapshots, err := manager.Snapshots()
+	require.NoError(t, err)
+	assert.Len(t, snapshots, 1)
+
+	require.NoError(t, manager.UnpinSnapshot(ctx))
+	assertFlagsInCache(t, manager, "flag-b")
+}
@@ -0,0 +1,99 @@
+package sqliteretriever
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/shared/sqlretriever"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+	_ "modernc.org/sqlite" // register the SQLite driver
+)
+
+// Retriever is a configuration struct for a SQLite retriever.
+type Retriever struct {
+	// Path is the location of the SQLite database file, the database is opened in read-only mode.
+	// A SQLite URI (ex: file:/data/flags.db?_pragma=busy_timeout(5000)) can be used instead, it is also opened
+	// in read-only mode.
+	Path string
+	// Table is the name of the table containing the flags.
+	Table string
+	// Columns (optional) is the mapping of the column names, if they are not the default ones
+	// (flag_name, flagset, config).
+	Columns map[string]string
+
+	core sqlretriever.Core
+}
+
+// Init initializes the connection to the database.
+func (r *Retriever) Init(ctx context.Context, logger *fflog.FFLogger, flagset *string) error {
+	r.core.Dialect = r.dialect()
+	r.core.Table = r.Table
+	r.core.Columns = r.Columns
+	return r.core.Init(ctx, logger, flagset)
+}
+
+// Status returns the current status of the retriever
+func (r *Retriever) Status() retriever.Status {
+	if r == nil {
+		return retriever.RetrieverNotReady
+	}
+	return r.core.Status()
+}
+
+// Shutdown closes the database connection.
+func (r *Retriever) Shutdown(_ context.Context) error {
+	return r.core.Shutdown()
+}
+
+// Retrieve fetches flag configuration from SQLite.
+func (r *Retriever) Retrieve(ctx context.Context) ([]byte, error) {
+	return r.core.Retrieve(ctx)
+}
+
+// OutputFormat declares that this retriever always returns JSON-encoded data,
+// so the manager can pick the JSON parser regardless of the global FileFormat.
+func (r *Retriever) OutputFormat() string {
+	return "json"
+}
+
+// dialect returns the SQLite dialect.
+// We don't quote with double quotes: SQLite would use an unknown column quoted with double quotes
+// as a string literal.
+func (r *Retriever) dialect() sqlretriever.Dialect {
+	return sqlretriever.Dialect{
+		Name:            "SQLite",
+		QuoteIdentifier: sqlretriever.QuoteWithBackticks,
+		Placeholder:     sqlretriever.QuestionMarkPlaceholder,
+		Open: func(ctx context.Context) (*sql.DB, error) {
+			dsn, err := r.dataSourceName()
+			if err != nil {
+				return nil, err
+			}
+			return sqlretriever.OpenDB(ctx, "sqlite", dsn)
+		},
+	}
+}
+
+// dataSourceName returns the data source name to open the database in read-only mode,
+// SQLite would otherwise create an empty database if the file does not exist.
+// The mode of a SQLite URI is replaced by mode=ro.
+func (r *Retriever) dataSourceName() (string, error) {
+	if uri, ok := strings.CutPrefix(r.Path, "file:"); ok {
+		path, query, _ := strings.Cut(uri, "?")
+		params := []string{}
+		for _, param := range strings.Split(query, "&") {
+			if param != "" && !strings.HasPrefix(param, "mode=") {
+				params = append(params, param)
+			}
+		}
+		return "file:" + path + "?" + strings.Join(append(params, "mode=ro"), "&"), nil
+	}
+	if _, err := os.Stat(r.Path); err != nil {
+		return "", fmt.Errorf("impossible to open the SQLite database: %w", err)
+	}
+	return "file:" + r.Path + "?mode=ro", nil
+}
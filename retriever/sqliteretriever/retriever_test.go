@@ -0,0 +1,222 @@
+package sqliteretriever_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/retriever/sqliteretriever"
+)
+
+func TestSQLiteRetriever(t *testing.T) {
+	tests := []struct {
+		name      string
+		files     []string
+		tableName string
+		columns   map[string]string
+		flagset   string
+		assertErr assert.ErrorAssertionFunc
+		want      string
+	}{
+		{
+			name: "valid set of flag",
+			files: []string{
+				"sql/init.sql",
+				"sql/insert_data.sql",
+			},
+			tableName: "go_feature_flag",
+			assertErr: assert.NoError,
+			want:      "response/valid.json",
+		},
+		{
+			name: "invalid table name",
+			files: []string{
+				"sql/init.sql",
+				"sql/insert_data.sql",
+			},
+			tableName: "invalid_table_name",
+			assertErr: assert.Error,
+		},
+		{
+			name: "invalid column names",
+			files: []string{
+				"sql/init.sql",
+				"sql/insert_data.sql",
+			},
+			tableName: "go_feature_flag",
+			assertErr: assert.Error,
+			columns: map[string]string{
+				"flag_name": "invalid_flag_name",
+				"flagset":   "invalid_flagset",
+				"config":    "invalid_config",
+			},
+		},
+		{
+			name: "valid set of flag with flagset",
+			files: []string{
+				"sql/init.sql",
+				"sql/insert_data.sql",
+				"sql/insert_alternative_flagset.sql",
+			},
+			tableName: "go_feature_flag",
+			assertErr: assert.NoError,
+			flagset:   "team-A",
+			want:      "response/valid_alternative_flagset.json",
+		},
+		{
+			name: "valid set of flag with empty flagset",
+			files: []string{
+				"sql/init.sql",
+				"sql/insert_data.sql",
+			},
+			tableName: "go_feature_flag",
+			assertErr: assert.NoError,
+			flagset:   "empty-flagset",
+			want:      "response/empty-flagset.json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := createSQLiteDatabase(t, tt.files)
+			r := sqliteretriever.Retriever{
+				Path:    path,
+				Table:   tt.tableName,
+				Columns: tt.columns,
+			}
+			assert.NoError(t, r.Init(context.TODO(), nil, &tt.flagset))
+			defer func() {
+				assert.NoError(t, r.Shutdown(context.TODO()))
+			}()
+
+			assert.Equal(t, r.Status(), retriever.RetrieverReady)
+			got, err := r.Retrieve(context.TODO())
+			tt.assertErr(t, err)
+			if err != nil {
+				return
+			}
+
+			// the expected responses are shared by the SQL retrievers
+			want, err := os.ReadFile(filepath.Join("..", "shared", "sqlretriever", "testdata", tt.want))
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(want), string(got))
+		})
+	}
+}
+
+func TestSQLiteRetriever_CustomColumns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flags.db")
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE "my flags" (name TEXT, namespace TEXT, settings TEXT);
+		INSERT INTO "my flags" VALUES ('my-flag', 'default', '{"variations":{"A":true},"defaultRule":{"variation":"A"}}');`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	r := sqliteretriever.Retriever{
+		Path:    path,
+		Table:   "my flags",
+		Columns: map[string]string{"flag_name": "name", "flagset": "namespace", "config": "settings"},
+	}
+	require.NoError(t, r.Init(context.TODO(), nil, nil))
+	defer func() { _ = r.Shutdown(context.TODO()) }()
+	got, err := r.Retrieve(context.TODO())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"my-flag":{"variations":{"A":true},"defaultRule":{"variation":"A"}}}`, string(got))
+	assert.Equal(t, "json", r.OutputFormat())
+}
+
+func TestSQLiteRetriever_URI(t *testing.T) {
+	path := createSQLiteDatabase(t, []string{"sql/init.sql", "sql/insert_data.sql"})
+	r := sqliteretriever.Retriever{
+		Path:  "file:" + path + "?mode=rw&_pragma=busy_timeout(5000)",
+		Table: "go_feature_flag",
+	}
+	require.NoError(t, r.Init(context.TODO(), nil, nil))
+	defer func() { _ = r.Shutdown(context.TODO()) }()
+	got, err := r.Retrieve(context.TODO())
+	require.NoError(t, err)
+	want, err := os.ReadFile(filepath.Join("..", "shared", "sqlretriever", "testdata", "response", "valid.json"))
+	require.NoError(t, err)
+	assert.JSONEq(t, string(want), string(got))
+}
+
+// TestRetrieverErrorHandling tests various error conditions in the SQLite retriever
+func TestRetrieverErrorHandling(t *testing.T) {
+	t.Run("Init - database file does not exist", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "not-exists.db")
+		r := sqliteretriever.Retriever{
+			Path:  path,
+			Table: "test_table",
+		}
+
+		err := r.Init(context.Background(), nil, nil)
+		assert.Error(t, err)
+		assert.Equal(t, retriever.RetrieverError, r.Status())
+		assert.NoFileExists(t, path, "the retriever should not create the database")
+	})
+
+	t.Run("Init - database URI does not exist", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "not-exists.db")
+		r := sqliteretriever.Retriever{
+			Path:  "file:" + path + "?mode=rwc",
+			Table: "test_table",
+		}
+
+		err := r.Init(context.Background(), nil, nil)
+		assert.Error(t, err)
+		assert.Equal(t, retriever.RetrieverError, r.Status())
+		assert.NoFileExists(t, path, "the retriever should open the URI in read-only mode")
+	})
+
+	t.Run("Retrieve - nil connection", func(t *testing.T) {
+		r := sqliteretriever.Retriever{
+			Path:  "flags.db",
+			Table: "test_table",
+		}
+		// Don't call Init() to ensure db is nil
+
+		_, err := r.Retrieve(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "database connection is not initialized")
+	})
+
+	t.Run("Status - nil receiver", func(t *testing.T) {
+		var r *sqliteretriever.Retriever
+		assert.Equal(t, retriever.RetrieverNotReady, r.Status())
+	})
+
+	t.Run("Status - empty status", func(t *testing.T) {
+		r := &sqliteretriever.Retriever{}
+		assert.Equal(t, retriever.RetrieverNotReady, r.Status())
+	})
+
+	t.Run("Shutdown - nil connection", func(t *testing.T) {
+		r := sqliteretriever.Retriever{
+			Path:  "flags.db",
+			Table: "test_table",
+		}
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+}
+
+func createSQLiteDatabase(t *testing.T, files []string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "flags.db")
+	db, err := sql.Open("sqlite", path)
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join("testdata", file))
+		require.NoError(t, err)
+		_, err = db.Exec(string(content))
+		require.NoError(t, err)
+	}
+	return path
+}
@@ -0,0 +1,18 @@
+-- ----------------------------------------------------------------------------
+-- GO FEATURE FLAG SQLITE RETRIEVER INITIALIZATION SCRIPT
+-- This script is used to initialize the SQLite database for the Go Feature Flag retriever.
+-- This is a very minimal setup for the retriever, you can add more columns or constraints to the table if you need to.
+-- ----------------------------------------------------------------------------
+
+-- Create the go_feature_flag table
+CREATE TABLE IF NOT EXISTS go_feature_flag (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    flag_name TEXT NOT NULL,
+    flagset TEXT NOT NULL,
+    config TEXT NOT NULL,
+    -- Prevent duplicate flags in the same flagset
+    UNIQUE (flag_name, flagset)
+);
+
+-- Create indexes for better query performance
+CREATE INDEX IF NOT EXISTS idx_go_feature_flag_flagset ON go_feature_flag(flagset);
@@ -0,0 +1,166 @@
+package ffclient
+
+import (
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagstate"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/notifier"
+)
+
+// subscriptionBufferSize is the number of changes a subscriber can have pending before the next
+// changes are dropped.
+const subscriptionBufferSize = 100
+
+// FlagChangeType is the type of change of a flag.
+type FlagChangeType string
+
+const (
+	FlagChangeAdded   FlagChangeType = "ADDED"
+	FlagChangeUpdated FlagChangeType = "UPDATED"
+	FlagChangeDeleted FlagChangeType = "DELETED"
+)
+
+// FlagChange is a change of a flag sent to the subscribers.
+type FlagChange struct {
+	// FlagKey is the key of the flag.
+	FlagKey string
+	// Type is the type of change (added, updated or deleted).
+	Type FlagChangeType
+	// Before is the previous version of the flag, nil if the flag has been added.
+	Before flag.Flag
+	// After is the new version of the flag, nil if the flag has been deleted.
+	After flag.Flag
+	// OldValue is the value of the flag before the change for the evaluation context of the subscription.
+	// It is only set by SubscribeEvaluation.
+	OldValue any
+	// NewValue is the value of the flag after the change for the evaluation context of the subscription.
+	// It is only set by SubscribeEvaluation.
+	NewValue any
+}
+
+// Subscribe returns a channel receiving the changes of the flags each time the configuration of
+// flags changes. If flagKeys is empty, the changes of all the flags are received.
+//
+// The returned function unsubscribes and closes the channel, it should be called when you are not
+// interested in the changes anymore.
+// The channel is buffered, if the subscriber is too slow to read the changes, the next changes are dropped.
+func Subscribe(flagKeys ...string) (<-chan FlagChange, func()) {
+	return ff.Subscribe(flagKeys...)
+}
+
+// SubscribeEvaluation returns a channel receiving the changes of the flags only if the value resolved
+// for evaluationCtx has changed. If flagKeys is empty, all the flags are evaluated.
+// See ffclient.Subscribe.
+func SubscribeEvaluation(evaluationCtx ffcontext.Context, flagKeys ...string) (<-chan FlagChange, func()) {
+	return ff.SubscribeEvaluation(evaluationCtx, flagKeys...)
+}
+
+// Subscribe returns a channel receiving the changes of the flags each time the configuration of
+// flags changes. If flagKeys is empty, the changes of all the flags are received.
+// See ffclient.Subscribe.
+func (g *GoFeatureFlag) Subscribe(flagKeys ...string) (<-chan FlagChange, func()) {
+	return g.subscribe(flagKeys, func(change FlagChange) (FlagChange, bool) {
+		return change, true
+	})
+}
+
+// SubscribeEvaluation returns a channel receiving the changes of the flags only if the value resolved
+// for evaluationCtx has changed. If flagKeys is empty, all the flags are evaluated.
+// See ffclient.Subscribe.
+func (g *GoFeatureFlag) SubscribeEvaluation(
+	evaluationCtx ffcontext.Context,
+	flagKeys ...string,
+) (<-chan FlagChange, func()) {
+	return g.subscribe(flagKeys, func(change FlagChange) (FlagChange, bool) {
+		change.OldValue = g.subscriptionValue(change.FlagKey, evaluationCtx, change.Before)
+		change.NewValue = g.subscriptionValue(change.FlagKey, evaluationCtx, change.After)
+		return change, !reflect.DeepEqual(change.OldValue, change.NewValue)
+	})
+}
+
+// subscribe registers a listener in the notification service sending the changes of flagKeys
+// accepted by filter in the returned channel.
+func (g *GoFeatureFlag) subscribe(
+	flagKeys []string,
+	filter func(change FlagChange) (FlagChange, bool),
+) (<-chan FlagChange, func()) {
+	changes := make(chan FlagChange, subscriptionBufferSize)
+	if g == nil || g.notificationService == nil {
+		// no configuration is loaded in offline mode, so the flags will never change.
+		close(changes)
+		return changes, func() {}
+	}
+
+	remove := g.notificationService.AddListener(func(diff notifier.DiffCache) {
+		for _, change := range flagChanges(diff, flagKeys) {
+			change, ok := filter(change)
+			if !ok {
+				continue
+			}
+			select {
+			case changes <- change:
+			default:
+				g.config.internalLogger.Warn("the subscriber is too slow to read the flag changes, dropping a change",
+					slog.String("flag", change.FlagKey))
+			}
+		}
+	})
+	var once sync.Once
+	return changes, func() {
+		once.Do(func() {
+			remove()
+			close(changes)
+		})
+	}
+}
+
+// subscriptionValue returns the value of a version of a flag for the evaluation context,
+// nil if the flag does not exist.
+func (g *GoFeatureFlag) subscriptionValue(flagKey string, evaluationCtx ffcontext.Context, f flag.Flag) any {
+	if f == nil {
+		return nil
+	}
+	flagCtx := flag.Context{
+		EvaluationContextEnrichment: g.config.EvaluationContextEnrichment,
+	}
+	if g.config.Environment != "" {
+		flagCtx.AddIntoEvaluationContextEnrichment("env", g.config.Environment)
+	}
+	f = g.rolloutGuardController.Apply(flagKey, f)
+	return flagstate.FromFlagEvaluation(flagKey, evaluationCtx, flagCtx, f).Value
+}
+
+// flagChanges converts the differences of the cache into a list of changes sorted by flag key.
+// If flagKeys is not empty, only the changes of those flags are returned.
+func flagChanges(diff notifier.DiffCache, flagKeys []string) []FlagChange {
+	changes := make([]FlagChange, 0, len(diff.Added)+len(diff.Updated)+len(diff.Deleted))
+	subscribed := func(key string) bool {
+		return len(flagKeys) == 0 || slices.Contains(flagKeys, key)
+	}
+	for key, f := range diff.Added {
+		if subscribed(key) {
+			changes = append(changes, FlagChange{FlagKey: key, Type: FlagChangeAdded, After: f})
+		}
+	}
+	for key, update := range diff.Updated {
+		if subscribed(key) {
+			changes = append(changes, FlagChange{
+				FlagKey: key, Type: FlagChangeUpdated, Before: update.Before, After: update.After})
+		}
+	}
+	for key, f := range diff.Deleted {
+		if subscribed(key) {
+			changes = append(changes, FlagChange{FlagKey: key, Type: FlagChangeDeleted, Before: f})
+		}
+	}
+	slices.SortFunc(changes, func(a, b FlagChange) int {
+		return strings.Compare(a.FlagKey, b.FlagKey)
+	})
+	return changes
+}
@@ -0,0 +1,131 @@
+package ffclient
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+)
+
+const subscriptionFlagConfig = `
+workers:
+  variations:
+    small: 2
+    large: 8
+  targeting:
+    - query: region eq "eu"
+      variation: large
+  defaultRule:
+    variation: small
+banner:
+  variations:
+    default: "hello"
+  defaultRule:
+    variation: default
+`
+
+const subscriptionFlagConfigUpdated = `
+workers:
+  variations:
+    small: 4
+    large: 8
+  targeting:
+    - query: region eq "eu"
+      variation: large
+  defaultRule:
+    variation: small
+new-flag:
+  variations:
+    default: true
+  defaultRule:
+    variation: default
+`
+
+func receiveChanges(t *testing.T, changes <-chan FlagChange) []FlagChange {
+	t.Helper()
+	var got []FlagChange
+	for {
+		select {
+		case change := <-changes:
+			got = append(got, change)
+		case <-time.After(100 * time.Millisecond):
+			return got
+		}
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	g, path := newBindTestClient(t, subscriptionFlagConfig)
+
+	all, unsubscribeAll := g.Subscribe()
+	defer unsubscribeAll()
+	workers, unsubscribeWorkers := g.Subscribe("workers")
+	defer unsubscribeWorkers()
+
+	require.NoError(t, os.WriteFile(path, []byte(subscriptionFlagConfigUpdated), 0o600))
+	require.True(t, g.ForceRefresh())
+
+	got := receiveChanges(t, all)
+	require.Len(t, got, 3)
+	assert.Equal(t, "banner", got[0].FlagKey)
+	assert.Equal(t, FlagChangeDeleted, got[0].Type)
+	assert.NotNil(t, got[0].Before)
+	assert.Nil(t, got[0].After)
+	assert.Equal(t, "new-flag", got[1].FlagKey)
+	assert.Equal(t, FlagChangeAdded, got[1].Type)
+	assert.Nil(t, got[1].Before)
+	assert.NotNil(t, got[1].After)
+	assert.Equal(t, "workers", got[2].FlagKey)
+	assert.Equal(t, FlagChangeUpdated, got[2].Type)
+	assert.Nil(t, got[2].OldValue, "the values are only set by SubscribeEvaluation")
+
+	got = receiveChanges(t, workers)
+	require.Len(t, got, 1)
+	assert.Equal(t, "workers", got[0].FlagKey)
+}
+
+func TestSubscribeEvaluation(t *testing.T) {
+	g, path := newBindTestClient(t, subscriptionFlagConfig)
+
+	us, unsubscribeUS := g.SubscribeEvaluation(
+		ffcontext.NewEvaluationContextBuilder("user-key").AddCustom("region", "us").Build(), "workers")
+	defer unsubscribeUS()
+	eu, unsubscribeEU := g.SubscribeEvaluation(
+		ffcontext.NewEvaluationContextBuilder("user-key").AddCustom("region", "eu").Build(), "workers")
+	defer unsubscribeEU()
+
+	require.NoError(t, os.WriteFile(path, []byte(subscriptionFlagConfigUpdated), 0o600))
+	require.True(t, g.ForceRefresh())
+
+	got := receiveChanges(t, us)
+	require.Len(t, got, 1)
+	assert.Equal(t, 2, got[0].OldValue)
+	assert.Equal(t, 4, got[0].NewValue)
+
+	assert.Empty(t, receiveChanges(t, eu), "the value resolved for this context has not changed")
+}
+
+func TestSubscribe_Unsubscribe(t *testing.T) {
+	g, path := newBindTestClient(t, subscriptionFlagConfig)
+
+	changes, unsubscribe := g.Subscribe()
+	unsubscribe()
+	unsubscribe()
+	_, open := <-changes
+	assert.False(t, open, "the channel is closed")
+
+	require.NoError(t, os.WriteFile(path, []byte(subscriptionFlagConfigUpdated), 0o600))
+	assert.True(t, g.ForceRefresh())
+}
+
+func TestSubscribe_Offline(t *testing.T) {
+	g, err := New(Config{Offline: true})
+	require.NoError(t, err)
+	changes, unsubscribe := g.Subscribe("workers")
+	defer unsubscribe()
+	_, open := <-changes
+	assert.False(t, open, "no change is received in offline mode")
+}
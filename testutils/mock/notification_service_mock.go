@@ -3,6 +3,7 @@ package mock
 import (
 	"sync"
 
+	"github.com/thomaspoignant/go-feature-flag/internal/notification"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
 	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
 )
@@ -25,6 +26,10 @@ func (n *NotificationService) Close() {
 	n.CloseCalled = true
 }
 
+func (n *NotificationService) AddListener(_ notification.Listener) func() {
+	return func() {}
+}
+
 func (n *NotificationService) GetNotifyCalls() int {
 	n.mu.Lock()
 	defer n.mu.Unlock()
@@ -14,7 +14,7 @@ func (g *GoFeatureFlag) Track(
 	ctx ffcontext.EvaluationContext,
 	trackingEventDetails exporter.TrackingEventDetails,
 ) {
-	if g != nil && g.trackingEventDataExporter != nil {
+	if g != nil && (g.trackingEventDataExporter != nil || g.rolloutGuardController != nil) {
 		contextKind := "user"
 		if ctx.IsAnonymous() {
 			contextKind = "anonymousUser"
@@ -28,7 +28,12 @@ func (g *GoFeatureFlag) Track(
 			EvaluationContext: ctx.ToMap(),
 			TrackingDetails:   trackingEventDetails,
 		}
-		g.trackingEventDataExporter.AddEvent(event)
+		if g.rolloutGuardController != nil {
+			g.rolloutGuardController.ObserveTrackingEvent(event)
+		}
+		if g.trackingEventDataExporter != nil {
+			g.trackingEventDataExporter.AddEvent(event)
+		}
 	}
 }
 
@@ -0,0 +1,75 @@
+package usage
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultStaleAfter is the duration used to report a flag as stale if none is provided.
+	DefaultStaleAfter = 30 * 24 * time.Hour
+	// DefaultPersistInterval is the interval used to persist the usage if none is provided.
+	DefaultPersistInterval = 1 * time.Minute
+	// DefaultMaxUnknownFlags is the maximum number of unknown flags tracked if none is provided.
+	DefaultMaxUnknownFlags = 1000
+)
+
+// Config is the configuration of the flag usage tracking.
+type Config struct {
+	// StaleAfter (optional) is the duration without evaluation after which a flag is reported as stale.
+	// Default: 30 days
+	StaleAfter time.Duration
+
+	// PersistentFile (optional) is the file where the usage is stored, it is loaded when starting
+	// so the usage survives restarts.
+	// Default: "" (the usage is only kept in memory)
+	PersistentFile string
+
+	// PersistInterval (optional) is the interval between two writes of the PersistentFile,
+	// the usage is also written when closing GO Feature Flag.
+	// Default: 1 minute
+	PersistInterval time.Duration
+
+	// MaxUnknownFlags (optional) is the maximum number of unknown flags tracked, when it is reached
+	// the unknown flag evaluated the longest time ago is forgotten.
+	// Default: 1000
+	MaxUnknownFlags int
+}
+
+// IsValid checks that the usage tracking is correctly configured.
+func (c *Config) IsValid() error {
+	if c.StaleAfter < 0 {
+		return fmt.Errorf("invalid usage tracking: stale after should be positive")
+	}
+	if c.PersistInterval < 0 {
+		return fmt.Errorf("invalid usage tracking: persist interval should be positive")
+	}
+	if c.MaxUnknownFlags < 0 {
+		return fmt.Errorf("invalid usage tracking: max unknown flags should be positive")
+	}
+	return nil
+}
+
+// GetStaleAfter returns the stale duration, or the default one if not set.
+func (c *Config) GetStaleAfter() time.Duration {
+	if c.StaleAfter <= 0 {
+		return DefaultStaleAfter
+	}
+	return c.StaleAfter
+}
+
+// GetPersistInterval returns the persist interval, or the default one if not set.
+func (c *Config) GetPersistInterval() time.Duration {
+	if c.PersistInterval <= 0 {
+		return DefaultPersistInterval
+	}
+	return c.PersistInterval
+}
+
+// GetMaxUnknownFlags returns the maximum number of unknown flags, or the default one if not set.
+func (c *Config) GetMaxUnknownFlags() int {
+	if c.MaxUnknownFlags <= 0 {
+		return DefaultMaxUnknownFlags
+	}
+	return c.MaxUnknownFlags
+}
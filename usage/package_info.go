@@ -0,0 +1,17 @@
+// Package usage contains the configuration and the report of the flag usage tracking.
+//
+// When the usage tracking is enabled, GO Feature Flag keeps the number of evaluations and the
+// date of the last evaluation of each flag and variation, and the keys of the flags requested
+// but not present in the configuration. The report helps you find the dead flags.
+//
+//	ffclient.Init(ffclient.Config{
+//	  //...
+//	  UsageTracking: &usage.Config{
+//	    StaleAfter:     30 * 24 * time.Hour,
+//	    PersistentFile: "/var/lib/goff/usage.json",
+//	  },
+//	  //...
+//	})
+//
+//	report, err := ffclient.GetFlagUsageReport()
+package usage
@@ -0,0 +1,36 @@
+package usage
+
+import "time"
+
+// Usage is the number of evaluations and the date of the last evaluation.
+type Usage struct {
+	// EvaluationCount is the number of evaluations since the tracking started.
+	EvaluationCount int64 `json:"evaluationCount"`
+	// LastEvaluation is the date of the last evaluation, nil if never evaluated.
+	LastEvaluation *time.Time `json:"lastEvaluation,omitempty"`
+}
+
+// FlagUsage is the usage of a flag of the configuration.
+type FlagUsage struct {
+	Usage
+	// Variations is the usage of each variation served by the flag.
+	Variations map[string]Usage `json:"variations"`
+}
+
+// Report is the usage of the flags since the tracking started.
+type Report struct {
+	// TrackingSince is the date of the start of the tracking (kept across restarts with a persistent file).
+	TrackingSince time.Time `json:"trackingSince"`
+	// GeneratedAt is the date of the report.
+	GeneratedAt time.Time `json:"generatedAt"`
+	// Flags is the usage of every flag of the configuration.
+	Flags map[string]FlagUsage `json:"flags"`
+	// UnknownFlags is the usage of the flags requested but not present in the configuration (FLAG_NOT_FOUND).
+	UnknownFlags map[string]Usage `json:"unknownFlags"`
+	// Unused contains the flags never evaluated since the tracking started.
+	Unused []string `json:"unused"`
+	// Stale contains the flags evaluated, but not since the StaleAfter duration.
+	Stale []string `json:"stale"`
+	// SingleVariation contains the flags evaluated that always served the same variation.
+	SingleVariation []string `json:"singleVariation"`
+}
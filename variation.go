@@ -1,6 +1,7 @@
 package ffclient
 
 import (
+	"context"
 	"fmt"
 	"maps"
 
@@ -13,6 +14,7 @@ import (
 
 const (
 	errorFlagNotAvailable = "flag %v is not present or disabled"
+	errorWrongVariation   = "wrong variation used for flag %v"
 )
 
 // BoolVariation return the value of the flag in boolean.
@@ -52,8 +54,7 @@ func (g *GoFeatureFlag) BoolVariationDetails(
 	ctx ffcontext.Context,
 	defaultValue bool,
 ) (model.VariationResult[bool], error) {
-	res, err := getVariation(g, flagKey, ctx, defaultValue, "bool")
-	notifyVariation(g, flagKey, ctx, res)
+	res, err := evaluateWithHooks(context.Background(), g, flagKey, ctx, defaultValue, "bool", getVariation)
 	return res, err
 }
 
@@ -94,8 +95,7 @@ func IntVariationDetails(
 // Note: Use this function only if you are using multiple go-feature-flag instances.
 func (g *GoFeatureFlag) IntVariationDetails(flagKey string, ctx ffcontext.Context, defaultValue int,
 ) (model.VariationResult[int], error) {
-	res, err := getVariation(g, flagKey, ctx, defaultValue, "int")
-	notifyVariation(g, flagKey, ctx, res)
+	res, err := evaluateWithHooks(context.Background(), g, flagKey, ctx, defaultValue, "int", getVariation)
 	return res, err
 }
 
@@ -140,8 +140,7 @@ func (g *GoFeatureFlag) Float64VariationDetails(
 	ctx ffcontext.Context,
 	defaultValue float64,
 ) (model.VariationResult[float64], error) {
-	res, err := getVariation(g, flagKey, ctx, defaultValue, "float64")
-	notifyVariation(g, flagKey, ctx, res)
+	res, err := evaluateWithHooks(context.Background(), g, flagKey, ctx, defaultValue, "float64", getVariation)
 	return res, err
 }
 
@@ -182,8 +181,7 @@ func (g *GoFeatureFlag) StringVariationDetails(
 	ctx ffcontext.Context,
 	defaultValue string,
 ) (model.VariationResult[string], error) {
-	res, err := getVariation(g, flagKey, ctx, defaultValue, "string")
-	notifyVariation(g, flagKey, ctx, res)
+	res, err := evaluateWithHooks(context.Background(), g, flagKey, ctx, defaultValue, "string", getVariation)
 	return res, err
 }
 
@@ -226,8 +224,7 @@ func (g *GoFeatureFlag) JSONArrayVariationDetails(
 	ctx ffcontext.Context,
 	defaultValue []any,
 ) (model.VariationResult[[]any], error) {
-	res, err := getVariation(g, flagKey, ctx, defaultValue, "[]interface{}")
-	notifyVariation(g, flagKey, ctx, res)
+	res, err := evaluateWithHooks(context.Background(), g, flagKey, ctx, defaultValue, "[]interface{}", getVariation)
 	return res, err
 }
 
@@ -271,8 +268,7 @@ func (g *GoFeatureFlag) JSONVariationDetails(
 	ctx ffcontext.Context,
 	defaultValue map[string]any,
 ) (model.VariationResult[map[string]any], error) {
-	res, err := getVariation(g, flagKey, ctx, defaultValue, "bool")
-	notifyVariation(g, flagKey, ctx, res)
+	res, err := evaluateWithHooks(context.Background(), g, flagKey, ctx, defaultValue, "map[string]interface{}", getVariation)
 	return res, err
 }
 
@@ -286,8 +282,7 @@ func (g *GoFeatureFlag) RawVariation(
 	ctx ffcontext.Context,
 	sdkDefaultValue any,
 ) (model.RawVarResult, error) {
-	res, err := getVariation(g, flagKey, ctx, sdkDefaultValue, "interface{}")
-	notifyVariation(g, flagKey, ctx, res)
+	res, err := evaluateWithHooks(context.Background(), g, flagKey, ctx, sdkDefaultValue, "interface{}", getVariation)
 	return model.RawVarResult(res), err
 }
 
@@ -298,7 +293,7 @@ func (g *GoFeatureFlag) getFlagFromCache(flagKey string) (flag.Flag, error) {
 	if err != nil {
 		return f, fmt.Errorf(errorFlagNotAvailable, flagKey)
 	}
-	return f, nil
+	return g.rolloutGuardController.Apply(flagKey, f), nil
 }
 
 // CollectEventData is collecting events and sending them to the data exporter to be stored.
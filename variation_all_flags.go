@@ -2,10 +2,14 @@ package ffclient
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/thomaspoignant/go-feature-flag/ffcontext"
 	"github.com/thomaspoignant/go-feature-flag/internal/flagstate"
+	"github.com/thomaspoignant/go-feature-flag/internal/flagtag"
 	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // AllFlagsState return the values of all the flags for a specific user.
@@ -14,6 +18,19 @@ func AllFlagsState(ctx ffcontext.Context) flagstate.AllFlags {
 	return ff.AllFlagsState(ctx)
 }
 
+// AllFlagsStateCtx return the values of all the flags for the evaluation context stored in ctx
+// (see ffcontext.WithEvaluationContext).
+// If a valid field is false, it means that we had an error when checking the flags.
+func AllFlagsStateCtx(ctx context.Context) flagstate.AllFlags {
+	return ff.AllFlagsStateCtx(ctx)
+}
+
+// AllFlagsStateWithTags return the values of the flags having at least one of the tags for a specific user.
+// The tags of a flag are set in the "tags" field of its metadata, if tags is empty all the flags are evaluated.
+func AllFlagsStateWithTags(ctx ffcontext.Context, tags ...string) flagstate.AllFlags {
+	return ff.AllFlagsStateWithTags(ctx, tags...)
+}
+
 // GetFlagsFromCache returns all the flags present in the cache with their
 // current state when calling this method. If cache hasn't been initialized, an
 // error reporting this is returned.
@@ -33,6 +50,28 @@ func GetFlagsFromCacheWithContext(ctx context.Context) (map[string]flag.Flag, er
 func (g *GoFeatureFlag) GetFlagStates(
 	evaluationCtx ffcontext.Context,
 	flagsToEvaluate []string,
+) flagstate.AllFlags {
+	return g.getFlagStates(context.Background(), evaluationCtx, flagsToEvaluate, nil)
+}
+
+// GetFlagStatesWithTags is evaluating the flags in flagsToEvaluate having at least one of the tags.
+// If flagsToEvaluate is empty, all the flags with one of the tags are evaluated, and if tags is empty
+// the flags are not filtered by tags.
+func (g *GoFeatureFlag) GetFlagStatesWithTags(
+	evaluationCtx ffcontext.Context,
+	flagsToEvaluate []string,
+	tags []string,
+) flagstate.AllFlags {
+	return g.getFlagStates(context.Background(), evaluationCtx, flagsToEvaluate, tags)
+}
+
+// getFlagStates is evaluating all the flags in flagsToEvaluate having one of the tags,
+// ctx is the context.Context of the evaluation.
+func (g *GoFeatureFlag) getFlagStates(
+	ctx context.Context,
+	evaluationCtx ffcontext.Context,
+	flagsToEvaluate []string,
+	tags []string,
 ) flagstate.AllFlags {
 	if g == nil {
 		return flagstate.AllFlags{}
@@ -41,6 +80,9 @@ func (g *GoFeatureFlag) GetFlagStates(
 		return flagstate.NewAllFlags()
 	}
 
+	ctx, span := g.telemetry.StartAllFlagsEvaluation(ctx)
+	defer span.End()
+
 	// prepare evaluation context enrichment
 	flagCtx := flag.Context{
 		EvaluationContextEnrichment: g.config.EvaluationContextEnrichment,
@@ -54,14 +96,14 @@ func (g *GoFeatureFlag) GetFlagStates(
 	if len(flagsToEvaluate) != 0 {
 		flagStates := flagstate.NewAllFlags()
 		for _, key := range flagsToEvaluate {
-			currentFlag, err := g.retrieverManager.GetFlag(key)
-			if err != nil {
+			currentFlag, err := g.getFlagFromCache(key)
+			if err != nil || !flagtag.Match(currentFlag, tags) {
 				// We ignore flags in error
 				continue
 			}
 			flagStates.AddFlag(
 				key,
-				flagstate.FromFlagEvaluation(key, evaluationCtx, flagCtx, currentFlag),
+				g.evaluateFlagState(ctx, span, key, evaluationCtx, flagCtx, currentFlag),
 			)
 		}
 		return flagStates
@@ -74,14 +116,36 @@ func (g *GoFeatureFlag) GetFlagStates(
 	}
 	allFlags := flagstate.NewAllFlags()
 	for key, currentFlag := range flags {
+		if !flagtag.Match(currentFlag, tags) {
+			continue
+		}
 		allFlags.AddFlag(
 			key,
-			flagstate.FromFlagEvaluation(key, evaluationCtx, flagCtx, currentFlag),
+			g.evaluateFlagState(ctx, span,
+				key, evaluationCtx, flagCtx, g.rolloutGuardController.Apply(key, currentFlag)),
 		)
 	}
 	return allFlags
 }
 
+// evaluateFlagState evaluates a flag for GetFlagStates and records the evaluation in the telemetry.
+func (g *GoFeatureFlag) evaluateFlagState(
+	ctx context.Context,
+	span trace.Span,
+	flagKey string,
+	evaluationCtx ffcontext.Context,
+	flagCtx flag.Context,
+	currentFlag flag.Flag,
+) flagstate.FlagState {
+	start := time.Now()
+	state, evaluationCtx := g.flagStateWithHooks(ctx, flagKey, evaluationCtx, flagCtx, currentFlag)
+	g.telemetry.RecordFlagState(
+		ctx, span, flagKey, evaluationCtx, flagStateToRawVarResult(state), time.Since(start))
+	g.usageTracker.Record(flagKey, state.VariationType, state.ErrorCode)
+	g.deprecationLogger.Log(flagKey, state.Metadata)
+	return state
+}
+
 // AllFlagsState return a flagstate.AllFlags that contains all the flags for a specific user.
 func (g *GoFeatureFlag) AllFlagsState(evaluationCtx ffcontext.Context) flagstate.AllFlags {
 	if g == nil {
@@ -90,6 +154,37 @@ func (g *GoFeatureFlag) AllFlagsState(evaluationCtx ffcontext.Context) flagstate
 	return g.GetFlagStates(evaluationCtx, []string{})
 }
 
+// AllFlagsStateCtx return a flagstate.AllFlags that contains all the flags for the evaluation context
+// stored in ctx (see ffcontext.WithEvaluationContext).
+func (g *GoFeatureFlag) AllFlagsStateCtx(ctx context.Context) flagstate.AllFlags {
+	if g == nil {
+		return flagstate.AllFlags{}
+	}
+	return g.getFlagStates(ctx, evaluationContextFrom(ctx), []string{}, nil)
+}
+
+// AllFlagsStateWithTags return a flagstate.AllFlags that contains the flags having at least one of
+// the tags for a specific user. If tags is empty, all the flags are evaluated.
+func (g *GoFeatureFlag) AllFlagsStateWithTags(evaluationCtx ffcontext.Context, tags ...string) flagstate.AllFlags {
+	if g == nil {
+		return flagstate.AllFlags{}
+	}
+	return g.GetFlagStatesWithTags(evaluationCtx, []string{}, tags)
+}
+
+// GetFlagTags returns the tags of a flag, they are set in the "tags" field of the flag.
+// An error is returned if the flag does not exist.
+func (g *GoFeatureFlag) GetFlagTags(flagKey string) ([]string, error) {
+	if g == nil || g.config.Offline {
+		return nil, fmt.Errorf(errorFlagNotAvailable, flagKey)
+	}
+	f, err := g.getFlagFromCache(flagKey)
+	if err != nil {
+		return nil, err
+	}
+	return f.GetTags(), nil
+}
+
 // GetFlagsFromCache returns all the flags present in the cache with their
 // current state when calling this method. If cache hasn't been initialized, an
 // error reporting this is returned.
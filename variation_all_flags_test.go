@@ -1,6 +1,7 @@
 package ffclient_test
 
 import (
+	"context"
 	"encoding/json"
 	"log/slog"
 	"os"
@@ -149,6 +150,25 @@ func TestAllFlagsState(t *testing.T) {
 	}
 }
 
+func TestAllFlagsStateCtx(t *testing.T) {
+	gffClient, err := ffclient.New(ffclient.Config{
+		PollingInterval: 1 * time.Minute,
+		Retriever:       &fileretriever.Retriever{Path: "testdata/flag-config.yaml"},
+	})
+	require.NoError(t, err)
+	defer gffClient.Close()
+
+	ctx := ffcontext.WithEvaluationContext(context.Background(), ffcontext.NewEvaluationContext("random-key"))
+	states := gffClient.AllFlagsStateCtx(ctx)
+	assert.True(t, states.IsValid())
+	assert.Equal(t, true, states.GetFlags()["test-flag"].Value)
+	assert.Equal(t, "True", states.GetFlags()["test-flag"].VariationType)
+
+	states = gffClient.AllFlagsStateCtx(context.Background())
+	assert.Equal(t, "TARGETING_KEY_MISSING", states.GetFlags()["test-flag"].ErrorCode,
+		"without evaluation context in the context, there is no targeting key")
+}
+
 func TestGetFlagStates(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -0,0 +1,290 @@
+package ffclient
+
+import (
+	"context"
+
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/model"
+)
+
+// evaluationContextFrom returns the evaluation context stored in ctx.
+// If ctx does not contain any evaluation context, we use an empty evaluation context.
+func evaluationContextFrom(ctx context.Context) ffcontext.Context {
+	if evaluationCtx, ok := ffcontext.FromContext(ctx); ok {
+		return evaluationCtx
+	}
+	return ffcontext.NewEvaluationContext("")
+}
+
+// BoolVariationCtx return the value of the flag in boolean for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func BoolVariationCtx(ctx context.Context, flagKey string, defaultValue bool) (bool, error) {
+	return ff.BoolVariationCtx(ctx, flagKey, defaultValue)
+}
+
+// BoolVariationCtx return the value of the flag in boolean for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func (g *GoFeatureFlag) BoolVariationCtx(
+	ctx context.Context, flagKey string, defaultValue bool,
+) (bool, error) {
+	res, err := g.BoolVariationDetailsCtx(ctx, flagKey, defaultValue)
+	return res.Value, err
+}
+
+// BoolVariationDetailsCtx return the details of the evaluation for boolean flag
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func BoolVariationDetailsCtx(
+	ctx context.Context, flagKey string, defaultValue bool,
+) (model.VariationResult[bool], error) {
+	return ff.BoolVariationDetailsCtx(ctx, flagKey, defaultValue)
+}
+
+// BoolVariationDetailsCtx return the details of the evaluation for boolean flag
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func (g *GoFeatureFlag) BoolVariationDetailsCtx(
+	ctx context.Context, flagKey string, defaultValue bool,
+) (model.VariationResult[bool], error) {
+	return evaluateWithHooks(ctx, g, flagKey, evaluationContextFrom(ctx), defaultValue, "bool", getVariation)
+}
+
+// IntVariationCtx return the value of the flag in int for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func IntVariationCtx(ctx context.Context, flagKey string, defaultValue int) (int, error) {
+	return ff.IntVariationCtx(ctx, flagKey, defaultValue)
+}
+
+// IntVariationCtx return the value of the flag in int for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func (g *GoFeatureFlag) IntVariationCtx(
+	ctx context.Context, flagKey string, defaultValue int,
+) (int, error) {
+	res, err := g.IntVariationDetailsCtx(ctx, flagKey, defaultValue)
+	return res.Value, err
+}
+
+// IntVariationDetailsCtx return the details of the evaluation for int flag
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func IntVariationDetailsCtx(
+	ctx context.Context, flagKey string, defaultValue int,
+) (model.VariationResult[int], error) {
+	return ff.IntVariationDetailsCtx(ctx, flagKey, defaultValue)
+}
+
+// IntVariationDetailsCtx return the details of the evaluation for int flag
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func (g *GoFeatureFlag) IntVariationDetailsCtx(
+	ctx context.Context, flagKey string, defaultValue int,
+) (model.VariationResult[int], error) {
+	return evaluateWithHooks(ctx, g, flagKey, evaluationContextFrom(ctx), defaultValue, "int", getVariation)
+}
+
+// Float64VariationCtx return the value of the flag in float64 for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func Float64VariationCtx(ctx context.Context, flagKey string, defaultValue float64) (float64, error) {
+	return ff.Float64VariationCtx(ctx, flagKey, defaultValue)
+}
+
+// Float64VariationCtx return the value of the flag in float64 for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func (g *GoFeatureFlag) Float64VariationCtx(
+	ctx context.Context, flagKey string, defaultValue float64,
+) (float64, error) {
+	res, err := g.Float64VariationDetailsCtx(ctx, flagKey, defaultValue)
+	return res.Value, err
+}
+
+// Float64VariationDetailsCtx return the details of the evaluation for float64 flag
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func Float64VariationDetailsCtx(
+	ctx context.Context, flagKey string, defaultValue float64,
+) (model.VariationResult[float64], error) {
+	return ff.Float64VariationDetailsCtx(ctx, flagKey, defaultValue)
+}
+
+// Float64VariationDetailsCtx return the details of the evaluation for float64 flag
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func (g *GoFeatureFlag) Float64VariationDetailsCtx(
+	ctx context.Context, flagKey string, defaultValue float64,
+) (model.VariationResult[float64], error) {
+	return evaluateWithHooks(ctx, g, flagKey, evaluationContextFrom(ctx), defaultValue, "float64", getVariation)
+}
+
+// StringVariationCtx return the value of the flag in string for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func StringVariationCtx(ctx context.Context, flagKey string, defaultValue string) (string, error) {
+	return ff.StringVariationCtx(ctx, flagKey, defaultValue)
+}
+
+// StringVariationCtx return the value of the flag in string for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func (g *GoFeatureFlag) StringVariationCtx(
+	ctx context.Context, flagKey string, defaultValue string,
+) (string, error) {
+	res, err := g.StringVariationDetailsCtx(ctx, flagKey, defaultValue)
+	return res.Value, err
+}
+
+// StringVariationDetailsCtx return the details of the evaluation for string flag
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func StringVariationDetailsCtx(
+	ctx context.Context, flagKey string, defaultValue string,
+) (model.VariationResult[string], error) {
+	return ff.StringVariationDetailsCtx(ctx, flagKey, defaultValue)
+}
+
+// StringVariationDetailsCtx return the details of the evaluation for string flag
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func (g *GoFeatureFlag) StringVariationDetailsCtx(
+	ctx context.Context, flagKey string, defaultValue string,
+) (model.VariationResult[string], error) {
+	return evaluateWithHooks(ctx, g, flagKey, evaluationContextFrom(ctx), defaultValue, "string", getVariation)
+}
+
+// JSONArrayVariationCtx return the value of the flag in []interface{} for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func JSONArrayVariationCtx(ctx context.Context, flagKey string, defaultValue []any) ([]any, error) {
+	return ff.JSONArrayVariationCtx(ctx, flagKey, defaultValue)
+}
+
+// JSONArrayVariationCtx return the value of the flag in []interface{} for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func (g *GoFeatureFlag) JSONArrayVariationCtx(
+	ctx context.Context, flagKey string, defaultValue []any,
+) ([]any, error) {
+	res, err := g.JSONArrayVariationDetailsCtx(ctx, flagKey, defaultValue)
+	return res.Value, err
+}
+
+// JSONArrayVariationDetailsCtx return the details of the evaluation for []interface{} flag
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func JSONArrayVariationDetailsCtx(
+	ctx context.Context, flagKey string, defaultValue []any,
+) (model.VariationResult[[]any], error) {
+	return ff.JSONArrayVariationDetailsCtx(ctx, flagKey, defaultValue)
+}
+
+// JSONArrayVariationDetailsCtx return the details of the evaluation for []interface{} flag
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func (g *GoFeatureFlag) JSONArrayVariationDetailsCtx(
+	ctx context.Context, flagKey string, defaultValue []any,
+) (model.VariationResult[[]any], error) {
+	return evaluateWithHooks(ctx, g, flagKey, evaluationContextFrom(ctx), defaultValue, "[]interface{}", getVariation)
+}
+
+// JSONVariationCtx return the value of the flag in map[string]interface{} for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func JSONVariationCtx(ctx context.Context, flagKey string, defaultValue map[string]any) (map[string]any, error) {
+	return ff.JSONVariationCtx(ctx, flagKey, defaultValue)
+}
+
+// JSONVariationCtx return the value of the flag in map[string]interface{} for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func (g *GoFeatureFlag) JSONVariationCtx(
+	ctx context.Context, flagKey string, defaultValue map[string]any,
+) (map[string]any, error) {
+	res, err := g.JSONVariationDetailsCtx(ctx, flagKey, defaultValue)
+	return res.Value, err
+}
+
+// JSONVariationDetailsCtx return the details of the evaluation for map[string]interface{} flag
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func JSONVariationDetailsCtx(
+	ctx context.Context, flagKey string, defaultValue map[string]any,
+) (model.VariationResult[map[string]any], error) {
+	return ff.JSONVariationDetailsCtx(ctx, flagKey, defaultValue)
+}
+
+// JSONVariationDetailsCtx return the details of the evaluation for map[string]interface{} flag
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func (g *GoFeatureFlag) JSONVariationDetailsCtx(
+	ctx context.Context, flagKey string, defaultValue map[string]any,
+) (model.VariationResult[map[string]any], error) {
+	return evaluateWithHooks(
+		ctx, g, flagKey, evaluationContextFrom(ctx), defaultValue, "map[string]interface{}", getVariation)
+}
+
+// VariationCtx return the value of the flag decoded into the type T for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist or if the value can't be decoded into T, we return the default value.
+func VariationCtx[T any](ctx context.Context, flagKey string, defaultValue T) (T, error) {
+	return VariationFromCtx(ctx, ff, flagKey, defaultValue)
+}
+
+// VariationFromCtx return the value of the flag decoded into the type T for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist or if the value can't be decoded into T, we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func VariationFromCtx[T any](ctx context.Context, g *GoFeatureFlag, flagKey string, defaultValue T) (T, error) {
+	res, err := VariationDetailsFromCtx(ctx, g, flagKey, defaultValue)
+	return res.Value, err
+}
+
+// VariationDetailsCtx return the details of the evaluation of the flag with the value decoded into the type T
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func VariationDetailsCtx[T any](
+	ctx context.Context, flagKey string, defaultValue T,
+) (model.VariationResult[T], error) {
+	return VariationDetailsFromCtx(ctx, ff, flagKey, defaultValue)
+}
+
+// VariationDetailsFromCtx return the details of the evaluation of the flag with the value decoded into the type T
+// for the evaluation context stored in ctx.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func VariationDetailsFromCtx[T any](
+	ctx context.Context, g *GoFeatureFlag, flagKey string, defaultValue T,
+) (model.VariationResult[T], error) {
+	return evaluateWithHooks(
+		ctx, g, flagKey, evaluationContextFrom(ctx), defaultValue, "interface{}", getDecodedVariation[T])
+}
@@ -0,0 +1,113 @@
+package ffclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/hook"
+	"github.com/thomaspoignant/go-feature-flag/internal/telemetry"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type contextHook struct {
+	hook.Base
+	contexts []context.Context
+}
+
+func (h *contextHook) Before(hookCtx hook.Context) (ffcontext.Context, error) {
+	h.contexts = append(h.contexts, hookCtx.Context)
+	return nil, nil
+}
+
+func TestVariationCtx(t *testing.T) {
+	betaCtx := ffcontext.NewEvaluationContextBuilder("random-key").AddCustom("beta", true).Build()
+	ctx := ffcontext.WithEvaluationContext(context.Background(), betaCtx)
+
+	t.Run("use the evaluation context of the context", func(t *testing.T) {
+		g := newTestClient(NewCacheMock(betaFlag(), nil), false)
+		got, err := g.BoolVariationCtx(ctx, "beta-flag", false)
+		require.NoError(t, err)
+		assert.True(t, got)
+
+		details, err := g.BoolVariationDetailsCtx(ctx, "beta-flag", false)
+		require.NoError(t, err)
+		assert.Equal(t, "on", details.VariationType)
+	})
+
+	t.Run("no evaluation context in the context", func(t *testing.T) {
+		g := newTestClient(NewCacheMock(betaFlag(), nil), false)
+		got, err := g.BoolVariationDetailsCtx(context.Background(), "beta-flag", true)
+		require.NoError(t, err)
+		assert.False(t, got.Value)
+		assert.Equal(t, "off", got.VariationType)
+	})
+
+	t.Run("all the types", func(t *testing.T) {
+		g := newTestClient(NewCacheMock(staticFlag(42), nil), false)
+		gotInt, err := g.IntVariationCtx(ctx, "my-flag", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 42, gotInt)
+		gotString, err := g.StringVariationCtx(ctx, "my-flag", "default")
+		assert.Error(t, err)
+		assert.Equal(t, "default", gotString)
+
+		g = newTestClient(NewCacheMock(staticFlag(4.2), nil), false)
+		gotFloat, err := g.Float64VariationCtx(ctx, "my-flag", 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 4.2, gotFloat)
+
+		g = newTestClient(NewCacheMock(staticFlag([]any{"a", "b"}), nil), false)
+		gotArray, err := g.JSONArrayVariationCtx(ctx, "my-flag", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, []any{"a", "b"}, gotArray)
+
+		g = newTestClient(NewCacheMock(staticFlag(map[string]any{"title": "Hello"}), nil), false)
+		gotJSON, err := g.JSONVariationCtx(ctx, "my-flag", nil)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]any{"title": "Hello"}, gotJSON)
+		gotStruct, err := VariationFromCtx(ctx, g, "my-flag", bannerConfig{})
+		assert.NoError(t, err)
+		assert.Equal(t, bannerConfig{Title: "Hello"}, gotStruct)
+	})
+
+	t.Run("global instance", func(t *testing.T) {
+		ff = newTestClient(NewCacheMock(betaFlag(), nil), false)
+		defer func() { ff = nil }()
+		got, err := BoolVariationCtx(ctx, "beta-flag", false)
+		require.NoError(t, err)
+		assert.True(t, got)
+	})
+
+	t.Run("hooks receive the context", func(t *testing.T) {
+		h := &contextHook{}
+		g := newTestClient(NewCacheMock(betaFlag(), nil), false)
+		g.config.Hooks = []hook.Hook{h}
+		_, _ = g.BoolVariationCtx(ctx, "beta-flag", false)
+		require.Len(t, h.contexts, 1)
+		evaluationCtx, ok := ffcontext.FromContext(h.contexts[0])
+		assert.True(t, ok)
+		assert.Equal(t, betaCtx, evaluationCtx)
+	})
+
+	t.Run("evaluation span is a child of the span of the context", func(t *testing.T) {
+		spanRecorder := tracetest.NewSpanRecorder()
+		tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spanRecorder))
+		instrumentation, err := telemetry.New(tracerProvider, nil, "", false)
+		require.NoError(t, err)
+		g := newTestClient(NewCacheMock(betaFlag(), nil), false)
+		g.telemetry = instrumentation
+
+		parentCtx, parent := tracerProvider.Tracer("test").Start(ctx, "request")
+		_, _ = g.BoolVariationCtx(parentCtx, "beta-flag", false)
+		parent.End()
+
+		spans := spanRecorder.Ended()
+		require.Len(t, spans, 2)
+		assert.Equal(t, telemetry.EvaluationSpanName, spans[0].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+	})
+}
@@ -0,0 +1,115 @@
+package ffclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/model"
+)
+
+// Variation return the value of the flag decoded into the type T.
+// Object and array variations are decoded into your own types (structs, slices, maps ...)
+// using their JSON representation, so you can use `json` struct tags to map the fields.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist or if the value can't be decoded into T, we return the default value.
+func Variation[T any](flagKey string, ctx ffcontext.Context, defaultValue T) (T, error) {
+	return VariationFrom(ff, flagKey, ctx, defaultValue)
+}
+
+// VariationFrom return the value of the flag decoded into the type T.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist or if the value can't be decoded into T, we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func VariationFrom[T any](g *GoFeatureFlag, flagKey string, ctx ffcontext.Context, defaultValue T) (T, error) {
+	res, err := VariationDetailsFrom(g, flagKey, ctx, defaultValue)
+	return res.Value, err
+}
+
+// VariationDetails return the details of the evaluation of the flag with the value decoded into the type T.
+// If the value can't be decoded into T, the result contains the default value and
+// the error code TYPE_MISMATCH.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+func VariationDetails[T any](
+	flagKey string,
+	ctx ffcontext.Context,
+	defaultValue T,
+) (model.VariationResult[T], error) {
+	return VariationDetailsFrom(ff, flagKey, ctx, defaultValue)
+}
+
+// VariationDetailsFrom return the details of the evaluation of the flag with the value decoded into the type T.
+// If the value can't be decoded into T, the result contains the default value and
+// the error code TYPE_MISMATCH.
+// An error is return if you don't have init the library before calling the function.
+// If the key does not exist we return the default value.
+// Note: Use this function only if you are using multiple go-feature-flag instances.
+func VariationDetailsFrom[T any](
+	g *GoFeatureFlag,
+	flagKey string,
+	ctx ffcontext.Context,
+	defaultValue T,
+) (model.VariationResult[T], error) {
+	return evaluateWithHooks(context.Background(), g, flagKey, ctx, defaultValue, "interface{}", getDecodedVariation[T])
+}
+
+// getDecodedVariation evaluates the flag and decodes the value into the type T.
+func getDecodedVariation[T any](
+	g *GoFeatureFlag,
+	flagKey string,
+	ctx ffcontext.Context,
+	defaultValue T,
+	expectedType string,
+) (model.VariationResult[T], error) {
+	raw, err := getVariation[any](g, flagKey, ctx, any(defaultValue), expectedType)
+	res := model.VariationResult[T]{
+		TrackEvents:   raw.TrackEvents,
+		VariationType: raw.VariationType,
+		Failed:        raw.Failed,
+		Version:       raw.Version,
+		Reason:        raw.Reason,
+		ErrorCode:     raw.ErrorCode,
+		ErrorDetails:  raw.ErrorDetails,
+		Value:         defaultValue,
+		Cacheable:     raw.Cacheable,
+		Metadata:      raw.Metadata,
+	}
+	if err != nil {
+		return res, err
+	}
+	value, err := decodeVariationValue[T](raw.Value)
+	if err != nil {
+		res.VariationType = flag.VariationSDKDefault
+		res.Failed = true
+		res.Reason = flag.ReasonError
+		res.ErrorCode = flag.ErrorCodeTypeMismatch
+		res.ErrorDetails = err.Error()
+		res.Cacheable = false
+		return res, fmt.Errorf(errorWrongVariation, flagKey)
+	}
+	res.Value = value
+	return res, nil
+}
+
+// decodeVariationValue converts the value of a variation into the type T.
+// If the value is not directly a T, we use its JSON representation to decode it.
+func decodeVariationValue[T any](value any) (T, error) {
+	var result T
+	switch v := value.(type) {
+	case nil:
+		return result, nil
+	case T:
+		return v, nil
+	}
+	content, err := json.Marshal(value)
+	if err != nil {
+		return result, err
+	}
+	if err := json.Unmarshal(content, &result); err != nil {
+		return result, fmt.Errorf("impossible to convert %s into %T: %w", content, result, err)
+	}
+	return result, nil
+}
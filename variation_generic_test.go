@@ -0,0 +1,204 @@
+package ffclient
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thomaspoignant/go-feature-flag/ffcontext"
+	"github.com/thomaspoignant/go-feature-flag/internal/cache"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/flag"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/model"
+	"github.com/thomaspoignant/go-feature-flag/modules/core/testutils/testconvert"
+	"github.com/thomaspoignant/go-feature-flag/retriever"
+	"github.com/thomaspoignant/go-feature-flag/utils/fflog"
+)
+
+type bannerConfig struct {
+	Title    string   `json:"title"`
+	MaxItems int      `json:"maxItems"`
+	Colors   []string `json:"colors"`
+}
+
+type menuItem struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+func newTestClient(cacheMock cache.Manager, offline bool) *GoFeatureFlag {
+	c := retriever.ManagerConfig{
+		FileFormat:      "YAML",
+		PollingInterval: 500,
+	}
+	return &GoFeatureFlag{
+		retrieverManager: retriever.NewManager(c, []retriever.Retriever{}, cacheMock, &fflog.FFLogger{}),
+		config:           Config{Offline: offline},
+	}
+}
+
+func staticFlag(value any) *flag.InternalFlag {
+	return &flag.InternalFlag{
+		Variations: &map[string]*any{
+			"enabled": testconvert.Interface(value),
+		},
+		DefaultRule: &flag.Rule{
+			VariationResult: testconvert.String("enabled"),
+		},
+	}
+}
+
+func TestVariationDetails_Struct(t *testing.T) {
+	defaultBanner := bannerConfig{Title: "default"}
+	tests := []struct {
+		name      string
+		cacheMock cache.Manager
+		offline   bool
+		want      model.VariationResult[bannerConfig]
+		wantErr   assert.ErrorAssertionFunc
+	}{
+		{
+			name: "object variation decoded into a struct",
+			cacheMock: NewCacheMock(staticFlag(map[string]any{
+				"title":    "Black Friday",
+				"maxItems": 3,
+				"colors":   []any{"red", "black"},
+			}), nil),
+			want: model.VariationResult[bannerConfig]{
+				TrackEvents:   true,
+				VariationType: "enabled",
+				Reason:        flag.ReasonStatic,
+				Value:         bannerConfig{Title: "Black Friday", MaxItems: 3, Colors: []string{"red", "black"}},
+				Cacheable:     true,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "variation with the wrong type",
+			cacheMock: NewCacheMock(staticFlag("not an object"), nil),
+			want: model.VariationResult[bannerConfig]{
+				TrackEvents:   true,
+				VariationType: flag.VariationSDKDefault,
+				Failed:        true,
+				Reason:        flag.ReasonError,
+				ErrorCode:     flag.ErrorCodeTypeMismatch,
+				ErrorDetails: "impossible to convert \"not an object\" into ffclient.bannerConfig: " +
+					"json: cannot unmarshal string into Go value of type ffclient.bannerConfig",
+				Value: defaultBanner,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:      "object variation with a field of the wrong type",
+			cacheMock: NewCacheMock(staticFlag(map[string]any{"title": "Black Friday", "maxItems": "3"}), nil),
+			want: model.VariationResult[bannerConfig]{
+				TrackEvents:   true,
+				VariationType: flag.VariationSDKDefault,
+				Failed:        true,
+				Reason:        flag.ReasonError,
+				ErrorCode:     flag.ErrorCodeTypeMismatch,
+				ErrorDetails: "impossible to convert {\"maxItems\":\"3\",\"title\":\"Black Friday\"} into " +
+					"ffclient.bannerConfig: json: cannot unmarshal string into Go struct field " +
+					"bannerConfig.maxItems of type int",
+				Value: defaultBanner,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name:      "flag not found",
+			cacheMock: NewCacheMock(nil, errors.New("impossible to read the toggle before the initialisation")),
+			want: model.VariationResult[bannerConfig]{
+				VariationType: flag.VariationSDKDefault,
+				Failed:        true,
+				Reason:        flag.ReasonError,
+				ErrorCode:     flag.ErrorCodeFlagNotFound,
+				Value:         defaultBanner,
+			},
+			wantErr: assert.Error,
+		},
+		{
+			name: "disabled flag",
+			cacheMock: NewCacheMock(&flag.InternalFlag{
+				Disable: testconvert.Bool(true),
+			}, nil),
+			want: model.VariationResult[bannerConfig]{
+				TrackEvents:   true,
+				VariationType: flag.VariationSDKDefault,
+				Reason:        flag.ReasonDisabled,
+				Value:         defaultBanner,
+				Cacheable:     true,
+			},
+			wantErr: assert.NoError,
+		},
+		{
+			name:      "offline mode",
+			cacheMock: NewCacheMock(staticFlag(map[string]any{"title": "Black Friday"}), nil),
+			offline:   true,
+			want: model.VariationResult[bannerConfig]{
+				VariationType: flag.VariationSDKDefault,
+				Reason:        flag.ReasonOffline,
+				Value:         defaultBanner,
+			},
+			wantErr: assert.NoError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestClient(tt.cacheMock, tt.offline)
+			got, err := VariationDetailsFrom(g, "banner", ffcontext.NewEvaluationContext("random-key"), defaultBanner)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestVariation_Types(t *testing.T) {
+	evalCtx := ffcontext.NewEvaluationContext("random-key")
+
+	t.Run("array variation decoded into a slice of structs", func(t *testing.T) {
+		g := newTestClient(NewCacheMock(staticFlag([]any{
+			map[string]any{"name": "Home", "url": "/"},
+			map[string]any{"name": "Shop", "url": "/shop"},
+		}), nil), false)
+		got, err := VariationFrom(g, "menu", evalCtx, []menuItem{})
+		assert.NoError(t, err)
+		assert.Equal(t, []menuItem{{Name: "Home", URL: "/"}, {Name: "Shop", URL: "/shop"}}, got)
+	})
+
+	t.Run("scalar variations", func(t *testing.T) {
+		g := newTestClient(NewCacheMock(staticFlag(42), nil), false)
+		gotInt, err := VariationFrom(g, "int-flag", evalCtx, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, 42, gotInt)
+
+		gotFloat, err := VariationFrom(g, "int-flag", evalCtx, 0.0)
+		assert.NoError(t, err)
+		assert.Equal(t, 42.0, gotFloat)
+
+		gotString, err := VariationFrom(g, "int-flag", evalCtx, "default")
+		assert.Error(t, err)
+		assert.Equal(t, "default", gotString)
+	})
+
+	t.Run("float variation into an int", func(t *testing.T) {
+		g := newTestClient(NewCacheMock(staticFlag(1.5), nil), false)
+		got, err := VariationDetailsFrom(g, "float-flag", evalCtx, 1)
+		assert.Error(t, err)
+		assert.Equal(t, 1, got.Value)
+		assert.Equal(t, flag.ErrorCodeTypeMismatch, got.ErrorCode)
+	})
+
+	t.Run("use the global instance", func(t *testing.T) {
+		ff = newTestClient(NewCacheMock(staticFlag(map[string]any{"title": "Hello"}), nil), false)
+		defer func() { ff = nil }()
+		got, err := Variation("banner", evalCtx, bannerConfig{})
+		assert.NoError(t, err)
+		assert.Equal(t, bannerConfig{Title: "Hello"}, got)
+	})
+
+	t.Run("not initialised", func(t *testing.T) {
+		got, err := VariationDetails("banner", evalCtx, bannerConfig{Title: "default"})
+		assert.Error(t, err)
+		assert.Equal(t, bannerConfig{Title: "default"}, got.Value)
+		assert.Equal(t, flag.ErrorCodeProviderNotReady, got.ErrorCode)
+	})
+}
@@ -41,6 +41,15 @@ export const integrations = {
       logo: filelogo,
       docLink: 'file',
     },
+    {
+      name: 'Directory',
+      description:
+        'Load and merge all the configuration files of a local directory.',
+      longDescription: `Load and merge all the configuration files of a local directory or matching a glob pattern. This retriever is useful when your flags are split into several files, for example one file per team.`,
+      bgColor: '#000000',
+      faLogo: 'fas fa-folder-open fa-stack-1x fa-inverse',
+      docLink: 'directory',
+    },
     {
       name: 'Kubernetes ConfigMap',
       description: 'Loads the configuration from a Kubernetes ConfigMap.',
@@ -104,6 +113,15 @@ export const integrations = {
       logo: bitbucketlogo,
       docLink: 'bitbucket',
     },
+    {
+      name: 'Git',
+      description:
+        'Fetch the configuration from a file stored in any GIT repository.',
+      longDescription: `Fetch the configuration from a file stored in any GIT repository. This retriever is useful when your repository is hosted on a self-hosted git server or accessible only over SSH.`,
+      bgColor: '#f05032',
+      faLogo: 'devicon-git-plain colored',
+      docLink: 'git',
+    },
     {
       name: 'MongoDB',
       description: 'Load the configuration from a MongoDB collection.',
@@ -131,6 +149,46 @@ export const integrations = {
       docLink: 'postgresql',
       minVersion: 'v1.46.0',
     },
+    {
+      name: 'MySQL',
+      description: 'Load the configuration from a MySQL or MariaDB database.',
+      longDescription: `Load the configuration from a MySQL or MariaDB database. This retriever is useful when you are using MySQL or MariaDB and want to use a database to store your configuration files.`,
+      bgColor: '#00758f',
+      faLogo: 'devicon-mysql-plain',
+      docLink: 'mysql',
+    },
+    {
+      name: 'SQLite',
+      description: 'Load the configuration from a SQLite database file.',
+      longDescription: `Load the configuration from a SQLite database file. This retriever is useful for small deployments that want to store the flags in an embedded database, without running a database server.`,
+      bgColor: '#003b57',
+      faLogo: 'devicon-sqlite-plain',
+      docLink: 'sqlite',
+    },
+    {
+      name: 'etcd',
+      description: 'Load the configuration from etcd, from a key or a prefix.',
+      longDescription: `Load the configuration from etcd, from a single key or from a prefix with one key per flag. The retriever watches the keys, so a change in etcd is applied without waiting for the next polling.`,
+      bgColor: '#419eda',
+      faLogo: 'fas fa-database fa-stack-1x fa-inverse',
+      docLink: 'etcd',
+    },
+    {
+      name: 'Consul KV',
+      description: 'Load the configuration from the Consul KV store, from a key or a prefix.',
+      longDescription: `Load the configuration from the Consul KV store, from a single key or from a prefix with one key per flag. The retriever uses blocking queries, so a change in Consul is applied without waiting for the next polling.`,
+      bgColor: '#e03875',
+      faLogo: 'fas fa-key fa-stack-1x fa-inverse',
+      docLink: 'consul',
+    },
+    {
+      name: 'NATS JetStream KV',
+      description: 'Load the configuration from a NATS JetStream Key-Value bucket.',
+      longDescription: `Load the configuration from a NATS JetStream Key-Value bucket, with one key per flag. The retriever watches the bucket, so a change in NATS is applied without waiting for the next polling.`,
+      bgColor: '#27aae1',
+      faLogo: 'fas fa-bolt fa-stack-1x fa-inverse',
+      docLink: 'nats',
+    },
   ],
   exporters: [
     {
@@ -204,6 +262,15 @@ export const integrations = {
       logo: bigquerylogo,
       docLink: 'google-cloud-bigquery',
     },
+    {
+      name: 'NATS JetStream',
+      description: 'Export evaluation and tracking data to a NATS JetStream subject.',
+      longDescription: `The NATS JetStream exporter publishes each evaluation and tracking event as a JSON message on a JetStream subject, and waits for the acknowledgement of the stream.`,
+      type: 'sync',
+      bgColor: '#27aae1',
+      faLogo: 'fas fa-bolt fa-inverse',
+      docLink: 'nats',
+    },
     {
       name: 'AWS SQS',
       description: 'Export evaluation data inside a AWS SQS queue.',
@@ -100,7 +100,8 @@ const FAQ_ITEMS = [
         A lot of places, with no plugin to install. Built-in{' '}
         <Link to={RETRIEVER_DOCS}>retrievers</Link> cover HTTP(S), the local
         file system, Kubernetes ConfigMaps, AWS S3, Google Cloud Storage, Azure
-        Blob Storage, GitHub, GitLab, Bitbucket, MongoDB, Redis and PostgreSQL.
+        Blob Storage, GitHub, GitLab, Bitbucket, MongoDB, Redis, PostgreSQL,
+        MySQL, SQLite, etcd, Consul KV and NATS JetStream KV.
         You point GO Feature Flag at wherever your configuration already lives.
       </>
     ),
@@ -113,7 +114,7 @@ const FAQ_ITEMS = [
         <Link to={EXPORTER_DOCS}>exporters</Link> send evaluation events to AWS
         S3, Azure Blob Storage, the file system, Apache Kafka, AWS Kinesis,
         Google Cloud Storage, Google Cloud PubSub, Google Cloud BigQuery, AWS
-        SQS, a webhook, your application logs, or OpenTelemetry.
+        SQS, NATS JetStream, a webhook, your application logs, or OpenTelemetry.
       </>
     ),
   },